@@ -12,6 +12,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/internal/contracts"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
 	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
 	rpctypes "github.com/NilFoundation/nil/nil/services/rpc/types"
 	"github.com/NilFoundation/nil/nil/services/txnpool"
@@ -88,6 +89,12 @@ type Client interface {
 	GasPrice(ctx context.Context, shardId types.ShardId) (types.Value, error)
 	ChainId(ctx context.Context) (types.ChainId, error)
 
+	// NewFilter installs a filter of the logs matching the query and returns its id
+	NewFilter(ctx context.Context, query *filters.FilterQuery) (string, error)
+	// GetFilterLogs returns the logs matched by the filter since the previous call
+	GetFilterLogs(ctx context.Context, id string) ([]*jsonrpc.RPCLog, error)
+	UninstallFilter(ctx context.Context, id string) (bool, error)
+
 	DeployContract(
 		ctx context.Context, shardId types.ShardId, smartAccountAddress types.Address, payload types.DeployPayload,
 		value types.Value, fee types.FeePack, pk *ecdsa.PrivateKey,
@@ -119,10 +126,26 @@ type Client interface {
 	// GetTokens retrieves the contract tokens at the given address
 	GetTokens(ctx context.Context, address types.Address, blockId any) (types.TokensMap, error)
 
+	// GetTokenInfo retrieves the metadata of the token
+	GetTokenInfo(ctx context.Context, tokenId types.TokenId) (*jsonrpc.RPCTokenInfo, error)
+
+	// GetTokenHolders retrieves a page of the token holders
+	GetTokenHolders(ctx context.Context, tokenId types.TokenId, page uint64) ([]types.TokenHolder, error)
+
 	// SetTokenName sets token name
 	SetTokenName(
 		ctx context.Context, contractAddr types.Address, name string, pk *ecdsa.PrivateKey) (common.Hash, error)
 
+	// SetTokenMetadata sets token name, symbol and decimals
+	SetTokenMetadata(
+		ctx context.Context,
+		contractAddr types.Address,
+		name string,
+		symbol string,
+		decimals uint8,
+		pk *ecdsa.PrivateKey,
+	) (common.Hash, error)
+
 	// ChangeTokenAmount mints / burns token for the contract
 	ChangeTokenAmount(
 		ctx context.Context,
@@ -11,6 +11,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/contracts"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
 	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
 	"github.com/NilFoundation/nil/nil/services/rpc/rawapi"
 	"github.com/NilFoundation/nil/nil/services/rpc/transport"
@@ -174,6 +175,18 @@ func (c *DirectClient) SendRawTransaction(ctx context.Context, data []byte) (com
 	return c.ethApi.SendRawTransaction(ctx, data)
 }
 
+func (c *DirectClient) NewFilter(ctx context.Context, query *filters.FilterQuery) (string, error) {
+	return c.ethApi.NewFilter(ctx, *query)
+}
+
+func (c *DirectClient) GetFilterLogs(ctx context.Context, id string) ([]*jsonrpc.RPCLog, error) {
+	return c.ethApi.GetFilterLogs(ctx, id)
+}
+
+func (c *DirectClient) UninstallFilter(ctx context.Context, id string) (bool, error) {
+	return c.ethApi.UninstallFilter(ctx, id)
+}
+
 func (c *DirectClient) GetInTransactionByHash(
 	ctx context.Context,
 	hash common.Hash,
@@ -252,6 +265,16 @@ func (c *DirectClient) GetTokens(ctx context.Context, address types.Address, blo
 	return c.ethApi.GetTokens(ctx, address, transport.BlockNumberOrHash(blockNrOrHash))
 }
 
+func (c *DirectClient) GetTokenInfo(ctx context.Context, tokenId types.TokenId) (*jsonrpc.RPCTokenInfo, error) {
+	return c.ethApi.GetTokenInfo(ctx, tokenId)
+}
+
+func (c *DirectClient) GetTokenHolders(
+	ctx context.Context, tokenId types.TokenId, page uint64,
+) ([]types.TokenHolder, error) {
+	return c.ethApi.GetTokenHolders(ctx, tokenId, page)
+}
+
 func (c *DirectClient) GetStorageAt(
 	ctx context.Context,
 	address types.Address,
@@ -365,6 +388,22 @@ func (c *DirectClient) SetTokenName(
 	return c.SendExternalTransaction(ctx, data, contractAddr, pk, types.NewFeePackFromGas(100_000))
 }
 
+func (c *DirectClient) SetTokenMetadata(
+	ctx context.Context,
+	contractAddr types.Address,
+	name string,
+	symbol string,
+	decimals uint8,
+	pk *ecdsa.PrivateKey,
+) (common.Hash, error) {
+	data, err := contracts.NewCallData(contracts.NameNilTokenBase, "setTokenMetadata", name, symbol, decimals)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	return c.SendExternalTransaction(ctx, data, contractAddr, pk, types.NewFeePackFromGas(100_000))
+}
+
 func (c *DirectClient) ChangeTokenAmount(
 	ctx context.Context,
 	contractAddr types.Address,
@@ -22,6 +22,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/contracts"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
 	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
 	"github.com/NilFoundation/nil/nil/services/rpc/transport"
 	rpctypes "github.com/NilFoundation/nil/nil/services/rpc/types"
@@ -73,13 +74,18 @@ const (
 	Eth_getBlockTransactionCountByNumber = "eth_getBlockTransactionCountByNumber"
 	Eth_getBlockTransactionCountByHash   = "eth_getBlockTransactionCountByHash"
 	Eth_getBalance                       = "eth_getBalance"
-	Eth_getTokens                        = "eth_getTokens" //nolint:gosec
+	Eth_getTokens                        = "eth_getTokens"       //nolint:gosec
+	Eth_getTokenInfo                     = "eth_getTokenInfo"    //nolint:gosec
+	Eth_getTokenHolders                  = "eth_getTokenHolders" //nolint:gosec
 	Eth_getStorageAt                     = "eth_getStorageAt"
 	Eth_getShardIdList                   = "eth_getShardIdList"
 	Eth_getNumShards                     = "eth_getNumShards"
 	Eth_gasPrice                         = "eth_gasPrice"
 	Eth_chainId                          = "eth_chainId"
 	Eth_getProof                         = "eth_getProof"
+	Eth_newFilter                        = "eth_newFilter"
+	Eth_getFilterLogs                    = "eth_getFilterLogs"
+	Eth_uninstallFilter                  = "eth_uninstallFilter"
 	Debug_getBlockByHash                 = "debug_getBlockByHash"
 	Debug_getBlockByNumber               = "debug_getBlockByNumber"
 	Debug_getContract                    = "debug_getContract"
@@ -524,6 +530,18 @@ func (c *Client) SendRawTransaction(ctx context.Context, data []byte) (common.Ha
 	return simpleCall[common.Hash](ctx, c, Eth_sendRawTransaction, hexutil.Bytes(data))
 }
 
+func (c *Client) NewFilter(ctx context.Context, query *filters.FilterQuery) (string, error) {
+	return simpleCall[string](ctx, c, Eth_newFilter, query)
+}
+
+func (c *Client) GetFilterLogs(ctx context.Context, id string) ([]*jsonrpc.RPCLog, error) {
+	return simpleCall[[]*jsonrpc.RPCLog](ctx, c, Eth_getFilterLogs, id)
+}
+
+func (c *Client) UninstallFilter(ctx context.Context, id string) (bool, error) {
+	return simpleCall[bool](ctx, c, Eth_uninstallFilter, id)
+}
+
 func (c *Client) GetInTransactionByHash(ctx context.Context, hash common.Hash) (*jsonrpc.RPCInTransaction, error) {
 	return simpleCall[*jsonrpc.RPCInTransaction](ctx, c, Eth_getInTransactionByHash, hash)
 }
@@ -605,6 +623,16 @@ func (c *Client) GetTokens(ctx context.Context, address types.Address, blockId a
 	return simpleCall[types.TokensMap](ctx, c, Eth_getTokens, address, transport.BlockNumberOrHash(blockNrOrHash))
 }
 
+func (c *Client) GetTokenInfo(ctx context.Context, tokenId types.TokenId) (*jsonrpc.RPCTokenInfo, error) {
+	return simpleCall[*jsonrpc.RPCTokenInfo](ctx, c, Eth_getTokenInfo, tokenId)
+}
+
+func (c *Client) GetTokenHolders(
+	ctx context.Context, tokenId types.TokenId, page uint64,
+) ([]types.TokenHolder, error) {
+	return simpleCall[[]types.TokenHolder](ctx, c, Eth_getTokenHolders, tokenId, page)
+}
+
 func (c *Client) GetStorageAt(
 	ctx context.Context,
 	address types.Address,
@@ -739,6 +767,22 @@ func (c *Client) SetTokenName(
 	return c.SendExternalTransaction(ctx, data, contractAddr, pk, types.NewFeePackFromGas(100_000))
 }
 
+func (c *Client) SetTokenMetadata(
+	ctx context.Context,
+	contractAddr types.Address,
+	name string,
+	symbol string,
+	decimals uint8,
+	pk *ecdsa.PrivateKey,
+) (common.Hash, error) {
+	data, err := contracts.NewCallData(contracts.NameNilTokenBase, "setTokenMetadata", name, symbol, decimals)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	return c.SendExternalTransaction(ctx, data, contractAddr, pk, types.NewFeePackFromGas(100_000))
+}
+
 func (c *Client) ChangeTokenAmount(
 	ctx context.Context,
 	contractAddr types.Address,
@@ -12,4 +12,9 @@ type Config struct {
 	FaucetEndpoint string            `mapstructure:"faucet_endpoint"`
 	PrivateKey     *ecdsa.PrivateKey `mapstructure:"private_key"`
 	Address        types.Address     `mapstructure:"address"`
+
+	// KeyName refers to the key in the keystore, it is used if PrivateKey is not set
+	KeyName                string `mapstructure:"key_name"`
+	KeystoreDir            string `mapstructure:"keystore_dir"`
+	KeystorePassphraseFile string `mapstructure:"keystore_passphrase_file"`
 }
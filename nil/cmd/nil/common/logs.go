@@ -0,0 +1,148 @@
+package common
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TopicAnyValue matches any topic in the position it is used in.
+const TopicAnyValue = "_"
+
+// TopicAlternativesSeparator separates the alternatives of a single topic position.
+const TopicAlternativesSeparator = "|"
+
+var ErrEventNotFound = errors.New("event not found")
+
+type EventArg struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+	Value   any    `json:"value"`
+}
+
+type DecodedEvent struct {
+	Name string      `json:"name"`
+	Args []*EventArg `json:"args"`
+}
+
+// MakeEventTopics builds a filter topic set from the command line values.
+// Each value restricts one topic position, "_" or an empty value matches any topic, and
+// alternatives are separated by "|". If the event is given, the first position is its ID and
+// the values restrict its indexed arguments, so they are parsed according to the argument types.
+// Otherwise, the values are raw topic hashes starting from the first position.
+func MakeEventTopics(event *abi.Event, values []string) ([][]common.Hash, error) {
+	if event == nil {
+		topics := make([][]common.Hash, len(values))
+		for i, value := range values {
+			for _, alt := range splitTopicValue(value) {
+				var hash common.Hash
+				if err := hash.UnmarshalText([]byte(alt)); err != nil {
+					return nil, fmt.Errorf("invalid topic %d: %w", i, err)
+				}
+				topics[i] = append(topics[i], hash)
+			}
+		}
+		return topics, nil
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(values) > len(indexed) {
+		return nil, fmt.Errorf(
+			"too many topics: event %s has only %d indexed arguments", event.Name, len(indexed))
+	}
+
+	query := make([][]any, len(values))
+	for i, value := range values {
+		for _, alt := range splitTopicValue(value) {
+			val, err := parseCallArgument(alt, indexed[i].Type)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse topic for argument %q: %w", indexed[i].Name, err)
+			}
+			query[i] = append(query[i], val)
+		}
+	}
+	topics, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]common.Hash{{event.ID}}, topics...), nil
+}
+
+func splitTopicValue(value string) []string {
+	if value == "" || value == TopicAnyValue {
+		return nil
+	}
+	return strings.Split(value, TopicAlternativesSeparator)
+}
+
+// DecodeEvent decodes both the indexed and the non-indexed arguments of the log.
+// Indexed arguments of dynamic types are stored as hashes, so their values are the topics.
+// It returns ErrEventNotFound if the ABI has no event with the log signature.
+func DecodeEvent(contractAbi abi.ABI, log *types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrEventNotFound
+	}
+	event, err := contractAbi.EventByID(log.Topics[0])
+	if err != nil || event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	values := make(map[string]any, len(event.Inputs))
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to parse topics of event %q: %w", event.Name, err)
+	}
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(values, log.Data); err != nil {
+		return nil, fmt.Errorf("failed to unpack data of event %q: %w", event.Name, err)
+	}
+
+	res := &DecodedEvent{
+		Name: event.Name,
+		Args: make([]*EventArg, len(event.Inputs)),
+	}
+	for i, arg := range event.Inputs {
+		res.Args[i] = &EventArg{
+			Name:    arg.Name,
+			Type:    arg.Type.String(),
+			Indexed: arg.Indexed,
+			Value:   normalizeEventValue(values[arg.Name]),
+		}
+	}
+	return res, nil
+}
+
+// normalizeEventValue converts raw byte arrays to hex, so they are printed the same way in all the formats.
+// Types with their own text representation (e.g. addresses) are kept as is.
+func normalizeEventValue(value any) any {
+	if _, ok := value.(encoding.TextMarshaler); ok {
+		return value
+	}
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Array && val.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, val.Len())
+		reflect.Copy(reflect.ValueOf(data), val)
+		return hexutil.Bytes(data)
+	}
+	if data, ok := value.([]byte); ok {
+		return hexutil.Bytes(data)
+	}
+	return value
+}
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+const eventsAbi = `[
+	{"type": "event", "name": "Transfer", "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "amount", "type": "uint256", "indexed": false},
+		{"name": "memo", "type": "bytes4", "indexed": false}
+	]},
+	{"type": "event", "name": "Named", "inputs": [
+		{"name": "name", "type": "string", "indexed": true}
+	]}
+]`
+
+func TestMakeEventTopics(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := abi.JSON(strings.NewReader(eventsAbi))
+	require.NoError(t, err)
+	transfer := contractAbi.Events["Transfer"]
+
+	from := types.HexToAddress("0x0001111111111111111111111111111111111111")
+	to1 := types.HexToAddress("0x0001222222222222222222222222222222222222")
+	to2 := types.HexToAddress("0x0001333333333333333333333333333333333333")
+
+	t.Run("Event", func(t *testing.T) {
+		t.Parallel()
+
+		topics, err := MakeEventTopics(&transfer, []string{from.Hex(), to1.Hex() + "|" + to2.Hex()})
+		require.NoError(t, err)
+		require.Equal(t, [][]common.Hash{
+			{transfer.ID},
+			{common.BytesToHash(from.Bytes())},
+			{common.BytesToHash(to1.Bytes()), common.BytesToHash(to2.Bytes())},
+		}, topics)
+	})
+
+	t.Run("AnyValue", func(t *testing.T) {
+		t.Parallel()
+
+		topics, err := MakeEventTopics(&transfer, []string{TopicAnyValue, to1.Hex()})
+		require.NoError(t, err)
+		require.Equal(t, [][]common.Hash{{transfer.ID}, nil, {common.BytesToHash(to1.Bytes())}}, topics)
+	})
+
+	t.Run("TooManyTopics", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := MakeEventTopics(&transfer, []string{"_", "_", "_"})
+		require.ErrorContains(t, err, "too many topics")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := MakeEventTopics(&transfer, []string{"not-an-address"})
+		require.Error(t, err)
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		t.Parallel()
+
+		topics, err := MakeEventTopics(nil, []string{transfer.ID.Hex(), ""})
+		require.NoError(t, err)
+		require.Equal(t, [][]common.Hash{{transfer.ID}, nil}, topics)
+
+		_, err = MakeEventTopics(nil, []string{"0x01"})
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := abi.JSON(strings.NewReader(eventsAbi))
+	require.NoError(t, err)
+
+	t.Run("Transfer", func(t *testing.T) {
+		t.Parallel()
+
+		transfer := contractAbi.Events["Transfer"]
+		from := types.HexToAddress("0x0001111111111111111111111111111111111111")
+		to := types.HexToAddress("0x0001222222222222222222222222222222222222")
+		data, err := transfer.Inputs.NonIndexed().Pack(big.NewInt(100), [4]byte{1, 2, 3, 4})
+		require.NoError(t, err)
+
+		event, err := DecodeEvent(contractAbi, &types.Log{
+			Address: from,
+			Topics: []common.Hash{
+				transfer.ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()),
+			},
+			Data: data,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &DecodedEvent{
+			Name: "Transfer",
+			Args: []*EventArg{
+				{Name: "from", Type: "address", Indexed: true, Value: from},
+				{Name: "to", Type: "address", Indexed: true, Value: to},
+				{Name: "amount", Type: "uint256", Value: big.NewInt(100)},
+				{Name: "memo", Type: "bytes4", Value: hexutil.Bytes{1, 2, 3, 4}},
+			},
+		}, event)
+	})
+
+	t.Run("DynamicIndexed", func(t *testing.T) {
+		t.Parallel()
+
+		named := contractAbi.Events["Named"]
+		hash := common.Keccak256Hash([]byte("name"))
+		event, err := DecodeEvent(contractAbi, &types.Log{Topics: []common.Hash{named.ID, hash}})
+		require.NoError(t, err)
+		require.Len(t, event.Args, 1)
+		require.Equal(t, hash, event.Args[0].Value)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeEvent(contractAbi, &types.Log{Topics: []common.Hash{common.EmptyHash}})
+		require.ErrorIs(t, err, ErrEventNotFound)
+
+		_, err = DecodeEvent(contractAbi, &types.Log{})
+		require.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
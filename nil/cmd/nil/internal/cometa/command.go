@@ -36,10 +36,12 @@ func GetRegisterCommand() *cobra.Command {
 
 	cmd.Flags().Var(&params.address, "address", "The contract address")
 	cmd.Flags().StringVar(&params.inputJsonFile, "compile-input", "", "The JSON file with the compilation input")
-	if err := cmd.MarkFlagRequired("compile-input"); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
+	cmd.Flags().StringVar(&params.buildInfoFile, "build-info", "", "The build info JSON file of Hardhat or Foundry")
+	cmd.Flags().StringVar(
+		&params.contractName, "contract-name", "", "The contract in the build info, in the format <file>:<contract>")
+	cmd.MarkFlagsOneRequired("compile-input", "build-info")
+	cmd.MarkFlagsMutuallyExclusive("compile-input", "build-info")
+	cmd.MarkFlagsRequiredTogether("build-info", "contract-name")
 
 	return cmd
 }
@@ -69,6 +71,20 @@ func GetInfoCommand() *cobra.Command {
 func runRegisterCommand(cmd *cobra.Command, params *cometaParams) error {
 	cometaClient := common.GetCometaRpcClient()
 
+	if len(params.buildInfoFile) > 0 {
+		buildInfo, err := os.ReadFile(params.buildInfoFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the build info file: %w", err)
+		}
+		err = cometaClient.RegisterContractBuildInfo(
+			cmd.Context(), string(buildInfo), params.contractName, params.address)
+		if err != nil {
+			return fmt.Errorf("failed to register the contract: %w", err)
+		}
+		fmt.Printf("Contract metadata for address %s has been registered\n", params.address)
+		return nil
+	}
+
 	inputJsonData, err := os.ReadFile(params.inputJsonFile)
 	if err != nil {
 		return fmt.Errorf("failed to read the input JSON file: %w", err)
@@ -135,6 +151,11 @@ func runInfoCommand(cmd *cobra.Command, params *cometaParams) error {
 		}
 		fmt.Printf("]\n")
 		fmt.Printf("  Bytecode size: %d\n", len(contract.Code))
+		if len(contract.VerificationStatus) > 0 {
+			fmt.Printf("  Verification: %s match\n", contract.VerificationStatus)
+		} else {
+			fmt.Printf("  Verification: unknown\n")
+		}
 	}
 
 	return nil
@@ -6,4 +6,6 @@ type cometaParams struct {
 	address       types.Address
 	saveToFile    string
 	inputJsonFile string
+	buildInfoFile string
+	contractName  string
 }
@@ -22,6 +22,10 @@ var supportedOptions = map[string]struct{}{
 	"faucet_endpoint": {},
 	"private_key":     {},
 	"address":         {},
+
+	"key_name":                 {},
+	"keystore_dir":             {},
+	"keystore_passphrase_file": {},
 }
 
 func GetCommand(configPath *string) *cobra.Command {
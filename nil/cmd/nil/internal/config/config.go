@@ -13,6 +13,7 @@ import (
 	"github.com/NilFoundation/nil/nil/cmd/nil/common"
 	"github.com/NilFoundation/nil/nil/common/check"
 	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/go-viper/encoding/ini"
@@ -21,9 +22,12 @@ import (
 )
 
 const (
-	AddressField     = "address"
-	PrivateKeyField  = "private_key"
-	RPCEndpointField = "rpc_endpoint"
+	AddressField                = "address"
+	PrivateKeyField             = "private_key"
+	RPCEndpointField            = "rpc_endpoint"
+	KeyNameField                = "key_name"
+	KeystoreDirField            = "keystore_dir"
+	KeystorePassphraseFileField = "keystore_passphrase_file"
 )
 
 const InitConfigTemplate = `; Configuration for interacting with the =nil; cluster
@@ -47,18 +51,29 @@ const InitConfigTemplate = `; Configuration for interacting with the =nil; clust
 ; You can generate a new key with "nil keygen new".
 ; private_key = "WRITE_YOUR_PRIVATE_KEY_HERE"
 
+; Alternatively, refer to a key kept encrypted in the keystore by its name.
+; You can save a new key to the keystore with "nil keygen new --save-as <name>".
+; The passphrase is read from the file below or from the NIL_KEYSTORE_PASSWORD environment variable.
+; key_name = "WRITE_YOUR_KEY_NAME_HERE"
+; keystore_dir = "~/.config/nil/keystore"
+; keystore_passphrase_file = "WRITE_PATH_TO_YOUR_PASSPHRASE_FILE_HERE"
+
 ; Specify the address of your smart account to be the receiver of your external transactions.
 ; You can deploy a new account and save its address with "nil smart account new".
 ; address = "0xWRITE_YOUR_ADDRESS_HERE"
 `
 
-var DefaultConfigPath string
+var (
+	DefaultConfigPath  string
+	DefaultKeystoreDir string
+)
 
 func init() {
 	homeDir, err := os.UserHomeDir()
 	check.PanicIfErr(err)
 
 	DefaultConfigPath = filepath.Join(homeDir, ".config/nil/config.ini")
+	DefaultKeystoreDir = filepath.Join(homeDir, ".config/nil/keystore")
 
 	codecRegistry := viper.NewCodecRegistry()
 	check.PanicIfErr(codecRegistry.RegisterCodec("ini", ini.Codec{}))
@@ -140,6 +155,10 @@ func decodePrivateKey(f reflect.Type, t reflect.Type, data any) (any, error) {
 	if f.Kind() == reflect.String && t == reflect.TypeOf(&ecdsa.PrivateKey{}) {
 		s, ok := data.(string)
 		check.PanicIfNot(ok)
+		// the key is cleared when it's moved to the keystore
+		if s == "" {
+			return (*ecdsa.PrivateKey)(nil), nil
+		}
 		return crypto.HexToECDSA(s)
 	}
 	return data, nil
@@ -168,6 +187,16 @@ func updateDecoderConfig(config *mapstructure.DecoderConfig) {
 
 // LoadConfig loads the configuration from the config file
 func LoadConfig(cfgFilePath string, logger logging.Logger) (*common.Config, error) {
+	return loadConfig(cfgFilePath, true, logger)
+}
+
+// LoadOfflineConfig loads the configuration for the commands that don't access the cluster,
+// so the RPC endpoint is not required
+func LoadOfflineConfig(cfgFilePath string, logger logging.Logger) (*common.Config, error) {
+	return loadConfig(cfgFilePath, false, logger)
+}
+
+func loadConfig(cfgFilePath string, requireEndpoint bool, logger logging.Logger) (*common.Config, error) {
 	err := viper.ReadInConfig()
 
 	// Create file if it doesn't exist
@@ -194,7 +223,11 @@ func LoadConfig(cfgFilePath string, logger logging.Logger) (*common.Config, erro
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
 
-	if err := validateConfig(&config, logger); err != nil {
+	if err := validateConfig(&config, requireEndpoint, logger); err != nil {
+		return nil, err
+	}
+
+	if err := unlockPrivateKey(&config); err != nil {
 		return nil, err
 	}
 
@@ -203,13 +236,40 @@ func LoadConfig(cfgFilePath string, logger logging.Logger) (*common.Config, erro
 }
 
 // validateConfig perform some simple configuration validation
-func validateConfig(config *common.Config, logger logging.Logger) error {
-	if config.RPCEndpoint == "" {
+func validateConfig(config *common.Config, requireEndpoint bool, logger logging.Logger) error {
+	if requireEndpoint && config.RPCEndpoint == "" {
 		return MissingKeyError(RPCEndpointField, logger)
 	}
 	return nil
 }
 
+// unlockPrivateKey loads the key referenced by KeyName from the keystore if there is no plain private key
+func unlockPrivateKey(config *common.Config) error {
+	if config.PrivateKey != nil || config.KeyName == "" {
+		return nil
+	}
+
+	passphrase, err := keystore.ReadPassphrase(config.KeystorePassphraseFile)
+	if err != nil {
+		return fmt.Errorf("failed to unlock key %q: %w", config.KeyName, err)
+	}
+
+	privateKey, err := OpenKeystore(config.KeystoreDir).LoadSecp256k1(config.KeyName, passphrase)
+	if err != nil {
+		return err
+	}
+	config.PrivateKey = privateKey
+	return nil
+}
+
+// OpenKeystore opens the keystore in the given directory, or in the default one if the directory is empty
+func OpenKeystore(dir string) *keystore.Keystore {
+	if dir == "" {
+		dir = DefaultKeystoreDir
+	}
+	return keystore.New(dir)
+}
+
 var generateCommands = map[string]string{
 	PrivateKeyField: "keygen",
 	AddressField:    "smart-account new",
@@ -34,6 +34,8 @@ func GetCommand(cfg *common.Config) *cobra.Command {
 		GetEstimateFeeCommand(cfg),
 		GetTopUpCommand(cfg),
 		GetSeqnoCommand(),
+		GetLogsCommand(),
+		GetStorageCommand(),
 	)
 
 	return serverCmd
@@ -0,0 +1,329 @@
+package contract
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	libcommon "github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+	"github.com/holiman/uint256"
+	"github.com/spf13/cobra"
+)
+
+const (
+	logsFormatText = "text"
+	logsFormatJson = "json"
+	logsFormatCsv  = "csv"
+)
+
+type logsParams struct {
+	abiPath  string
+	event    string
+	from     uint64
+	to       uint64
+	topics   []string
+	format   string
+	follow   bool
+	interval time.Duration
+}
+
+type logEntry struct {
+	*jsonrpc.RPCLog
+
+	// Event is nil if the log is not decoded
+	Event *common.DecodedEvent `json:"event,omitempty"`
+}
+
+func GetLogsCommand() *cobra.Command {
+	params := &logsParams{}
+
+	cmd := &cobra.Command{
+		Use:   "logs [address]",
+		Short: "Get the events emitted by a smart contract",
+		Long: "Get the events emitted by the smart contract with the given address in the range of blocks.\n" +
+			"The events are decoded with the ABI from the file or, if it is not set, with the one from Cometa.\n" +
+			"Each --topic value restricts one topic position (the indexed arguments if --event is set), " +
+			"\"" + common.TopicAnyValue + "\" matches any topic, " +
+			"alternatives are separated by \"" + common.TopicAlternativesSeparator + "\".",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(cmd, args, params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&params.abiPath, abiFlag, "", "The path to the ABI file")
+	cmd.Flags().StringVar(&params.event, eventFlag, "", "The name of the event to filter by")
+	cmd.Flags().Uint64Var(&params.from, fromFlag, 0,
+		fmt.Sprintf("The first block of the range, it is queried by %d blocks", filters.MaxBlocksRange))
+	cmd.Flags().Uint64Var(&params.to, toFlag, 0, "The last block of the range (the latest block by default)")
+	cmd.Flags().StringArrayVar(
+		&params.topics,
+		topicFlag,
+		nil,
+		"The topic value, may be repeated for the next positions",
+	)
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		logsFormatText,
+		"The output format: "+strings.Join([]string{logsFormatText, logsFormatJson, logsFormatCsv}, ", "),
+	)
+	cmd.Flags().BoolVar(&params.follow, followFlag, false, "Keep waiting for new events")
+	cmd.Flags().DurationVar(&params.interval, intervalFlag, time.Second, "The polling interval in the follow mode")
+
+	return cmd
+}
+
+func runLogs(cmd *cobra.Command, args []string, params *logsParams) error {
+	var address types.Address
+	if err := address.Set(args[0]); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+
+	out, err := newLogsWriter(params.format, params.follow)
+	if err != nil {
+		return err
+	}
+	if params.follow && cmd.Flags().Changed(toFlag) {
+		return fmt.Errorf("--%s can't be used with --%s", toFlag, followFlag)
+	}
+
+	var contractAbi abi.ABI
+	var abiErr error
+	if len(params.abiPath) > 0 {
+		contractAbi, abiErr = common.ReadAbiFromFile(params.abiPath)
+	} else {
+		contractAbi, abiErr = common.FetchAbiFromCometa(cmd.Context(), address)
+	}
+	if abiErr != nil {
+		if len(params.abiPath) > 0 || len(params.event) > 0 {
+			return fmt.Errorf("failed to fetch ABI: %w", abiErr)
+		}
+		// The events can be printed raw
+		if !common.Quiet {
+			fmt.Fprintf(os.Stderr, "Failed to fetch ABI, the events are not decoded: %s\n", abiErr)
+		}
+	}
+
+	var event *abi.Event
+	if len(params.event) > 0 {
+		e, ok := contractAbi.Events[params.event]
+		if !ok {
+			return fmt.Errorf("%w: %s", common.ErrEventNotFound, params.event)
+		}
+		event = &e
+	}
+	topics, err := common.MakeEventTopics(event, params.topics)
+	if err != nil {
+		return err
+	}
+
+	query := &filters.FilterQuery{
+		Addresses: []types.Address{address},
+		Topics:    topics,
+	}
+
+	handler := func(logs []*jsonrpc.RPCLog) error {
+		for _, log := range logs {
+			entry := &logEntry{RPCLog: log}
+			if abiErr == nil {
+				var err error
+				entry.Event, err = common.DecodeEvent(contractAbi, log.Log)
+				if err != nil && !errors.Is(err, common.ErrEventNotFound) {
+					return err
+				}
+			}
+			if err := out.write(entry); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	}
+
+	ctx := cmd.Context()
+	if params.follow {
+		// Stop following on interrupt, so that the filter is removed from the node
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+	}
+	rpcClient := common.GetRpcClient()
+	service := cliservice.NewService(ctx, rpcClient, nil, nil)
+
+	latest, err := rpcClient.GetBlock(ctx, address.ShardId(), "latest", false)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the latest block: %w", err)
+	}
+	to := uint64(latest.Number)
+	if cmd.Flags().Changed(toFlag) {
+		to = min(to, params.to)
+	}
+
+	// The node scans at most filters.MaxBlocksRange blocks for a filter, so the range is queried in windows
+	var logs []*jsonrpc.RPCLog
+	for from := params.from; from <= to; from += filters.MaxBlocksRange {
+		window := *query
+		window.FromBlock = uint256.NewInt(from)
+		window.ToBlock = uint256.NewInt(min(from+filters.MaxBlocksRange-1, to))
+		windowLogs, err := service.GetLogs(&window)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, windowLogs...)
+	}
+	if !params.follow {
+		return handler(logs)
+	}
+	if len(logs) > 0 {
+		if err := handler(logs); err != nil {
+			return err
+		}
+	}
+
+	query.FromBlock = uint256.NewInt(max(params.from, to+1))
+	return service.FollowLogs(query, params.interval, handler)
+}
+
+// logsWriter prints the logs in one of the output formats.
+// The logs are passed in batches, flush is called after each one.
+type logsWriter interface {
+	write(entry *logEntry) error
+	flush() error
+}
+
+func newLogsWriter(format string, follow bool) (logsWriter, error) {
+	switch format {
+	case logsFormatText:
+		return &textLogsWriter{}, nil
+	case logsFormatJson:
+		// In the follow mode the output is never complete, so it is printed as JSON lines
+		return &jsonLogsWriter{lines: follow}, nil
+	case logsFormatCsv:
+		w := &csvLogsWriter{w: csv.NewWriter(os.Stdout)}
+		// The header is written immediately, so it is printed even if there are no logs
+		if !common.Quiet {
+			if err := w.w.Write([]string{"blockNumber", "address", "event", "args", "topics", "data"}); err != nil {
+				return nil, err
+			}
+		}
+		return w, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+type textLogsWriter struct{}
+
+func (w *textLogsWriter) write(entry *logEntry) error {
+	fmt.Printf("%d\t%s\t", entry.BlockNumber, entry.Address)
+	if entry.Event == nil {
+		fmt.Printf("topics=%s\tdata=%s\n", formatTopics(entry.Topics), entry.Data)
+		return nil
+	}
+	args := make([]string, len(entry.Event.Args))
+	for i, arg := range entry.Event.Args {
+		args[i] = fmt.Sprintf("%s=%v", arg.Name, arg.Value)
+	}
+	fmt.Printf("%s(%s)\n", entry.Event.Name, strings.Join(args, ", "))
+	return nil
+}
+
+func (w *textLogsWriter) flush() error {
+	return nil
+}
+
+type jsonLogsWriter struct {
+	lines   bool
+	entries []*logEntry
+}
+
+func (w *jsonLogsWriter) write(entry *logEntry) error {
+	if !w.lines {
+		w.entries = append(w.entries, entry)
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func (w *jsonLogsWriter) flush() error {
+	if w.lines {
+		return nil
+	}
+	// Print an empty array rather than null if nothing is found
+	entries := w.entries
+	if entries == nil {
+		entries = []*logEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	w.entries = nil
+	return nil
+}
+
+type csvLogsWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvLogsWriter) write(entry *logEntry) error {
+	var name, args string
+	if entry.Event != nil {
+		name = entry.Event.Name
+		// The arguments are stored as a JSON object to keep the columns the same for all events
+		fields := make([]string, len(entry.Event.Args))
+		for i, arg := range entry.Event.Args {
+			key, err := json.Marshal(arg.Name)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(arg.Value)
+			if err != nil {
+				return err
+			}
+			fields[i] = string(key) + ":" + string(value)
+		}
+		args = "{" + strings.Join(fields, ",") + "}"
+	}
+	return w.w.Write([]string{
+		strconv.FormatUint(uint64(entry.BlockNumber), 10),
+		entry.Address.Hex(),
+		name,
+		args,
+		formatTopics(entry.Topics),
+		entry.Data.String(),
+	})
+}
+
+func (w *csvLogsWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func formatTopics(topics []libcommon.Hash) string {
+	res := make([]string, len(topics))
+	for i, topic := range topics {
+		res[i] = topic.Hex()
+	}
+	return strings.Join(res, " ")
+}
@@ -20,6 +20,13 @@ const (
 	outOverridesFlag = "out-overrides"
 	withDetailsFlag  = "with-details"
 	asJsonFlag       = "json"
+	eventFlag        = "event"
+	fromFlag         = "from"
+	toFlag           = "to"
+	topicFlag        = "topic"
+	formatFlag       = "format"
+	followFlag       = "follow"
+	intervalFlag     = "interval"
 )
 
 type contractParams struct {
@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/spf13/cobra"
+)
+
+func GetStorageCommand() *cobra.Command {
+	params := &contractParams{
+		Params: &common.Params{},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "storage [address] [paths...]",
+		Short: "Get the storage variables of a smart contract",
+		Long: "Get the storage variables of the smart contract with the given address, decoded with the storage " +
+			"layout from Cometa.\nA path starts with a variable name followed by struct members and mapping keys " +
+			"or array indexes, e.g. balances[0x0001...].amount. If no paths are given, all the top-level variables " +
+			"are printed. Mappings can't be enumerated, so their values are printed only for the given keys.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStorage(cmd, args, params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&params.blockId, "block", "latest", "Block number, hash or tag")
+	cmd.Flags().BoolVar(&params.AsJson, asJsonFlag, false, "Output as JSON")
+
+	return cmd
+}
+
+func runStorage(cmd *cobra.Command, args []string, params *contractParams) error {
+	var address types.Address
+	if err := address.Set(args[0]); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+
+	values, err := common.GetCometaRpcClient().GetStorageVariables(cmd.Context(), address, args[1:], params.blockId)
+	if err != nil {
+		return err
+	}
+
+	if params.AsJson {
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, v := range values {
+		value, err := json.Marshal(v.Value)
+		if err != nil {
+			return err
+		}
+		if common.Quiet {
+			fmt.Println(string(value))
+			continue
+		}
+		fmt.Printf("%s (%s, slot %s, offset %d): %s\n", v.Path, v.Type, v.Slot.Hex(), v.Offset, value)
+	}
+	return nil
+}
@@ -38,8 +38,8 @@ func runTokens(cmd *cobra.Command, args []string, cfg *common.Config) error {
 	}
 	for k, v := range tokens {
 		fmt.Printf("%s\t%s", k, v)
-		if name := types.GetTokenName(k); len(name) > 0 && !common.Quiet {
-			fmt.Printf("\t[%s]", name)
+		if symbol := service.GetTokenSymbol(k); len(symbol) > 0 && !common.Quiet {
+			fmt.Printf("\t[%s]", symbol)
 		}
 		fmt.Println()
 	}
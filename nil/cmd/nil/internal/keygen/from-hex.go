@@ -15,6 +15,7 @@ func FromHexCommand(keygen *cliservice.Service) *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
+	addSaveAsFlag(cmd)
 	return cmd
 }
 
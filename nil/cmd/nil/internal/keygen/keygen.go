@@ -18,6 +18,9 @@ func GetCommand() *cobra.Command {
 		Short: "Generate a new key or generate a key from the provided hex private key",
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
 			privateKey := keygen.GetPrivateKey()
+			if ksParams.saveAs != "" {
+				return saveToKeystore(privateKey)
+			}
 			logger.Info().Msgf("Private key: %v", privateKey)
 
 			if err := config.PatchConfig(map[string]any{
@@ -32,10 +35,15 @@ func GetCommand() *cobra.Command {
 
 	keygen = cliservice.NewService(keygenCmd.Context(), &rpc.Client{}, nil, nil)
 
+	addKeystoreFlags(keygenCmd)
+
 	keygenCmd.AddCommand(
 		NewCommand(keygen),
 		FromHexCommand(keygen),
 		NewP2pCommand(keygen),
+		ImportCommand(),
+		ExportCommand(),
+		ListCommand(),
 	)
 	return keygenCmd
 }
@@ -0,0 +1,267 @@
+package keygen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/cmd/nil/internal/config"
+	"github.com/NilFoundation/nil/nil/internal/keys"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	keystoreDirFlag    = "keystore-dir"
+	passphraseFileFlag = "passphrase-file"
+	saveAsFlag         = "save-as"
+	fileFlag           = "file"
+	validatorKeysFlag  = "validator-keys"
+	outputFlag         = "output"
+	plaintextFlag      = "plaintext"
+)
+
+type keystoreParams struct {
+	dir            string
+	passphraseFile string
+
+	// saveAs is the name to store a generated key under, the key is written to the config otherwise
+	saveAs string
+}
+
+var ksParams = &keystoreParams{}
+
+func addKeystoreFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&ksParams.dir,
+		keystoreDirFlag,
+		"",
+		"The keystore directory (defaults to keystore_dir from the config or "+config.DefaultKeystoreDir+")",
+	)
+	cmd.PersistentFlags().StringVar(
+		&ksParams.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"The file with the keystore passphrase (defaults to the "+keystore.PassphraseEnv+" environment variable)",
+	)
+}
+
+func addSaveAsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&ksParams.saveAs,
+		saveAsFlag,
+		"",
+		"Save the key to the keystore under the given name instead of writing it to the config",
+	)
+}
+
+// readConfigValue returns the option from the config file, keygen commands don't load the config by default
+func readConfigValue(key string) string {
+	if err := viper.ReadInConfig(); err != nil {
+		return ""
+	}
+	return viper.GetString("nil." + key)
+}
+
+func openKeystore() *keystore.Keystore {
+	dir := ksParams.dir
+	if dir == "" {
+		dir = readConfigValue(config.KeystoreDirField)
+	}
+	return config.OpenKeystore(dir)
+}
+
+func readPassphrase() (string, error) {
+	passphraseFile := ksParams.passphraseFile
+	if passphraseFile == "" {
+		passphraseFile = readConfigValue(config.KeystorePassphraseFileField)
+	}
+	return keystore.ReadPassphrase(passphraseFile)
+}
+
+// useKeyFromKeystore makes the config refer to the key by name instead of keeping it in plain text
+func useKeyFromKeystore(name string) error {
+	delta := map[string]any{
+		config.KeyNameField:    name,
+		config.PrivateKeyField: "",
+	}
+	if ksParams.dir != "" {
+		delta[config.KeystoreDirField] = ksParams.dir
+	}
+	return config.PatchConfig(delta, false)
+}
+
+func saveToKeystore(hexKey string) error {
+	privateKey, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return err
+	}
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return err
+	}
+
+	ks := openKeystore()
+	if err := ks.StoreSecp256k1(ksParams.saveAs, privateKey, passphrase); err != nil {
+		return err
+	}
+	if !common.Quiet {
+		fmt.Printf("Key %q saved to the keystore %s\n", ksParams.saveAs, ks.Dir())
+	}
+
+	if err := useKeyFromKeystore(ksParams.saveAs); err != nil {
+		logger.Error().Err(err).Msg("failed to update the key name in the config file")
+	}
+	return nil
+}
+
+// skipConfigUpdate overrides the parent hook, keystore commands don't touch the private key in the config
+func skipConfigUpdate(*cobra.Command, []string) error {
+	return nil
+}
+
+func ImportCommand() *cobra.Command {
+	var file, validatorKeys string
+
+	cmd := &cobra.Command{
+		Use:   "import NAME",
+		Short: "Import a key to the keystore",
+		Long: "Import a key to the keystore. By default the private key from the config is imported " +
+			"and the config is switched to use it by name. A Web3 Secret Storage (v3) key file " +
+			"or validator keys of nild can be imported instead.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(args[0], file, validatorKeys)
+		},
+		PersistentPostRunE: skipConfigUpdate,
+		SilenceUsage:       true,
+	}
+	cmd.Flags().StringVar(&file, fileFlag, "", "The Web3 Secret Storage (v3) key file to import")
+	cmd.Flags().StringVar(&validatorKeys, validatorKeysFlag, "", "The validator keys file of nild to import")
+	cmd.MarkFlagsMutuallyExclusive(fileFlag, validatorKeysFlag)
+	return cmd
+}
+
+func runImport(name, file, validatorKeys string) error {
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return err
+	}
+	ks := openKeystore()
+
+	switch {
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := ks.Import(name, content, passphrase); err != nil {
+			return err
+		}
+	case validatorKeys != "":
+		key, err := keys.LoadValidatorKeyFile(validatorKeys)
+		if err != nil {
+			return fmt.Errorf("failed to load validator keys: %w", err)
+		}
+		if err := ks.StoreBls(name, key, passphrase); err != nil {
+			return err
+		}
+	default:
+		hexKey := readConfigValue(config.PrivateKeyField)
+		if hexKey == "" {
+			return errors.New("private key is not set in the config, specify a file to import")
+		}
+		privateKey, err := crypto.HexToECDSA(hexKey)
+		if err != nil {
+			return fmt.Errorf("invalid private key in the config: %w", err)
+		}
+		if err := ks.StoreSecp256k1(name, privateKey, passphrase); err != nil {
+			return err
+		}
+		if err := useKeyFromKeystore(name); err != nil {
+			return fmt.Errorf("failed to update the config: %w", err)
+		}
+	}
+
+	if !common.Quiet {
+		fmt.Printf("Key %q imported to the keystore %s\n", name, ks.Dir())
+	}
+	return nil
+}
+
+func ExportCommand() *cobra.Command {
+	var output string
+	var plaintext bool
+
+	cmd := &cobra.Command{
+		Use:   "export NAME",
+		Short: "Export a key from the keystore",
+		Long: "Export a key from the keystore. The encrypted key file is printed by default, " +
+			"secp256k1 keys are exported in the Web3 Secret Storage (v3) format.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(args[0], output, plaintext)
+		},
+		PersistentPostRunE: skipConfigUpdate,
+		SilenceUsage:       true,
+	}
+	cmd.Flags().StringVarP(&output, outputFlag, "o", "", "The file to write the key to instead of stdout")
+	cmd.Flags().BoolVar(&plaintext, plaintextFlag, false, "Export the decrypted secp256k1 private key in hex")
+	return cmd
+}
+
+func runExport(name, output string, plaintext bool) error {
+	ks := openKeystore()
+
+	var content []byte
+	if plaintext {
+		passphrase, err := readPassphrase()
+		if err != nil {
+			return err
+		}
+		privateKey, err := ks.LoadSecp256k1(name, passphrase)
+		if err != nil {
+			return err
+		}
+		content = []byte(hexutil.Encode(crypto.FromECDSA(privateKey)))
+	} else {
+		var err error
+		if content, err = ks.Export(name); err != nil {
+			return err
+		}
+	}
+
+	if output == "" {
+		fmt.Println(string(content))
+		return nil
+	}
+	return os.WriteFile(output, content, 0o600)
+}
+
+func ListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the keys in the keystore",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList()
+		},
+		PersistentPostRunE: skipConfigUpdate,
+		SilenceUsage:       true,
+	}
+}
+
+func runList() error {
+	infos, err := openKeystore().List()
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		fmt.Printf("%s\t%s\t%s\n", info.Name, info.Type, hexutil.Encode(info.PublicKey))
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ func NewCommand(keygen *cliservice.Service) *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
+	addSaveAsFlag(cmd)
 	return cmd
 }
 
@@ -25,6 +26,10 @@ func runNew(_ *cobra.Command, _ []string, keygen *cliservice.Service) error {
 	if err := keygen.GenerateNewKey(); err != nil {
 		return err
 	}
+	// the key is not printed if it goes to the keystore
+	if ksParams.saveAs != "" {
+		return nil
+	}
 	if !common.Quiet {
 		fmt.Printf("Private key: ")
 	}
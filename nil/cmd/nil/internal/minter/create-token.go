@@ -10,20 +10,25 @@ import (
 )
 
 func CreateTokenCommand(cfg *common.Config) *cobra.Command {
+	params := &tokenParams{}
+
 	cmd := &cobra.Command{
 		Use:   "create-token [address] [amount] [name]",
 		Short: "Create a custom token",
 		Args:  cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreateToken(cmd, args, cfg)
+			return runCreateToken(cmd, args, cfg, params)
 		},
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().StringVar(&params.symbol, symbolFlag, "", "The symbol of the token")
+	cmd.Flags().Uint8Var(&params.decimals, decimalsFlag, 0, "The number of decimals of the token")
+
 	return cmd
 }
 
-func runCreateToken(cmd *cobra.Command, args []string, cfg *common.Config) error {
+func runCreateToken(cmd *cobra.Command, args []string, cfg *common.Config, params *tokenParams) error {
 	service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), cfg.PrivateKey, nil)
 
 	var address types.Address
@@ -36,15 +41,23 @@ func runCreateToken(cmd *cobra.Command, args []string, cfg *common.Config) error
 		return err
 	}
 
-	name := args[2]
+	metadata := cliservice.TokenMetadata{
+		Name:     args[2],
+		Symbol:   params.symbol,
+		Decimals: params.decimals,
+	}
 
-	tokenId, err := service.TokenCreate(address, amount, name)
+	tokenId, err := service.TokenCreate(address, amount, metadata)
 	if err != nil {
 		return err
 	}
 	if !common.Quiet {
 		fmt.Print("Created Token ID: ")
 	}
-	fmt.Println(tokenId)
+	fmt.Print(tokenId)
+	if metadata.Symbol != "" && !common.Quiet {
+		fmt.Printf("\t[%s]", metadata.Symbol)
+	}
+	fmt.Println()
 	return nil
 }
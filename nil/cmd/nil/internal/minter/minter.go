@@ -14,6 +14,8 @@ func GetCommand(cfg *common.Config) *cobra.Command {
 	serverCmd.AddCommand(CreateTokenCommand(cfg))
 	serverCmd.AddCommand(ChangeTokenAmountCommand(cfg, true))
 	serverCmd.AddCommand(ChangeTokenAmountCommand(cfg, false))
+	serverCmd.AddCommand(TokenInfoCommand(cfg))
+	serverCmd.AddCommand(TokenHoldersCommand(cfg))
 
 	return serverCmd
 }
@@ -0,0 +1,13 @@
+package minter
+
+const (
+	symbolFlag   = "symbol"
+	decimalsFlag = "decimals"
+	pageFlag     = "page"
+)
+
+type tokenParams struct {
+	symbol   string
+	decimals uint8
+	page     uint64
+}
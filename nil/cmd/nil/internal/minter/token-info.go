@@ -0,0 +1,85 @@
+package minter
+
+import (
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/spf13/cobra"
+)
+
+func TokenInfoCommand(cfg *common.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token-info [token id]",
+		Short: "Get the metadata of a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTokenInfo(cmd, args, cfg)
+		},
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func runTokenInfo(cmd *cobra.Command, args []string, cfg *common.Config) error {
+	service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), cfg.PrivateKey, nil)
+
+	var tokenId types.Address
+	if err := tokenId.Set(args[0]); err != nil {
+		return err
+	}
+
+	info, err := service.GetTokenInfo(types.TokenId(tokenId))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Token ID:     %s\n", info.Id)
+	fmt.Printf("Minter:       %s\n", info.Minter)
+	fmt.Printf("Name:         %s\n", info.Name)
+	fmt.Printf("Symbol:       %s\n", info.Symbol)
+	fmt.Printf("Decimals:     %d\n", info.Decimals)
+	fmt.Printf("Total supply: %s\n", info.TotalSupply)
+	return nil
+}
+
+func TokenHoldersCommand(cfg *common.Config) *cobra.Command {
+	params := &tokenParams{}
+
+	cmd := &cobra.Command{
+		Use:   "token-holders [token id]",
+		Short: "Get the accounts holding a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTokenHolders(cmd, args, cfg, params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().Uint64Var(&params.page, pageFlag, 0, "The page of the holders list")
+
+	return cmd
+}
+
+func runTokenHolders(cmd *cobra.Command, args []string, cfg *common.Config, params *tokenParams) error {
+	service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), cfg.PrivateKey, nil)
+
+	var tokenId types.Address
+	if err := tokenId.Set(args[0]); err != nil {
+		return err
+	}
+
+	holders, err := service.GetTokenHolders(types.TokenId(tokenId), params.page)
+	if err != nil {
+		return err
+	}
+	if !common.Quiet {
+		fmt.Println("Token holders:")
+	}
+	for _, holder := range holders {
+		fmt.Printf("%s\t%s\n", holder.Address, holder.Balance)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package smartaccount
+
+import (
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+// multisigCommands don't need the smart account address from the config file
+var multisigCommands = map[string]bool{
+	"sign":    true,
+	"combine": true,
+	"submit":  true,
+}
+
+// parseMultisigOwners accepts both public keys and addresses of the owners
+func parseMultisigOwners(values []string) ([]ethcommon.Address, error) {
+	owners := make([]ethcommon.Address, 0, len(values))
+	for _, value := range values {
+		data, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid owner %q: %w", value, err)
+		}
+		if len(data) == ethcommon.AddressLength {
+			owners = append(owners, ethcommon.BytesToAddress(data))
+			continue
+		}
+		pubKey, err := crypto.UnmarshalPubkey(data)
+		if err != nil {
+			pubKey, err = crypto.DecompressPubkey(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid owner %q: expected a public key or an address", value)
+		}
+		owners = append(owners, crypto.PubkeyToAddress(*pubKey))
+	}
+	return owners, nil
+}
+
+func MultisigPrepareCommand(cfg *common.Config) *cobra.Command {
+	params := &smartAccountParams{
+		Params: &common.Params{},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "prepare [address] [bytecode or method] [args...]",
+		Short: "Prepare an unsigned transaction of the multisig smart account",
+		Long: "Prepare a transaction to the smart contract via the multisig smart account and save it to a file. " +
+			"The file is passed to the owners to be signed offline.",
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMultisigPrepare(cmd, args, cfg, params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&params.AbiPath, abiFlag, "", "The path to the ABI file")
+	cmd.Flags().Var(&params.amount, amountFlag, "The amount of default tokens to send")
+	cmd.Flags().Var(&params.Fee.FeeCredit, feeCreditFlag,
+		"The fee credit for transaction processing. If set to 0, it will be estimated automatically")
+	cmd.Flags().StringArrayVar(&params.tokens, tokenFlag, nil,
+		"The custom tokens to transfer in as a map 'tokenId=amount', can be set multiple times")
+	cmd.Flags().StringVarP(&params.out, outFlag, "o", "", "The path to the output file")
+	check.PanicIfErr(cmd.MarkFlagRequired(outFlag))
+
+	return cmd
+}
+
+func runMultisigPrepare(cmd *cobra.Command, args []string, cfg *common.Config, params *smartAccountParams) error {
+	service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), nil, nil)
+
+	var address types.Address
+	if err := address.Set(args[0]); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+
+	abi, err := common.ReadAbiFromFile(params.AbiPath)
+	if err != nil {
+		return err
+	}
+
+	calldata, err := common.PrepareArgs(abi, args[1], args[2:])
+	if err != nil {
+		return err
+	}
+
+	tokens, err := common.ParseTokens(params.tokens)
+	if err != nil {
+		return err
+	}
+
+	m, err := service.PrepareMultisigTransaction(
+		cfg.Address, calldata, types.NewFeePackFromFeeCredit(params.Fee.FeeCredit), params.amount, tokens, address)
+	if err != nil {
+		return err
+	}
+	if err := m.WriteToFile(params.out); err != nil {
+		return err
+	}
+
+	if !common.Quiet {
+		fmt.Print("Signing hash: ")
+	}
+	fmt.Println(m.SigningHash)
+	return nil
+}
+
+func MultisigSignCommand(cfg *common.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sign [path to file]",
+		Short: "Sign a multisig transaction with the key from the config file",
+		Long:  "Add the signature made with the private key from the config file to the multisig transaction file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := cliservice.ReadMultisigTransaction(args[0])
+			if err != nil {
+				return err
+			}
+			signer, err := m.Sign(cfg.PrivateKey)
+			if err != nil {
+				return err
+			}
+			if err := m.WriteToFile(args[0]); err != nil {
+				return err
+			}
+
+			if !common.Quiet {
+				fmt.Print("Signed by: ")
+			}
+			fmt.Println(signer.Hex())
+			return nil
+		},
+		SilenceUsage: true,
+	}
+}
+
+func MultisigCombineCommand() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "combine [path to file] [path to file...]",
+		Short: "Combine signatures of a multisig transaction",
+		Long:  "Combine the signatures collected for the same multisig transaction in separate files",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := cliservice.ReadMultisigTransaction(args[0])
+			if err != nil {
+				return err
+			}
+			for _, path := range args[1:] {
+				other, err := cliservice.ReadMultisigTransaction(path)
+				if err != nil {
+					return err
+				}
+				if err := m.Merge(other); err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+			}
+			if err := m.WriteToFile(out); err != nil {
+				return err
+			}
+
+			if !common.Quiet {
+				fmt.Print("Signatures: ")
+			}
+			fmt.Println(len(m.Signatures))
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&out, outFlag, "o", "", "The path to the output file")
+	check.PanicIfErr(cmd.MarkFlagRequired(outFlag))
+
+	return cmd
+}
+
+func MultisigSubmitCommand() *cobra.Command {
+	var noWait bool
+
+	cmd := &cobra.Command{
+		Use:   "submit [path to file]",
+		Short: "Submit a signed multisig transaction",
+		Long:  "Send the multisig transaction once it is signed by enough owners",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := cliservice.ReadMultisigTransaction(args[0])
+			if err != nil {
+				return err
+			}
+			service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), nil, nil)
+			txnHash, err := service.SubmitMultisigTransaction(m)
+			if err != nil {
+				return err
+			}
+
+			if !noWait {
+				receipt, err := service.WaitForReceipt(txnHash)
+				if err != nil {
+					return err
+				}
+				if !receipt.AllSuccess() {
+					return fmt.Errorf("multisig transaction processing failed: %s", receipt.ErrorMessage)
+				}
+			}
+
+			if !common.Quiet {
+				fmt.Print("Transaction hash: ")
+			}
+			fmt.Println(txnHash)
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&noWait, noWaitFlag, false, "Define whether the command should wait for the receipt")
+
+	return cmd
+}
@@ -57,6 +57,27 @@ func setFlags(cmd *cobra.Command, params *smartAccountParams) {
 		amountFlag,
 		"The initial balance (capped at 10'000'000). The deployment fee will be subtracted from this balance",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.multisig,
+		multisigFlag,
+		false,
+		"Create an M-of-N multisig smart account controlled by the specified owners",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.owners,
+		ownerFlag,
+		nil,
+		"The public key or the address of a multisig owner, can be set multiple times",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.threshold,
+		thresholdFlag,
+		0,
+		"The number of owner signatures required by the multisig smart account",
+	)
 }
 
 func runNew(cmd *cobra.Command, _ []string, cfg *common.Config, params *smartAccountParams) error {
@@ -74,10 +95,27 @@ func runNew(cmd *cobra.Command, _ []string, cfg *common.Config, params *smartAcc
 	}
 	srv := cliservice.NewService(cmd.Context(), common.GetRpcClient(), cfg.PrivateKey, faucet)
 	check.PanicIfNotf(cfg.PrivateKey != nil, "A private key is not set in the config file")
-	smartAccountAddress, err := srv.CreateSmartAccount(params.shardId, &params.salt, amount,
-		types.NewFeePackFromFeeCredit(params.Fee.FeeCredit), &cfg.PrivateKey.PublicKey)
-	if err != nil {
-		return err
+	fee := types.NewFeePackFromFeeCredit(params.Fee.FeeCredit)
+	var smartAccountAddress types.Address
+	if params.multisig {
+		owners, err := parseMultisigOwners(params.owners)
+		if err != nil {
+			return err
+		}
+		smartAccountAddress, err = srv.CreateMultisigSmartAccount(
+			params.shardId, &params.salt, amount, fee, owners, params.threshold)
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(params.owners) > 0 || params.threshold != 0 {
+			return fmt.Errorf("--%s and --%s require --%s", ownerFlag, thresholdFlag, multisigFlag)
+		}
+		smartAccountAddress, err = srv.CreateSmartAccount(
+			params.shardId, &params.salt, amount, fee, &cfg.PrivateKey.PublicKey)
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := config.PatchConfig(map[string]any{
@@ -21,6 +21,10 @@ const (
 	asJsonFlag       = "json"
 	compileInput     = "compile-input"
 	priorityFee      = "priority-fee"
+	multisigFlag     = "multisig"
+	ownerFlag        = "owner"
+	thresholdFlag    = "threshold"
+	outFlag          = "out"
 )
 
 type smartAccountParams struct {
@@ -37,4 +41,8 @@ type smartAccountParams struct {
 	tokens                []string
 	compileInput          string
 	priorityFee           string
+	multisig              bool
+	owners                []string
+	threshold             uint64
+	out                   string
 }
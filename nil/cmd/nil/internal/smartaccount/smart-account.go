@@ -24,10 +24,11 @@ func GetCommand(cfg *common.Config) *cobra.Command {
 					}
 				}
 			}
-			if cfg.PrivateKey == nil {
+			// combining and submitting signatures doesn't involve the key from the config file
+			if cfg.PrivateKey == nil && cmd.Name() != "combine" && cmd.Name() != "submit" {
 				return config.MissingKeyError(config.PrivateKeyField, logger)
 			}
-			if cfg.Address == types.EmptyAddress && cmd.Name() != "new" {
+			if cfg.Address == types.EmptyAddress && cmd.Name() != "new" && !multisigCommands[cmd.Name()] {
 				return config.MissingKeyError(config.AddressField, logger)
 			}
 			return nil
@@ -45,6 +46,10 @@ func GetCommand(cfg *common.Config) *cobra.Command {
 		NewCommand(cfg),
 		CallReadonlyCommand(cfg),
 		GetEstimateFeeCommand(cfg),
+		MultisigPrepareCommand(cfg),
+		MultisigSignCommand(cfg),
+		MultisigCombineCommand(),
+		MultisigSubmitCommand(),
 	)
 
 	return serverCmd
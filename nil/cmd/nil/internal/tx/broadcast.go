@@ -0,0 +1,56 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/spf13/cobra"
+)
+
+func BroadcastCommand() *cobra.Command {
+	var noWait bool
+
+	cmd := &cobra.Command{
+		Use:   "broadcast [path to file]",
+		Short: "Broadcast a signed transaction",
+		Long:  "Send the raw bytes of a transaction signed by \"tx sign\" to the cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txn, err := cliservice.ReadExternalTransaction(args[0])
+			if err != nil {
+				return err
+			}
+			if len(txn.AuthData) == 0 {
+				logger.Warn().Msg("The transaction is not signed")
+			}
+
+			service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), nil, nil)
+			txnHash, err := service.BroadcastExternalTransaction(txn)
+			if err != nil {
+				return err
+			}
+
+			if !noWait {
+				receipt, err := service.WaitForReceipt(txnHash)
+				if err != nil {
+					return err
+				}
+				if !receipt.AllSuccess() {
+					return fmt.Errorf("transaction %s processing failed: %s", txnHash, receipt.ErrorMessage)
+				}
+			}
+
+			if !common.Quiet {
+				fmt.Print("Transaction hash: ")
+			}
+			fmt.Println(txnHash)
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&noWait, noWaitFlag, false, "Define whether the command should wait for the receipt")
+
+	return cmd
+}
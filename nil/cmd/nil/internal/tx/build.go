@@ -0,0 +1,182 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	libcommon "github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/spf13/cobra"
+)
+
+func BuildCommand(cfg *common.Config) *cobra.Command {
+	params := &buildParams{
+		Params: &common.Params{},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "build [address] [bytecode or method] [args...]",
+		Short: "Build an unsigned external transaction",
+		Long: "Build an unsigned external transaction calling the contract via the smart account. " +
+			"With --deploy the arguments are the path to the bytecode file and the constructor arguments. " +
+			"Seqno and fee are fetched from the cluster unless they are specified explicitly.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBuild(cmd, args, cfg, params)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&params.AbiPath, abiFlag, "", "The path to the ABI file")
+	cmd.Flags().Var(&params.amount, amountFlag, "The amount of default tokens to send")
+	cmd.Flags().StringArrayVar(&params.tokens, tokenFlag, nil,
+		"The custom tokens to transfer in as a map 'tokenId=amount', can be set multiple times")
+	cmd.Flags().BoolVar(&params.direct, directFlag, false,
+		"Send the transaction directly to the contract instead of calling it via the smart account")
+	cmd.Flags().BoolVar(&params.deploy, deployFlag, false, "Build a transaction deploying a contract")
+	params.salt = *types.NewUint256(0)
+	cmd.Flags().Var(&params.salt, saltFlag, "The salt for the deployed contract address")
+	cmd.Flags().Var(types.NewShardId(&params.shardId, types.BaseShardId), shardIdFlag,
+		"The shard ID of the deployed contract")
+	cmd.Flags().Var(&params.smartAccount, smartAccountFlag,
+		"The smart account sending the transaction (the one from the config file by default)")
+
+	cmd.Flags().Uint64Var(&params.seqno, seqnoFlag, 0, "The seqno of the transaction (fetched if not set)")
+	cmd.Flags().Uint64Var(&params.chainId, chainIdFlag, uint64(types.DefaultChainId), "The chain ID")
+	cmd.Flags().Var(&params.Fee.FeeCredit, feeCreditFlag,
+		"The fee credit for transaction processing. If set to 0, the fee is estimated by the cluster")
+	cmd.Flags().Var(&params.Fee.MaxFeePerGas, maxFeeFlag, "The maximal fee per gas")
+	cmd.Flags().Var(&params.Fee.MaxPriorityFeePerGas, priorityFeeFlag, "The maximal priority fee per gas")
+
+	cmd.Flags().StringVarP(&params.out, outFlag, outFlagShort, "", "The path to the output file (stdout by default)")
+
+	return cmd
+}
+
+func runBuild(cmd *cobra.Command, args []string, cfg *common.Config, params *buildParams) error {
+	service := cliservice.NewService(cmd.Context(), common.GetRpcClient(), nil, nil)
+
+	smartAccount := params.smartAccount
+	if smartAccount == types.EmptyAddress {
+		smartAccount = cfg.Address
+	}
+	if !params.direct && smartAccount == types.EmptyAddress {
+		return fmt.Errorf("smart account is not set: use --%s or the config file", smartAccountFlag)
+	}
+
+	var (
+		to, contractAddress types.Address
+		data                types.Code
+		err                 error
+	)
+	if params.deploy {
+		to, data, contractAddress, err = buildDeployPayload(args, smartAccount, params)
+	} else {
+		to, data, err = buildCallPayload(args, smartAccount, params)
+	}
+	if err != nil {
+		return err
+	}
+
+	var seqno *types.Seqno
+	if cmd.Flags().Changed(seqnoFlag) {
+		seqno = (*types.Seqno)(&params.seqno)
+	}
+	txn, err := service.BuildExternalTransaction(
+		to, data, params.deploy && params.direct, seqno, params.Fee, types.ChainId(params.chainId))
+	if err != nil {
+		return err
+	}
+
+	if err := writeTransaction(txn, params.out, false); err != nil {
+		return err
+	}
+	// stdout is occupied by the transaction itself if there is no output file
+	if params.out == "" || common.Quiet {
+		return nil
+	}
+	hash, err := txn.SigningHash()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Signing hash:", hash)
+	if params.deploy {
+		fmt.Println("Contract address:", contractAddress)
+	}
+	return nil
+}
+
+// buildCallPayload returns the destination and the data of the transaction calling the contract
+func buildCallPayload(
+	args []string, smartAccount types.Address, params *buildParams,
+) (types.Address, types.Code, error) {
+	if len(args) < 2 {
+		return types.EmptyAddress, nil, errors.New("address and bytecode or method are required")
+	}
+
+	var address types.Address
+	if err := address.Set(args[0]); err != nil {
+		return types.EmptyAddress, nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	// the ABI is not needed if the calldata is passed as is
+	var contractAbi abi.ABI
+	if params.AbiPath != "" {
+		var err error
+		if contractAbi, err = common.ReadAbiFromFile(params.AbiPath); err != nil {
+			return types.EmptyAddress, nil, err
+		}
+	}
+	calldata, err := common.PrepareArgs(contractAbi, args[1], args[2:])
+	if err != nil {
+		return types.EmptyAddress, nil, err
+	}
+
+	if params.direct {
+		if !params.amount.IsZero() || len(params.tokens) > 0 {
+			return types.EmptyAddress, nil, errors.New("external transactions can't transfer tokens")
+		}
+		return address, calldata, nil
+	}
+
+	tokens, err := common.ParseTokens(params.tokens)
+	if err != nil {
+		return types.EmptyAddress, nil, err
+	}
+	payload, err := client.CreateInternalTransactionPayload(calldata, params.amount, tokens, address, false)
+	if err != nil {
+		return types.EmptyAddress, nil, err
+	}
+	return smartAccount, payload, nil
+}
+
+// buildDeployPayload returns the destination and the data of the transaction deploying the contract
+// along with the address of the contract
+func buildDeployPayload(
+	args []string, smartAccount types.Address, params *buildParams,
+) (types.Address, types.Code, types.Address, error) {
+	if len(args) == 0 {
+		return types.EmptyAddress, nil, types.EmptyAddress, errors.New("path to the bytecode file is required")
+	}
+	bytecode, err := common.ReadBytecode(args[0], params.AbiPath, args[1:])
+	if err != nil {
+		return types.EmptyAddress, nil, types.EmptyAddress, err
+	}
+	payload := types.BuildDeployPayload(bytecode, libcommon.Hash(params.salt.Bytes32()))
+	contractAddress := types.CreateAddress(params.shardId, payload)
+
+	if params.direct {
+		return contractAddress, payload.Bytes(), contractAddress, nil
+	}
+
+	data, err := client.CreateInternalTransactionPayload(
+		payload.Bytes(), params.amount, nil, contractAddress, true)
+	if err != nil {
+		return types.EmptyAddress, nil, types.EmptyAddress, err
+	}
+	return smartAccount, data, contractAddress, nil
+}
@@ -0,0 +1,192 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	libcommon "github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/contracts"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+func InspectCommand() *cobra.Command {
+	var abiPath string
+
+	cmd := &cobra.Command{
+		Use:   "inspect [path to file]",
+		Short: "Show the content of a transaction",
+		Long: "Show the fields of a transaction and decode its calldata. " +
+			"The ABI of the called contract is taken from the file or fetched from Cometa.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txn, err := cliservice.ReadExternalTransaction(args[0])
+			if err != nil {
+				return err
+			}
+
+			var contractAbi *abi.ABI
+			if abiPath != "" {
+				a, err := common.ReadAbiFromFile(abiPath)
+				if err != nil {
+					return err
+				}
+				contractAbi = &a
+			}
+			return inspectTransaction(cmd.Context(), txn, contractAbi)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&abiPath, abiFlag, "", "The path to the ABI of the called contract")
+
+	return cmd
+}
+
+func inspectTransaction(ctx context.Context, txn *types.ExternalTransaction, contractAbi *abi.ABI) error {
+	signingHash, err := txn.SigningHash()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Hash:", txn.Hash())
+	fmt.Println("Signing hash:", signingHash)
+	fmt.Println("Kind:", txn.Kind)
+	fmt.Printf("To: %s (shard %d)\n", txn.To, txn.To.ShardId())
+	fmt.Println("ChainId:", txn.ChainId)
+	fmt.Println("Seqno:", txn.Seqno)
+	fmt.Println("FeeCredit:", txn.FeeCredit)
+	fmt.Println("MaxFeePerGas:", txn.MaxFeePerGas)
+	fmt.Println("MaxPriorityFeePerGas:", txn.MaxPriorityFeePerGas)
+	printAuthData(signingHash, txn.AuthData)
+
+	if len(txn.Data) == 0 {
+		fmt.Println("Data: <empty>")
+		return nil
+	}
+	fmt.Println("Data:", txn.Data.Hex())
+
+	if txn.Kind == types.DeployTransactionKind {
+		printDeploy("Deploy", txn.To.ShardId(), txn.Data, &txn.To)
+		return nil
+	}
+
+	if method, args := decodeSmartAccountCall(txn.Data); method != "" {
+		printSmartAccountCall(ctx, method, args, contractAbi)
+		return nil
+	}
+	fmt.Println("Call:", decodeCall(ctx, txn.To, txn.Data, contractAbi))
+	return nil
+}
+
+func printAuthData(signingHash libcommon.Hash, authData []byte) {
+	if len(authData) == 0 {
+		fmt.Println("Signed: no")
+		return
+	}
+	fmt.Println("AuthData:", hexutil.Encode(authData))
+	if len(authData)%crypto.SignatureLength != 0 {
+		return
+	}
+
+	// a single signature is made by the owner of a regular smart account,
+	// several ones are made by the owners of a multisig smart account
+	for i := 0; i < len(authData); i += crypto.SignatureLength {
+		pubKey, err := crypto.SigToPub(signingHash.Bytes(), authData[i:i+crypto.SignatureLength])
+		if err != nil {
+			fmt.Println("Signer: <invalid signature>")
+			continue
+		}
+		fmt.Printf("Signer: %s (address %s)\n",
+			hexutil.Encode(crypto.FromECDSAPub(pubKey)), crypto.PubkeyToAddress(*pubKey))
+	}
+}
+
+// decodeSmartAccountCall recognizes the calls of the smart account made by "tx build"
+func decodeSmartAccountCall(data []byte) (string, []any) {
+	smartAccountAbi, err := contracts.GetAbi(contracts.NameSmartAccount)
+	if err != nil || len(data) < 4 {
+		return "", nil
+	}
+	method, err := smartAccountAbi.MethodById(data[:4])
+	if err != nil || (method.Name != "asyncCall" && method.Name != "asyncDeploy") {
+		return "", nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", nil
+	}
+	return method.Name, args
+}
+
+func printSmartAccountCall(ctx context.Context, method string, args []any, contractAbi *abi.ABI) {
+	if method == "asyncDeploy" {
+		// asyncDeploy(shardId, value, code, salt)
+		shardId, _ := args[0].(*big.Int)
+		value, _ := args[1].(*big.Int)
+		code, _ := args[2].([]byte)
+		salt, _ := args[3].(*big.Int)
+		fmt.Println("Value:", value)
+		if shardId == nil || salt == nil {
+			return
+		}
+		payload := types.BuildDeployPayload(code, libcommon.BigToHash(salt))
+		printDeploy("Deploy via smart account", types.ShardId(shardId.Uint64()), payload.Bytes(), nil)
+		return
+	}
+
+	// asyncCall(dst, refundTo, bounceTo, tokens, value, callData)
+	dst, _ := args[0].(types.Address)
+	value, _ := args[4].(*big.Int)
+	callData, _ := args[5].([]byte)
+	fmt.Printf("Call via smart account: %s\n", dst)
+	fmt.Println("Value:", value)
+	fmt.Printf("Tokens: %v\n", args[3])
+	if len(callData) == 0 {
+		fmt.Println("Call: <empty>")
+		return
+	}
+	fmt.Println("Call:", decodeCall(ctx, dst, callData, contractAbi))
+}
+
+func printDeploy(title string, shardId types.ShardId, data []byte, to *types.Address) {
+	payload := types.ParseDeployPayload(data)
+	if payload == nil {
+		fmt.Printf("%s: <invalid deploy payload>\n", title)
+		return
+	}
+	address := types.CreateAddress(shardId, *payload)
+	fmt.Printf("%s: %d bytes of code, salt %s, address %s\n", title, len(payload.Code()), payload.Salt(), address)
+	if to != nil && *to != address {
+		fmt.Println("Warning: the destination doesn't match the deployed contract address")
+	}
+}
+
+// decodeCall decodes the calldata with the given ABI or with the ABI of the contract registered in Cometa
+func decodeCall(ctx context.Context, address types.Address, data []byte, contractAbi *abi.ABI) string {
+	if contractAbi == nil {
+		a, err := common.FetchAbiFromCometa(ctx, address)
+		if err != nil {
+			return fmt.Sprintf("<unknown: %s>", err)
+		}
+		contractAbi = &a
+	}
+	if len(data) < 4 {
+		return "<unknown: too short calldata>"
+	}
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return fmt.Sprintf("<unknown: %s>", err)
+	}
+	res, err := contracts.DecodeCallData(method, data)
+	if err != nil {
+		return fmt.Sprintf("<unknown: %s>", err)
+	}
+	return res
+}
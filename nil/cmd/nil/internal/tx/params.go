@@ -0,0 +1,42 @@
+package tx
+
+import (
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+const (
+	abiFlag          = "abi"
+	amountFlag       = "amount"
+	chainIdFlag      = "chain-id"
+	deployFlag       = "deploy"
+	directFlag       = "direct"
+	feeCreditFlag    = "fee-credit"
+	maxFeeFlag       = "max-fee-per-gas"
+	noWaitFlag       = "no-wait"
+	outFlag          = "out"
+	outFlagShort     = "o"
+	priorityFeeFlag  = "priority-fee"
+	rawFlag          = "raw"
+	saltFlag         = "salt"
+	seqnoFlag        = "seqno"
+	shardIdFlag      = "shard-id"
+	tokenFlag        = "token"
+	smartAccountFlag = "smart-account"
+)
+
+type buildParams struct {
+	*common.Params
+
+	direct       bool
+	deploy       bool
+	amount       types.Value
+	tokens       []string
+	salt         types.Uint256
+	shardId      types.ShardId
+	seqno        uint64
+	chainId      uint64
+	smartAccount types.Address
+	out          string
+	raw          bool
+}
@@ -0,0 +1,60 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/cmd/nil/internal/config"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/spf13/cobra"
+)
+
+func SignCommand(cfg *common.Config) *cobra.Command {
+	var (
+		out string
+		raw bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sign [path to file]",
+		Short: "Sign a transaction with the key from the config file",
+		Long: "Sign a transaction built with \"tx build\". The cluster is not accessed, " +
+			"so the transaction can be signed on an offline machine.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.PrivateKey == nil {
+				return config.MissingKeyError(config.PrivateKeyField, logger)
+			}
+
+			txn, err := cliservice.ReadExternalTransaction(args[0])
+			if err != nil {
+				return err
+			}
+			// the service is used only for signing, so it doesn't need a client
+			service := cliservice.NewService(cmd.Context(), nil, cfg.PrivateKey, nil)
+			if err := service.SignExternalTransaction(txn); err != nil {
+				return err
+			}
+
+			if out == "" {
+				out = args[0]
+			}
+			if err := writeTransaction(txn, out, raw); err != nil {
+				return err
+			}
+
+			if !common.Quiet {
+				fmt.Print("Transaction hash: ")
+			}
+			fmt.Println(txn.Hash())
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&out, outFlag, outFlagShort, "",
+		"The path to the signed transaction file (the input file is overwritten by default)")
+	cmd.Flags().BoolVar(&raw, rawFlag, false, "Write the hex-encoded raw transaction instead of JSON")
+
+	return cmd
+}
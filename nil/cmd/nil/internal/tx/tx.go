@@ -0,0 +1,48 @@
+package tx
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/NilFoundation/nil/nil/cmd/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/cliservice"
+	"github.com/spf13/cobra"
+)
+
+var logger = logging.NewLogger("txCommand")
+
+func GetCommand(cfg *common.Config) *cobra.Command {
+	serverCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Build, sign and broadcast external transactions separately",
+		Long: "Build an unsigned external transaction, sign it on an offline machine " +
+			"and broadcast it to the cluster afterwards",
+	}
+
+	serverCmd.AddCommand(
+		BuildCommand(cfg),
+		SignCommand(cfg),
+		BroadcastCommand(),
+		InspectCommand(),
+	)
+
+	return serverCmd
+}
+
+// writeTransaction writes the transaction to the file or to stdout if the path is empty
+func writeTransaction(txn *types.ExternalTransaction, path string, raw bool) error {
+	data, err := cliservice.EncodeExternalTransaction(txn, raw)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write transaction: %w", err)
+	}
+	return nil
+}
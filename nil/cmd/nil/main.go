@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/NilFoundation/nil/nil/cmd/nil/common"
 	"github.com/NilFoundation/nil/nil/cmd/nil/internal/abi"
@@ -17,6 +18,7 @@ import (
 	"github.com/NilFoundation/nil/nil/cmd/nil/internal/smartaccount"
 	"github.com/NilFoundation/nil/nil/cmd/nil/internal/system"
 	"github.com/NilFoundation/nil/nil/cmd/nil/internal/transaction"
+	"github.com/NilFoundation/nil/nil/cmd/nil/internal/tx"
 	"github.com/NilFoundation/nil/nil/cmd/nil/internal/version"
 	"github.com/NilFoundation/nil/nil/common/check"
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -46,6 +48,13 @@ var noConfigCmd = map[string]struct{}{
 	"version":          {},
 }
 
+// offlineCmd holds the commands that use the config, but don't access the cluster,
+// so they work without the RPC endpoint
+var offlineCmd = map[string]struct{}{
+	"tx sign":    {},
+	"tx inspect": {},
+}
+
 func main() {
 	var rootCmd *RootCommand
 
@@ -66,6 +75,12 @@ func main() {
 				// E.g. "keygen" command writes a private key to the config file (and creates if it doesn't exist)
 				config.SetConfigFile(rootCmd.cfgFile)
 
+				loadConfig := config.LoadConfig
+				cmdPath := strings.TrimPrefix(cmd.CommandPath(), rootCmd.baseCmd.Name()+" ")
+				if _, offline := offlineCmd[cmdPath]; offline {
+					loadConfig = config.LoadOfflineConfig
+				}
+
 				// Traverse up to find the top-level command
 				for cmd.HasParent() && cmd.Parent() != rootCmd.baseCmd {
 					cmd = cmd.Parent()
@@ -76,7 +91,7 @@ func main() {
 				}
 
 				var err error
-				cfg, err := config.LoadConfig(rootCmd.cfgFile, logger)
+				cfg, err := loadConfig(rootCmd.cfgFile, logger)
 				if err != nil {
 					return err
 				}
@@ -129,6 +144,7 @@ func (rc *RootCommand) registerSubCommands() {
 		contract.GetCommand(&rc.config),
 		keygen.GetCommand(),
 		transaction.GetCommand(&rc.cfgFile),
+		tx.GetCommand(&rc.config),
 		minter.GetCommand(&rc.config),
 		receipt.GetCommand(&rc.config),
 		system.GetCommand(&rc.config),
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/keys"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
+	"github.com/NilFoundation/nil/nil/services/remotesigner"
+	"github.com/spf13/cobra"
+)
+
+type config struct {
+	endpoint      string
+	dbPath        string
+	retainHeights uint64
+
+	validatorKeysPath string
+	keystoreDir       string
+	keyName           string
+	passphraseFile    string
+}
+
+func main() {
+	check.PanicIfErr(execute())
+}
+
+func execute() error {
+	cfg := &config{}
+
+	rootCmd := &cobra.Command{
+		Use:           os.Args[0],
+		Short:         "Remote signer of consensus messages for nild validators",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	logLevel := rootCmd.PersistentFlags().StringP(
+		"log-level",
+		"l",
+		"info",
+		"log level: trace|debug|info|warn|error|fatal|panic")
+
+	runCmd := &cobra.Command{
+		Use:   "run",
+		Short: "Serve signing requests",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logging.SetupGlobalLogger(*logLevel)
+			return run(cmd.Context(), cfg)
+		},
+	}
+	runCmd.Flags().StringVar(&cfg.endpoint, "endpoint", "unix:///tmp/nil_signer.sock", "endpoint to serve requests on")
+	runCmd.Flags().StringVar(&cfg.dbPath, "db-path", "signer.db", "path to the slashing protection database")
+	runCmd.Flags().Uint64Var(
+		&cfg.retainHeights,
+		"retain-heights",
+		signer.DefaultRetainHeights,
+		"number of recent heights to keep in the slashing protection database (0 to keep all)")
+	runCmd.Flags().StringVar(&cfg.validatorKeysPath, "validator-keys", "", "path to the plain validator keys file")
+	runCmd.Flags().StringVar(&cfg.keystoreDir, "keystore-dir", "", "keystore directory with the validator key")
+	runCmd.Flags().StringVar(&cfg.keyName, "key-name", "validator", "name of the validator key in the keystore")
+	runCmd.Flags().StringVar(
+		&cfg.passphraseFile,
+		"passphrase-file",
+		"",
+		fmt.Sprintf("file with the keystore passphrase, %s env variable is used if not set", keystore.PassphraseEnv))
+	runCmd.MarkFlagsMutuallyExclusive("validator-keys", "keystore-dir")
+	runCmd.MarkFlagsOneRequired("validator-keys", "keystore-dir")
+
+	rootCmd.AddCommand(runCmd)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+func loadKey(cfg *config) (bls.PrivateKey, error) {
+	if cfg.keystoreDir == "" {
+		return keys.LoadValidatorKeyFile(cfg.validatorKeysPath)
+	}
+	passphrase, err := keystore.ReadPassphrase(cfg.passphraseFile)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.New(cfg.keystoreDir).LoadBls(cfg.keyName, passphrase)
+}
+
+func run(ctx context.Context, cfg *config) error {
+	logger := logging.NewLogger("signer")
+
+	// Only the node user is supposed to reach the signer. The umask is set before the socket is created,
+	// so that it is never accessible by others, the slashing protection database is kept private as well.
+	syscall.Umask(0o077)
+
+	key, err := loadKey(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load validator key: %w", err)
+	}
+
+	database, err := db.NewBadgerDb(cfg.dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open slashing protection database: %w", err)
+	}
+	defer database.Close()
+
+	localSigner, err := signer.NewLocalSigner(key, signer.NewProtection(database, cfg.retainHeights))
+	if err != nil {
+		return err
+	}
+	logger.Info().
+		Hex(logging.FieldPublicKey, localSigner.PublicKey()).
+		Str(logging.FieldUrl, cfg.endpoint).
+		Msg("Starting remote signer...")
+
+	return remotesigner.NewService(localSigner, logger).Run(ctx, cfg.endpoint, nil)
+}
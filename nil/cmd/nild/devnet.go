@@ -9,11 +9,13 @@ import (
 
 	"github.com/NilFoundation/nil/nil/cmd/nild/nildconfig"
 	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/internal/config"
 	nilcrypto "github.com/NilFoundation/nil/nil/internal/crypto"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/execution"
 	"github.com/NilFoundation/nil/nil/internal/keys"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/NilFoundation/nil/nil/internal/network"
 	"github.com/NilFoundation/nil/nil/internal/telemetry"
 	"github.com/NilFoundation/nil/nil/services/nilservice"
@@ -23,6 +25,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+var devnetLogger = logging.NewLogger("gen-configs")
+
 type nodeSpec struct {
 	ID                   int    `yaml:"id"`
 	Shards               []uint `yaml:"shards"`
@@ -61,6 +65,10 @@ type clusterSpec struct {
 	InstanceEnv            string                `yaml:"instance_env"`
 	SignozJournaldLogs     []string              `yaml:"signoz_journald_logs"`
 
+	// NildKeystorePassphraseFile protects the main key kept in the keystore of NildCredentialsDir,
+	// keystore.PassphraseEnv is used if it is not set
+	NildKeystorePassphraseFile string `yaml:"nild_keystore_passphrase_file"`
+
 	NilConfig        []nodeSpec `yaml:"nil_config"`
 	NilArchiveConfig []nodeSpec `yaml:"nil_archive_config"`
 	NilRPCConfig     []nodeSpec `yaml:"nil_rpc_config"`
@@ -138,8 +146,7 @@ func (c *cluster) generateZeroState(nShards uint32, servers []server) (*executio
 		}
 	}
 
-	mainKeyPath := c.spec.NildCredentialsDir + "/keys.yaml"
-	mainPublicKey, err := ensurePublicKey(mainKeyPath)
+	mainPublicKey, err := c.mainPublicKey()
 	if err != nil {
 		return nil, err
 	}
@@ -153,22 +160,83 @@ func (c *cluster) generateZeroState(nShards uint32, servers []server) (*executio
 	return zeroState, nil
 }
 
-func ensurePublicKey(keyPath string) ([]byte, error) {
-	privateKey, err := execution.LoadMainKeys(keyPath)
+// mainPublicKey returns the public key of the main key, generating the key if there is none.
+// The key is kept in the keystore of the credentials directory if the keystore passphrase is provided,
+// otherwise it is kept in plain text in keys.yaml.
+func (c *cluster) mainPublicKey() ([]byte, error) {
+	ks := keystore.New(c.spec.NildCredentialsDir + "/keystore")
+	keysPath := c.spec.NildCredentialsDir + "/keys.yaml"
+
+	passphrase, err := keystore.ReadPassphrase(c.spec.NildKeystorePassphraseFile)
+	if errors.Is(err, keystore.ErrNoPassphrase) {
+		return ensurePlainPublicKey(ks, keysPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock main key keystore: %w", err)
+	}
+	return ensurePublicKey(ks, keysPath, passphrase)
+}
+
+// ensurePublicKey loads the main key from the keystore.
+// The key of keys.yaml is imported into the keystore if it is not there yet.
+func ensurePublicKey(ks *keystore.Keystore, keysPath string, passphrase string) ([]byte, error) {
+	privateKey, err := execution.LoadMainKeys(ks, passphrase)
+	if err == nil {
+		return crypto.FromECDSAPub(&privateKey.PublicKey), nil
+	}
+	if !errors.Is(err, keystore.ErrKeyNotFound) {
+		// if the key exists but is invalid, return the error
+		return nil, err
+	}
+
+	privateKey, err = execution.LoadMainKeysFile(keysPath)
+	switch {
+	case err == nil:
+		devnetLogger.Info().Msgf("Importing the main key of %s into the keystore, the file can be removed", keysPath)
+	case errors.Is(err, os.ErrNotExist):
+		if privateKey, _, err = nilcrypto.GenerateKeyPair(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("failed to import main key from %s: %w", keysPath, err)
+	}
+
+	if err := execution.DumpMainKeys(ks, passphrase, privateKey); err != nil {
+		return nil, err
+	}
+	return crypto.FromECDSAPub(&privateKey.PublicKey), nil
+}
+
+// ensurePlainPublicKey is used if no keystore passphrase is provided.
+// The public key of the keystore doesn't need the passphrase, so the keystore is still preferred to keys.yaml.
+func ensurePlainPublicKey(ks *keystore.Keystore, keysPath string) ([]byte, error) {
+	publicKey, err := ks.PublicKey(execution.MainKeyName)
+	if err == nil {
+		key, err := crypto.DecompressPubkey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid main public key in the keystore: %w", err)
+		}
+		return crypto.FromECDSAPub(key), nil
+	}
+	if !errors.Is(err, keystore.ErrKeyNotFound) {
+		return nil, err
+	}
+
+	privateKey, err := execution.LoadMainKeysFile(keysPath)
 	if err == nil {
-		publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
-		return publicKey, nil
+		return crypto.FromECDSAPub(&privateKey.PublicKey), nil
 	}
 	if !errors.Is(err, os.ErrNotExist) {
 		// if the file exists but is invalid, return the error
 		return nil, err
 	}
 
-	privateKey, publicKey, err := nilcrypto.GenerateKeyPair()
+	devnetLogger.Warn().Msgf("%s is not set, the main key is kept unencrypted in %s", keystore.PassphraseEnv, keysPath)
+	privateKey, publicKey, err = nilcrypto.GenerateKeyPair()
 	if err != nil {
 		return nil, err
 	}
-	if err := execution.DumpMainKeys(keyPath, privateKey); err != nil {
+	if err := execution.DumpMainKeysFile(keysPath, privateKey); err != nil {
 		return nil, err
 	}
 	return publicKey, nil
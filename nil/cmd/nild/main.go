@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/cobrax"
 	"github.com/NilFoundation/nil/nil/internal/cobrax/cmdflags"
 	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/NilFoundation/nil/nil/internal/profiling"
 	"github.com/NilFoundation/nil/nil/internal/readthroughdb"
 	"github.com/NilFoundation/nil/nil/internal/types"
@@ -199,6 +201,23 @@ func parseArgs(logger logging.Logger) *nildconfig.Config {
 	runCmd.Flags().StringVar(&cfg.CometaConfig, "cometa-config", "", "path to Cometa config")
 	runCmd.Flags().StringVar(
 		&cfg.ValidatorKeysPath, "validator-keys-path", cfg.ValidatorKeysPath, "path to write validator keys")
+	runCmd.Flags().StringVar(
+		&cfg.ValidatorKeystoreDir,
+		"validator-keystore-dir",
+		cfg.ValidatorKeystoreDir,
+		"keystore directory to keep the encrypted validator key in, overrides --validator-keys-path")
+	runCmd.Flags().StringVar(
+		&cfg.ValidatorKeyName, "validator-key-name", cfg.ValidatorKeyName, "name of the validator key in the keystore")
+	runCmd.Flags().StringVar(
+		&cfg.KeystorePassphraseFile,
+		"keystore-passphrase-file",
+		cfg.KeystorePassphraseFile,
+		fmt.Sprintf("file with the keystore passphrase, %s env variable is used if not set", keystore.PassphraseEnv))
+	runCmd.Flags().StringVar(
+		&cfg.RemoteSignerEndpoint,
+		"remote-signer",
+		cfg.RemoteSignerEndpoint,
+		"remote signer endpoint (e.g. unix:///run/nil/signer.sock), the validator key is not loaded if set")
 	runCmd.Flags().BoolVar(&cfg.EnableDevApi, "dev-api", cfg.EnableDevApi, "enable development API")
 	runCmd.Flags().StringVar(&cfg.IndexerConfig, "indexer-config", "", "path to Indexer config")
 
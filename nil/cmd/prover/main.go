@@ -131,6 +131,25 @@ func addCommonFlags(cmd *cobra.Command, cfg *CommonConfig) {
 	)
 	cmd.PersistentFlags().StringVar(&cfg.NilRpcEndpoint, "nil-endpoint", cfg.NilRpcEndpoint, "nil rpc endpoint")
 
+	cmd.PersistentFlags().StringSliceVar(
+		&cfg.TaskTypes,
+		"task-types",
+		cfg.TaskTypes,
+		"comma separated list of task types to request from the proof provider; all supported types by default",
+	)
+	cmd.PersistentFlags().StringVar(
+		&cfg.ResourceClass,
+		"resource-class",
+		cfg.ResourceClass,
+		"resource class of the prover machine: Small|Medium|Large",
+	)
+	cmd.PersistentFlags().Uint32Var(
+		&cfg.Concurrency,
+		"concurrency",
+		cfg.Concurrency,
+		"maximum number of tasks assigned to the prover at once; 0 means no limit",
+	)
+
 	logLevel := cmd.PersistentFlags().String("log-level", "info", "log level: trace|debug|info|warn|error|fatal|panic")
 
 	cmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
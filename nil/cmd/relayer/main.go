@@ -171,6 +171,61 @@ func addRunCommandFlags(runCmd *cobra.Command, cfg *Config) error {
 		cfg.TransactionSenderConfig.DbPollInterval,
 		"Poll interval for L2 transaction sender",
 	)
+	runCmd.Flags().IntVar(
+		&cfg.TransactionSenderConfig.MaxBatchSize,
+		"l2-relay-batch-size",
+		cfg.TransactionSenderConfig.MaxBatchSize,
+		"Max number of L1 events relayed by a single L2 transaction (1 for L2BridgeMessenger without batch support)",
+	)
+	runCmd.Flags().Var(
+		&cfg.L2ContractConfig.FeeCreditCeiling,
+		"l2-fee-credit-ceiling",
+		"Max fee credit spent by a single L2 relaying transaction",
+	)
+	runCmd.Flags().Uint64Var(
+		&cfg.L2ContractConfig.FeeBumpPercent,
+		"l2-fee-bump-percent",
+		cfg.L2ContractConfig.FeeBumpPercent,
+		"Priority fee increase (in percent) for resending rejected L2 transaction",
+	)
+	runCmd.Flags().IntVar(
+		&cfg.L2ContractConfig.MaxSendAttempts,
+		"l2-max-send-attempts",
+		cfg.L2ContractConfig.MaxSendAttempts,
+		"Max number of attempts to send L2 transaction with bumped fee",
+	)
+
+	// L2->L1 withdrawal relaying flags
+	runCmd.Flags().StringVar(
+		&cfg.WithdrawalFinalizerConfig.RollupContractAddress,
+		"l1-rollup-contract-addr",
+		cfg.WithdrawalFinalizerConfig.RollupContractAddress,
+		"Address of NilRollup contract to wait for batch finalization (empty to disable withdrawal relaying)",
+	)
+	runCmd.Flags().StringVar(
+		&cfg.WithdrawalFinalizerConfig.PrivateKeyPath,
+		"l1-private-key-path",
+		cfg.WithdrawalFinalizerConfig.PrivateKeyPath,
+		"Path to private key file for L1 account claiming withdrawals",
+	)
+	runCmd.Flags().DurationVar(
+		&cfg.WithdrawalFinalizerConfig.PollInterval,
+		"l1-withdrawal-finalizer-poll-interval",
+		cfg.WithdrawalFinalizerConfig.PollInterval,
+		"Poll interval for checking finalized batches on L1",
+	)
+	runCmd.Flags().Uint64Var(
+		&cfg.WithdrawalListenerConfig.StartBlock,
+		"l2-withdrawals-start-block",
+		cfg.WithdrawalListenerConfig.StartBlock,
+		"L2 block to start fetching withdrawals from",
+	)
+	runCmd.Flags().DurationVar(
+		&cfg.WithdrawalListenerConfig.PollInterval,
+		"l2-withdrawals-poll-interval",
+		cfg.WithdrawalListenerConfig.PollInterval,
+		"Poll interval for fetching withdrawals from L2",
+	)
 
 	// L2 debug mode flags
 	runCmd.Flags().BoolVar(&cfg.L2ContractConfig.DebugMode,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/NilFoundation/nil/nil/common/check"
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -111,6 +112,30 @@ func addFlags(cmd *cobra.Command, cfg *cmdConfig) {
 		"disable-l1",
 		cfg.ContractWrapperConfig.DisableL1,
 		"Disable send trancations to L1")
+	cmd.Flags().Var(
+		&cfg.DataAvailability.Backend,
+		"da-backend",
+		fmt.Sprintf("data availability backend: %s", strings.Join(cfg.DataAvailability.Backend.PossibleValues(), "|")))
+	cmd.Flags().StringVar(
+		&cfg.DataAvailability.LocalDir,
+		"da-local-dir",
+		cfg.DataAvailability.LocalDir,
+		"directory of the local DA backend and DA server")
+	cmd.Flags().StringVar(
+		&cfg.DataAvailability.LocalEndpoint,
+		"da-local-endpoint",
+		cfg.DataAvailability.LocalEndpoint,
+		"DA server URL used by the local DA backend")
+	cmd.Flags().StringVar(
+		&cfg.DataAvailability.ServerListenAddr,
+		"da-server-listen-addr",
+		cfg.DataAvailability.ServerListenAddr,
+		"address to serve the local DA directory at")
+	cmd.Flags().StringVar(
+		&cfg.DataAvailability.ServerToken,
+		"da-server-token",
+		cfg.DataAvailability.ServerToken,
+		"token authorizing uploads to the DA server, required to listen on a non-loopback address")
 	cmd.Flags().IntVar(
 		&cfg.ProposerParams.BridgeStateKeeperShardId,
 		"bridge-state-keeper-shard-id",
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/exec"
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/output"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/debug"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"github.com/spf13/cobra"
+)
+
+type getFeeComponents struct {
+	logger logging.Logger
+}
+
+func NewGetFeeComponentsCmd(logger logging.Logger) *getFeeComponents {
+	return &getFeeComponents{
+		logger: logger,
+	}
+}
+
+func (c *getFeeComponents) Build() (*cobra.Command, error) {
+	paramsWithEndpoint := defaultParamsWithEndpoint()
+
+	cmd := &cobra.Command{
+		Use:   "get-fee-components",
+		Short: "Retrieve components of the latest fee params evaluated for L1 gas price oracle",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			executor := exec.NewExecutor(os.Stdout, c.logger, paramsWithEndpoint)
+			client := debug.NewFeesClient(paramsWithEndpoint.RpcEndpoint, c.logger)
+			return executor.Run(func(ctx context.Context) (exec.CmdOutput, error) {
+				return c.getFeeComponents(ctx, client)
+			})
+		},
+	}
+
+	paramsWithEndpoint.bind(cmd)
+	return cmd, nil
+}
+
+func (c *getFeeComponents) getFeeComponents(ctx context.Context, client public.FeeDebugApi) (exec.CmdOutput, error) {
+	components, err := client.GetFeeComponents(ctx)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to get fee components: %w", err)
+	}
+	if components == nil {
+		return exec.EmptyOutput, errors.New("fee params are not evaluated yet")
+	}
+
+	table, err := c.dataAsTable(components)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to build fee components table: %w", err)
+	}
+
+	return table.AsCmdOutput(), nil
+}
+
+func (*getFeeComponents) dataAsTable(components *public.FeeComponents) (*output.Table, error) {
+	header := output.NewTableRowStr("Field", "Value")
+
+	rows := []output.TableRow{
+		output.NewTableRow(output.StrCell("Execution Fee Per Gas"), components.ExecutionFeePerGas),
+		output.NewTableRow(output.StrCell("L1 Blob Base Fee"), components.BlobBaseFee),
+		output.NewTableRowStr("Window Batch Count", strconv.Itoa(components.WindowBatchCount)),
+		output.NewTableRowStr("Window Blob Count", strconv.FormatUint(components.WindowBlobCount, 10)),
+		output.NewTableRow(output.StrCell("Window Gas Used"), components.WindowGasUsed),
+		output.NewTableRow(output.StrCell("Raw Data Cost Per Gas"), components.RawDataCostPerGas),
+		output.NewTableRow(output.StrCell("Smoothed Data Cost Per Gas"), components.DataCostPerGas),
+		output.NewTableRow(output.StrCell("Max Fee Per Gas"), components.MaxFeePerGas),
+		output.NewTableRow(output.StrCell("Max Priority Fee Per Gas"), components.MaxPriorityFeePerGas),
+		output.NewTableRowStr("Evaluated At", components.EvaluatedAt.Format(time.RFC3339)),
+	}
+
+	return output.NewTable(header, rows)
+}
@@ -112,10 +112,14 @@ func (*getTaskTree) buildTreeOutput(tree *public.TaskTreeView) (exec.CmdOutput,
 
 		var statusStr string
 		var errorText string
-		if node.IsFailed() {
+		switch {
+		case node.IsFailed():
 			statusStr = output.RedStr("%s", node.Status)
 			errorText = " " + node.ResultErrorText
-		} else {
+		case node.IsDeadLettered():
+			statusStr = output.RedStr("%s", node.Status)
+			errorText = fmt.Sprintf(" RetryCount=%d %s", node.RetryCount, node.ResultErrorText)
+		default:
 			statusStr = output.CyanStr("%s", node.Status)
 		}
 
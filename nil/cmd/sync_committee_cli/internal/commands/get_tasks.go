@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/exec"
 	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/flags"
@@ -18,6 +19,7 @@ type GetTasksParams struct {
 	exec.Params
 	public.TaskDebugRequest
 	FieldsToInclude []TaskField
+	ExecutorClasses bool
 }
 
 func (p *GetTasksParams) Validate() error {
@@ -64,6 +66,9 @@ func (c *getTasks) Build() (*cobra.Command, error) {
 			executor := exec.NewExecutor(os.Stdout, c.logger, cmdParams)
 			client := debug.NewTasksClient(paramsWithEndpoint.RpcEndpoint, c.logger)
 			return executor.Run(func(ctx context.Context) (exec.CmdOutput, error) {
+				if cmdParams.ExecutorClasses {
+					return c.getExecutorClasses(ctx, client)
+				}
 				return c.getTasks(ctx, cmdParams, client)
 			})
 		},
@@ -84,6 +89,13 @@ func (c *getTasks) Build() (*cobra.Command, error) {
 		"comma separated list of fields to include in the output table; pass 'all' value to include every field",
 	)
 
+	cmdFlags.BoolVar(
+		&cmdParams.ExecutorClasses,
+		"executor-classes",
+		cmdParams.ExecutorClasses,
+		"show active executors and pending tasks per resource class instead of the tasks list",
+	)
+
 	paramsWithEndpoint.bind(cmd)
 	bindListRequest(&cmdParams.ListRequest, cmd)
 	return cmd, nil
@@ -130,3 +142,35 @@ func (*getTasks) toTasksTableRow(task *public.TaskView, fieldsToInclude []TaskFi
 
 	return row
 }
+
+func (c *getTasks) getExecutorClasses(ctx context.Context, api public.TaskDebugApi) (exec.CmdOutput, error) {
+	classes, err := api.GetExecutorClasses(ctx)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to get executor classes from debug API: %w", err)
+	}
+
+	if len(classes) == 0 {
+		return exec.EmptyOutput, fmt.Errorf("%w: no active executors or pending tasks were found", exec.ErrNoDataFound)
+	}
+
+	header := output.NewTableRowStr(
+		"ResourceClass", "Executors", "IdleExecutors", "PendingTasks", "UnservedTasks", "Starving",
+	)
+	rows := make([]output.TableRow, 0, len(classes))
+	for _, class := range classes {
+		rows = append(rows, output.NewTableRowStr(
+			class.ResourceClass.String(),
+			strconv.FormatUint(uint64(class.Executors), 10),
+			strconv.FormatUint(uint64(class.IdleExecutors), 10),
+			strconv.FormatUint(uint64(class.PendingTasks), 10),
+			strconv.FormatUint(uint64(class.UnservedTasks), 10),
+			strconv.FormatBool(class.IsStarving()),
+		))
+	}
+
+	table, err := output.NewTable(header, rows)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to build executor classes table: %w", err)
+	}
+	return table.AsCmdOutput(), nil
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/exec"
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/flags"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/debug"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"github.com/spf13/cobra"
+)
+
+type DeadLetterAction string
+
+const (
+	// ActionRequeue makes the task available for execution again with the full retry budget
+	ActionRequeue DeadLetterAction = "requeue"
+
+	// ActionAbandon fails the task, the failure is handled the same way as a non-retryable execution error
+	ActionAbandon DeadLetterAction = "abandon"
+)
+
+var DeadLetterActions = map[string]DeadLetterAction{
+	string(ActionRequeue): ActionRequeue,
+	string(ActionAbandon): ActionAbandon,
+}
+
+func (a *DeadLetterAction) String() string {
+	return string(*a)
+}
+
+func (a *DeadLetterAction) Set(str string) error {
+	value, ok := DeadLetterActions[str]
+	if !ok {
+		return fmt.Errorf("unknown dead-letter action: %s", str)
+	}
+	*a = value
+	return nil
+}
+
+func (*DeadLetterAction) Type() string {
+	return "DeadLetterAction"
+}
+
+func (*DeadLetterAction) PossibleValues() []string {
+	return slices.Collect(maps.Keys(DeadLetterActions))
+}
+
+type ResolveDeadLetterParams struct {
+	exec.NoRefreshParams
+	TaskId public.TaskId
+	Action DeadLetterAction
+}
+
+func (p *ResolveDeadLetterParams) Validate() error {
+	if _, ok := DeadLetterActions[string(p.Action)]; !ok {
+		return fmt.Errorf("unknown dead-letter action: %s", p.Action)
+	}
+	return nil
+}
+
+type resolveDeadLetter struct {
+	logger logging.Logger
+}
+
+func NewResolveDeadLetterCmd(logger logging.Logger) *resolveDeadLetter {
+	return &resolveDeadLetter{
+		logger: logger,
+	}
+}
+
+func (c *resolveDeadLetter) Build() (*cobra.Command, error) {
+	paramsWithEndpoint := defaultParamsWithEndpoint()
+	cmdParams := &ResolveDeadLetterParams{}
+
+	cmd := &cobra.Command{
+		Use:   "resolve-dead-letter",
+		Short: "Re-queue or abandon a task which has exhausted its retry budget",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			executor := exec.NewExecutor(os.Stdout, c.logger, cmdParams)
+			client := debug.NewTasksClient(paramsWithEndpoint.RpcEndpoint, c.logger)
+			return executor.Run(func(ctx context.Context) (exec.CmdOutput, error) {
+				return c.resolveDeadLetter(ctx, cmdParams, client)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&paramsWithEndpoint.RpcEndpoint,
+		"endpoint",
+		paramsWithEndpoint.RpcEndpoint,
+		"target rpc endpoint")
+
+	const taskIdFlag = "task-id"
+	cmd.Flags().Var(&cmdParams.TaskId, taskIdFlag, "dead-lettered task id")
+
+	const actionFlag = "action"
+	flags.EnumVar(cmd.Flags(), &cmdParams.Action, actionFlag, "action to apply to the task")
+
+	for _, flagId := range []string{taskIdFlag, actionFlag} {
+		if err := cmd.MarkFlagRequired(flagId); err != nil {
+			return nil, err
+		}
+	}
+
+	return cmd, nil
+}
+
+func (*resolveDeadLetter) resolveDeadLetter(
+	ctx context.Context, params *ResolveDeadLetterParams, api public.TaskDebugApi,
+) (exec.CmdOutput, error) {
+	var err error
+	var result string
+	switch params.Action {
+	case ActionRequeue:
+		err = api.RequeueDeadLetterTask(ctx, params.TaskId)
+		result = "re-queued"
+	case ActionAbandon:
+		err = api.AbandonDeadLetterTask(ctx, params.TaskId)
+		result = "abandoned"
+	}
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to %s task with id=%s: %w", params.Action, params.TaskId, err)
+	}
+
+	return fmt.Sprintf("Task with id=%s is %s successfully", params.TaskId, result), nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
+	"github.com/jonboulle/clockwork"
 	"github.com/spf13/cobra"
 )
 
@@ -123,7 +124,7 @@ func (c *rollbackState) rollbackState(ctx context.Context, params *RollbackState
 		false,
 	)
 
-	wrapper, err := rollupcontract.NewWrapper(ctx, config, c.logger)
+	wrapper, err := rollupcontract.NewWrapper(ctx, config, nil, clockwork.NewRealClock(), c.logger)
 	if err != nil {
 		return exec.EmptyOutput, fmt.Errorf("reset failed on wrapper creation: %w", err)
 	}
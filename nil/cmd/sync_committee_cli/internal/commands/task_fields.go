@@ -3,6 +3,7 @@ package commands
 import (
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/output"
@@ -32,8 +33,9 @@ var TaskViewFields = map[TaskField]struct {
 		}
 		return output.EmptyCell
 	}, true},
-	"Owner":  {func(task *public.TaskView) string { return task.Owner.String() }, true},
-	"Status": {func(task *public.TaskView) string { return task.Status.String() }, true},
+	"Owner":      {func(task *public.TaskView) string { return task.Owner.String() }, true},
+	"Status":     {func(task *public.TaskView) string { return task.Status.String() }, true},
+	"RetryCount": {func(task *public.TaskView) string { return strconv.Itoa(task.RetryCount) }, false},
 }
 
 func AllTaskFields() []TaskField {
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/exec"
+	"github.com/NilFoundation/nil/nil/cmd/sync_committee_cli/internal/output"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batchverifier"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"github.com/spf13/cobra"
+)
+
+type VerifyBatchParams struct {
+	exec.NoRefreshParams
+
+	BatchId        public.BatchId
+	OwnRpcEndpoint string
+	NilRpcEndpoint string
+
+	// one of, both are optional
+	DaDir      string
+	DaEndpoint string
+
+	// optional, enables UpdateState dry-run
+	L1Endpoint               string
+	L1PrivateKeyHex          string
+	L1ContractAddressHex     string
+	L2BridgeMessengerAddress string
+}
+
+func (p *VerifyBatchParams) Validate() error {
+	if p.BatchId == (public.BatchId{}) {
+		return errors.New("batch id must be specified")
+	}
+
+	if len(p.DaDir) != 0 && len(p.DaEndpoint) != 0 {
+		return errors.New("only one of DA directory or DA endpoint can be specified, got both")
+	}
+
+	if len(p.L1Endpoint) == 0 {
+		return nil
+	}
+
+	if _, err := url.Parse(p.L1Endpoint); err != nil {
+		return fmt.Errorf("invalid L1 endpoint: %w", err)
+	}
+
+	if !privateKeyRegex.MatchString(p.L1PrivateKeyHex) {
+		return errors.New("invalid private key format: must be a 32-byte hex string")
+	}
+
+	if !addressRegex.MatchString(p.L1ContractAddressHex) {
+		return errors.New("invalid contract address format: must be a 20-byte hex string")
+	}
+
+	if !addressRegex.MatchString(p.L2BridgeMessengerAddress) {
+		return errors.New("invalid L2 bridge messenger address format: must be a 20-byte hex string")
+	}
+
+	return nil
+}
+
+type verifyBatch struct {
+	logger logging.Logger
+}
+
+func NewVerifyBatchCmd(logger logging.Logger) *verifyBatch {
+	return &verifyBatch{
+		logger: logger,
+	}
+}
+
+func (c *verifyBatch) Build() (*cobra.Command, error) {
+	params := &VerifyBatchParams{
+		OwnRpcEndpoint:       core.DefaultOwnRpcEndpoint,
+		NilRpcEndpoint:       core.DefaultNilRpcEndpoint,
+		L1PrivateKeyHex:      rollupcontract.DefaultPrivateKey,
+		L1ContractAddressHex: rollupcontract.DefaultContractAddress,
+	}
+
+	cmd := &cobra.Command{
+		Use: "verify-batch",
+		Short: "Re-derive batch data from L2 blocks and check it against stored commitments, " +
+			"optionally dry-running UpdateState on L1",
+		RunE: func(*cobra.Command, []string) error {
+			executor := exec.NewExecutor(os.Stdout, c.logger, params)
+			return executor.Run(func(ctx context.Context) (exec.CmdOutput, error) {
+				return c.verify(ctx, params)
+			})
+		},
+	}
+
+	const batchIdFlag = "batch-id"
+	cmd.Flags().Var(&params.BatchId, batchIdFlag, "batch identifier")
+	if err := cmd.MarkFlagRequired(batchIdFlag); err != nil {
+		return nil, err
+	}
+
+	cmd.Flags().StringVar(&params.OwnRpcEndpoint, "endpoint", params.OwnRpcEndpoint, "sync committee rpc endpoint")
+	cmd.Flags().StringVar(&params.NilRpcEndpoint, "nil-endpoint", params.NilRpcEndpoint, "L2 rpc endpoint")
+
+	cmd.Flags().StringVar(
+		&params.DaDir,
+		"da-dir",
+		params.DaDir,
+		"directory of the local DA backend, stored blobs are not checked if neither it nor DA endpoint is set")
+	cmd.Flags().StringVar(&params.DaEndpoint, "da-endpoint", params.DaEndpoint, "endpoint of the DA server")
+
+	cmd.Flags().StringVar(
+		&params.L1Endpoint,
+		"l1-endpoint",
+		params.L1Endpoint,
+		"L1 endpoint used for UpdateState dry-run, e.g. an anvil fork of the target network; "+
+			"the dry-run is skipped if not set")
+	cmd.Flags().StringVar(
+		&params.L1PrivateKeyHex,
+		"l1-private-key",
+		params.L1PrivateKeyHex,
+		"private key of the account used as the sender of the simulated call, no transactions are sent")
+	cmd.Flags().StringVar(
+		&params.L1ContractAddressHex,
+		"l1-contract-address",
+		params.L1ContractAddressHex,
+		"L1 update state contract address")
+	cmd.Flags().StringVar(
+		&params.L2BridgeMessengerAddress,
+		"l2-bridge-messenger-address",
+		params.L2BridgeMessengerAddress,
+		"L2 bridge messenger contract address")
+
+	return cmd, nil
+}
+
+func (c *verifyBatch) verify(ctx context.Context, params *VerifyBatchParams) (exec.CmdOutput, error) {
+	config := batchverifier.EndpointsConfig{
+		Config:                   batchverifier.NewDefaultConfig(),
+		OwnRpcEndpoint:           params.OwnRpcEndpoint,
+		NilRpcEndpoint:           params.NilRpcEndpoint,
+		L2BridgeMessengerAddress: params.L2BridgeMessengerAddress,
+	}
+	if len(params.L1Endpoint) > 0 {
+		wrapperConfig := rollupcontract.NewWrapperConfig(
+			params.L1Endpoint,
+			params.L1PrivateKeyHex,
+			params.L1ContractAddressHex,
+			rollupcontract.DefaultRequestTimeout,
+			false,
+		)
+		config.L1 = &wrapperConfig
+	}
+
+	var blobStore batchverifier.BlobStore
+	switch {
+	case len(params.DaDir) > 0:
+		blobStore = da.NewFsStore(params.DaDir)
+	case len(params.DaEndpoint) > 0:
+		blobStore = da.NewHttpStore(params.DaEndpoint, "")
+	}
+
+	verifier, err := batchverifier.NewVerifierWithEndpoints(ctx, config, blobStore, c.logger)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to initialize batch verifier: %w", err)
+	}
+
+	report, err := verifier.Verify(ctx, params.BatchId)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("batch verification failed: %w", err)
+	}
+
+	table, err := c.reportAsTable(report)
+	if err != nil {
+		return exec.EmptyOutput, fmt.Errorf("failed to build verification report table: %w", err)
+	}
+
+	summary := "All checks passed"
+	if report.HasFailures() {
+		summary = "Batch data mismatch detected"
+	}
+	return table.AsCmdOutput() + "\n" + summary, nil
+}
+
+func (*verifyBatch) reportAsTable(report *batchverifier.Report) (*output.Table, error) {
+	header := output.NewTableRowStr("Check", "Status", "Details")
+
+	rows := make([]output.TableRow, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		rows = append(rows, output.NewTableRowStr(check.Name, string(check.Status), check.Details))
+	}
+
+	return output.NewTable(header, rows)
+}
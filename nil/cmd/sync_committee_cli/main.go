@@ -31,15 +31,19 @@ func execute() error {
 	}{
 		commands.NewGetTasksCmd(logger),
 		commands.NewGetTaskTreeCmd(logger),
+		commands.NewResolveDeadLetterCmd(logger),
 
 		commands.NewDecodeBatchCmd(logger),
 		commands.NewRollbackStateCmd(logger),
+		commands.NewVerifyBatchCmd(logger),
 
 		commands.NewGetStateRootDataCmd(logger),
 		commands.NewGetBatchesCmd(logger),
 		commands.NewGetBatchCmd(logger),
 		commands.NewGetLatestFetchedCmd(logger),
 		commands.NewGetBatchStatsCmd(logger),
+
+		commands.NewGetFeeComponentsCmd(logger),
 	}
 
 	for _, builder := range builders {
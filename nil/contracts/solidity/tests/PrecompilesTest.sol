@@ -31,4 +31,19 @@ contract PrecompilesTest is NilBase {
     ) public view returns (uint) {
         return Nil.tokenBalance(addr, tokenId);
     }
+
+    function testScheduleCall(
+        address dst,
+        uint64 startBlock,
+        uint64 interval,
+        uint64 count,
+        uint feeCredit,
+        bytes memory callData
+    ) public returns (uint64) {
+        return Nil.scheduleCall(dst, startBlock, interval, count, feeCredit, callData);
+    }
+
+    function testCancelScheduledCall(uint64 id) public {
+        Nil.cancelScheduledCall(id);
+    }
 }
@@ -107,6 +107,24 @@ contract TokensTest is NilTokenBase {
         require(Nil.tokenBalance(addr, id) == balance, "Balance mismatch");
     }
 
+    function testTransferTokenFrom(
+        TokenId id,
+        address from,
+        address to,
+        uint256 amount
+    ) public {
+        Nil.transferTokenFrom(id, from, to, amount);
+    }
+
+    function checkTokenAllowance(
+        address owner,
+        address spender,
+        TokenId id,
+        uint256 allowance
+    ) public view {
+        require(Nil.tokenAllowance(owner, spender, id) == allowance, "Allowance mismatch");
+    }
+
     function testConsole() public pure {
         console.log("test console.log: int=%_, str=%_, addr=%_",
             1234567890,
@@ -130,7 +148,15 @@ contract TokensTest is NilTokenBase {
         emit tokenBalance(Nil.tokenBalance(address(this), id));
     }
 
-    receive() external payable {}
+    bool rejectTokens;
+
+    function setRejectTokens(bool reject) public {
+        rejectTokens = reject;
+    }
+
+    receive() external payable {
+        require(!rejectTokens, "Tokens are rejected");
+    }
 }
 
 contract TokensTestNoExternalAccess is NilTokenBase {
@@ -24,6 +24,7 @@ const (
 	defaultMaxGasInBlock                 = types.DefaultMaxGasInBlock
 	maxTxnsFromPool                      = 10_000
 	defaultMaxForwardTransactionsInBlock = 200
+	maxScheduledTxnsInBlock              = 1_000
 
 	validatorPatchLevel = 1
 )
@@ -63,6 +64,10 @@ func newProposer(params *Params, topology ShardTopology, pool TxnPool, logger lo
 func (p *proposer) GenerateProposal(ctx context.Context, txFabric db.DB) (*execution.ProposalSerializable, error) {
 	p.proposal = &execution.ProposalSerializable{}
 
+	if err := p.reconcileScheduledTransactions(ctx, txFabric); err != nil {
+		return nil, fmt.Errorf("failed to reconcile scheduled transactions: %w", err)
+	}
+
 	tx, err := txFabric.CreateRoTx(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
@@ -108,11 +113,19 @@ func (p *proposer) GenerateProposal(ctx context.Context, txFabric db.DB) (*execu
 		return nil, fmt.Errorf("failed to fetch last block hashes: %w", err)
 	}
 
+	if err := p.fetchRandaoSignature(tx, prevBlock); err != nil {
+		return nil, fmt.Errorf("failed to fetch randao signature: %w", err)
+	}
+
 	if err := p.handleL1Attributes(tx, prevBlockHash); err != nil {
 		// TODO: change to Error severity once Consensus/Proposer increase time intervals
 		p.logger.Trace().Err(err).Msg("Failed to handle L1 attributes")
 	}
 
+	if err := p.handleScheduledTransactions(tx); err != nil {
+		return nil, fmt.Errorf("failed to handle scheduled transactions: %w", err)
+	}
+
 	if err := p.handleTransactionsFromNeighbors(tx); err != nil {
 		return nil, fmt.Errorf("failed to handle transactions from neighbors: %w", err)
 	}
@@ -171,6 +184,27 @@ func (p *proposer) fetchLastBlockHashes(tx db.RoTx) error {
 	return nil
 }
 
+// fetchRandaoSignature takes the commit signature of the main shard block referenced by the new block as the proof
+// that the block is finalized, so the randomness derived from its hash is fixed.
+func (p *proposer) fetchRandaoSignature(tx db.RoTx, prevBlock *types.Block) error {
+	mainBlock := prevBlock
+	if !p.params.ShardId.IsMainShard() {
+		if p.proposal.MainShardHash.Empty() {
+			return nil
+		}
+		data, err := p.params.StateAccessor.Access(tx, types.MainShardId).GetBlock().ByHash(p.proposal.MainShardHash)
+		if err != nil {
+			return err
+		}
+		mainBlock = data.Block()
+	}
+
+	if mainBlock.Signature != nil && len(mainBlock.Signature.Sig) != 0 {
+		p.proposal.RandaoSignature = mainBlock.Signature
+	}
+	return nil
+}
+
 func (p *proposer) handleL1Attributes(tx db.RoTx, mainShardHash common.Hash) error {
 	if !p.params.ShardId.IsMainShard() {
 		return nil
@@ -354,6 +388,61 @@ func (p *proposer) handleTransactionsFromPool() error {
 	return nil
 }
 
+// reconcileScheduledTransactions reverts the schedules changed by the blocks replaced on rollback,
+// the proposal is built with a read-only transaction, so it is done beforehand.
+func (p *proposer) reconcileScheduledTransactions(ctx context.Context, txFabric db.DB) error {
+	tx, err := txFabric.CreateRwTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	lastBlockHash, err := db.ReadLastBlockHash(tx, p.params.ShardId)
+	if err != nil {
+		return err
+	}
+	if err := execution.ReconcileScheduledTransactions(tx, p.params.ShardId, lastBlockHash); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// handleScheduledTransactions executes the runs of the schedules due at the new block, the oldest ones first.
+// They are included as special transactions, validators check them against their own copy of the schedules.
+func (p *proposer) handleScheduledTransactions(tx db.RoTx) error {
+	due, err := db.ReadDueScheduledTransactions(
+		tx, p.params.ShardId, p.proposal.PrevBlockId+1, maxScheduledTxnsInBlock)
+	if err != nil {
+		return err
+	}
+
+	collected := 0
+	for _, scheduled := range due {
+		if p.executionState.GasUsed >= p.params.MaxGasInBlock {
+			break
+		}
+
+		txn := scheduled.Transaction()
+		txnHash := txn.Hash()
+		if err := p.executionState.AcceptInternalTransaction(txn); err != nil {
+			p.logger.Warn().Err(err).
+				Stringer(logging.FieldTransactionHash, txnHash).
+				Msg("Invalid scheduled transaction")
+			continue
+		}
+		if err := p.handleTransaction(txn, txnHash, execution.NewTransactionPayer(txn, p.executionState)); err != nil {
+			return err
+		}
+		p.proposal.SpecialTxns = append(p.proposal.SpecialTxns, txn)
+		collected++
+	}
+
+	if len(due) != 0 {
+		p.logger.Debug().Msgf("Collected %d of %d due scheduled transactions", collected, len(due))
+	}
+	return nil
+}
+
 func (p *proposer) handleTransactionsFromNeighbors(tx db.RoTx) error {
 	state, err := db.ReadCollatorState(tx, p.params.ShardId)
 	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
@@ -1,6 +1,7 @@
 package collate
 
 import (
+	"errors"
 	"slices"
 	"testing"
 
@@ -58,6 +59,28 @@ func (s *ProposerTestSuite) generateProposal(p *proposer) *execution.Proposal {
 	return proposal
 }
 
+func (s *ProposerTestSuite) generateBlock(p *proposer) (*execution.Proposal, *execution.BlockGenerationResult) {
+	s.T().Helper()
+
+	proposal := s.generateProposal(p)
+
+	tx, err := s.db.CreateRoTx(s.T().Context())
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	prevBlock, err := db.ReadBlock(tx, p.params.ShardId, proposal.PrevBlockHash)
+	s.Require().NoError(err)
+
+	gen, err := execution.NewBlockGenerator(s.T().Context(), p.params.BlockGeneratorParams, s.db, prevBlock)
+	s.Require().NoError(err)
+	defer gen.Rollback()
+
+	block, err := gen.GenerateBlock(proposal, &types.ConsensusParams{})
+	s.Require().NoError(err)
+
+	return proposal, block
+}
+
 func (s *ProposerTestSuite) TestBlockGas() {
 	s.Run("GenerateZeroState", func() {
 		execution.GenerateZeroState(s.T(), types.MainShardId, s.db)
@@ -98,23 +121,7 @@ func (s *ProposerTestSuite) TestCollator() {
 	shardId := p.params.ShardId
 
 	generateBlock := func() (*execution.Proposal, *execution.BlockGenerationResult) {
-		proposal := s.generateProposal(p)
-
-		tx, err := s.db.CreateRoTx(s.T().Context())
-		s.Require().NoError(err)
-		defer tx.Rollback()
-
-		prevBlock, err := db.ReadBlock(tx, shardId, proposal.PrevBlockHash)
-		s.Require().NoError(err)
-
-		gen, err := execution.NewBlockGenerator(s.T().Context(), params.BlockGeneratorParams, s.db, prevBlock)
-		s.Require().NoError(err)
-		defer gen.Rollback()
-
-		block, err := gen.GenerateBlock(proposal, &types.ConsensusParams{})
-		s.Require().NoError(err)
-
-		return proposal, block
+		return s.generateBlock(p)
 	}
 
 	s.Run("GenerateZeroState", func() {
@@ -195,6 +202,112 @@ func (s *ProposerTestSuite) TestCollator() {
 	})
 }
 
+func (s *ProposerTestSuite) TestScheduledTransactions() {
+	pool := &MockTxnPool{}
+	p := newTestProposer(s.newParams(), pool)
+	shardId := p.params.ShardId
+
+	s.Run("GenerateZeroState", func() {
+		execution.GenerateZeroState(s.T(), types.MainShardId, s.db)
+		execution.GenerateZeroState(s.T(), shardId, s.db)
+	})
+
+	fee := types.NewFeePackFromGas(100_000)
+	scheduled := &types.ScheduledTransaction{
+		Id:           1,
+		Owner:        types.MainSmartAccountAddress,
+		To:           types.MainSmartAccountAddress,
+		NextBlock:    2,
+		Interval:     2,
+		Remaining:    2,
+		FeeCredit:    fee.FeeCredit,
+		MaxFeePerGas: fee.MaxFeePerGas,
+	}
+
+	s.Run("Schedule", func() {
+		tx, err := s.db.CreateRwTx(s.T().Context())
+		s.Require().NoError(err)
+		defer tx.Rollback()
+
+		s.Require().NoError(db.WriteScheduledTransaction(tx, shardId, scheduled))
+		s.Require().NoError(db.WriteNextScheduledTransactionId(tx, shardId, scheduled.Id+1))
+		s.Require().NoError(tx.Commit())
+	})
+
+	readScheduled := func() *types.ScheduledTransaction {
+		s.T().Helper()
+
+		tx, err := s.db.CreateRoTx(s.T().Context())
+		s.Require().NoError(err)
+		defer tx.Rollback()
+
+		res, err := db.ReadScheduledTransaction(tx, shardId, scheduled.Id)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		}
+		s.Require().NoError(err)
+		return res
+	}
+
+	scheduledRuns := func(proposal *execution.Proposal) []*types.Transaction {
+		var res []*types.Transaction
+		for _, txn := range proposal.InternalTxns {
+			if txn.IsScheduled() {
+				res = append(res, txn)
+			}
+		}
+		return res
+	}
+
+	checkRun := func(proposal *execution.Proposal, res *execution.BlockGenerationResult, run *types.Transaction) {
+		s.T().Helper()
+
+		runs := scheduledRuns(proposal)
+		s.Require().Len(runs, 1)
+		s.Equal(run.Hash(), runs[0].Hash())
+		receipt := s.checkReceipt(res, run)
+		s.True(receipt.Success)
+	}
+
+	s.Run("NotDueYet", func() {
+		proposal, _ := s.generateBlock(p)
+		s.Empty(scheduledRuns(proposal))
+		s.Equal(scheduled, readScheduled())
+	})
+
+	s.Run("FirstRun", func() {
+		run := scheduled.Transaction()
+		proposal, res := s.generateBlock(p)
+		checkRun(proposal, res, run)
+
+		// The schedule is moved to the next run
+		next := readScheduled()
+		s.Require().NotNil(next)
+		s.Equal(types.BlockNumber(4), next.NextBlock)
+		s.EqualValues(1, next.Runs)
+		s.EqualValues(1, next.Remaining)
+		scheduled = next
+	})
+
+	s.Run("Interval", func() {
+		proposal, _ := s.generateBlock(p)
+		s.Empty(scheduledRuns(proposal))
+	})
+
+	s.Run("LastRun", func() {
+		run := scheduled.Transaction()
+		proposal, res := s.generateBlock(p)
+		checkRun(proposal, res, run)
+
+		s.Nil(readScheduled())
+	})
+
+	s.Run("NoMoreRuns", func() {
+		proposal, _ := s.generateBlock(p)
+		s.Empty(scheduledRuns(proposal))
+	})
+}
+
 func (s *ProposerTestSuite) getMainBalance() types.Value {
 	s.T().Helper()
 
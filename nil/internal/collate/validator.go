@@ -209,6 +209,16 @@ func (s *Validator) IsValidProposal(ctx context.Context, proposal *execution.Pro
 		return err
 	}
 
+	if !s.params.DisableConsensus {
+		mainShardHash := p.MainShardHash
+		if s.params.ShardId.IsMainShard() {
+			mainShardHash = p.PrevBlockHash
+		}
+		if err := s.blockVerifier.VerifyRandao(ctx, mainShardHash, p.RandaoSignature); err != nil {
+			return newErrInvalidSignature(err)
+		}
+	}
+
 	hash, err := s.buildBlockHashByProposal(ctx, p)
 	if err != nil {
 		return fmt.Errorf("failed to build block by proposal: %w", err)
@@ -414,6 +424,8 @@ func (s *Validator) replayBlockUnlocked(ctx context.Context, block *types.BlockW
 		PrevBlockHash: block.PrevBlock,
 		MainShardHash: block.MainShardHash,
 		ShardHashes:   block.ChildBlocks,
+
+		RandaoSignature: block.RandaoSignature,
 	}
 
 	if err := s.validateProposalUnlocked(ctx, proposal); err != nil {
@@ -13,6 +13,7 @@ import (
 	protoIBFT "github.com/NilFoundation/nil/nil/go-ibft/messages/proto"
 	cerrors "github.com/NilFoundation/nil/nil/internal/collate/errors"
 	"github.com/NilFoundation/nil/nil/internal/config"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
 	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/execution"
@@ -28,7 +29,7 @@ type ConsensusParams struct {
 	Db         db.DB
 	Validator  validator
 	NetManager network.Manager
-	PrivateKey bls.PrivateKey
+	Signer     signer.Signer
 }
 
 type validator interface {
@@ -50,7 +51,7 @@ type backendIBFT struct {
 	logger       logging.Logger
 	nm           network.Manager
 	transport    transport
-	signer       *Signer
+	signer       signer.Signer
 	mh           *MetricsHandler
 	txFabric     db.DB
 }
@@ -191,7 +192,7 @@ func (i *backendIBFT) InsertProposal(proposal *protoIBFT.Proposal, committedSeal
 }
 
 func (i *backendIBFT) ID() []byte {
-	return i.signer.GetPublicKey()
+	return i.signer.PublicKey()
 }
 
 func (i *backendIBFT) isActiveValidator() bool {
@@ -221,7 +222,7 @@ func NewConsensus(cfg *ConsensusParams) (*backendIBFT, error) {
 		validator: cfg.Validator,
 		logger:    logger,
 		nm:        cfg.NetManager,
-		signer:    NewSigner(cfg.PrivateKey),
+		signer:    cfg.Signer,
 		mh:        mh,
 		txFabric:  cfg.Db,
 	}
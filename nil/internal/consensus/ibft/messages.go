@@ -13,7 +13,7 @@ func (i *backendIBFT) signMessage(msg *protoIBFT.IbftMessage) *protoIBFT.IbftMes
 		return nil
 	}
 
-	if msg.Signature, err = i.signer.Sign(raw); err != nil {
+	if msg.Signature, err = i.signer.SignMessage(i.ctx, i.shardId, raw); err != nil {
 		event := i.logger.Error().Err(err).
 			Stringer("type", msg.GetType())
 		if view := msg.GetView(); view != nil {
@@ -74,10 +74,10 @@ func (i *backendIBFT) BuildPrepareMessage(proposalHash []byte, view *protoIBFT.V
 }
 
 func (i *backendIBFT) BuildCommitMessage(proposalHash []byte, view *protoIBFT.View) *protoIBFT.IbftMessage {
-	seal, err := i.signer.SignHash(proposalHash)
+	seal, err := i.signer.SignCommitSeal(i.ctx, i.shardId, view.GetHeight(), view.GetRound(), proposalHash)
 	if err != nil {
 		i.logger.Error().Err(err).
-			Hex(logging.FieldPublicKey, i.signer.GetPublicKey()).
+			Hex(logging.FieldPublicKey, i.signer.PublicKey()).
 			Hex(logging.FieldSignature, seal).
 			Hex(logging.FieldBlockHash, proposalHash).
 			Msg("Failed to sign a proposal hash")
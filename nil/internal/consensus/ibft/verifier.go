@@ -84,7 +84,7 @@ func (i *backendIBFT) IsValidValidator(msg *protoIBFT.IbftMessage) bool {
 		return false
 	}
 
-	if err := i.signer.VerifyWithKey(msg.GetFrom(), msgNoSig, msg.GetSignature()); err != nil {
+	if err := verifyWithKey(msg.GetFrom(), msgNoSig, msg.GetSignature()); err != nil {
 		logger.Err(err).Msg("Failed to verify signature")
 		return false
 	}
@@ -170,7 +170,7 @@ func (i *backendIBFT) IsValidCommittedSeal(
 	proposalHash []byte,
 	committedSeal *messages.CommittedSeal,
 ) bool {
-	if err := i.signer.VerifyWithKeyHash(committedSeal.Signer, proposalHash, committedSeal.Signature); err != nil {
+	if err := verifyWithKeyHash(committedSeal.Signer, proposalHash, committedSeal.Signature); err != nil {
 		i.logger.Error().
 			Err(err).
 			Hex(logging.FieldPublicKey, committedSeal.Signer).
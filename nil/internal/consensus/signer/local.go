@@ -0,0 +1,70 @@
+package signer
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+// LocalSigner signs with the key held in memory of the current process.
+// With slashing protection it is used by the remote signer, without it serves as the in-process fallback.
+type LocalSigner struct {
+	privateKey bls.PrivateKey
+	publicKey  []byte
+	protection *Protection
+}
+
+var _ Signer = (*LocalSigner)(nil)
+
+// NewLocalSigner creates a signer for the key, protection is optional.
+func NewLocalSigner(privateKey bls.PrivateKey, protection *Protection) (*LocalSigner, error) {
+	publicKey, err := privateKey.PublicKey().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &LocalSigner{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		protection: protection,
+	}, nil
+}
+
+func (s *LocalSigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+func (s *LocalSigner) SignMessage(
+	ctx context.Context, shardId types.ShardId, rawMessage []byte,
+) (types.BlsSignature, error) {
+	if s.protection != nil {
+		vote, err := ParseVote(rawMessage, s.publicKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.protection.CheckAndRecord(ctx, shardId, vote); err != nil {
+			return nil, err
+		}
+	}
+	return s.sign(MessageHash(rawMessage))
+}
+
+func (s *LocalSigner) SignCommitSeal(
+	ctx context.Context, shardId types.ShardId, height, round uint64, proposalHash []byte,
+) (types.BlsSignature, error) {
+	if s.protection != nil {
+		vote := NewCommitSealVote(height, round, proposalHash)
+		if err := s.protection.CheckAndRecord(ctx, shardId, vote); err != nil {
+			return nil, err
+		}
+	}
+	return s.sign(proposalHash)
+}
+
+func (s *LocalSigner) sign(hash []byte) (types.BlsSignature, error) {
+	sig, err := s.privateKey.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Marshal()
+}
@@ -0,0 +1,147 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultRetainHeights is the number of recent heights the slashing protection keeps the votes for.
+const DefaultRetainHeights = 1024
+
+var (
+	ErrDoubleSign   = errors.New("refusing to vote for a different proposal at the same height and round")
+	ErrHeightPruned = errors.New("refusing to sign at a height below the slashing protection history")
+)
+
+// Protection is a slashing protection database.
+// It remembers the proposal the validator voted for at each height and round and refuses to vote
+// for another one there, whatever the message type is. Votes older than retainHeights are pruned,
+// signing below the pruned heights is refused since the history is not known anymore.
+type Protection struct {
+	db            db.DB
+	retainHeights uint64
+
+	// serializes the check-and-record sequence, so concurrent votes don't race
+	mu sync.Mutex
+}
+
+// NewProtection creates the slashing protection. retainHeights equal to zero disables pruning.
+func NewProtection(database db.DB, retainHeights uint64) *Protection {
+	return &Protection{
+		db:            database,
+		retainHeights: retainHeights,
+	}
+}
+
+// CheckAndRecord records the vote, or fails if it conflicts with an already signed one.
+// Signing the same vote again is allowed, so the validator can repeat messages after a restart.
+func (p *Protection) CheckAndRecord(ctx context.Context, shardId types.ShardId, vote *Vote) error {
+	if len(vote.ProposalHash) == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tx, err := p.db.CreateRwTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	lowestHeight, err := getLowestHeight(tx, shardId)
+	if err != nil {
+		return err
+	}
+	if vote.Height < lowestHeight {
+		return fmt.Errorf("%w: height %d, lowest height %d", ErrHeightPruned, vote.Height, lowestHeight)
+	}
+
+	key := voteKey(vote)
+	signed, err := tx.GetFromShard(shardId, db.SlashingProtectionTable, key)
+	switch {
+	case err == nil:
+		if !bytes.Equal(signed, vote.ProposalHash) {
+			return fmt.Errorf("%w: %s at height %d, round %d: voted for %s, requested %s",
+				ErrDoubleSign, vote.Type, vote.Height, vote.Round,
+				hexutil.Encode(signed), hexutil.Encode(vote.ProposalHash))
+		}
+		return nil
+	case errors.Is(err, db.ErrKeyNotFound):
+	default:
+		return err
+	}
+
+	if err := tx.PutToShard(shardId, db.SlashingProtectionTable, key, vote.ProposalHash); err != nil {
+		return err
+	}
+	if err := p.prune(tx, shardId, vote.Height, lowestHeight); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (p *Protection) prune(tx db.RwTx, shardId types.ShardId, height, lowestHeight uint64) error {
+	if p.retainHeights == 0 || height < lowestHeight+p.retainHeights {
+		return nil
+	}
+	newLowestHeight := height - p.retainHeights + 1
+
+	// the upper bound is compared with full keys, so votes at newLowestHeight itself are not included
+	iter, err := tx.RangeByShard(
+		shardId, db.SlashingProtectionTable, heightKey(lowestHeight), heightKey(newLowestHeight),
+	)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for iter.HasNext() {
+		key, _, err := iter.Next()
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		keys = append(keys, key)
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		if err := tx.DeleteFromShard(shardId, db.SlashingProtectionTable, key); err != nil {
+			return err
+		}
+	}
+	return tx.PutToShard(shardId, db.SlashingProtectionLowestHeightTable, lowestHeightKey, heightKey(newLowestHeight))
+}
+
+var lowestHeightKey = []byte("lowestHeight")
+
+func getLowestHeight(tx db.RoTx, shardId types.ShardId) (uint64, error) {
+	value, err := tx.GetFromShard(shardId, db.SlashingProtectionLowestHeightTable, lowestHeightKey)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+func heightKey(height uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, height)
+}
+
+// voteKey orders the votes by height, so the old ones can be pruned with a range scan.
+// The message type is not a part of the key: the proposal, prepare and commit messages of a round
+// must all refer to the same proposal.
+func voteKey(vote *Vote) []byte {
+	key := heightKey(vote.Height)
+	return binary.BigEndian.AppendUint64(key, vote.Round)
+}
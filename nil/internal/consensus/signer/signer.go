@@ -0,0 +1,30 @@
+// Package signer signs consensus messages with the validator BLS key.
+//
+// The key may be loaded into the node (LocalSigner) or kept by an external signer process,
+// in which case the node talks to it through services/remotesigner.
+package signer
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+type Signer interface {
+	// PublicKey returns the marshaled BLS public key of the validator.
+	PublicKey() []byte
+
+	// SignMessage signs an IBFT message marshaled without the signature.
+	SignMessage(ctx context.Context, shardId types.ShardId, rawMessage []byte) (types.BlsSignature, error)
+
+	// SignCommitSeal signs the hash of the proposal the validator commits to at the given height and round.
+	SignCommitSeal(
+		ctx context.Context, shardId types.ShardId, height, round uint64, proposalHash []byte,
+	) (types.BlsSignature, error)
+}
+
+// MessageHash returns the hash the message signature is calculated over.
+func MessageHash(rawMessage []byte) []byte {
+	return common.KeccakHash(rawMessage).Bytes()
+}
@@ -0,0 +1,159 @@
+package signer
+
+import (
+	"context"
+	"testing"
+
+	protoIBFT "github.com/NilFoundation/nil/nil/go-ibft/messages/proto"
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newPrepareMessage(t *testing.T, from []byte, height, round uint64, proposalHash []byte) []byte {
+	t.Helper()
+
+	raw, err := proto.Marshal(&protoIBFT.IbftMessage{
+		View: &protoIBFT.View{Height: height, Round: round},
+		From: from,
+		Type: protoIBFT.MessageType_PREPARE,
+		Payload: &protoIBFT.IbftMessage_PrepareData{
+			PrepareData: &protoIBFT.PrepareMessage{ProposalHash: proposalHash},
+		},
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func newProtectedSigner(t *testing.T, retainHeights uint64) *LocalSigner {
+	t.Helper()
+
+	database, err := db.NewBadgerDbInMemory()
+	require.NoError(t, err)
+	t.Cleanup(database.Close)
+
+	s, err := NewLocalSigner(bls.NewRandomKey(), NewProtection(database, retainHeights))
+	require.NoError(t, err)
+	return s
+}
+
+func TestSignMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newProtectedSigner(t, 0)
+
+	raw := newPrepareMessage(t, s.PublicKey(), 10, 0, []byte{1})
+	sig, err := s.SignMessage(ctx, types.MainShardId, raw)
+	require.NoError(t, err)
+
+	signature, err := bls.SignatureFromBytes(sig)
+	require.NoError(t, err)
+	require.NoError(t, signature.Verify(s.privateKey.PublicKey(), MessageHash(raw)))
+
+	t.Run("SameVoteAgain", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.SignMessage(ctx, types.MainShardId, raw)
+		require.NoError(t, err)
+	})
+
+	t.Run("DoubleSign", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.SignMessage(ctx, types.MainShardId, newPrepareMessage(t, s.PublicKey(), 10, 0, []byte{2}))
+		require.ErrorIs(t, err, ErrDoubleSign)
+	})
+
+	t.Run("NextRound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.SignMessage(ctx, types.MainShardId, newPrepareMessage(t, s.PublicKey(), 10, 1, []byte{2}))
+		require.NoError(t, err)
+	})
+
+	t.Run("OtherShard", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.SignMessage(ctx, types.BaseShardId, newPrepareMessage(t, s.PublicKey(), 10, 0, []byte{2}))
+		require.NoError(t, err)
+	})
+
+	t.Run("ForeignMessage", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := s.SignMessage(ctx, types.MainShardId, newPrepareMessage(t, []byte("other"), 11, 0, []byte{1}))
+		require.Error(t, err)
+	})
+}
+
+func TestSignCommitSeal(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newProtectedSigner(t, 0)
+
+	_, err := s.SignCommitSeal(ctx, types.MainShardId, 5, 0, []byte{1})
+	require.NoError(t, err)
+
+	_, err = s.SignCommitSeal(ctx, types.MainShardId, 5, 0, []byte{2})
+	require.ErrorIs(t, err, ErrDoubleSign)
+}
+
+func TestVoteAcrossMessageTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newProtectedSigner(t, 0)
+
+	// the commit of the round must refer to the proposal prepared in it
+	_, err := s.SignMessage(ctx, types.MainShardId, newPrepareMessage(t, s.PublicKey(), 7, 0, []byte{1}))
+	require.NoError(t, err)
+	_, err = s.SignCommitSeal(ctx, types.MainShardId, 7, 0, []byte{1})
+	require.NoError(t, err)
+	_, err = s.SignCommitSeal(ctx, types.MainShardId, 7, 0, []byte{2})
+	require.ErrorIs(t, err, ErrDoubleSign)
+
+	// and the other way around
+	_, err = s.SignCommitSeal(ctx, types.MainShardId, 8, 0, []byte{2})
+	require.NoError(t, err)
+	_, err = s.SignMessage(ctx, types.MainShardId, newPrepareMessage(t, s.PublicKey(), 8, 0, []byte{1}))
+	require.ErrorIs(t, err, ErrDoubleSign)
+}
+
+func TestProtectionPruning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newProtectedSigner(t, 3)
+
+	for height := range uint64(5) {
+		_, err := s.SignCommitSeal(ctx, types.MainShardId, height, 0, []byte{byte(height)})
+		require.NoError(t, err)
+	}
+
+	// heights 2..4 are retained
+	_, err := s.SignCommitSeal(ctx, types.MainShardId, 1, 0, []byte{1})
+	require.ErrorIs(t, err, ErrHeightPruned)
+
+	_, err = s.SignCommitSeal(ctx, types.MainShardId, 2, 0, []byte{100})
+	require.ErrorIs(t, err, ErrDoubleSign)
+
+	tx, err := s.protection.db.CreateRoTx(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	iter, err := tx.RangeByShard(types.MainShardId, db.SlashingProtectionTable, nil, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+
+	var count int
+	for iter.HasNext() {
+		_, _, err := iter.Next()
+		require.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 3, count)
+}
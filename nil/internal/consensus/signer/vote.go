@@ -0,0 +1,63 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+
+	protoIBFT "github.com/NilFoundation/nil/nil/go-ibft/messages/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Vote is the part of a consensus message the slashing protection is concerned with.
+type Vote struct {
+	Type   protoIBFT.MessageType
+	Height uint64
+	Round  uint64
+
+	// ProposalHash is empty for messages that don't vote for a proposal (e.g. round change)
+	ProposalHash []byte
+}
+
+func NewCommitSealVote(height, round uint64, proposalHash []byte) *Vote {
+	// The commit seal and the commit message are recorded together, they always refer to the same proposal.
+	return &Vote{
+		Type:         protoIBFT.MessageType_COMMIT,
+		Height:       height,
+		Round:        round,
+		ProposalHash: proposalHash,
+	}
+}
+
+// ParseVote extracts the vote from the message that is about to be signed.
+// The message sender must match the public key of the signer.
+func ParseVote(rawMessage []byte, publicKey []byte) (*Vote, error) {
+	msg := &protoIBFT.IbftMessage{}
+	if err := proto.Unmarshal(rawMessage, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	if string(msg.GetFrom()) != string(publicKey) {
+		return nil, errors.New("message is not from the signer")
+	}
+	view := msg.GetView()
+	if view == nil {
+		return nil, errors.New("message has no view")
+	}
+
+	vote := &Vote{
+		Type:   msg.GetType(),
+		Height: view.GetHeight(),
+		Round:  view.GetRound(),
+	}
+	switch msg.GetType() {
+	case protoIBFT.MessageType_PREPREPARE:
+		vote.ProposalHash = msg.GetPreprepareData().GetProposalHash()
+	case protoIBFT.MessageType_PREPARE:
+		vote.ProposalHash = msg.GetPrepareData().GetProposalHash()
+	case protoIBFT.MessageType_COMMIT:
+		vote.ProposalHash = msg.GetCommitData().GetProposalHash()
+	case protoIBFT.MessageType_ROUND_CHANGE:
+	default:
+		return nil, fmt.Errorf("unknown message type %s", msg.GetType())
+	}
+	return vote, nil
+}
@@ -21,15 +21,16 @@ import (
 )
 
 const (
-	NameSmartAccount  = "SmartAccount"
-	NameFaucet        = "Faucet"
-	NameFaucetToken   = "FaucetToken"
-	NamePrecompile    = "__Precompile__"
-	NameNilTokenBase  = "NilTokenBase"
-	NameNilBounceable = "NilBounceable"
-	NameNilConfigAbi  = "NilConfigAbi"
-	NameL1BlockInfo   = "system/L1BlockInfo"
-	NameGovernance    = "system/Governance"
+	NameSmartAccount         = "SmartAccount"
+	NameMultisigSmartAccount = "MultisigSmartAccount"
+	NameFaucet               = "Faucet"
+	NameFaucetToken          = "FaucetToken"
+	NamePrecompile           = "__Precompile__"
+	NameNilTokenBase         = "NilTokenBase"
+	NameNilBounceable        = "NilBounceable"
+	NameNilConfigAbi         = "NilConfigAbi"
+	NameL1BlockInfo          = "system/L1BlockInfo"
+	NameGovernance           = "system/Governance"
 )
 
 var (
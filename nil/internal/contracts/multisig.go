@@ -0,0 +1,109 @@
+package contracts
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MultisigMaxOwners mirrors MAX_OWNERS of MultisigSmartAccount.
+// It keeps the number of signatures checked by `verifyExternal` small enough to fit the verification gas.
+const MultisigMaxOwners = 10
+
+var (
+	ErrInvalidMultisigOwners    = errors.New("invalid multisig owners")
+	ErrNotEnoughMultisigSigners = errors.New("not enough multisig signatures")
+)
+
+// MultisigOwner returns the address the multisig account identifies the owner with
+func MultisigOwner(publicKey *ecdsa.PublicKey) ethcommon.Address {
+	return crypto.PubkeyToAddress(*publicKey)
+}
+
+// SortMultisigOwners returns the owners in the order expected by the contract
+func SortMultisigOwners(owners []ethcommon.Address) []ethcommon.Address {
+	sorted := slices.Clone(owners)
+	slices.SortFunc(sorted, func(a, b ethcommon.Address) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return sorted
+}
+
+func validateMultisigOwners(owners []ethcommon.Address, threshold uint64) error {
+	if len(owners) == 0 || len(owners) > MultisigMaxOwners {
+		return fmt.Errorf("%w: %d owners, expected 1 to %d", ErrInvalidMultisigOwners, len(owners), MultisigMaxOwners)
+	}
+	if threshold == 0 || threshold > uint64(len(owners)) {
+		return fmt.Errorf("%w: threshold %d for %d owners", ErrInvalidMultisigOwners, threshold, len(owners))
+	}
+	for i := 1; i < len(owners); i++ {
+		if owners[i] == owners[i-1] {
+			return fmt.Errorf("%w: duplicate owner %s", ErrInvalidMultisigOwners, owners[i])
+		}
+	}
+	return nil
+}
+
+// PrepareMultisigSmartAccountCode returns the deploy code of an M-of-N smart account
+func PrepareMultisigSmartAccountCode(owners []ethcommon.Address, threshold uint64) (types.Code, error) {
+	owners = SortMultisigOwners(owners)
+	if err := validateMultisigOwners(owners, threshold); err != nil {
+		return nil, err
+	}
+
+	code, err := GetCode(NameMultisigSmartAccount)
+	if err != nil {
+		return nil, err
+	}
+	args, err := NewCallData(NameMultisigSmartAccount, "", owners, new(big.Int).SetUint64(threshold))
+	if err != nil {
+		return nil, err
+	}
+	return append(code, args...), nil
+}
+
+// RecoverMultisigSigner returns the owner who signed the hash
+func RecoverMultisigSigner(hash common.Hash, signature []byte) (ethcommon.Address, error) {
+	publicKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+	return MultisigOwner(publicKey), nil
+}
+
+// EncodeMultisigAuthData builds the auth data accepted by `verifyExternal` of the multisig account.
+// Signatures of non-owners and repeated ones are dropped, and only `threshold` of them are kept
+// since checking extra signatures just wastes the verification gas.
+func EncodeMultisigAuthData(
+	hash common.Hash, signatures [][]byte, owners []ethcommon.Address, threshold uint64,
+) ([]byte, error) {
+	bySigner := make(map[ethcommon.Address][]byte, len(signatures))
+	for _, signature := range signatures {
+		signer, err := RecoverMultisigSigner(hash, signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		if slices.Contains(owners, signer) {
+			bySigner[signer] = signature
+		}
+	}
+	if uint64(len(bySigner)) < threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughMultisigSigners, len(bySigner), threshold)
+	}
+
+	signers := SortMultisigOwners(slices.Collect(maps.Keys(bySigner)))[:threshold]
+	authData := make([]byte, 0, len(signers)*crypto.SignatureLength)
+	for _, signer := range signers {
+		authData = append(authData, bySigner[signer]...)
+	}
+	return authData, nil
+}
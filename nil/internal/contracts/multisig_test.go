@@ -0,0 +1,70 @@
+package contracts
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMultisigAuthData(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	owners := make([]ethcommon.Address, 3)
+	for i := range keys {
+		var err error
+		keys[i], err = crypto.GenerateKey()
+		require.NoError(t, err)
+		if i < len(owners) {
+			owners[i] = MultisigOwner(&keys[i].PublicKey)
+		}
+	}
+
+	hash := common.KeccakHash([]byte("transaction"))
+	sign := func(key *ecdsa.PrivateKey) []byte {
+		sig, err := crypto.Sign(hash.Bytes(), key)
+		require.NoError(t, err)
+		return sig
+	}
+
+	t.Run("Ok", func(t *testing.T) {
+		t.Parallel()
+
+		// a repeated signature and a signature of a non-owner are dropped
+		signatures := [][]byte{sign(keys[2]), sign(keys[3]), sign(keys[0]), sign(keys[2])}
+		authData, err := EncodeMultisigAuthData(hash, signatures, owners, 2)
+		require.NoError(t, err)
+		require.Len(t, authData, 2*crypto.SignatureLength)
+
+		first, err := RecoverMultisigSigner(hash, authData[:crypto.SignatureLength])
+		require.NoError(t, err)
+		second, err := RecoverMultisigSigner(hash, authData[crypto.SignatureLength:])
+		require.NoError(t, err)
+		expected := SortMultisigOwners([]ethcommon.Address{owners[0], owners[2]})
+		require.Equal(t, expected, []ethcommon.Address{first, second})
+	})
+
+	t.Run("NotEnoughSigners", func(t *testing.T) {
+		t.Parallel()
+
+		signatures := [][]byte{sign(keys[1]), sign(keys[1]), sign(keys[3])}
+		_, err := EncodeMultisigAuthData(hash, signatures, owners, 2)
+		require.ErrorIs(t, err, ErrNotEnoughMultisigSigners)
+	})
+}
+
+func TestValidateMultisigOwners(t *testing.T) {
+	t.Parallel()
+
+	owner := ethcommon.HexToAddress("0x01")
+	require.NoError(t, validateMultisigOwners([]ethcommon.Address{owner}, 1))
+	require.ErrorIs(t, validateMultisigOwners(nil, 1), ErrInvalidMultisigOwners)
+	require.ErrorIs(t, validateMultisigOwners([]ethcommon.Address{owner}, 2), ErrInvalidMultisigOwners)
+	require.ErrorIs(t, validateMultisigOwners([]ethcommon.Address{owner, owner}, 1), ErrInvalidMultisigOwners)
+	require.ErrorIs(t,
+		validateMultisigOwners(make([]ethcommon.Address, MultisigMaxOwners+1), 1), ErrInvalidMultisigOwners)
+}
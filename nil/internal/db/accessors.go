@@ -1,9 +1,11 @@
 package db
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/NilFoundation/nil/nil/common"
@@ -211,3 +213,218 @@ func ReadBlockByNumber(tx RoTx, shardId types.ShardId, blockNumber types.BlockNu
 	}
 	return ReadBlock(tx, shardId, blockHash)
 }
+
+func tokenHolderKey(tokenId types.TokenId, holder types.Address) []byte {
+	return append(tokenId[:], holder.Bytes()...)
+}
+
+// WriteTokenHolder updates the balance of the holder in the token holders index.
+// Holders with zero balance are removed from the index.
+func WriteTokenHolder(
+	tx RwTx, shardId types.ShardId, tokenId types.TokenId, holder types.Address, balance types.Value,
+) error {
+	key := tokenHolderKey(tokenId, holder)
+	if balance.IsZero() {
+		return tx.DeleteFromShard(shardId, TokenHoldersIndex, key)
+	}
+
+	value, err := balance.MarshalNil()
+	if err != nil {
+		return err
+	}
+	return tx.PutToShard(shardId, TokenHoldersIndex, key, value)
+}
+
+// ClearTokenHolders removes all the holders of all the tokens of the shard from the index.
+func ClearTokenHolders(tx RwTx, shardId types.ShardId) error {
+	iter, err := tx.RangeByShard(shardId, TokenHoldersIndex, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var keys [][]byte
+	for iter.HasNext() {
+		key, _, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		if err := tx.DeleteFromShard(shardId, TokenHoldersIndex, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadTokenHoldersIndexHead returns the hash of the last block applied to the token holders index of the shard.
+func ReadTokenHoldersIndexHead(tx RoTx, shardId types.ShardId) (common.Hash, error) {
+	h, err := Get(tx, TokenHoldersIndexHeadTable, shardId)
+	return common.BytesToHash(h), err
+}
+
+func WriteTokenHoldersIndexHead(tx RwTx, shardId types.ShardId, hash common.Hash) error {
+	return tx.Put(TokenHoldersIndexHeadTable, shardId.Bytes(), hash.Bytes())
+}
+
+// ReadTokenHolders returns up to `limit` holders of the token in the shard, skipping the first `offset` of them.
+// It also returns the total number of the holders in the shard.
+func ReadTokenHolders(
+	tx RoTx, shardId types.ShardId, tokenId types.TokenId, offset uint64, limit uint64,
+) ([]types.TokenHolder, uint64, error) {
+	last := types.BytesToAddress(bytes.Repeat([]byte{0xff}, types.AddrSize))
+	iter, err := tx.RangeByShard(
+		shardId, TokenHoldersIndex, tokenHolderKey(tokenId, types.EmptyAddress), tokenHolderKey(tokenId, last))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer iter.Close()
+
+	var holders []types.TokenHolder
+	var total uint64
+	for iter.HasNext() {
+		key, value, err := iter.Next()
+		if err != nil {
+			return nil, 0, err
+		}
+		total++
+		if total <= offset || uint64(len(holders)) >= limit {
+			continue
+		}
+
+		holder := types.TokenHolder{Address: types.BytesToAddress(key[len(tokenId):])}
+		if err := holder.Balance.UnmarshalNil(value); err != nil {
+			return nil, 0, err
+		}
+		holders = append(holders, holder)
+	}
+	return holders, total, nil
+}
+
+// scheduledTransactionNextIdKey holds the id of the next schedule. Ids start from one and are never reused, since they
+// are part of the hashes of the scheduled transactions.
+var scheduledTransactionNextIdKey = []byte("nextId")
+
+func scheduledTransactionKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+func scheduledTransactionQueueKey(block types.BlockNumber, id uint64) []byte {
+	return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, uint64(block)), id)
+}
+
+func ReadScheduledTransaction(tx RoTx, shardId types.ShardId, id uint64) (*types.ScheduledTransaction, error) {
+	data, err := tx.GetFromShard(shardId, ScheduledTransactionsTable, scheduledTransactionKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: shard=%d, scheduled transaction=%d", err, shardId, id)
+	}
+
+	res := &types.ScheduledTransaction{}
+	if err := res.UnmarshalNil(data); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WriteScheduledTransaction puts the schedule into the table and moves it to its next due block in the queue.
+func WriteScheduledTransaction(tx RwTx, shardId types.ShardId, scheduled *types.ScheduledTransaction) error {
+	if err := DeleteScheduledTransaction(tx, shardId, scheduled.Id); err != nil {
+		return err
+	}
+
+	value, err := scheduled.MarshalNil()
+	if err != nil {
+		return err
+	}
+	key := scheduledTransactionKey(scheduled.Id)
+	if err := tx.PutToShard(shardId, ScheduledTransactionsTable, key, value); err != nil {
+		return err
+	}
+	return tx.PutToShard(
+		shardId, ScheduledTransactionsQueue, scheduledTransactionQueueKey(scheduled.NextBlock, scheduled.Id), []byte{})
+}
+
+// DeleteScheduledTransaction removes the schedule from the table and the queue. Absent schedules are ignored.
+func DeleteScheduledTransaction(tx RwTx, shardId types.ShardId, id uint64) error {
+	prev, err := ReadScheduledTransaction(tx, shardId, id)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.DeleteFromShard(
+		shardId, ScheduledTransactionsQueue, scheduledTransactionQueueKey(prev.NextBlock, id)); err != nil {
+		return err
+	}
+	return tx.DeleteFromShard(shardId, ScheduledTransactionsTable, scheduledTransactionKey(id))
+}
+
+// ReadDueScheduledTransactions returns up to `limit` schedules due at the given block or earlier,
+// the oldest ones first.
+func ReadDueScheduledTransactions(
+	tx RoTx, shardId types.ShardId, block types.BlockNumber, limit int,
+) ([]*types.ScheduledTransaction, error) {
+	iter, err := tx.RangeByShard(
+		shardId, ScheduledTransactionsQueue, nil, scheduledTransactionQueueKey(block, math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var res []*types.ScheduledTransaction
+	for iter.HasNext() && len(res) < limit {
+		key, _, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		scheduled, err := ReadScheduledTransaction(tx, shardId, binary.BigEndian.Uint64(key[8:]))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, scheduled)
+	}
+	return res, nil
+}
+
+func ReadNextScheduledTransactionId(tx RoTx, shardId types.ShardId) (uint64, error) {
+	value, err := tx.GetFromShard(shardId, ScheduledTransactionsTable, scheduledTransactionNextIdKey)
+	if errors.Is(err, ErrKeyNotFound) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+func WriteNextScheduledTransactionId(tx RwTx, shardId types.ShardId, id uint64) error {
+	return tx.PutToShard(
+		shardId, ScheduledTransactionsTable, scheduledTransactionNextIdKey, scheduledTransactionKey(id))
+}
+
+// ReadScheduledTransactionsHead returns the hash of the last block applied to the schedules of the shard.
+func ReadScheduledTransactionsHead(tx RoTx, shardId types.ShardId) (common.Hash, error) {
+	h, err := Get(tx, ScheduledTransactionsHeadTable, shardId)
+	return common.BytesToHash(h), err
+}
+
+func WriteScheduledTransactionsHead(tx RwTx, shardId types.ShardId, hash common.Hash) error {
+	return tx.Put(ScheduledTransactionsHeadTable, shardId.Bytes(), hash.Bytes())
+}
+
+func ReadScheduledTransactionsUndo(
+	tx RoTx, shardId types.ShardId, blockHash common.Hash,
+) (*ScheduledTransactionsUndo, error) {
+	return readDecodable[*ScheduledTransactionsUndo](tx, ScheduledTransactionsUndoTable, shardId, blockHash)
+}
+
+func WriteScheduledTransactionsUndo(
+	tx RwTx, shardId types.ShardId, blockHash common.Hash, undo *ScheduledTransactionsUndo,
+) error {
+	return writeEncodable(tx, ScheduledTransactionsUndoTable, shardId, blockHash, undo)
+}
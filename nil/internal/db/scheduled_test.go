@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteScheduledTransactions struct {
+	suite.Suite
+	db      DB
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+func (suite *SuiteScheduledTransactions) SetupTest() {
+	var err error
+	suite.db, err = NewBadgerDb(suite.Suite.T().TempDir())
+	suite.Require().NoError(err)
+	suite.context, suite.cancel = context.WithCancel(context.Background())
+}
+
+func (suite *SuiteScheduledTransactions) TearDownTest() {
+	suite.db.Close()
+	suite.cancel()
+}
+
+func (suite *SuiteScheduledTransactions) TestDueQueue() {
+	tx, err := suite.db.CreateRwTx(suite.context)
+	suite.Require().NoError(err)
+	defer tx.Rollback()
+
+	const shardId = types.ShardId(1)
+
+	for _, s := range []*types.ScheduledTransaction{
+		{Id: 1, NextBlock: 10, Remaining: 1},
+		{Id: 2, NextBlock: 5, Interval: 5, Remaining: 3},
+		{Id: 3, NextBlock: 20, Remaining: 1},
+	} {
+		suite.Require().NoError(WriteScheduledTransaction(tx, shardId, s))
+	}
+
+	readDue := func(block types.BlockNumber) []uint64 {
+		due, err := ReadDueScheduledTransactions(tx, shardId, block, 10)
+		suite.Require().NoError(err)
+		ids := make([]uint64, 0, len(due))
+		for _, s := range due {
+			ids = append(ids, s.Id)
+		}
+		return ids
+	}
+
+	suite.Empty(readDue(4))
+	suite.Equal([]uint64{2}, readDue(5))
+	suite.Equal([]uint64{2, 1}, readDue(10))
+
+	// Moving the schedule to the next run removes the old position from the queue
+	scheduled, err := ReadScheduledTransaction(tx, shardId, 2)
+	suite.Require().NoError(err)
+	suite.True(scheduled.Advance())
+	suite.Require().NoError(WriteScheduledTransaction(tx, shardId, scheduled))
+	suite.Equal([]uint64{1, 2}, readDue(10))
+
+	suite.Require().NoError(DeleteScheduledTransaction(tx, shardId, 1))
+	suite.Equal([]uint64{2, 3}, readDue(20))
+
+	_, err = ReadScheduledTransaction(tx, shardId, 1)
+	suite.Require().ErrorIs(err, ErrKeyNotFound)
+
+	// Deleting an absent schedule is not an error
+	suite.Require().NoError(DeleteScheduledTransaction(tx, shardId, 1))
+}
+
+func (suite *SuiteScheduledTransactions) TestNextId() {
+	tx, err := suite.db.CreateRwTx(suite.context)
+	suite.Require().NoError(err)
+	defer tx.Rollback()
+
+	const shardId = types.ShardId(1)
+
+	id, err := ReadNextScheduledTransactionId(tx, shardId)
+	suite.Require().NoError(err)
+	suite.Equal(uint64(1), id)
+
+	suite.Require().NoError(WriteNextScheduledTransactionId(tx, shardId, 42))
+	suite.Require().NoError(WriteScheduledTransaction(tx, shardId, &types.ScheduledTransaction{Id: 1, Remaining: 1}))
+
+	id, err = ReadNextScheduledTransactionId(tx, shardId)
+	suite.Require().NoError(err)
+	suite.Equal(uint64(42), id)
+
+	due, err := ReadDueScheduledTransactions(tx, shardId, 0, 10)
+	suite.Require().NoError(err)
+	suite.Len(due, 1)
+}
+
+func TestSuiteScheduledTransactions(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(SuiteScheduledTransactions))
+}
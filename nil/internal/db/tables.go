@@ -30,11 +30,27 @@ const (
 	BlockHashAndOutTransactionIndexByTransactionHash = ShardedTableName(
 		"BlockHashAndOutTransactionIndexByTransactionHash")
 	AsyncCallContextTable = ShardedTableName("AsyncCallContext")
+	// TokenHoldersIndex maps (token id, holder address) to the balance of the holder.
+	TokenHoldersIndex = ShardedTableName("TokenHoldersIndex")
+	// ScheduledTransactionsTable maps the schedule id to the scheduled transaction.
+	ScheduledTransactionsTable = ShardedTableName("ScheduledTransactions")
+	// ScheduledTransactionsQueue maps (due block, schedule id) to nothing, ordering the schedules by height.
+	ScheduledTransactionsQueue = ShardedTableName("ScheduledTransactionsQueue")
+	// ScheduledTransactionsUndoTable maps the block hash to the schedules the block replaced.
+	ScheduledTransactionsUndoTable = ShardedTableName("ScheduledTransactionsUndo")
+	// SlashingProtectionTable maps (height, round) to the proposal hash the validator voted for in any message type.
+	SlashingProtectionTable = ShardedTableName("SlashingProtection")
+	// SlashingProtectionLowestHeightTable keeps the lowest height the validator is still allowed to sign at.
+	SlashingProtectionLowestHeightTable = ShardedTableName("SlashingProtectionLowestHeight")
 
 	collatorStateTable          = TableName("CollatorState")
 	errorByTransactionHashTable = TableName("ErrorByTransactionHash")
 	schemeVersionTable          = TableName("SchemeVersion")
 	LastBlockTable              = TableName("LastBlock")
+	// TokenHoldersIndexHeadTable keeps the hash of the last block applied to the token holders index of the shard.
+	TokenHoldersIndexHeadTable = TableName("TokenHoldersIndexHead")
+	// ScheduledTransactionsHeadTable keeps the hash of the last block applied to the schedules of the shard.
+	ScheduledTransactionsHeadTable = TableName("ScheduledTransactionsHead")
 
 	DHTTable = TableName("DHT")
 )
@@ -59,3 +75,21 @@ func (i *BlockHashAndTransactionIndex) UnmarshalNil(buf []byte) error {
 func (i BlockHashAndTransactionIndex) MarshalNil() ([]byte, error) {
 	return rlp.EncodeToBytes(&i)
 }
+
+// ScheduledTransactionsUndo holds the schedules of the shard as they were before the block changed them,
+// so that the block can be reverted on rollback.
+type ScheduledTransactionsUndo struct {
+	// Restored holds the previous versions of the schedules changed or removed by the block.
+	Restored []types.ScheduledTransaction
+	// Removed holds the ids of the schedules created by the block.
+	Removed []uint64
+	NextId  uint64
+}
+
+func (u *ScheduledTransactionsUndo) UnmarshalNil(buf []byte) error {
+	return rlp.DecodeBytes(buf, u)
+}
+
+func (u ScheduledTransactionsUndo) MarshalNil() ([]byte, error) {
+	return rlp.EncodeToBytes(&u)
+}
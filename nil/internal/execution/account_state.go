@@ -61,6 +61,7 @@ type AccountReader interface {
 
 	GetTokenBalance(id types.TokenId) *types.Value
 	GetTokens() map[types.TokenId]types.Value
+	GetTouchedTokens() map[types.TokenId]types.Value
 
 	GetSeqno() types.Seqno
 	GetExtSeqno() types.Seqno
@@ -320,6 +321,19 @@ func (as *AccountStateImpl) GetTokenBalance(id types.TokenId) *types.Value {
 	return tokenBalance
 }
 
+// GetTouchedTokens returns the balances of the tokens that were read or modified during execution.
+func (as *AccountStateImpl) GetTouchedTokens() map[types.TokenId]types.Value {
+	res := make(map[types.TokenId]types.Value, len(as.Tokens))
+	for id, balance := range as.Tokens {
+		if balance == nil {
+			res[id] = types.NewZeroValue()
+		} else {
+			res[id] = *balance
+		}
+	}
+	return res
+}
+
 func (as *AccountStateImpl) SetTokenBalance(id types.TokenId, amount types.Value) {
 	as.Tokens[id] = &amount
 	as.logger.Debug().
@@ -63,10 +63,28 @@ type BlockGenerationResult struct {
 	OutTxnHashes []common.Hash
 	Receipts     []*types.Receipt
 	ConfigParams map[string][]byte
+	// TokenBalances holds the token balances of the accounts touched in the block.
+	TokenBalances []TokenBalanceUpdate
+	// ScheduledTransactions holds the schedules changed in the block.
+	ScheduledTransactions []ScheduledTransactionUpdate
+	// NextScheduledId is set if new schedules were registered in the block.
+	NextScheduledId uint64
 
 	Counters *BlockGeneratorCounters
 }
 
+type TokenBalanceUpdate struct {
+	Token   types.TokenId
+	Holder  types.Address
+	Balance types.Value
+}
+
+// ScheduledTransactionUpdate is a change of the schedule, nil Scheduled means that the schedule is removed.
+type ScheduledTransactionUpdate struct {
+	Id        uint64
+	Scheduled *types.ScheduledTransaction
+}
+
 func NewBlockGenerator(
 	ctx context.Context,
 	params BlockGeneratorParams,
@@ -250,6 +268,7 @@ func (g *BlockGenerator) prepareExecutionState(proposal *Proposal, gasPrices []t
 	g.executionState.MainShardHash = proposal.MainShardHash
 	g.executionState.PatchLevel = proposal.PatchLevel
 	g.executionState.RollbackCounter = proposal.RollbackCounter
+	g.executionState.RandaoSignature = proposal.RandaoSignature
 
 	for _, txn := range proposal.InternalTxns {
 		if err := g.handleTxn(txn); err != nil {
@@ -0,0 +1,143 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/internal/vm"
+)
+
+// AuthorizationBaseGas is charged for every authorization of a set-code transaction,
+// regardless of whether it was applied or skipped.
+const AuthorizationBaseGas = types.Gas(25_000)
+
+var errAuthorizationSkipped = errors.New("authorization skipped")
+
+// handleSetCodeTransaction applies the authorization list of the transaction and then executes it as a regular
+// execution transaction. Authorizations stay applied even if the execution fails.
+func (es *ExecutionState) handleSetCodeTransaction(ctx context.Context, txn *types.Transaction) *ExecutionResult {
+	authRes := es.applyAuthorizations(txn)
+	if authRes.Failed() {
+		return authRes
+	}
+
+	feeCredit := es.txnFeeCredit
+	es.txnFeeCredit = feeCredit.Sub(authRes.CoinsUsed())
+	defer func() { es.txnFeeCredit = feeCredit }()
+
+	return es.handleExecutionTransaction(ctx, txn).AddUsed(authRes.GasUsed)
+}
+
+// applyAuthorizations sets the delegation designators of the accounts from the authorization list.
+// Authorizations that fail verification are skipped, as in EIP-7702.
+func (es *ExecutionState) applyAuthorizations(txn *types.Transaction) *ExecutionResult {
+	gasAvailable := es.txnFeeCredit.ToGas(es.GasPrice)
+	gasUsed := types.Gas(0)
+
+	for i := range txn.AuthorizationList {
+		auth := &txn.AuthorizationList[i]
+
+		gasUsed = gasUsed.Add(AuthorizationBaseGas)
+		if gasAvailable.Lt(gasUsed) {
+			return NewExecutionResult().
+				SetError(types.NewError(types.ErrorOutOfGas)).
+				SetUsed(gasAvailable, es.GasPrice)
+		}
+
+		verificationGas := min(ExternalTransactionVerificationMaxGas, gasAvailable.Sub(gasUsed))
+		spent, err := es.applyAuthorization(txn, auth, verificationGas)
+		gasUsed = gasUsed.Add(spent)
+		if errors.Is(err, errAuthorizationSkipped) {
+			es.logger.Debug().
+				Err(err).
+				Stringer(logging.FieldTransactionHash, es.InTransactionHash).
+				Stringer("authority", auth.Address).
+				Msgf("Authorization %d is skipped", i)
+			continue
+		}
+		if err != nil {
+			return NewExecutionResult().SetFatal(err)
+		}
+	}
+
+	return NewExecutionResult().SetUsed(gasUsed, es.GasPrice)
+}
+
+// applyAuthorization verifies the authorization against the current state of the authority and sets its code.
+// It returns the gas spent on the signature verification.
+func (es *ExecutionState) applyAuthorization(
+	txn *types.Transaction, auth *types.Authorization, gas types.Gas,
+) (types.Gas, error) {
+	if err := auth.Verify(); err != nil {
+		return 0, fmt.Errorf("%w: %w", errAuthorizationSkipped, err)
+	}
+	if auth.Address.ShardId() != es.ShardId {
+		return 0, fmt.Errorf("%w: authority is in shard %d", errAuthorizationSkipped, auth.Address.ShardId())
+	}
+
+	acc, err := es.GetAccount(auth.Address)
+	if err != nil {
+		return 0, err
+	}
+	if acc == nil {
+		return 0, fmt.Errorf("%w: authority does not exist", errAuthorizationSkipped)
+	}
+	if acc.GetExtSeqno() != auth.Seqno {
+		return 0, fmt.Errorf("%w: seqno mismatch: account %d != authorization %d",
+			errAuthorizationSkipped, acc.GetExtSeqno(), auth.Seqno)
+	}
+
+	hash, err := auth.SigningHash()
+	if err != nil {
+		return 0, err
+	}
+	spent, err := es.verifyAccountSignature(txn, auth.Address, hash, auth.Signature, gas)
+	if err != nil {
+		return spent, err
+	}
+
+	acc.JournaledSetExtSeqno(auth.Seqno + 1)
+	if auth.Delegate.IsEmpty() {
+		if _, ok := types.ParseDelegation(acc.GetCode()); ok {
+			acc.JournaledSetCode(types.EmptyCodeHash, nil)
+		}
+		return spent, nil
+	}
+	code := types.NewDelegationDesignator(auth.Delegate)
+	acc.JournaledSetCode(code.Hash(), code)
+	return spent, nil
+}
+
+// verifyAccountSignature checks the signature by calling `verifyExternal` of the account.
+// It returns errAuthorizationSkipped if the account rejects the signature.
+func (es *ExecutionState) verifyAccountSignature(
+	txn *types.Transaction, addr types.Address, hash common.Hash, signature []byte, gas types.Gas,
+) (types.Gas, error) {
+	calldata, err := verifyExternalCallData(hash, signature)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := es.newVm(txn.IsInternal(), txn.From); err != nil {
+		return 0, fmt.Errorf("newVm failed: %w", err)
+	}
+	defer es.resetVm()
+
+	ret, leftOverGas, err := es.evm.StaticCall((vm.AccountRef)(addr), addr, calldata, gas.Uint64())
+	spent := gas.Sub(types.Gas(leftOverGas))
+	if err != nil {
+		if !types.IsValidError(err) {
+			return spent, err
+		}
+		return spent, fmt.Errorf("%w: verification failed: %w", errAuthorizationSkipped, err)
+	}
+	if !bytes.Equal(ret, common.LeftPadBytes([]byte{1}, 32)) {
+		return spent, fmt.Errorf("%w: invalid signature", errAuthorizationSkipped)
+	}
+	return spent, nil
+}
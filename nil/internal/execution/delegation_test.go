@@ -0,0 +1,201 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// acceptCode returns true for any call, so it accepts every signature in `verifyExternal`.
+	acceptCode = ethcommon.FromHex("600160005260206000f3")
+
+	// counterCode increments the storage slot 0 when called without calldata
+	// and returns true otherwise (e.g., from `verifyExternal`).
+	counterCode = ethcommon.FromHex("3615600f57600160005260206000f35b60005460010160005500")
+)
+
+type delegationTest struct {
+	t  *testing.T
+	es *ExecutionState
+}
+
+func (d *delegationTest) createAccount(code []byte) types.Address {
+	d.t.Helper()
+
+	addr := types.GenerateRandomAddress(d.es.ShardId)
+	require.NoError(d.t, d.es.CreateAccount(addr))
+	require.NoError(d.t, d.es.SetCode(addr, code))
+	return addr
+}
+
+func (d *delegationTest) setCode(sponsor types.Address, auths ...types.Authorization) *ExecutionResult {
+	d.t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(d.t, err)
+	for i := range auths {
+		auths[i].ChainId = types.DefaultChainId
+		require.NoError(d.t, auths[i].Sign(key))
+	}
+
+	seqno, err := d.es.GetExtSeqno(sponsor)
+	require.NoError(d.t, err)
+
+	txn := NewExecutionTransaction(sponsor, sponsor, seqno, nil)
+	txn.Flags = types.NewTransactionFlags(types.TransactionFlagSetCode)
+	txn.FeePack = types.NewFeePackFromGas(1_000_000)
+	txn.AuthorizationList = auths
+	return d.es.AddAndHandleTransaction(d.t.Context(), txn, dummyPayer{})
+}
+
+func (d *delegationTest) call(addr types.Address) *ExecutionResult {
+	d.t.Helper()
+
+	txn := NewExecutionTransaction(addr, addr, 0, nil)
+	txn.Flags = types.NewTransactionFlags(types.TransactionFlagInternal)
+	txn.FeePack = types.NewFeePackFromGas(1_000_000)
+	return d.es.AddAndHandleTransaction(d.t.Context(), txn, dummyPayer{})
+}
+
+func (d *delegationTest) rawCode(addr types.Address) types.Code {
+	d.t.Helper()
+
+	acc, err := d.es.GetAccount(addr)
+	require.NoError(d.t, err)
+	require.NotNil(d.t, acc)
+	return acc.GetCode()
+}
+
+func (d *delegationTest) counter(addr types.Address) common.Hash {
+	d.t.Helper()
+
+	val, err := d.es.GetState(addr, common.EmptyHash)
+	require.NoError(d.t, err)
+	return val
+}
+
+func (d *delegationTest) extSeqno(addr types.Address) types.Seqno {
+	d.t.Helper()
+
+	seqno, err := d.es.GetExtSeqno(addr)
+	require.NoError(d.t, err)
+	return seqno
+}
+
+func TestSetCodeTransaction(t *testing.T) {
+	t.Parallel()
+
+	database, err := db.NewBadgerDbInMemory()
+	require.NoError(t, err)
+	defer database.Close()
+
+	tx, err := database.CreateRwTx(t.Context())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	es := NewTestExecutionState(t, tx, types.BaseShardId, StateParams{})
+	es.BaseFee = types.DefaultGasPrice
+
+	d := &delegationTest{t: t, es: es}
+
+	sponsor := d.createAccount(acceptCode)
+	authority := d.createAccount(acceptCode)
+	delegate := d.createAccount(counterCode)
+
+	t.Run("SetDelegation", func(t *testing.T) {
+		res := d.setCode(sponsor, types.Authorization{Address: authority, Delegate: delegate, Seqno: 0})
+		require.False(t, res.Failed(), res.Error)
+
+		assert.Equal(t, types.NewDelegationDesignator(delegate), d.rawCode(authority))
+		assert.EqualValues(t, 1, d.extSeqno(authority))
+
+		code, _, err := es.GetCode(authority)
+		require.NoError(t, err)
+		assert.Equal(t, counterCode, code)
+	})
+
+	t.Run("CallThroughDelegation", func(t *testing.T) {
+		res := d.call(authority)
+		require.False(t, res.Failed(), res.Error)
+
+		// The delegate code is executed in the context of the authority.
+		assert.Equal(t, common.IntToHash(1), d.counter(authority))
+		assert.Equal(t, common.EmptyHash, d.counter(delegate))
+	})
+
+	t.Run("ReplayAuthorization", func(t *testing.T) {
+		other := d.createAccount(counterCode)
+
+		// The seqno of the authorization is already used, so it is skipped without failing the transaction.
+		res := d.setCode(sponsor, types.Authorization{Address: authority, Delegate: other, Seqno: 0})
+		require.False(t, res.Failed(), res.Error)
+		assert.GreaterOrEqual(t, res.GasUsed, AuthorizationBaseGas)
+
+		assert.Equal(t, types.NewDelegationDesignator(delegate), d.rawCode(authority))
+		assert.EqualValues(t, 1, d.extSeqno(authority))
+	})
+
+	t.Run("UnknownAuthority", func(t *testing.T) {
+		unknown := types.GenerateRandomAddress(es.ShardId)
+
+		res := d.setCode(sponsor, types.Authorization{Address: unknown, Delegate: delegate, Seqno: 0})
+		require.False(t, res.Failed(), res.Error)
+
+		code, _, err := es.GetCode(unknown)
+		require.NoError(t, err)
+		assert.Empty(t, code)
+	})
+
+	t.Run("DelegationChain", func(t *testing.T) {
+		chained := d.createAccount(acceptCode)
+
+		res := d.setCode(sponsor, types.Authorization{Address: chained, Delegate: authority, Seqno: 0})
+		require.False(t, res.Failed(), res.Error)
+
+		// The chain is not followed: the designator of the authority is loaded as the code, and it is not executable.
+		code, _, err := es.GetCode(chained)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(types.NewDelegationDesignator(delegate)), code)
+
+		res = d.call(chained)
+		require.True(t, res.Failed())
+		assert.Equal(t, common.EmptyHash, d.counter(chained))
+		assert.Equal(t, common.IntToHash(1), d.counter(authority))
+	})
+
+	t.Run("ClearDelegation", func(t *testing.T) {
+		// The authorization is verified by the delegated code.
+		res := d.setCode(sponsor, types.Authorization{Address: authority, Seqno: 1})
+		require.False(t, res.Failed(), res.Error)
+
+		assert.Empty(t, d.rawCode(authority))
+		assert.EqualValues(t, 2, d.extSeqno(authority))
+
+		code, _, err := es.GetCode(authority)
+		require.NoError(t, err)
+		assert.Empty(t, code)
+
+		// Storage written through the delegation stays with the authority.
+		assert.Equal(t, common.IntToHash(1), d.counter(authority))
+	})
+
+	t.Run("SelfSponsored", func(t *testing.T) {
+		self := d.createAccount(acceptCode)
+
+		// The external seqno of the sender is incremented before the authorizations are applied.
+		res := d.setCode(self, types.Authorization{Address: self, Delegate: delegate, Seqno: 1})
+		require.False(t, res.Failed(), res.Error)
+
+		assert.Equal(t, types.NewDelegationDesignator(delegate), d.rawCode(self))
+		assert.EqualValues(t, 2, d.extSeqno(self))
+		// The transaction is executed against the new code.
+		assert.Equal(t, common.IntToHash(1), d.counter(self))
+	})
+}
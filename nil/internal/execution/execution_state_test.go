@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -577,6 +578,97 @@ func (s *SuiteExecutionState) TestPrecompiles() {
 		s.Equal(types.ErrorCrossShardTransaction, res.Error.Code())
 	})
 
+	s.Run("testScheduleCall: success", func() {
+		s.Require().NoError(es.SetBalance(testAddr, types.NewValueFromUint64(1_000_000)))
+
+		txn.Data, err = abi.Pack("testScheduleCall", testAddr, uint64(10), uint64(5), uint64(3),
+			big.NewInt(1_000), []byte{1, 2, 3})
+		s.Require().NoError(err)
+		res := es.AddAndHandleTransaction(s.ctx, txn, dummyPayer{})
+		s.Require().False(res.Failed(), res.Error)
+
+		s.Require().Len(es.ScheduledTransactions, 1)
+		for _, scheduled := range es.ScheduledTransactions {
+			s.Require().NotNil(scheduled)
+			s.Equal(testAddr, scheduled.Owner)
+			s.Equal(testAddr, scheduled.To)
+			s.Equal(types.Code{1, 2, 3}, scheduled.Data)
+			s.Equal(types.BlockNumber(10), scheduled.NextBlock)
+			s.EqualValues(5, scheduled.Interval)
+			s.EqualValues(3, scheduled.Remaining)
+			s.Equal(types.NewValueFromUint64(3_000), scheduled.Prepaid())
+		}
+
+		balance, err := es.GetBalance(testAddr)
+		s.Require().NoError(err)
+		s.Equal(types.NewValueFromUint64(997_000), balance)
+	})
+
+	s.Run("testScheduleCall: gas depends on call data size and runs", func() {
+		schedule := func(callData []byte, count uint64) types.Gas {
+			txn.Data, err = abi.Pack("testScheduleCall", testAddr, uint64(10), uint64(1), count,
+				big.NewInt(0), callData)
+			s.Require().NoError(err)
+			res := es.AddAndHandleTransaction(s.ctx, txn, dummyPayer{})
+			s.Require().False(res.Failed(), res.Error)
+			return res.GasUsed
+		}
+
+		small := schedule(make([]byte, 1), 1)
+		large := schedule(make([]byte, 320), 10)
+
+		smallRequired, err := vm.ScheduleCallRequiredGas(1, 1)
+		s.Require().NoError(err)
+		largeRequired, err := vm.ScheduleCallRequiredGas(320, 10)
+		s.Require().NoError(err)
+		s.Equal(vm.ScheduleCallGas+vm.ScheduleCallDataWordGas+vm.ScheduleCallRunGas, smallRequired)
+		s.Equal(vm.ScheduleCallGas+10*vm.ScheduleCallDataWordGas+10*vm.ScheduleCallRunGas, largeRequired)
+		s.GreaterOrEqual(uint64(large-small), largeRequired-smallRequired)
+
+		_, err = vm.ScheduleCallRequiredGas(1, math.MaxUint64)
+		s.Require().ErrorIs(err, vm.ErrGasUintOverflow)
+	})
+
+	s.Run("testScheduleCall: cross shard", func() {
+		txn.Data, err = abi.Pack("testScheduleCall", types.GenerateRandomAddress(2), uint64(10), uint64(0),
+			uint64(1), big.NewInt(0), []byte{})
+		s.Require().NoError(err)
+		res := es.AddAndHandleTransaction(s.ctx, txn, dummyPayer{})
+		s.True(res.Failed())
+		s.Equal(types.ErrorCrossShardTransaction, res.Error.Code())
+	})
+
+	s.Run("testScheduleCall: start block is not in the future", func() {
+		txn.Data, err = abi.Pack("testScheduleCall", testAddr, uint64(0), uint64(0),
+			uint64(1), big.NewInt(0), []byte{})
+		s.Require().NoError(err)
+		res := es.AddAndHandleTransaction(s.ctx, txn, dummyPayer{})
+		s.True(res.Failed())
+		s.Equal(types.ErrorPrecompileBadArgument, res.Error.Code())
+	})
+
+	s.Run("testCancelScheduledCall", func() {
+		var id uint64
+		for scheduledId, scheduled := range es.ScheduledTransactions {
+			if scheduled != nil && !scheduled.FeeCredit.IsZero() {
+				id = scheduledId
+			}
+		}
+
+		txn.Data, err = abi.Pack("testCancelScheduledCall", id)
+		s.Require().NoError(err)
+		res := es.AddAndHandleTransaction(s.ctx, txn, dummyPayer{})
+		s.Require().False(res.Failed(), res.Error)
+
+		scheduled, err := es.GetScheduledTransaction(id)
+		s.Require().NoError(err)
+		s.Nil(scheduled)
+
+		balance, err := es.GetBalance(testAddr)
+		s.Require().NoError(err)
+		s.Equal(types.NewValueFromUint64(1_000_000), balance)
+	})
+
 	s.Run("Test required gas for outbound transactions", func() {
 		gasPrice := types.DefaultGasPrice
 		gasScale := types.DefaultGasPrice.Div(types.Value100)
@@ -17,6 +17,8 @@ type IRevertableExecutionState interface {
 	DeleteLog(txHash common.Hash)
 	SetTransientNoJournal(addr types.Address, key common.Hash, prevValue common.Hash)
 	DeleteOutTransaction(index int, txnHash common.Hash)
+	SetScheduledTransactionNoJournal(id uint64, scheduled *types.ScheduledTransaction, existed bool)
+	SetNextScheduledIdNoJournal(id uint64)
 }
 
 // JournalEntry is a modification entry in the state change journal that can be
@@ -126,6 +128,16 @@ type (
 		account   *types.Address
 		requestId types.TransactionIndex
 	}
+
+	// Changes to the scheduled transactions.
+	scheduledTransactionChange struct {
+		id      uint64
+		prev    *types.ScheduledTransaction
+		existed bool
+	}
+	nextScheduledIdChange struct {
+		prev uint64
+	}
 )
 
 func (ch createAccountChange) revert(s IRevertableExecutionState) {
@@ -184,6 +196,14 @@ func (ch asyncContextChange) revert(s IRevertableExecutionState) {
 	reverter{s}.revertAsyncContextChange(*ch.account, ch.requestId)
 }
 
+func (ch scheduledTransactionChange) revert(s IRevertableExecutionState) {
+	reverter{s}.revertScheduledTransactionChange(ch.id, ch.prev, ch.existed)
+}
+
+func (ch nextScheduledIdChange) revert(s IRevertableExecutionState) {
+	reverter{s}.revertNextScheduledIdChange(ch.prev)
+}
+
 type reverter struct {
 	es IRevertableExecutionState
 }
@@ -283,3 +303,11 @@ func (w reverter) revertAsyncContextChange(addr types.Address, requestId types.T
 		account.UndoSetAsyncContext(requestId)
 	}
 }
+
+func (w reverter) revertScheduledTransactionChange(id uint64, prev *types.ScheduledTransaction, existed bool) {
+	w.es.SetScheduledTransactionNoJournal(id, prev, existed)
+}
+
+func (w reverter) revertNextScheduledIdChange(prev uint64) {
+	w.es.SetNextScheduledIdNoJournal(prev)
+}
@@ -30,6 +30,8 @@ func (pp *blockPostprocessor) Postprocess() error {
 		pp.fillLastBlockTable,
 		pp.fillBlockHashByNumberIndex,
 		pp.fillBlockHashAndTransactionIndexByTransactionHash,
+		pp.fillTokenHoldersIndex,
+		pp.updateScheduledTransactions,
 	} {
 		if err := postpocessor(); err != nil {
 			return err
@@ -82,3 +84,102 @@ func (pp *blockPostprocessor) fillBlockHashAndTransactionIndexByTransactionHash(
 	}
 	return fill(pp.blockResult.OutTxnHashes, db.BlockHashAndOutTransactionIndexByTransactionHash)
 }
+
+// fillTokenHoldersIndex applies the token balances of the block to the index.
+// The index is rebuilt from the state of the block if it does not continue the indexed chain:
+// either the index is absent (e.g., the database was created before it was introduced),
+// or the previous indexed block was replaced.
+func (pp *blockPostprocessor) fillTokenHoldersIndex() error {
+	block := pp.blockResult.Block
+	head, err := db.ReadTokenHoldersIndexHead(pp.tx, pp.shardId)
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return err
+	}
+
+	if err == nil && head == block.PrevBlock {
+		for _, update := range pp.blockResult.TokenBalances {
+			if err := db.WriteTokenHolder(pp.tx, pp.shardId, update.Token, update.Holder, update.Balance); err != nil {
+				return err
+			}
+		}
+	} else if err := rebuildTokenHoldersIndex(pp.tx, pp.shardId, block); err != nil {
+		return fmt.Errorf("failed to rebuild token holders index: %w", err)
+	}
+	return db.WriteTokenHoldersIndexHead(pp.tx, pp.shardId, pp.blockResult.BlockHash)
+}
+
+// rebuildTokenHoldersIndex replaces the token holders index of the shard with the balances from the state
+// of the block. It iterates over all the accounts of the shard, so it is only used on backfill and rollback.
+func rebuildTokenHoldersIndex(tx db.RwTx, shardId types.ShardId, block *types.Block) error {
+	if err := db.ClearTokenHolders(tx, shardId); err != nil {
+		return err
+	}
+
+	contracts := NewDbContractTrieReader(tx, shardId)
+	if err := contracts.SetRootHash(block.SmartContractsRoot); err != nil {
+		return err
+	}
+	entries, err := contracts.Entries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		tokens := NewDbTokenTrieReader(tx, shardId)
+		if err := tokens.SetRootHash(entry.Val.TokenRoot); err != nil {
+			return err
+		}
+		balances, err := tokens.Entries()
+		if err != nil {
+			return err
+		}
+		for _, balance := range balances {
+			if err := db.WriteTokenHolder(tx, shardId, balance.Key, entry.Val.Address, *balance.Val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateScheduledTransactions applies the schedule changes of the block. The schedules are already reconciled
+// with the previous block by the execution state, the replaced ones are recorded for the case of a rollback.
+func (pp *blockPostprocessor) updateScheduledTransactions() error {
+	if len(pp.blockResult.ScheduledTransactions) != 0 {
+		nextId, err := db.ReadNextScheduledTransactionId(pp.tx, pp.shardId)
+		if err != nil {
+			return err
+		}
+		undo := &db.ScheduledTransactionsUndo{NextId: nextId}
+
+		for _, update := range pp.blockResult.ScheduledTransactions {
+			prev, err := db.ReadScheduledTransaction(pp.tx, pp.shardId, update.Id)
+			switch {
+			case err == nil:
+				undo.Restored = append(undo.Restored, *prev)
+			case errors.Is(err, db.ErrKeyNotFound):
+				undo.Removed = append(undo.Removed, update.Id)
+			default:
+				return err
+			}
+
+			if update.Scheduled == nil {
+				err = db.DeleteScheduledTransaction(pp.tx, pp.shardId, update.Id)
+			} else {
+				err = db.WriteScheduledTransaction(pp.tx, pp.shardId, update.Scheduled)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := db.WriteScheduledTransactionsUndo(pp.tx, pp.shardId, pp.blockResult.BlockHash, undo); err != nil {
+			return err
+		}
+	}
+
+	if pp.blockResult.NextScheduledId != 0 {
+		if err := db.WriteNextScheduledTransactionId(pp.tx, pp.shardId, pp.blockResult.NextScheduledId); err != nil {
+			return err
+		}
+	}
+	return db.WriteScheduledTransactionsHead(pp.tx, pp.shardId, pp.blockResult.BlockHash)
+}
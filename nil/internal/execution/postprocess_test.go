@@ -0,0 +1,154 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenHoldersIndex(t *testing.T) {
+	t.Parallel()
+
+	const shardId = types.BaseShardId
+
+	database, err := db.NewBadgerDbInMemory()
+	require.NoError(t, err)
+	defer database.Close()
+
+	tx, err := database.CreateRwTx(t.Context())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	token := *types.TokenIdForAddress(types.GenerateRandomAddress(shardId))
+	holderA := types.GenerateRandomAddress(shardId)
+	holderB := types.GenerateRandomAddress(shardId)
+	holderC := types.GenerateRandomAddress(shardId)
+
+	generate := func(id types.BlockNumber, prev *types.Block, balances map[types.Address]uint64) *types.Block {
+		t.Helper()
+
+		es := NewTestExecutionState(t, tx, shardId, StateParams{Block: prev})
+		for addr, amount := range balances {
+			acc, err := es.GetAccount(addr)
+			require.NoError(t, err)
+			if acc == nil {
+				require.NoError(t, es.CreateAccount(addr))
+			}
+			require.NoError(t, es.AddToken(addr, token, types.NewValueFromUint64(amount)))
+		}
+
+		res, err := es.Commit(id, &types.ConsensusParams{})
+		require.NoError(t, err)
+		require.NoError(t, PostprocessBlock(tx, shardId, res, ModeVerify))
+		return res.Block
+	}
+
+	check := func(expected ...types.TokenHolder) {
+		t.Helper()
+
+		holders, total, err := db.ReadTokenHolders(tx, shardId, token, 0, 100)
+		require.NoError(t, err)
+		require.ElementsMatch(t, expected, holders)
+		require.EqualValues(t, len(expected), total)
+	}
+
+	block0 := generate(0, nil, map[types.Address]uint64{holderA: 10, holderB: 5})
+	check(
+		types.TokenHolder{Address: holderA, Balance: types.NewValueFromUint64(10)},
+		types.TokenHolder{Address: holderB, Balance: types.NewValueFromUint64(5)})
+
+	generate(1, block0, map[types.Address]uint64{holderB: 5, holderC: 3})
+	check(
+		types.TokenHolder{Address: holderA, Balance: types.NewValueFromUint64(10)},
+		types.TokenHolder{Address: holderB, Balance: types.NewValueFromUint64(10)},
+		types.TokenHolder{Address: holderC, Balance: types.NewValueFromUint64(3)})
+
+	t.Run("Rollback", func(t *testing.T) {
+		// Block 1 is replaced, so the balances it changed must be reverted.
+		block1 := generate(1, block0, map[types.Address]uint64{holderA: 1})
+		check(
+			types.TokenHolder{Address: holderA, Balance: types.NewValueFromUint64(11)},
+			types.TokenHolder{Address: holderB, Balance: types.NewValueFromUint64(5)})
+
+		t.Run("Backfill", func(t *testing.T) {
+			// Simulate a database created before the index was introduced.
+			require.NoError(t, db.ClearTokenHolders(tx, shardId))
+			require.NoError(t, tx.Delete(db.TokenHoldersIndexHeadTable, shardId.Bytes()))
+			check()
+
+			generate(2, block1, nil)
+			check(
+				types.TokenHolder{Address: holderA, Balance: types.NewValueFromUint64(11)},
+				types.TokenHolder{Address: holderB, Balance: types.NewValueFromUint64(5)})
+		})
+	})
+}
+
+func TestScheduledTransactionsRollback(t *testing.T) {
+	t.Parallel()
+
+	const shardId = types.BaseShardId
+
+	database, err := db.NewBadgerDbInMemory()
+	require.NoError(t, err)
+	defer database.Close()
+
+	tx, err := database.CreateRwTx(t.Context())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	owner := types.GenerateRandomAddress(shardId)
+
+	generate := func(id types.BlockNumber, prev *types.Block, change func(es *ExecutionState)) *types.Block {
+		t.Helper()
+
+		es := NewTestExecutionState(t, tx, shardId, StateParams{Block: prev})
+		if change != nil {
+			change(es)
+		}
+
+		res, err := es.Commit(id, &types.ConsensusParams{})
+		require.NoError(t, err)
+		require.NoError(t, PostprocessBlock(tx, shardId, res, ModeVerify))
+		return res.Block
+	}
+
+	schedule := func(nextBlock types.BlockNumber) func(es *ExecutionState) {
+		return func(es *ExecutionState) {
+			_, err := es.ScheduleTransaction(&types.ScheduledTransaction{
+				Owner: owner, To: owner, NextBlock: nextBlock, Remaining: 1,
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	check := func(expected ...types.BlockNumber) {
+		t.Helper()
+
+		due, err := db.ReadDueScheduledTransactions(tx, shardId, 100, 10)
+		require.NoError(t, err)
+		blocks := make([]types.BlockNumber, 0, len(due))
+		for _, scheduled := range due {
+			blocks = append(blocks, scheduled.NextBlock)
+		}
+		require.Equal(t, expected, blocks)
+	}
+
+	block0 := generate(0, nil, schedule(10))
+	block1 := generate(1, block0, schedule(20))
+	generate(2, block1, func(es *ExecutionState) {
+		es.CancelScheduledTransaction(1)
+		schedule(30)(es)
+	})
+	check(20, 30)
+
+	// Block 1 is replaced, so the schedules of blocks 1 and 2 must be reverted.
+	generate(1, block0, schedule(40))
+	check(10, 40)
+
+	nextId, err := db.ReadNextScheduledTransactionId(tx, shardId)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, nextId)
+}
@@ -40,6 +40,8 @@ type Proposal struct {
 	MainShardHash   common.Hash         `json:"mainShardHash"`
 	ShardHashes     []common.Hash       `json:"shardHashes"`
 
+	RandaoSignature *types.BlsAggregateSignature `json:"randaoSignature,omitempty"`
+
 	InternalTxns []*types.Transaction `json:"internalTxns"`
 	ExternalTxns []*types.Transaction `json:"externalTxns"`
 	ForwardTxns  []*types.Transaction `json:"forwardTxns"`
@@ -64,8 +66,12 @@ type ProposalSerializable struct {
 
 	ExternalTxns []*types.Transaction
 
-	// SpecialTxns are internal transactions produced by the collator. They appear only on the main shard.
+	// SpecialTxns are internal transactions produced by the collator: L1 block updates on the main shard
+	// and the runs of the scheduled transactions.
 	SpecialTxns []*types.Transaction
+
+	// RandaoSignature proves that the main shard block is finalized, see types.Block.
+	RandaoSignature *types.BlsAggregateSignature `rlp:"optional"`
 }
 
 func (p *ProposalSerializable) UnmarshalNil(buf []byte) error {
@@ -182,8 +188,9 @@ func ConvertProposal(proposal *ProposalSerializable) (*Proposal, error) {
 		CollatorState:   proposal.CollatorState,
 		MainShardHash:   proposal.MainShardHash,
 		ShardHashes:     proposal.ShardHashes,
+		RandaoSignature: proposal.RandaoSignature,
 
-		// todo: special txns should be validated
+		// todo: special txns should be validated (only scheduled ones are checked during execution)
 		InternalTxns: append(proposal.SpecialTxns, internalTxns...),
 		ExternalTxns: proposal.ExternalTxns,
 		ForwardTxns:  forwardTxns,
@@ -0,0 +1,187 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+// GetScheduledTransaction returns the schedule with the given id or nil if there is no such schedule.
+// Changes made in the current block take precedence over the db.
+func (es *ExecutionState) GetScheduledTransaction(id uint64) (*types.ScheduledTransaction, error) {
+	scheduled, ok := es.ScheduledTransactions[id]
+	if !ok {
+		var err error
+		scheduled, err = db.ReadScheduledTransaction(es.tx, es.ShardId, id)
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if scheduled == nil {
+		return nil, nil
+	}
+
+	res := *scheduled
+	return &res, nil
+}
+
+// ScheduleTransaction registers a new schedule and returns its id. The fees must be already paid by the owner.
+func (es *ExecutionState) ScheduleTransaction(scheduled *types.ScheduledTransaction) (uint64, error) {
+	if es.nextScheduledId == 0 {
+		id, err := db.ReadNextScheduledTransactionId(es.tx, es.ShardId)
+		if err != nil {
+			return 0, err
+		}
+		es.nextScheduledId = id
+	}
+
+	res := *scheduled
+	res.Id = es.nextScheduledId
+	es.setScheduledTransaction(res.Id, &res)
+
+	es.journal.append(nextScheduledIdChange{prev: es.nextScheduledId})
+	es.nextScheduledId++
+	return res.Id, nil
+}
+
+// CancelScheduledTransaction removes the schedule. Refunding the prepaid fees is up to the caller.
+func (es *ExecutionState) CancelScheduledTransaction(id uint64) {
+	es.setScheduledTransaction(id, nil)
+}
+
+func (es *ExecutionState) setScheduledTransaction(id uint64, scheduled *types.ScheduledTransaction) {
+	prev, existed := es.ScheduledTransactions[id]
+	es.journal.append(scheduledTransactionChange{id: id, prev: prev, existed: existed})
+	es.ScheduledTransactions[id] = scheduled
+}
+
+func (es *ExecutionState) SetScheduledTransactionNoJournal(
+	id uint64, scheduled *types.ScheduledTransaction, existed bool,
+) {
+	if existed {
+		es.ScheduledTransactions[id] = scheduled
+	} else {
+		delete(es.ScheduledTransactions, id)
+	}
+}
+
+func (es *ExecutionState) SetNextScheduledIdNoJournal(id uint64) {
+	es.nextScheduledId = id
+}
+
+// acceptScheduledTransaction checks that the transaction is the due run of an existing schedule and moves the
+// schedule to the next run. Scheduled transactions are not sent by any shard, so they don't affect InTxCounts.
+func (es *ExecutionState) acceptScheduledTransaction(tx *types.Transaction) error {
+	scheduled, err := es.GetScheduledTransaction(uint64(tx.TxId))
+	if err != nil {
+		return err
+	}
+	if scheduled == nil {
+		return types.NewVerboseError(types.ErrorValidation, fmt.Sprintf("unknown schedule %d", tx.TxId))
+	}
+
+	blockId, err := es.currentBlockId()
+	if err != nil {
+		return err
+	}
+	if scheduled.NextBlock > blockId {
+		return types.NewVerboseError(types.ErrorValidation,
+			fmt.Sprintf("schedule %d is due at block %d, current block is %d", tx.TxId, scheduled.NextBlock, blockId))
+	}
+	if scheduled.Transaction().Hash() != tx.Hash() {
+		return types.NewVerboseError(types.ErrorValidation,
+			fmt.Sprintf("transaction doesn't match the next run of schedule %d", tx.TxId))
+	}
+
+	if scheduled.Advance() {
+		// A delayed run shifts the following ones, so they don't pile up.
+		for scheduled.NextBlock <= blockId {
+			scheduled.NextBlock += types.BlockNumber(scheduled.Interval)
+		}
+		es.setScheduledTransaction(scheduled.Id, scheduled)
+	} else {
+		es.setScheduledTransaction(scheduled.Id, nil)
+	}
+	return nil
+}
+
+func (es *ExecutionState) currentBlockId() (types.BlockNumber, error) {
+	data, err := es.shardAccessor.GetBlock().ByHash(es.PrevBlock)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return data.Block().Id + 1, nil
+}
+
+func (es *ExecutionState) collectScheduledTransactions() []ScheduledTransactionUpdate {
+	res := make([]ScheduledTransactionUpdate, 0, len(es.ScheduledTransactions))
+	for id, scheduled := range es.ScheduledTransactions {
+		res = append(res, ScheduledTransactionUpdate{Id: id, Scheduled: scheduled})
+	}
+	return res
+}
+
+// ReconcileScheduledTransactions reverts the schedules of the shard to the state of the given block.
+// The blocks applied after it are replaced on rollback or replay, so their changes are undone
+// before any schedule is read for the new block.
+func ReconcileScheduledTransactions(tx db.RwTx, shardId types.ShardId, blockHash common.Hash) error {
+	head, err := db.ReadScheduledTransactionsHead(tx, shardId)
+	if errors.Is(err, db.ErrKeyNotFound) || (err == nil && head == blockHash) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	target, err := db.ReadBlock(tx, shardId, blockHash)
+	if err != nil {
+		return err
+	}
+	for head != blockHash {
+		block, err := db.ReadBlock(tx, shardId, head)
+		if err != nil {
+			return err
+		}
+		if block.Id <= target.Id {
+			return fmt.Errorf("scheduled transactions are applied up to block %s, which doesn't descend from %s",
+				head, blockHash)
+		}
+		if err := revertScheduledTransactions(tx, shardId, head); err != nil {
+			return fmt.Errorf("failed to revert scheduled transactions of block %s: %w", head, err)
+		}
+		head = block.PrevBlock
+	}
+	return db.WriteScheduledTransactionsHead(tx, shardId, blockHash)
+}
+
+// revertScheduledTransactions undoes the schedule changes of the block, blocks without the undo record changed none.
+func revertScheduledTransactions(tx db.RwTx, shardId types.ShardId, blockHash common.Hash) error {
+	undo, err := db.ReadScheduledTransactionsUndo(tx, shardId, blockHash)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, id := range undo.Removed {
+		if err := db.DeleteScheduledTransaction(tx, shardId, id); err != nil {
+			return err
+		}
+	}
+	for _, scheduled := range undo.Restored {
+		if err := db.WriteScheduledTransaction(tx, shardId, &scheduled); err != nil {
+			return err
+		}
+	}
+	return db.WriteNextScheduledTransactionId(tx, shardId, undo.NextId)
+}
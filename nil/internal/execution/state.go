@@ -92,6 +92,7 @@ type ExecutionState struct {
 	// and are not used in the state
 	PatchLevel      uint32
 	RollbackCounter uint32
+	RandaoSignature *types.BlsAggregateSignature
 
 	InTransactionHash common.Hash
 	Logs              map[common.Hash][]*types.Log
@@ -107,6 +108,11 @@ type ExecutionState struct {
 	OutTransactions map[common.Hash][]*types.OutboundTransaction
 	OutTxCounts     TxCounts
 
+	// ScheduledTransactions holds the schedules changed in the block, where nil means that the schedule is removed
+	ScheduledTransactions map[uint64]*types.ScheduledTransaction
+	// nextScheduledId is loaded from the db on the first scheduling in the block
+	nextScheduledId uint64
+
 	Receipts []*types.Receipt
 	Errors   map[common.Hash]error
 
@@ -278,10 +284,15 @@ func NewEVMBlockContext(es *ExecutionState) (*vm.BlockContext, error) {
 		time = header.Id.Uint64()
 		rollbackCounter = header.RollbackCounter
 	}
+	mainShardHash := es.MainShardHash
+	if es.ShardId.IsMainShard() {
+		mainShardHash = es.PrevBlock
+	}
+	random := types.Randomness(mainShardHash)
 	return &vm.BlockContext{
 		GetHash:     getHashFn(es, header),
 		BlockNumber: currentBlockId,
-		Random:      &common.EmptyHash,
+		Random:      &random,
 		BaseFee:     big.NewInt(10),
 		BlobBaseFee: big.NewInt(10),
 		GasLimit:    es.GasLimit.Uint64(),
@@ -360,6 +371,8 @@ func NewExecutionState(tx db.RoTx, shardId types.ShardId, params StateParams) (*
 		Errors:           map[common.Hash]error{},
 		CoinbaseAddress:  types.ShardAndHexToAddress(shardId, CoinbaseShardIndependentAddr),
 
+		ScheduledTransactions: map[uint64]*types.ScheduledTransaction{},
+
 		journal:          newJournal(),
 		transientStorage: newTransientStorage(),
 
@@ -377,6 +390,13 @@ func NewExecutionState(tx db.RoTx, shardId types.ShardId, params StateParams) (*
 		logger: logger,
 	}
 
+	if !isReadOnly && params.Block != nil {
+		// the schedules changed by the blocks replaced on rollback must not be visible to the new block
+		if err := ReconcileScheduledTransactions(resTx, shardId, prevBlockHash); err != nil {
+			return nil, fmt.Errorf("failed to reconcile scheduled transactions: %w", err)
+		}
+	}
+
 	return res, res.initTries(&params)
 }
 
@@ -569,11 +589,25 @@ func (es *ExecutionState) Exists(addr types.Address) (bool, error) {
 	return acc != nil, err
 }
 
+// GetCode returns the code executed on behalf of the account.
+// For accounts with a delegation designator, the code of the delegate is returned.
 func (es *ExecutionState) GetCode(addr types.Address) ([]byte, common.Hash, error) {
 	acc, err := es.GetAccount(addr)
 	if err != nil || acc == nil {
 		return nil, types.EmptyCodeHash, err
 	}
+	if delegate, ok := types.ParseDelegation(acc.GetCode()); ok {
+		return es.getDelegatedCode(delegate)
+	}
+	return acc.GetCode(), acc.GetCodeHash(), nil
+}
+
+func (es *ExecutionState) getDelegatedCode(delegate types.Address) ([]byte, common.Hash, error) {
+	acc, err := es.GetAccount(delegate)
+	if err != nil || acc == nil {
+		return nil, types.EmptyCodeHash, err
+	}
+	// Delegation chains are not followed: the designator of the delegate is returned as is.
 	return acc.GetCode(), acc.GetCodeHash(), nil
 }
 
@@ -1012,9 +1046,16 @@ func (es *ExecutionState) sendBounceTransaction(txn *types.Transaction, execResu
 		return false, nil
 	}
 
-	data, err := contracts.NewCallData(contracts.NameNilBounceable, "bounce", execResult.Error.Error())
-	if err != nil {
-		return false, err
+	var data []byte
+	var err error
+	if vm.IsTokenAllowanceEscrow(txn.BounceTo) {
+		// The escrow has no code, the data tells it which allowance reservation to release.
+		data = vm.TokenAllowanceBounceData(txn.From, txn.Seqno)
+	} else {
+		data, err = contracts.NewCallData(contracts.NameNilBounceable, "bounce", execResult.Error.Error())
+		if err != nil {
+			return false, err
+		}
 	}
 
 	check.PanicIfNotf(
@@ -1088,6 +1129,10 @@ func (es *ExecutionState) SendResponseTransaction(txn *types.Transaction, res *E
 func (es *ExecutionState) AcceptInternalTransaction(tx *types.Transaction) error {
 	check.PanicIfNot(tx.IsInternal())
 
+	if tx.IsScheduled() {
+		return es.acceptScheduledTransaction(tx)
+	}
+
 	nextTxId := es.InTxCounts[tx.From.ShardId()]
 	if tx.TxId != nextTxId {
 		return types.NewError(types.ErrorTxIdGap)
@@ -1189,6 +1234,8 @@ func (es *ExecutionState) HandleTransaction(
 		return NewExecutionResult().SetFatal(es.handleRefundTransaction(ctx, txn))
 	case txn.IsDeploy():
 		res = es.handleDeployTransaction(ctx, txn)
+	case txn.IsSetCode():
+		res = es.handleSetCodeTransaction(ctx, txn)
 	default:
 		res = es.handleExecutionTransaction(ctx, txn)
 	}
@@ -1372,6 +1419,14 @@ func (es *ExecutionState) handleExecutionTransaction(
 
 	caller := (vm.AccountRef)(transaction.From)
 
+	if transaction.IsBounce() && vm.IsTokenAllowanceEscrow(addr) {
+		return NewExecutionResult().SetTxnErrorOrFatal(vm.ReleaseTokenAllowance(es, transaction))
+	}
+	if vm.IsTokenPullTransaction(transaction) {
+		// The pull of the spender from another shard is executed by the precompile instead of the owner code.
+		addr = vm.ManageAllowanceAddress
+	}
+
 	callData, res := es.TryProcessResponse(transaction)
 	if res != nil && res.Failed() {
 		return res
@@ -1503,6 +1558,9 @@ func (es *ExecutionState) writeTxCounts(root common.Hash, counts TxCounts) (comm
 }
 
 func (es *ExecutionState) BuildBlock(blockId types.BlockNumber) (*BlockGenerationResult, error) {
+	// Collect token balances before the accounts are committed, since the commit drops zero balances
+	tokenBalances := es.collectTokenBalances()
+
 	// Update contract tree with current account states
 	if err := es.updateContractTree(); err != nil {
 		return nil, err
@@ -1559,9 +1617,24 @@ func (es *ExecutionState) BuildBlock(blockId types.BlockNumber) (*BlockGeneratio
 		OutTxnHashes: outTxnHashes,
 		Receipts:     es.Receipts,
 		ConfigParams: configParams,
+
+		TokenBalances: tokenBalances,
+
+		ScheduledTransactions: es.collectScheduledTransactions(),
+		NextScheduledId:       es.nextScheduledId,
 	}, nil
 }
 
+func (es *ExecutionState) collectTokenBalances() []TokenBalanceUpdate {
+	var res []TokenBalanceUpdate
+	for addr, acc := range es.Accounts {
+		for id, balance := range acc.GetTouchedTokens() {
+			res = append(res, TokenBalanceUpdate{Token: id, Holder: addr, Balance: balance})
+		}
+	}
+	return res
+}
+
 func (es *ExecutionState) updateContractTree() error {
 	accounts := make(map[types.Address]AccountState)
 	for addr, journaledState := range es.Accounts {
@@ -1791,7 +1864,8 @@ func (es *ExecutionState) buildFinalBlock(
 			PatchLevel:          es.PatchLevel,
 			RollbackCounter:     es.RollbackCounter,
 		},
-		LogsBloom: types.CreateBloom(es.Receipts),
+		LogsBloom:       types.CreateBloom(es.Receipts),
+		RandaoSignature: es.RandaoSignature,
 	}
 }
 
@@ -1920,18 +1994,27 @@ func (es *ExecutionState) GetShardID() types.ShardId {
 	return es.ShardId
 }
 
+// verifyExternalCallData packs the call of `verifyExternal(uint256,bytes)` of an account.
+func verifyExternalCallData(hash common.Hash, signature []byte) ([]byte, error) {
+	methodSignature := "verifyExternal(uint256,bytes)"
+	methodSelector := crypto.Keccak256([]byte(methodSignature))[:4]
+	argSpec := vm.VerifySignatureArgs()[1:] // skip first arg (pubkey)
+	argData, err := argSpec.Pack(hash.Big(), signature)
+	if err != nil {
+		return nil, err
+	}
+	return append(methodSelector, argData...), nil
+}
+
 func (es *ExecutionState) CallVerifyExternal(
 	transaction *types.Transaction,
 	account AccountState,
 ) (res *ExecutionResult) {
-	methodSignature := "verifyExternal(uint256,bytes)"
-	methodSelector := crypto.Keccak256([]byte(methodSignature))[:4]
-	argSpec := vm.VerifySignatureArgs()[1:] // skip first arg (pubkey)
 	hash, err := transaction.SigningHash()
 	if err != nil {
 		return NewExecutionResult().SetFatal(fmt.Errorf("transaction.SigningHash() failed: %w", err))
 	}
-	argData, err := argSpec.Pack(hash.Big(), ([]byte)(transaction.Signature))
+	calldata, err := verifyExternalCallData(hash, transaction.Signature)
 	if err != nil {
 		es.logger.Error().Err(err).Msg("failed to pack arguments")
 		return NewExecutionResult().SetFatal(err)
@@ -1941,8 +2024,6 @@ func (es *ExecutionState) CallVerifyExternal(
 		return NewExecutionResult().SetError(types.KeepOrWrapError(types.ErrorBaseFeeTooHigh, err))
 	}
 
-	calldata := append(methodSelector, argData...) //nolint:gocritic
-
 	if err := es.newVm(transaction.IsInternal(), transaction.From); err != nil {
 		return NewExecutionResult().SetFatal(fmt.Errorf("newVm failed: %w", err))
 	}
@@ -2061,6 +2142,8 @@ func (es *ExecutionState) GetGasPrice(shardId types.ShardId) (types.Value, error
 	return types.Value{Uint256: &prices.Shards[shardId]}, nil
 }
 
+// Rollback records the rollback requested in the block. The indexes kept outside of the state, the token holders
+// and the scheduled transactions, are reconciled with the state of the block the next block is built on.
 func (es *ExecutionState) Rollback(counter, patchLevel uint32, mainBlock uint64) error {
 	es.rollback = &RollbackParams{
 		Counter:     counter,
@@ -1,6 +1,7 @@
 package execution
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/NilFoundation/nil/nil/common/check"
@@ -200,12 +201,26 @@ func ValidateExternalTransaction(es *ExecutionState, transaction *types.Transact
 		return NewExecutionResult().SetError(types.NewError(types.ErrorMaxFeePerGasIsZero))
 	}
 
+	// verifyExternal is paid by the account only after the check, so its input is kept small
+	if len(transaction.Signature) > types.TransactionMaxAuthDataSize {
+		return NewExecutionResult().SetError(types.NewError(types.ErrorAuthDataTooLarge))
+	}
+
 	if account, err := es.GetAccount(transaction.To); err != nil {
 		return NewExecutionResult().SetError(types.KeepOrWrapError(types.ErrorNoAccount, err))
 	} else if account == nil {
 		return NewExecutionResult().SetError(types.NewError(types.ErrorDestinationContractDoesNotExist))
 	}
 
+	if transaction.IsSetCode() {
+		if err := transaction.AuthorizationList.Verify(transaction.To.ShardId()); err != nil {
+			return NewExecutionResult().SetError(types.NewWrapError(types.ErrorInvalidAuthorizationList, err))
+		}
+	} else if len(transaction.AuthorizationList) > 0 {
+		return NewExecutionResult().SetError(types.NewWrapError(types.ErrorInvalidAuthorizationList,
+			errors.New("authorization list is allowed only in set code transactions")))
+	}
+
 	switch {
 	case transaction.IsDeploy():
 		return validateExternalDeployTransaction(es, transaction)
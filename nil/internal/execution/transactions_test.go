@@ -2,12 +2,15 @@ package execution
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"testing"
 
 	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/contracts"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -113,6 +116,12 @@ func (s *TransactionsSuite) TestValidateExternalTransaction() {
 
 		// todo: fail signature verification
 
+		s.Run("AuthDataTooLarge", func() {
+			txn.Signature = make([]byte, types.TransactionMaxAuthDataSize+1)
+			s.Require().Equal(types.ErrorAuthDataTooLarge, validate(txn).Code())
+			txn.Signature = nil
+		})
+
 		s.Run("InvalidChain", func() {
 			txn.ChainId = 100500
 			s.Require().Equal(types.ErrorInvalidChainId, validate(txn).Code())
@@ -126,6 +135,102 @@ func (s *TransactionsSuite) TestValidateExternalTransaction() {
 	})
 }
 
+func (s *TransactionsSuite) TestValidateMultisigExternalTransaction() {
+	tx, err := s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	es := NewTestExecutionState(s.T(), tx, types.BaseShardId, StateParams{})
+	es.GasPrice = es.BaseFee
+
+	// three owners with threshold 2 and a stranger
+	keys := make([]*ecdsa.PrivateKey, 4)
+	owners := make([]ethcommon.Address, 3)
+	for i := range keys {
+		keys[i], err = crypto.GenerateKey()
+		s.Require().NoError(err)
+		if i < len(owners) {
+			owners[i] = contracts.MultisigOwner(&keys[i].PublicKey)
+		}
+	}
+	owners = contracts.SortMultisigOwners(owners)
+	ownerKey := func(i int) *ecdsa.PrivateKey {
+		for _, key := range keys {
+			if contracts.MultisigOwner(&key.PublicKey) == owners[i] {
+				return key
+			}
+		}
+		s.FailNow("owner key not found")
+		return nil
+	}
+
+	code, err := contracts.PrepareMultisigSmartAccountCode(owners, 2)
+	s.Require().NoError(err)
+	addr := Deploy(s.T(), es, types.BuildDeployPayload(code, common.EmptyHash), types.BaseShardId, types.Address{}, 0)
+	s.Require().NoError(es.SetBalance(addr, types.NewValueFromUint64(10_000_000_000_000_000)))
+
+	txn := types.NewEmptyTransaction()
+	txn.To = addr
+	txn.Data = []byte("hello")
+	txn.MaxFeePerGas = types.MaxFeePerGasDefault
+
+	hash, err := txn.SigningHash()
+	s.Require().NoError(err)
+	sign := func(key *ecdsa.PrivateKey) []byte {
+		sig, err := crypto.Sign(hash.Bytes(), key)
+		s.Require().NoError(err)
+		return sig
+	}
+	validate := func(signature []byte) types.ExecError {
+		txn.Signature = signature
+		res := ValidateExternalTransaction(es, txn)
+		s.Require().False(res.IsFatal())
+		if res.Failed() {
+			return res.Error
+		}
+		return nil
+	}
+
+	s.Run("ThresholdMet", func() {
+		s.Require().NoError(validate(append(sign(ownerKey(0)), sign(ownerKey(2))...)))
+	})
+
+	s.Run("AllOwners", func() {
+		s.Require().NoError(validate(append(append(sign(ownerKey(0)), sign(ownerKey(1))...), sign(ownerKey(2))...)))
+	})
+
+	s.Run("BelowThreshold", func() {
+		s.Require().Equal(types.ErrorExternalVerificationFailed, validate(sign(ownerKey(1))).Code())
+	})
+
+	s.Run("DuplicateSigner", func() {
+		s.Require().Equal(types.ErrorExternalVerificationFailed,
+			validate(append(sign(ownerKey(1)), sign(ownerKey(1))...)).Code())
+	})
+
+	s.Run("UnsortedSigners", func() {
+		s.Require().Equal(types.ErrorExternalVerificationFailed,
+			validate(append(sign(ownerKey(2)), sign(ownerKey(0))...)).Code())
+	})
+
+	s.Run("NonOwnerSigner", func() {
+		signatures := [][]byte{sign(ownerKey(0)), sign(keys[3])}
+		if contracts.MultisigOwner(&keys[3].PublicKey).Cmp(owners[0]) < 0 {
+			signatures[0], signatures[1] = signatures[1], signatures[0]
+		}
+		s.Require().Equal(types.ErrorExternalVerificationFailed,
+			validate(append(signatures[0], signatures[1]...)).Code())
+	})
+
+	s.Run("AuthDataTooLarge", func() {
+		var authData []byte
+		for len(authData) <= types.TransactionMaxAuthDataSize {
+			authData = append(authData, sign(ownerKey(0))...)
+		}
+		s.Require().Equal(types.ErrorAuthDataTooLarge, validate(authData).Code())
+	})
+}
+
 func (s *TransactionsSuite) TestValidateDeployTransaction() {
 	txn := types.NewEmptyTransaction()
 	txn.Data = types.Code("no-salt")
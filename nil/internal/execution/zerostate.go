@@ -9,6 +9,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common/check"
 	"github.com/NilFoundation/nil/nil/internal/config"
 	"github.com/NilFoundation/nil/nil/internal/contracts"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -25,6 +26,10 @@ type ContractDescr struct {
 	CtorArgs []any         `yaml:"ctorArgs,omitempty" json:"ctorArgs,omitempty"`
 }
 
+// MainKeyName is the name the main key is kept under in the keystore
+const MainKeyName = "main"
+
+// MainKeys is the plain text file the main key was kept in before the keystore
 type MainKeys struct {
 	MainPrivateKey hexutil.Bytes `yaml:"mainPrivateKey"`
 	MainPublicKey  hexutil.Bytes `yaml:"mainPublicKey"`
@@ -86,7 +91,18 @@ func (cfg *ZeroStateConfig) GetValidators() []config.ListValidators {
 	return cfg.ConfigParams.Validators.Validators
 }
 
-func DumpMainKeys(fname string, mainPrivateKey *ecdsa.PrivateKey) error {
+// DumpMainKeys stores the main private key encrypted with the passphrase in the keystore.
+func DumpMainKeys(ks *keystore.Keystore, passphrase string, mainPrivateKey *ecdsa.PrivateKey) error {
+	return ks.StoreSecp256k1(MainKeyName, mainPrivateKey, passphrase)
+}
+
+// LoadMainKeys loads the main private key from the keystore, keystore.ErrKeyNotFound is returned if it is absent.
+func LoadMainKeys(ks *keystore.Keystore, passphrase string) (*ecdsa.PrivateKey, error) {
+	return ks.LoadSecp256k1(MainKeyName, passphrase)
+}
+
+// DumpMainKeysFile stores the main private key in plain text, it is used only if no keystore passphrase is provided.
+func DumpMainKeysFile(fname string, mainPrivateKey *ecdsa.PrivateKey) error {
 	mainPublicKey := crypto.FromECDSAPub(&mainPrivateKey.PublicKey)
 	keys := MainKeys{crypto.FromECDSA(mainPrivateKey), mainPublicKey}
 
@@ -95,17 +111,12 @@ func DumpMainKeys(fname string, mainPrivateKey *ecdsa.PrivateKey) error {
 		return err
 	}
 
-	file, err := os.Create(fname)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	_, err = file.Write(data)
-	return err
+	// the file contains the private key in plain text, so it must not be readable by others
+	return os.WriteFile(fname, data, 0o600)
 }
 
-func LoadMainKeys(fname string) (*ecdsa.PrivateKey, error) {
+// LoadMainKeysFile loads the main private key from the plain text file
+func LoadMainKeysFile(fname string) (*ecdsa.PrivateKey, error) {
 	var keys MainKeys
 
 	data, err := os.ReadFile(fname)
@@ -115,11 +126,7 @@ func LoadMainKeys(fname string) (*ecdsa.PrivateKey, error) {
 	if err := yaml.Unmarshal(data, &keys); err != nil {
 		return nil, err
 	}
-	mainPrivateKey, err := crypto.ToECDSA(keys.MainPrivateKey)
-	if err != nil {
-		return nil, err
-	}
-	return mainPrivateKey, err
+	return crypto.ToECDSA(keys.MainPrivateKey)
 }
 
 func (c *ZeroStateConfig) FindContractByName(name string) *ContractDescr {
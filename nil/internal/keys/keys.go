@@ -8,6 +8,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
 	"github.com/NilFoundation/nil/nil/internal/crypto/bls/kyber"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"gopkg.in/yaml.v3"
 )
@@ -24,6 +25,11 @@ type dumpedValidatorKey struct {
 type ValidatorKeysManager struct {
 	validatorKeyPath string
 	key              bls.PrivateKey
+
+	// if set, the key is kept encrypted in the keystore instead of the plain YAML file
+	keystore   *keystore.Keystore
+	keyName    string
+	passphrase string
 }
 
 func NewValidatorKeyManager(validatorKeyPath string) *ValidatorKeysManager {
@@ -32,6 +38,15 @@ func NewValidatorKeyManager(validatorKeyPath string) *ValidatorKeysManager {
 	}
 }
 
+// NewValidatorKeyManagerWithKeystore creates a manager that keeps the key in the keystore under the given name.
+func NewValidatorKeyManagerWithKeystore(ks *keystore.Keystore, keyName, passphrase string) *ValidatorKeysManager {
+	return &ValidatorKeysManager{
+		keystore:   ks,
+		keyName:    keyName,
+		passphrase: passphrase,
+	}
+}
+
 func (v *ValidatorKeysManager) generateKey() {
 	v.key = kyber.NewRandomKey()
 }
@@ -39,6 +54,10 @@ func (v *ValidatorKeysManager) generateKey() {
 const filePermissions = 0o644
 
 func (v *ValidatorKeysManager) dumpKey() error {
+	if v.keystore != nil {
+		return v.keystore.StoreBls(v.keyName, v.key, v.passphrase)
+	}
+
 	sk, err := v.key.Marshal()
 	if err != nil {
 		return err
@@ -62,30 +81,49 @@ func (v *ValidatorKeysManager) dumpKey() error {
 }
 
 func (v *ValidatorKeysManager) loadKey() error {
+	if v.keystore != nil {
+		Logger.Info().Msgf("Loading key %s from keystore: %s", v.keyName, v.keystore.Dir())
+		key, err := v.keystore.LoadBls(v.keyName, v.passphrase)
+		if err != nil {
+			return err
+		}
+		v.key = key
+		return nil
+	}
+
 	Logger.Info().Msgf("Loading key from path: %s", v.validatorKeyPath)
-	data, err := os.ReadFile(v.validatorKeyPath)
+	key, err := LoadValidatorKeyFile(v.validatorKeyPath)
 	if err != nil {
 		return err
 	}
+	v.key = key
+	return nil
+}
+
+// LoadValidatorKeyFile reads the key from the plain YAML file written by the manager without keystore.
+func LoadValidatorKeyFile(path string) (bls.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
 
 	dumpedKey := &dumpedValidatorKey{}
 	if err := yaml.Unmarshal(data, dumpedKey); err != nil {
-		return err
+		return nil, err
 	}
 
 	privKey, err := kyber.PrivateKeyFromBytes(dumpedKey.PrivateKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	pubKey, err := kyber.PublicKeyFromBytes(dumpedKey.PublicKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !pubKey.Equal(privKey.PublicKey()) {
-		return errors.New("public key mismatch")
+		return nil, errors.New("public key mismatch")
 	}
-	v.key = privKey
-	return nil
+	return privKey, nil
 }
 
 // This functions initializes key by loading it from the file if it exists,
@@ -94,11 +132,12 @@ func (v *ValidatorKeysManager) InitKey() error {
 	if v.key != nil {
 		return errors.New("key is already initialized")
 	}
-	if _, err := os.Stat(v.validatorKeyPath); err != nil {
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("error checking key file: %w", err)
-		}
-		Logger.Warn().Msgf("Key file not found, generating new key at path: %s", v.validatorKeyPath)
+	exists, err := v.keyExists()
+	if err != nil {
+		return fmt.Errorf("error checking key file: %w", err)
+	}
+	if !exists {
+		Logger.Warn().Msgf("Key file not found, generating new key at path: %s", v.GetKeysPath())
 		v.generateKey()
 		if err := v.dumpKey(); err != nil {
 			return fmt.Errorf("error saving key: %w", err)
@@ -125,6 +164,21 @@ func (v *ValidatorKeysManager) GetPublicKey() ([]byte, error) {
 	return v.key.PublicKey().Marshal()
 }
 
+func (v *ValidatorKeysManager) keyExists() (bool, error) {
+	if v.keystore != nil {
+		return v.keystore.Has(v.keyName)
+	}
+
+	_, err := os.Stat(v.validatorKeyPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 func (v *ValidatorKeysManager) GetKeysPath() string {
+	if v.keystore != nil {
+		return v.keystore.Dir()
+	}
 	return v.validatorKeyPath
 }
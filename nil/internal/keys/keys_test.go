@@ -3,6 +3,7 @@ package keys
 import (
 	"testing"
 
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,3 +26,24 @@ func TestInitKeys(t *testing.T) {
 
 	require.Equal(t, keys, keys2)
 }
+
+func TestInitKeysWithKeystore(t *testing.T) {
+	t.Parallel()
+
+	ks := keystore.NewLight(t.TempDir())
+	validatorKeysManager := NewValidatorKeyManagerWithKeystore(ks, "validator", "passphrase")
+	require.NoError(t, validatorKeysManager.InitKey())
+
+	keys, err := validatorKeysManager.GetKey()
+	require.NoError(t, err)
+
+	validatorKeysManager2 := NewValidatorKeyManagerWithKeystore(ks, "validator", "passphrase")
+	require.NoError(t, validatorKeysManager2.InitKey())
+
+	keys2, err := validatorKeysManager2.GetKey()
+	require.NoError(t, err)
+	require.True(t, keys.PublicKey().Equal(keys2.PublicKey()))
+
+	validatorKeysManager3 := NewValidatorKeyManagerWithKeystore(ks, "validator", "wrong")
+	require.ErrorIs(t, validatorKeysManager3.InitKey(), keystore.ErrDecrypt)
+}
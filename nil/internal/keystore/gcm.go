@@ -0,0 +1,148 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	cipherGCM = "aes-256-gcm"
+	kdfScrypt = "scrypt"
+
+	scryptR     = 8
+	scryptDKLen = 32
+	saltSize    = 32
+
+	// Key files with weaker KDF parameters are rejected, so that a tampered file can't make
+	// the passphrase cheap to brute-force. The light parameters are the weakest ones the keystore writes.
+	minScryptN = ethkeystore.LightScryptN
+	minScryptR = scryptR
+	minScryptP = 1
+)
+
+// encryptGCM encrypts data with AES-256-GCM using a key derived from the passphrase.
+// The GCM tag authenticates the ciphertext, so the MAC field of the result is left empty.
+func encryptGCM(data, passphrase []byte, scryptN, scryptP int) (ethkeystore.CryptoJSON, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return ethkeystore.CryptoJSON{}, err
+	}
+
+	derivedKey, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return ethkeystore.CryptoJSON{}, err
+	}
+
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return ethkeystore.CryptoJSON{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return ethkeystore.CryptoJSON{}, err
+	}
+
+	result := ethkeystore.CryptoJSON{
+		Cipher:     cipherGCM,
+		CipherText: hex.EncodeToString(aead.Seal(nil, nonce, data, nil)),
+		KDF:        kdfScrypt,
+		KDFParams: map[string]any{
+			"n":     scryptN,
+			"r":     scryptR,
+			"p":     scryptP,
+			"dklen": scryptDKLen,
+			"salt":  hex.EncodeToString(salt),
+		},
+	}
+	result.CipherParams.IV = hex.EncodeToString(nonce)
+	return result, nil
+}
+
+// checkKDFParams rejects the key derivation functions the keystore doesn't write
+// and the scrypt parameters weaker than the minimum ones
+func checkKDFParams(params ethkeystore.CryptoJSON) error {
+	if params.KDF != kdfScrypt {
+		return fmt.Errorf("unsupported key derivation function %q", params.KDF)
+	}
+
+	n, r, p := intParam(params.KDFParams, "n"), intParam(params.KDFParams, "r"), intParam(params.KDFParams, "p")
+	if n < minScryptN || r < minScryptR || p < minScryptP {
+		return fmt.Errorf("scrypt parameters n=%d, r=%d, p=%d are weaker than the minimum n=%d, r=%d, p=%d",
+			n, r, p, minScryptN, minScryptR, minScryptP)
+	}
+	if dkLen := intParam(params.KDFParams, "dklen"); dkLen != scryptDKLen {
+		return fmt.Errorf("unexpected scrypt key length %d", dkLen)
+	}
+	return nil
+}
+
+func decryptGCM(params ethkeystore.CryptoJSON, passphrase []byte) ([]byte, error) {
+	if err := checkKDFParams(params); err != nil {
+		return nil, err
+	}
+
+	salt, err := hex.DecodeString(stringParam(params.KDFParams, "salt"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	nonce, err := hex.DecodeString(params.CipherParams.IV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	cipherText, err := hex.DecodeString(params.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key(passphrase, salt,
+		intParam(params.KDFParams, "n"), intParam(params.KDFParams, "r"), intParam(params.KDFParams, "p"), scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+
+	data, err := aead.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// intParam reads a numeric KDF parameter, JSON decoding yields float64 for numbers
+func intParam(params map[string]any, name string) int {
+	switch v := params[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+func stringParam(params map[string]any, name string) string {
+	s, _ := params[name].(string)
+	return s
+}
@@ -0,0 +1,387 @@
+// Package keystore keeps named private keys encrypted with a passphrase.
+//
+// secp256k1 keys are stored in the Web3 Secret Storage (v3) format, so key files can be exchanged
+// with Ethereum tooling. BLS keys use the same file layout, but are encrypted with AES-256-GCM.
+// Both use scrypt as the key derivation function.
+package keystore
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+type KeyType string
+
+const (
+	KeyTypeSecp256k1 KeyType = "secp256k1"
+	KeyTypeBls       KeyType = "bls"
+)
+
+const (
+	keyFileVersion = 3
+	keyFileExt     = ".json"
+
+	dirPermissions  = 0o700
+	filePermissions = 0o600
+)
+
+var (
+	ErrKeyNotFound     = errors.New("key not found in keystore")
+	ErrKeyExists       = errors.New("key already exists in keystore")
+	ErrInvalidKeyName  = errors.New("invalid key name")
+	ErrKeyTypeMismatch = errors.New("key type mismatch")
+
+	// ErrDecrypt is returned if the key can't be decrypted with the given passphrase
+	ErrDecrypt = ethkeystore.ErrDecrypt
+)
+
+var keyNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// keyFile is a Web3 Secret Storage v3 document extended with the fields describing the key.
+// Files produced by other tools don't have the extension fields and are treated as secp256k1 keys.
+type keyFile struct {
+	Address string                 `json:"address,omitempty"`
+	Crypto  ethkeystore.CryptoJSON `json:"crypto"`
+	Id      string                 `json:"id"`
+	Version int                    `json:"version"`
+
+	Name      string        `json:"name,omitempty"`
+	KeyType   KeyType       `json:"keyType,omitempty"`
+	PublicKey hexutil.Bytes `json:"publicKey,omitempty"`
+}
+
+func (f *keyFile) keyType() KeyType {
+	if f.KeyType == "" {
+		return KeyTypeSecp256k1
+	}
+	return f.KeyType
+}
+
+type KeyInfo struct {
+	Name      string
+	Type      KeyType
+	PublicKey []byte
+}
+
+type Keystore struct {
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// New creates a keystore in the given directory using the standard scrypt parameters
+func New(dir string) *Keystore {
+	return &Keystore{
+		dir:     dir,
+		scryptN: ethkeystore.StandardScryptN,
+		scryptP: ethkeystore.StandardScryptP,
+	}
+}
+
+// NewLight creates a keystore using scrypt parameters that are cheaper to compute.
+// It is intended for tests and local development clusters.
+func NewLight(dir string) *Keystore {
+	return &Keystore{
+		dir:     dir,
+		scryptN: ethkeystore.LightScryptN,
+		scryptP: ethkeystore.LightScryptP,
+	}
+}
+
+func (ks *Keystore) Dir() string {
+	return ks.dir
+}
+
+func (ks *Keystore) Has(name string) (bool, error) {
+	path, err := ks.path(name)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, os.ErrNotExist):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// List returns all the keys in the keystore sorted by name
+func (ks *Keystore) List() ([]KeyInfo, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []KeyInfo
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), keyFileExt)
+		if entry.IsDir() || !ok {
+			continue
+		}
+		file, err := ks.read(name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, KeyInfo{
+			Name:      name,
+			Type:      file.keyType(),
+			PublicKey: file.PublicKey,
+		})
+	}
+	slices.SortFunc(infos, func(a, b KeyInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos, nil
+}
+
+// PublicKey returns the public key recorded in the key file, it doesn't require the passphrase
+func (ks *Keystore) PublicKey(name string) ([]byte, error) {
+	file, err := ks.read(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.PublicKey) == 0 {
+		return nil, fmt.Errorf("key %q has no public key", name)
+	}
+	return file.PublicKey, nil
+}
+
+func (ks *Keystore) StoreSecp256k1(name string, key *ecdsa.PrivateKey, passphrase string) error {
+	encrypted, err := ethkeystore.EncryptDataV3(crypto.FromECDSA(key), []byte(passphrase), ks.scryptN, ks.scryptP)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key %q: %w", name, err)
+	}
+
+	return ks.create(name, &keyFile{
+		Address:   addressOf(key),
+		Crypto:    encrypted,
+		Id:        uuid.NewString(),
+		Version:   keyFileVersion,
+		Name:      name,
+		KeyType:   KeyTypeSecp256k1,
+		PublicKey: crypto.CompressPubkey(&key.PublicKey),
+	})
+}
+
+func (ks *Keystore) LoadSecp256k1(name string, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := ks.decrypt(name, KeyTypeSecp256k1, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(data)
+}
+
+func (ks *Keystore) StoreBls(name string, key bls.PrivateKey, passphrase string) error {
+	data, err := key.Marshal()
+	if err != nil {
+		return err
+	}
+	publicKey, err := key.PublicKey().Marshal()
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := encryptGCM(data, []byte(passphrase), ks.scryptN, ks.scryptP)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key %q: %w", name, err)
+	}
+
+	return ks.create(name, &keyFile{
+		Crypto:    encrypted,
+		Id:        uuid.NewString(),
+		Version:   keyFileVersion,
+		Name:      name,
+		KeyType:   KeyTypeBls,
+		PublicKey: publicKey,
+	})
+}
+
+func (ks *Keystore) LoadBls(name string, passphrase string) (bls.PrivateKey, error) {
+	data, err := ks.decrypt(name, KeyTypeBls, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return bls.PrivateKeyFromBytes(data)
+}
+
+// Export returns the encrypted key file content
+func (ks *Keystore) Export(name string) ([]byte, error) {
+	file, err := ks.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// Import adds an encrypted key file under the given name.
+// The passphrase is used to check that the file can be decrypted, the key is not re-encrypted.
+func (ks *Keystore) Import(name string, content []byte, passphrase string) error {
+	var file keyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("failed to parse key file: %w", err)
+	}
+	if file.Version != keyFileVersion {
+		return fmt.Errorf("unsupported key file version %d", file.Version)
+	}
+
+	data, err := decryptFile(&file, passphrase)
+	if err != nil {
+		return err
+	}
+	// the public key is listed without the passphrase, so it must match the encrypted key
+	switch file.keyType() {
+	case KeyTypeSecp256k1:
+		key, err := crypto.ToECDSA(data)
+		if err != nil {
+			return fmt.Errorf("invalid secp256k1 key: %w", err)
+		}
+		file.Address = addressOf(key)
+		file.PublicKey = crypto.CompressPubkey(&key.PublicKey)
+	case KeyTypeBls:
+		key, err := bls.PrivateKeyFromBytes(data)
+		if err != nil {
+			return fmt.Errorf("invalid bls key: %w", err)
+		}
+		publicKey, err := key.PublicKey().Marshal()
+		if err != nil {
+			return err
+		}
+		if len(file.PublicKey) != 0 && !bytes.Equal(file.PublicKey, publicKey) {
+			return errors.New("public key of the key file doesn't match the bls key")
+		}
+		file.PublicKey = publicKey
+	default:
+		return fmt.Errorf("unsupported key type %q", file.KeyType)
+	}
+
+	file.Name = name
+	file.KeyType = file.keyType()
+	if file.Id == "" {
+		file.Id = uuid.NewString()
+	}
+	return ks.create(name, &file)
+}
+
+func (ks *Keystore) decrypt(name string, keyType KeyType, passphrase string) ([]byte, error) {
+	file, err := ks.read(name)
+	if err != nil {
+		return nil, err
+	}
+	if file.keyType() != keyType {
+		return nil, fmt.Errorf("%w: key %q is %s, expected %s", ErrKeyTypeMismatch, name, file.keyType(), keyType)
+	}
+	data, err := decryptFile(file, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key %q: %w", name, err)
+	}
+	return data, nil
+}
+
+func decryptFile(file *keyFile, passphrase string) ([]byte, error) {
+	switch file.Crypto.Cipher {
+	case cipherGCM:
+		return decryptGCM(file.Crypto, []byte(passphrase))
+	default:
+		if err := checkKDFParams(file.Crypto); err != nil {
+			return nil, err
+		}
+		return ethkeystore.DecryptDataV3(file.Crypto, passphrase)
+	}
+}
+
+func (ks *Keystore) read(name string) (*keyFile, error) {
+	path, err := ks.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// create writes a new key file, existing keys are never overwritten
+func (ks *Keystore) create(name string, file *keyFile) error {
+	path, err := ks.path(name)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(ks.dir, dirPermissions); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(ks.dir, "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), filePermissions); err != nil {
+		return err
+	}
+
+	// os.Link fails if the target exists, unlike os.Rename
+	if err := os.Link(tmpFile.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrKeyExists, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (ks *Keystore) path(name string) (string, error) {
+	if !keyNameRegex.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKeyName, name)
+	}
+	return filepath.Join(ks.dir, name+keyFileExt), nil
+}
+
+// addressOf returns the Ethereum address of the key in the form used by Web3 Secret Storage
+func addressOf(key *ecdsa.PrivateKey) string {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	return strings.TrimPrefix(strings.ToLower(address.Hex()), "0x")
+}
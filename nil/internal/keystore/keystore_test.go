@@ -0,0 +1,213 @@
+package keystore
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const passphrase = "correct horse battery staple"
+
+func TestSecp256k1RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	require.NoError(t, ks.StoreSecp256k1("alice", key, passphrase))
+
+	loaded, err := ks.LoadSecp256k1("alice", passphrase)
+	require.NoError(t, err)
+	require.Equal(t, crypto.FromECDSA(key), crypto.FromECDSA(loaded))
+
+	_, err = ks.LoadSecp256k1("alice", "wrong")
+	require.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = ks.LoadBls("alice", passphrase)
+	require.ErrorIs(t, err, ErrKeyTypeMismatch)
+
+	info, err := os.Stat(filepath.Join(ks.Dir(), "alice.json"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(filePermissions), info.Mode().Perm())
+}
+
+func TestBlsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+	key := bls.NewRandomKey()
+
+	require.NoError(t, ks.StoreBls("validator", key, passphrase))
+
+	loaded, err := ks.LoadBls("validator", passphrase)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey().Equal(loaded.PublicKey()))
+
+	_, err = ks.LoadBls("validator", "wrong")
+	require.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestKeyIsNotOverwritten(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	require.NoError(t, ks.StoreSecp256k1("alice", key, passphrase))
+	require.ErrorIs(t, ks.StoreSecp256k1("alice", key, passphrase), ErrKeyExists)
+
+	_, err = ks.LoadSecp256k1("bob", passphrase)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.ErrorIs(t, ks.StoreSecp256k1("../alice", key, passphrase), ErrInvalidKeyName)
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+
+	infos, err := ks.List()
+	require.NoError(t, err)
+	require.Empty(t, infos)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, ks.StoreSecp256k1("b-key", key, passphrase))
+	require.NoError(t, ks.StoreBls("a-key", bls.NewRandomKey(), passphrase))
+
+	infos, err = ks.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "a-key", infos[0].Name)
+	require.Equal(t, KeyTypeBls, infos[0].Type)
+	require.Equal(t, "b-key", infos[1].Name)
+	require.Equal(t, KeyTypeSecp256k1, infos[1].Type)
+	require.Equal(t, crypto.CompressPubkey(&key.PublicKey), infos[1].PublicKey)
+}
+
+// Key files must be interchangeable with the Web3 Secret Storage implementation of go-ethereum
+func TestWeb3SecretStorageCompatibility(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, ks.StoreSecp256k1("exported", key, passphrase))
+		content, err := ks.Export("exported")
+		require.NoError(t, err)
+
+		decrypted, err := ethkeystore.DecryptKey(content, passphrase)
+		require.NoError(t, err)
+		require.Equal(t, crypto.FromECDSA(key), crypto.FromECDSA(decrypted.PrivateKey))
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		t.Parallel()
+
+		content, err := ethkeystore.EncryptKey(&ethkeystore.Key{
+			Id:         uuid.New(),
+			Address:    crypto.PubkeyToAddress(key.PublicKey),
+			PrivateKey: key,
+		}, passphrase, ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, ks.Import("imported", content, "wrong"), ErrDecrypt)
+		require.NoError(t, ks.Import("imported", content, passphrase))
+
+		loaded, err := ks.LoadSecp256k1("imported", passphrase)
+		require.NoError(t, err)
+		require.Equal(t, crypto.FromECDSA(key), crypto.FromECDSA(loaded))
+	})
+}
+
+func TestReadPassphrase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte(passphrase+"\n"), 0o600))
+
+	read, err := ReadPassphrase(file)
+	require.NoError(t, err)
+	require.Equal(t, passphrase, read)
+
+	t.Setenv(PassphraseEnv, "from env")
+	read, err = ReadPassphrase("")
+	require.NoError(t, err)
+	require.Equal(t, "from env", read)
+}
+
+func TestGcmRejectsWeakScryptParams(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("secret")
+
+	encrypted, err := encryptGCM(data, []byte(passphrase), ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+	require.NoError(t, err)
+	decrypted, err := decryptGCM(encrypted, []byte(passphrase))
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	weak, err := encryptGCM(data, []byte(passphrase), 2, 1)
+	require.NoError(t, err)
+	_, err = decryptGCM(weak, []byte(passphrase))
+	require.ErrorContains(t, err, "weaker than the minimum")
+
+	for param, value := range map[string]any{"n": float64(1024), "r": float64(1), "p": float64(0)} {
+		tampered := encrypted
+		tampered.KDFParams = maps.Clone(encrypted.KDFParams)
+		tampered.KDFParams[param] = value
+
+		_, err = decryptGCM(tampered, []byte(passphrase))
+		require.ErrorContains(t, err, "weaker than the minimum", "param %s", param)
+	}
+}
+
+func TestImportValidatesKeyFile(t *testing.T) {
+	t.Parallel()
+
+	ks := NewLight(t.TempDir())
+	source := NewLight(t.TempDir())
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	weak, err := ethkeystore.EncryptKey(&ethkeystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, passphrase, 2, 1)
+	require.NoError(t, err)
+	require.ErrorContains(t, ks.Import("weak", weak, passphrase), "weaker than the minimum")
+
+	blsKey := bls.NewRandomKey()
+	require.NoError(t, source.StoreBls("validator", blsKey, passphrase))
+	content, err := source.Export("validator")
+	require.NoError(t, err)
+
+	var file keyFile
+	require.NoError(t, json.Unmarshal(content, &file))
+	file.PublicKey, err = bls.NewRandomKey().PublicKey().Marshal()
+	require.NoError(t, err)
+	forged, err := json.Marshal(&file)
+	require.NoError(t, err)
+	require.ErrorContains(t, ks.Import("forged", forged, passphrase), "doesn't match")
+
+	require.NoError(t, ks.Import("validator", content, passphrase))
+	publicKey, err := blsKey.PublicKey().Marshal()
+	require.NoError(t, err)
+	imported, err := ks.PublicKey("validator")
+	require.NoError(t, err)
+	require.Equal(t, publicKey, imported)
+}
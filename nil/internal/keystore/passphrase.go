@@ -0,0 +1,31 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PassphraseEnv is the environment variable the passphrase is taken from if no passphrase file is given
+const PassphraseEnv = "NIL_KEYSTORE_PASSWORD"
+
+var ErrNoPassphrase = errors.New("keystore passphrase is not provided")
+
+// ReadPassphrase reads the passphrase from the file, or from PassphraseEnv if the file is not specified.
+// Trailing line breaks are stripped, so files created with `echo` work as expected.
+func ReadPassphrase(passphraseFile string) (string, error) {
+	if passphraseFile != "" {
+		content, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	if passphrase, ok := os.LookupEnv(PassphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	return "", fmt.Errorf("%w: use a passphrase file or set %s", ErrNoPassphrase, PassphraseEnv)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/internal/config"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/types"
@@ -35,3 +36,41 @@ func (b *BlockVerifier) VerifyBlock(ctx context.Context, block *types.Block) err
 	}
 	return nil
 }
+
+// VerifyRandao checks that the randao signature proves the finalization of the main shard block referenced by a block:
+// it has to be signed by the quorum of the main shard validators.
+// Every validator aggregates its own set of commit seals, so the signature may differ from the locally stored one.
+// Only the zero-state block of the main shard has no signature.
+func (b *BlockVerifier) VerifyRandao(
+	ctx context.Context, mainShardHash common.Hash, sig *types.BlsAggregateSignature,
+) error {
+	tx, err := b.db.CreateRoTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	mainBlock, err := db.ReadBlock(tx, types.MainShardId, mainShardHash)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read main shard block: %w", errBlockVerify, err)
+	}
+	if mainBlock.Id == 0 {
+		if sig != nil {
+			return fmt.Errorf("%w: unexpected randao signature of the zero-state block", errBlockVerify)
+		}
+		return nil
+	}
+	if sig == nil {
+		return fmt.Errorf("%w: no randao signature of main shard block %d", errBlockVerify, mainBlock.Id)
+	}
+
+	params, err := config.GetConfigParams(ctx, b.db, types.MainShardId, mainBlock.Id.Uint64())
+	if err != nil {
+		return fmt.Errorf("%w: failed to get validators' params: %w", errBlockVerify, err)
+	}
+
+	if err := sig.VerifyQuorum(params.PublicKeys.Keys(), mainShardHash.Bytes()); err != nil {
+		return fmt.Errorf("%w: failed to verify randao signature: %w", errBlockVerify, err)
+	}
+	return nil
+}
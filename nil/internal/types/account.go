@@ -51,6 +51,12 @@ func (token TokenBalance) Value() (driver.Value, error) {
 	return []any{token.Token, token.Balance.ToBig()}, nil
 }
 
+// TokenHolder is an account holding a token, see `eth_getTokenHolders`.
+type TokenHolder struct {
+	Address Address `json:"address"`
+	Balance Value   `json:"balance"`
+}
+
 func TokenIdForAddress(a Address) *TokenId {
 	r := TokenId(a)
 	return &r
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DelegationPrefix is the code prefix of an account that delegates its execution to another contract (EIP-7702).
+// The full designator is the prefix followed by the address of the delegate.
+var DelegationPrefix = []byte{0xef, 0x01, 0x00}
+
+const (
+	DelegationDesignatorSize = 3 + AddrSize
+
+	// AuthorizationMagic is prepended to the encoded authorization before hashing.
+	// It separates authorization signatures from transaction signatures, which are verified by the same account code.
+	AuthorizationMagic = 0x05
+
+	// MaxAuthorizationListSize limits the number of authorizations in a single transaction.
+	MaxAuthorizationListSize = 16
+)
+
+// NewDelegationDesignator returns the code that makes an account delegate its execution to the given address.
+func NewDelegationDesignator(delegate Address) Code {
+	return append(bytes.Clone(DelegationPrefix), delegate.Bytes()...)
+}
+
+// ParseDelegation returns the delegate address if the code is a delegation designator.
+func ParseDelegation(code []byte) (Address, bool) {
+	if len(code) != DelegationDesignatorSize || !bytes.HasPrefix(code, DelegationPrefix) {
+		return EmptyAddress, false
+	}
+	return BytesToAddress(code[len(DelegationPrefix):]), true
+}
+
+// Authorization allows the account to set its code to a delegation designator pointing to Delegate.
+// It is signed with the account key and verified by the current code of the account (see `verifyExternal`).
+// An empty Delegate clears the delegation.
+type Authorization struct {
+	ChainId   ChainId       `json:"chainId"`
+	Address   Address       `json:"address"`
+	Delegate  Address       `json:"delegate"`
+	Seqno     Seqno         `json:"seqno"`
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+}
+
+type authorizationDigest struct {
+	ChainId  ChainId
+	Address  Address
+	Delegate Address
+	Seqno    Seqno
+}
+
+func (a Authorization) Value() (driver.Value, error) {
+	return []any{a.ChainId, a.Address, a.Delegate, a.Seqno, a.Signature}, nil
+}
+
+func (a *Authorization) SigningHash() (common.Hash, error) {
+	buf, err := rlp.EncodeToBytes(&authorizationDigest{
+		ChainId:  a.ChainId,
+		Address:  a.Address,
+		Delegate: a.Delegate,
+		Seqno:    a.Seqno,
+	})
+	if err != nil {
+		return common.EmptyHash, err
+	}
+	return common.KeccakHash(append([]byte{AuthorizationMagic}, buf...)), nil
+}
+
+func (a *Authorization) Sign(key *ecdsa.PrivateKey) error {
+	hash, err := a.SigningHash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return err
+	}
+
+	a.Signature = hexutil.Bytes(sig)
+	return nil
+}
+
+// Verify performs the stateless checks of the authorization.
+// The signature and the seqno are checked against the account state during execution.
+func (a *Authorization) Verify() error {
+	if a.ChainId != DefaultChainId {
+		return fmt.Errorf("invalid chain id %d", a.ChainId)
+	}
+	if a.Address.IsEmpty() {
+		return errors.New("empty authority address")
+	}
+	if a.Address.ShardId().IsMainShard() {
+		return errors.New("main shard accounts cannot delegate")
+	}
+	if !a.Delegate.IsEmpty() && a.Delegate.ShardId() != a.Address.ShardId() {
+		return fmt.Errorf("delegate %s must be in the same shard as the account %s", a.Delegate, a.Address)
+	}
+	if len(a.Signature) == 0 {
+		return errors.New("empty signature")
+	}
+	return nil
+}
+
+type AuthorizationList []Authorization
+
+// Verify performs the stateless checks of the authorization list of a set-code transaction.
+func (l AuthorizationList) Verify(shardId ShardId) error {
+	if len(l) == 0 {
+		return errors.New("empty authorization list")
+	}
+	if len(l) > MaxAuthorizationListSize {
+		return fmt.Errorf("authorization list is too long: %d > %d", len(l), MaxAuthorizationListSize)
+	}
+	for i := range l {
+		if err := l[i].Verify(); err != nil {
+			return fmt.Errorf("authorization %d: %w", i, err)
+		}
+		if l[i].Address.ShardId() != shardId {
+			return fmt.Errorf("authorization %d: account %s is not in shard %d", i, l[i].Address, shardId)
+		}
+	}
+	return nil
+}
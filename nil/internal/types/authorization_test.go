@@ -0,0 +1,136 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationDesignator(t *testing.T) {
+	t.Parallel()
+
+	delegate := ShardAndHexToAddress(1, "deadbeef")
+	code := NewDelegationDesignator(delegate)
+	require.Len(t, code, DelegationDesignatorSize)
+
+	addr, ok := ParseDelegation(code)
+	require.True(t, ok)
+	assert.Equal(t, delegate, addr)
+
+	_, ok = ParseDelegation(code[:len(code)-1])
+	assert.False(t, ok)
+
+	_, ok = ParseDelegation(append(Code{0x60}, code[1:]...))
+	assert.False(t, ok)
+}
+
+func TestAuthorizationSign(t *testing.T) {
+	t.Parallel()
+
+	auth := Authorization{
+		Address:  ShardAndHexToAddress(1, "01"),
+		Delegate: ShardAndHexToAddress(1, "02"),
+		Seqno:    5,
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, auth.Sign(key))
+	assert.Len(t, auth.Signature, common.SignatureSize)
+
+	h, err := auth.SigningHash()
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(h.Bytes(), auth.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pub)
+
+	// The signature does not affect the signing hash, while any other field does.
+	other := auth
+	other.Signature = nil
+	h2, err := other.SigningHash()
+	require.NoError(t, err)
+	assert.Equal(t, h, h2)
+
+	other.Seqno++
+	h2, err = other.SigningHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, h, h2)
+}
+
+func TestAuthorizationListVerify(t *testing.T) {
+	t.Parallel()
+
+	auth := Authorization{
+		Address:   ShardAndHexToAddress(1, "01"),
+		Delegate:  ShardAndHexToAddress(1, "02"),
+		Signature: []byte{1},
+	}
+
+	require.NoError(t, AuthorizationList{auth}.Verify(1))
+	require.Error(t, AuthorizationList{}.Verify(1))
+	require.Error(t, AuthorizationList{auth}.Verify(2))
+	require.Error(t, make(AuthorizationList, MaxAuthorizationListSize+1).Verify(1))
+
+	clear := auth
+	clear.Delegate = EmptyAddress
+	require.NoError(t, AuthorizationList{clear}.Verify(1))
+
+	wrongChain := auth
+	wrongChain.ChainId = 1
+	require.Error(t, AuthorizationList{wrongChain}.Verify(1))
+
+	otherShard := auth
+	otherShard.Delegate = ShardAndHexToAddress(2, "02")
+	require.Error(t, AuthorizationList{otherShard}.Verify(1))
+
+	unsigned := auth
+	unsigned.Signature = nil
+	require.Error(t, AuthorizationList{unsigned}.Verify(1))
+}
+
+func TestSetCodeTransaction(t *testing.T) {
+	t.Parallel()
+
+	to := ShardAndHexToAddress(1, "01")
+	ext := ExternalTransaction{
+		Kind: SetCodeTransactionKind,
+		To:   to,
+		AuthorizationList: AuthorizationList{{
+			Address:   to,
+			Delegate:  ShardAndHexToAddress(1, "02"),
+			Seqno:     1,
+			Signature: []byte{1, 2, 3},
+		}},
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, ext.Sign(key))
+
+	data, err := ext.MarshalNil()
+	require.NoError(t, err)
+	var decoded ExternalTransaction
+	require.NoError(t, decoded.UnmarshalNil(data))
+	assert.Equal(t, ext.AuthorizationList, decoded.AuthorizationList)
+	assert.Equal(t, ext.Hash(), decoded.Hash())
+
+	txn := ext.ToTransaction()
+	require.True(t, txn.IsSetCode())
+	require.True(t, txn.IsExecution())
+	require.NoError(t, txn.VerifyFlags())
+	assert.Equal(t, ext.Hash(), txn.Hash())
+
+	// The authorization list is covered by the transaction signature.
+	h, err := ext.SigningHash()
+	require.NoError(t, err)
+	ext.AuthorizationList[0].Seqno++
+	h2, err := ext.SigningHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, h, h2)
+
+	txn.Flags = NewTransactionFlags()
+	require.Error(t, txn.VerifyFlags())
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"math"
 	"strconv"
 
@@ -74,6 +75,11 @@ type Block struct {
 	BlockData
 	LogsBloom ethtypes.Bloom `json:"logsBloom" ch:"logs_bloom"`
 	ConsensusParams
+
+	// RandaoSignature is a quorum signature of the main shard block referenced by this block (see GetMainShardHash).
+	// It proves that the main shard block is finalized, so the randomness derived from it is fixed, see PrevRandao.
+	// The signature is not a part of the block hash, since the validators aggregate different signers into it.
+	RandaoSignature *BlsAggregateSignature `json:"randaoSignature,omitempty" ch:"-" rlp:"optional"`
 }
 
 type RawBlockWithExtractedData struct {
@@ -132,6 +138,21 @@ func (b *Block) GetMainShardHash(shardId ShardId) common.Hash {
 	return b.MainShardHash
 }
 
+// PrevRandao returns the randomness of the block. It is exposed to contracts as PREVRANDAO.
+func (b *Block) PrevRandao(shardId ShardId) common.Hash {
+	return Randomness(b.GetMainShardHash(shardId))
+}
+
+// Randomness returns the randomness of a block referencing the given main shard block.
+// It is derived from the main shard block hash rather than from the randao signature: every validator computes
+// the same hash, while the signature aggregates the commit seals of the signers chosen by the proposer.
+func Randomness(mainShardHash common.Hash) common.Hash {
+	if mainShardHash.Empty() {
+		return common.EmptyHash
+	}
+	return common.KeccakHash(mainShardHash.Bytes())
+}
+
 func (b *Block) UnmarshalNil(buf []byte) error {
 	return rlp.DecodeBytes(buf, b)
 }
@@ -223,26 +244,16 @@ func (b *BlockWithExtractedData) EncodeToBytes() (*RawBlockWithExtractedData, er
 }
 
 func (b *Block) VerifySignature(pubkeys []bls.PublicKey, shardId ShardId) error {
-	sig, err := bls.SignatureFromBytes(b.Signature.Sig)
-	if err != nil {
-		return err
-	}
-
-	mask, err := bls.NewMask(pubkeys)
-	if err != nil {
-		return err
-	}
-
-	if err := mask.SetBytes(b.Signature.Mask); err != nil {
-		return err
-	}
+	return b.Signature.Verify(pubkeys, b.Hash(shardId).Bytes())
+}
 
-	aggregatedKey, err := mask.AggregatePublicKeys()
-	if err != nil {
-		return err
+// VerifyRandao checks the randomness source of the block against the public keys of the main shard validators.
+// Light clients can use it to verify the randomness without executing the block.
+func (b *Block) VerifyRandao(mainShardPubkeys []bls.PublicKey, shardId ShardId) error {
+	if b.RandaoSignature == nil {
+		return errors.New("no randao signature")
 	}
-
-	return sig.Verify(aggregatedKey, b.Hash(shardId).Bytes())
+	return b.RandaoSignature.VerifyQuorum(mainShardPubkeys, b.GetMainShardHash(shardId).Bytes())
 }
 
 const InvalidDbTimestamp uint64 = math.MaxUint64
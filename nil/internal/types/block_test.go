@@ -3,6 +3,7 @@ package types
 import (
 	"testing"
 
+	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +66,126 @@ func TestBlock_SignAndVerifySignature(t *testing.T) {
 	err = block.VerifySignature(nil, BaseShardId)
 	require.ErrorContains(t, err, "not enough data")
 }
+
+func TestBlock_VerifyRandao(t *testing.T) {
+	t.Parallel()
+
+	privKey := bls.NewRandomKey()
+	pubKeys := []bls.PublicKey{privKey.PublicKey()}
+	mask, err := bls.NewMask(pubKeys)
+	require.NoError(t, err)
+	require.NoError(t, mask.SetParticipants([]uint32{0}))
+
+	mainBlock := &Block{}
+	mainBlock.Id = 5
+	mainBlockHash := mainBlock.Hash(MainShardId)
+
+	sig, err := privKey.Sign(mainBlockHash[:])
+	require.NoError(t, err)
+	sig, err = bls.AggregateSignatures([]bls.Signature{sig}, mask)
+	require.NoError(t, err)
+	sigBytes, err := sig.Marshal()
+	require.NoError(t, err)
+
+	// The randomness is derived from the main shard block, the signature only proves that it is finalized
+	block := &Block{}
+	require.Equal(t, common.EmptyHash, block.PrevRandao(BaseShardId))
+	block.MainShardHash = mainBlockHash
+	require.Equal(t, common.KeccakHash(mainBlockHash.Bytes()), block.PrevRandao(BaseShardId))
+	require.ErrorContains(t, block.VerifyRandao(pubKeys, BaseShardId), "no randao signature")
+
+	block.RandaoSignature = &BlsAggregateSignature{Sig: sigBytes, Mask: []byte{1}}
+	require.NoError(t, block.VerifyRandao(pubKeys, BaseShardId))
+	require.Equal(t, common.KeccakHash(mainBlockHash.Bytes()), block.PrevRandao(BaseShardId))
+
+	// The main shard block refers to the previous main shard block
+	mainNextBlock := &Block{}
+	mainNextBlock.PrevBlock = mainBlockHash
+	mainNextBlock.RandaoSignature = block.RandaoSignature
+	require.NoError(t, mainNextBlock.VerifyRandao(pubKeys, MainShardId))
+	require.Equal(t, block.PrevRandao(BaseShardId), mainNextBlock.PrevRandao(MainShardId))
+
+	// Signature of another block
+	block.MainShardHash = common.EmptyHash
+	require.ErrorContains(t, block.VerifyRandao(pubKeys, BaseShardId), "invalid signature")
+}
+
+func TestBlock_VerifyRandaoQuorum(t *testing.T) {
+	t.Parallel()
+
+	privKeys := make([]bls.PrivateKey, 4)
+	pubKeys := make([]bls.PublicKey, len(privKeys))
+	for i := range privKeys {
+		privKeys[i] = bls.NewRandomKey()
+		pubKeys[i] = privKeys[i].PublicKey()
+	}
+
+	mainBlock := &Block{}
+	mainBlock.Id = 5
+	mainBlockHash := mainBlock.Hash(MainShardId)
+
+	sign := func(signers ...uint32) *BlsAggregateSignature {
+		t.Helper()
+
+		mask, err := bls.NewMask(pubKeys)
+		require.NoError(t, err)
+		require.NoError(t, mask.SetParticipants(signers))
+
+		sigs := make([]bls.Signature, 0, len(signers))
+		for _, i := range signers {
+			sig, err := privKeys[i].Sign(mainBlockHash[:])
+			require.NoError(t, err)
+			sigs = append(sigs, sig)
+		}
+		sig, err := bls.AggregateSignatures(sigs, mask)
+		require.NoError(t, err)
+		sigBytes, err := sig.Marshal()
+		require.NoError(t, err)
+		return &BlsAggregateSignature{Sig: sigBytes, Mask: mask.Bytes()}
+	}
+
+	require.Equal(t, 3, SignaturesQuorum(len(pubKeys)))
+
+	block := &Block{}
+	block.MainShardHash = mainBlockHash
+
+	block.RandaoSignature = sign(1, 3)
+	require.Equal(t, 2, block.RandaoSignature.Signers())
+	require.ErrorContains(t, block.VerifyRandao(pubKeys, BaseShardId), "not enough signers: 2 < 3")
+
+	block.RandaoSignature = sign(0, 2, 3)
+	require.NoError(t, block.VerifyRandao(pubKeys, BaseShardId))
+	randomness := block.PrevRandao(BaseShardId)
+
+	// Another set of signers gives the same randomness
+	block.RandaoSignature = sign(0, 1, 2)
+	require.NoError(t, block.VerifyRandao(pubKeys, BaseShardId))
+	require.Equal(t, randomness, block.PrevRandao(BaseShardId))
+}
+
+func TestBlock_RandaoSignatureEncoding(t *testing.T) {
+	t.Parallel()
+
+	block := &Block{}
+	block.Id = 5
+	block.Signature = &BlsAggregateSignature{Sig: []byte{1}, Mask: []byte{1}}
+	hash := block.Hash(BaseShardId)
+
+	// The block without the signature is encoded without the trailing field
+	encoded, err := block.MarshalNil()
+	require.NoError(t, err)
+
+	var decoded Block
+	require.NoError(t, decoded.UnmarshalNil(encoded))
+	require.Nil(t, decoded.RandaoSignature)
+	require.Equal(t, hash, decoded.Hash(BaseShardId))
+
+	// The signature is not a part of the block hash
+	block.RandaoSignature = &BlsAggregateSignature{Sig: []byte{2}, Mask: []byte{3}}
+	require.Equal(t, hash, block.Hash(BaseShardId))
+
+	encoded, err = block.MarshalNil()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalNil(encoded))
+	require.Equal(t, block.RandaoSignature, decoded.RandaoSignature)
+}
@@ -195,6 +195,12 @@ const (
 	ErrorTransactionExceedsBlockGasLimit
 	// ErrorConsoleParseInputFailed is returned when the console fails to parse the input of the log function.
 	ErrorConsoleParseInputFailed
+	// ErrorInvalidAuthorizationList is returned when the authorization list of a set-code transaction is malformed.
+	ErrorInvalidAuthorizationList
+	// ErrorInsufficientAllowance is returned when the spender tries to transfer more tokens than the owner approved.
+	ErrorInsufficientAllowance
+	// ErrorAuthDataTooLarge is returned when the auth data of an external transaction exceeds the size limit.
+	ErrorAuthDataTooLarge
 )
 
 type ExecError interface {
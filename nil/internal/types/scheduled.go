@@ -0,0 +1,68 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ScheduledTransaction is an internal transaction that the collator executes at the given block height, optionally
+// repeating it every `Interval` blocks. Fees for all the runs are withdrawn from the owner at scheduling time.
+type ScheduledTransaction struct {
+	Id    uint64
+	Owner Address
+	To    Address
+	Data  Code
+
+	// NextBlock is the height of the block where the next run is due.
+	NextBlock BlockNumber
+	// Interval is the number of blocks between the runs. Zero means a one-shot transaction.
+	Interval uint64
+	// Runs is the number of the runs already executed.
+	Runs uint64
+	// Remaining is the number of the prepaid runs left.
+	Remaining uint64
+
+	// FeeCredit is prepaid for every run.
+	FeeCredit    Value
+	MaxFeePerGas Value
+}
+
+func (s *ScheduledTransaction) UnmarshalNil(buf []byte) error {
+	return rlp.DecodeBytes(buf, s)
+}
+
+func (s ScheduledTransaction) MarshalNil() ([]byte, error) {
+	return rlp.EncodeToBytes(&s)
+}
+
+// Prepaid returns the fee credit reserved for the remaining runs.
+func (s *ScheduledTransaction) Prepaid() Value {
+	return s.FeeCredit.Mul64(s.Remaining)
+}
+
+// Transaction returns the transaction of the next run. The schedule id and the run number make its hash unique.
+func (s *ScheduledTransaction) Transaction() *Transaction {
+	return &Transaction{
+		TransactionDigest: TransactionDigest{
+			Flags: NewTransactionFlags(TransactionFlagInternal, TransactionFlagScheduled),
+			FeePack: FeePack{
+				FeeCredit:    s.FeeCredit,
+				MaxFeePerGas: s.MaxFeePerGas,
+			},
+			To:    s.To,
+			Seqno: Seqno(s.Runs),
+			Data:  s.Data,
+		},
+		From:     s.Owner,
+		TxId:     TransactionIndex(s.Id),
+		RefundTo: s.Owner,
+		BounceTo: s.Owner,
+	}
+}
+
+// Advance moves the schedule to the next run. It returns false if there are no runs left.
+func (s *ScheduledTransaction) Advance() bool {
+	s.Runs++
+	s.Remaining--
+	s.NextBlock += BlockNumber(s.Interval)
+	return s.Remaining > 0 && s.Interval > 0
+}
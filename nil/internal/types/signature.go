@@ -2,7 +2,9 @@ package types
 
 import (
 	"fmt"
+	"math/bits"
 
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -18,3 +20,50 @@ type BlsAggregateSignature struct {
 func (b BlsAggregateSignature) String() string {
 	return fmt.Sprintf("BlsAggregateSignature{Sig: %x, Mask: %x}", b.Sig, b.Mask)
 }
+
+// Verify checks that the signature of the data is aggregated from the signatures of the keys selected by the mask.
+func (b *BlsAggregateSignature) Verify(pubkeys []bls.PublicKey, data []byte) error {
+	sig, err := bls.SignatureFromBytes(b.Sig)
+	if err != nil {
+		return err
+	}
+
+	mask, err := bls.NewMask(pubkeys)
+	if err != nil {
+		return err
+	}
+
+	if err := mask.SetBytes(b.Mask); err != nil {
+		return err
+	}
+
+	aggregatedKey, err := mask.AggregatePublicKeys()
+	if err != nil {
+		return err
+	}
+
+	return sig.Verify(aggregatedKey, data)
+}
+
+// SignaturesQuorum returns the number of the validators whose signatures finalize a block.
+// All the validators have the same voting power, so it is the IBFT quorum: more than two thirds of them.
+func SignaturesQuorum(validators int) int {
+	return 2*validators/3 + 1
+}
+
+// Signers returns the number of the validators selected by the mask.
+func (b *BlsAggregateSignature) Signers() int {
+	res := 0
+	for _, m := range b.Mask {
+		res += bits.OnesCount8(m)
+	}
+	return res
+}
+
+// VerifyQuorum checks the signature against the keys of the validators and requires the quorum of signers.
+func (b *BlsAggregateSignature) VerifyQuorum(pubkeys []bls.PublicKey, data []byte) error {
+	if signers, quorum := b.Signers(), SignaturesQuorum(len(pubkeys)); signers < quorum {
+		return fmt.Errorf("not enough signers: %d < %d", signers, quorum)
+	}
+	return b.Verify(pubkeys, data)
+}
@@ -25,6 +25,7 @@ const (
 	DeployTransactionKind
 	RefundTransactionKind
 	ResponseTransactionKind
+	SetCodeTransactionKind
 )
 
 func (k TransactionKind) String() string {
@@ -37,6 +38,8 @@ func (k TransactionKind) String() string {
 		return "RefundTransactionKind"
 	case ResponseTransactionKind:
 		return "ResponseTransactionKind"
+	case SetCodeTransactionKind:
+		return "SetCodeTransactionKind"
 	}
 	panic("unknown TransactionKind")
 }
@@ -51,6 +54,8 @@ func (k *TransactionKind) Set(input string) error {
 		*k = RefundTransactionKind
 	case "response", "ResponseTransactionKind":
 		*k = ResponseTransactionKind
+	case "setcode", "SetCodeTransactionKind":
+		*k = SetCodeTransactionKind
 	default:
 		return fmt.Errorf("unknown TransactionKind: %s", input)
 	}
@@ -138,6 +143,8 @@ const (
 	TransactionFlagRefund
 	TransactionFlagBounce
 	TransactionFlagResponse
+	TransactionFlagSetCode
+	TransactionFlagScheduled
 )
 
 type ForwardKind uint64
@@ -196,9 +203,32 @@ func (d TransactionDigest) MarshalNil() ([]byte, error) {
 	return rlp.EncodeToBytes(&d)
 }
 
+// setCodeTransactionDigest is signed instead of TransactionDigest in set-code transactions,
+// so that the signature covers the authorization list.
+type setCodeTransactionDigest struct {
+	Digest            TransactionDigest
+	AuthorizationList AuthorizationList
+}
+
+func (d setCodeTransactionDigest) MarshalNil() ([]byte, error) {
+	return rlp.EncodeToBytes(&d)
+}
+
+func signingHash(digest TransactionDigest, authorizationList AuthorizationList) (common.Hash, error) {
+	if len(authorizationList) == 0 {
+		return common.Keccak(&digest)
+	}
+	return common.Keccak(&setCodeTransactionDigest{
+		Digest:            digest,
+		AuthorizationList: authorizationList,
+	})
+}
+
 const (
 	TransactionMaxTokenSize = 256
 	TransactionMaxDataSize  = 24576
+	// TransactionMaxAuthDataSize bounds the data passed to `verifyExternal`, e.g. signatures of multisig owners.
+	TransactionMaxAuthDataSize = 1024
 )
 
 type Transaction struct {
@@ -214,6 +244,10 @@ type Transaction struct {
 
 	// This field should always be at the end of the structure for easy signing
 	Signature hexutil.Bytes `json:"signature,omitempty" ch:"signature" rlp:"optional"`
+
+	// AuthorizationList is set only in set-code transactions. It follows the signature to keep the encoding of
+	// regular transactions unchanged.
+	AuthorizationList AuthorizationList `json:"authorizationList,omitempty" ch:"authorization_list" rlp:"optional"`
 }
 
 type OutboundTransaction struct {
@@ -230,6 +264,8 @@ type ExternalTransaction struct {
 	Seqno    Seqno         `json:"seqno,omitempty" ch:"seqno"`
 	Data     Code          `json:"data,omitempty" ch:"data"`
 	AuthData hexutil.Bytes `json:"authData,omitempty" ch:"auth_data" rlp:"optional"`
+
+	AuthorizationList AuthorizationList `json:"authorizationList,omitempty" ch:"authorization_list" rlp:"optional"`
 }
 
 func (tx *ExternalTransaction) UnmarshalNil(buf []byte) error {
@@ -378,6 +414,8 @@ func (m *Transaction) toExternal() *ExternalTransaction {
 		kind = DeployTransactionKind
 	case m.IsRefund():
 		kind = RefundTransactionKind
+	case m.IsSetCode():
+		kind = SetCodeTransactionKind
 	default:
 		kind = ExecutionTransactionKind
 	}
@@ -389,6 +427,8 @@ func (m *Transaction) toExternal() *ExternalTransaction {
 		Seqno:    m.Seqno,
 		Data:     m.Data,
 		AuthData: m.Signature,
+
+		AuthorizationList: m.AuthorizationList,
 	}
 }
 
@@ -410,8 +450,19 @@ func (m *Transaction) VerifyFlags() error {
 		if num > 1 {
 			return errors.New("internal transaction cannot be deploy, refund, bounce or async at the same time")
 		}
-	} else if m.IsRefund() || m.IsBounce() || m.IsRequestOrResponse() {
-		return errors.New("external transaction cannot be bounce, refund or async")
+		if m.IsSetCode() {
+			return errors.New("internal transaction cannot set code")
+		}
+		if m.IsScheduled() && num > 0 {
+			return errors.New("scheduled transaction cannot be deploy, refund, bounce or async")
+		}
+	} else if m.IsRefund() || m.IsBounce() || m.IsRequestOrResponse() || m.IsScheduled() {
+		return errors.New("external transaction cannot be bounce, refund, async or scheduled")
+	} else if m.IsDeploy() && m.IsSetCode() {
+		return errors.New("external transaction cannot be deploy and set code at the same time")
+	}
+	if !m.IsSetCode() && len(m.AuthorizationList) > 0 {
+		return errors.New("authorization list is allowed only in set code transactions")
 	}
 	if m.To.ShardId().IsMainShard() && !m.From.ShardId().IsMainShard() {
 		return errors.New("transaction to main shard is not allowed from a regular shard")
@@ -451,6 +502,14 @@ func (m *Transaction) IsRequest() bool {
 	return m.IsRequestOrResponse() && !m.IsResponse()
 }
 
+func (m *Transaction) IsSetCode() bool {
+	return m.Flags.IsSetCode()
+}
+
+func (m *Transaction) IsScheduled() bool {
+	return m.Flags.IsScheduled()
+}
+
 func (m *Transaction) IsRequestOrResponse() bool {
 	return m.RequestId != 0
 }
@@ -513,7 +572,7 @@ func (m *ExternalTransaction) SigningHash() (common.Hash, error) {
 		ChainId: m.ChainId,
 	}
 
-	return common.Keccak(&transactionDigest)
+	return signingHash(transactionDigest, m.AuthorizationList)
 }
 
 func (m ExternalTransaction) ToTransaction() *Transaction {
@@ -528,11 +587,13 @@ func (m ExternalTransaction) ToTransaction() *Transaction {
 		},
 		From:      m.To,
 		Signature: m.AuthData,
+
+		AuthorizationList: m.AuthorizationList,
 	}
 }
 
 func (m *Transaction) SigningHash() (common.Hash, error) {
-	return common.Keccak(&m.TransactionDigest)
+	return signingHash(m.TransactionDigest, m.AuthorizationList)
 }
 
 func (m *ExternalTransaction) Sign(key *ecdsa.PrivateKey) error {
@@ -567,6 +628,8 @@ func TransactionFlagsFromKind(internal bool, kind TransactionKind) TransactionFl
 		flags = append(flags, TransactionFlagRefund)
 	case ResponseTransactionKind:
 		flags = append(flags, TransactionFlagResponse)
+	case SetCodeTransactionKind:
+		flags = append(flags, TransactionFlagSetCode)
 	case ExecutionTransactionKind: // do nothing
 	}
 	return NewTransactionFlags(flags...)
@@ -591,6 +654,12 @@ func (m TransactionFlags) String() string {
 	if m.IsResponse() {
 		res += ", Response"
 	}
+	if m.IsSetCode() {
+		res += ", SetCode"
+	}
+	if m.IsScheduled() {
+		res += ", Scheduled"
+	}
 	return res
 }
 
@@ -613,6 +682,12 @@ func (m TransactionFlags) MarshalJSON() ([]byte, error) {
 	if m.IsResponse() {
 		res += ", \"Response\""
 	}
+	if m.IsSetCode() {
+		res += ", \"SetCode\""
+	}
+	if m.IsScheduled() {
+		res += ", \"Scheduled\""
+	}
 	return []byte(fmt.Sprintf("[%s]", res)), nil
 }
 
@@ -634,6 +709,10 @@ func (m *TransactionFlags) UnmarshalJSON(data []byte) error {
 			m.SetBit(TransactionFlagBounce)
 		case "Response":
 			m.SetBit(TransactionFlagResponse)
+		case "SetCode":
+			m.SetBit(TransactionFlagSetCode)
+		case "Scheduled":
+			m.SetBit(TransactionFlagScheduled)
 		}
 	}
 	return nil
@@ -659,6 +738,14 @@ func (m TransactionFlags) IsResponse() bool {
 	return m.GetBit(TransactionFlagResponse)
 }
 
+func (m TransactionFlags) IsSetCode() bool {
+	return m.GetBit(TransactionFlagSetCode)
+}
+
+func (m TransactionFlags) IsScheduled() bool {
+	return m.GetBit(TransactionFlagScheduled)
+}
+
 type TxnWithHash struct {
 	*Transaction
 	hash common.Hash
@@ -20,6 +20,9 @@ type StateDBReadOnly interface {
 
 	GetTokens(types.Address) map[types.TokenId]types.Value
 	GetGasPrice(types.ShardId) (types.Value, error)
+
+	// Get execution context shard id
+	GetShardID() types.ShardId
 }
 
 type StateDB interface {
@@ -96,8 +99,12 @@ type StateDB interface {
 	// Get current transaction
 	GetInTransaction() *types.Transaction
 
-	// Get execution context shard id
-	GetShardID() types.ShardId
+	// GetScheduledTransaction returns the schedule with the given id or nil if there is no such schedule
+	GetScheduledTransaction(id uint64) (*types.ScheduledTransaction, error)
+	// ScheduleTransaction registers the prepaid schedule and returns its id
+	ScheduleTransaction(*types.ScheduledTransaction) (uint64, error)
+	// CancelScheduledTransaction removes the schedule without refunding its fees
+	CancelScheduledTransaction(id uint64)
 
 	GetConfigAccessor() config.ConfigAccessor
 
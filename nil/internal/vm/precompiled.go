@@ -89,10 +89,14 @@ var (
 	SendTokensAddress        = types.BytesToAddress([]byte{0xd2})
 	TransactionTokensAddress = types.BytesToAddress([]byte{0xd3})
 	GetGasPriceAddress       = types.BytesToAddress([]byte{0xd4})
+	ManageAllowanceAddress   = types.BytesToAddress([]byte{0xd5})
+	TokenAllowanceAddress    = types.BytesToAddress([]byte{0xd6})
 	ConfigParamAddress       = types.BytesToAddress([]byte{0xd7})
+	ScheduleCallAddress      = types.BytesToAddress([]byte{0xd8})
 	CheckIsResponseAddress   = types.BytesToAddress([]byte{0xd9})
 	LogAddress               = types.BytesToAddress([]byte{0xda})
 	GovernanceAddress        = types.BytesToAddress([]byte{0xdb})
+	RandomnessAddress        = types.BytesToAddress([]byte{0xdc})
 	ConsoleAddress           = types.HexToAddress("0x00000000000000000000000000000000000dEBa6")
 )
 
@@ -127,10 +131,14 @@ var PrecompiledContractsPrague = map[types.Address]PrecompiledContract{
 	SendTokensAddress:        &sendTokenSync{},
 	TransactionTokensAddress: &getTransactionTokens{},
 	GetGasPriceAddress:       &getGasPrice{},
+	ManageAllowanceAddress:   &manageAllowance{},
+	TokenAllowanceAddress:    &tokenAllowance{},
 	ConfigParamAddress:       &configParam{},
+	ScheduleCallAddress:      &scheduleCall{},
 	CheckIsResponseAddress:   &checkIsResponse{},
 	LogAddress:               &emitLog{},
 	GovernanceAddress:        &governance{},
+	RandomnessAddress:        &getRandomness{},
 	ConsoleAddress:           &consolePrecompile{},
 }
 
@@ -274,6 +282,14 @@ func getPrecompiledMethod(methodName string) abi.Method {
 	return method
 }
 
+func getPrecompiledEvent(eventName string) abi.Event {
+	a, err := contracts.GetAbi(contracts.NamePrecompile)
+	check.PanicIfErr(err)
+	event, ok := a.Events[eventName]
+	check.PanicIfNotf(ok, "event %s not found", eventName)
+	return event
+}
+
 // getBytesArgCopy returns a copy of the byte slice argument.
 // It is needed because `abi.Unpack` unpack []byte arguments as a slice pointing inside the input calldata.
 func getBytesArgCopy(arg any, methodName, paramName string) []byte {
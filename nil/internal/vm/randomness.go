@@ -0,0 +1,46 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/holiman/uint256"
+)
+
+type getRandomness struct{}
+
+var _ EvmAccessedPrecompiledContract = (*getRandomness)(nil)
+
+func (c *getRandomness) RequiredGas([]byte, StateDBReadOnly) (uint64, error) {
+	return 50, nil
+}
+
+// Run derives a value from the block randomness (PREVRANDAO), so that different contracts and seeds get independent
+// values within the same block.
+func (c *getRandomness) Run(evm *EVM, input []byte, value *uint256.Int, caller ContractRef) ([]byte, error) {
+	method := getPrecompiledMethod("precompileGetRandomness")
+
+	args, err := precompiledArgs("precompileGetRandomness", input, 1)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+
+	// Get `seed` argument
+	seed, ok := args[0].(*big.Int)
+	check.PanicIfNotf(ok, "getRandomness failed: seed is not a big.Int: %v", args[0])
+
+	random := common.EmptyHash
+	if evm.Context.Random != nil {
+		random = *evm.Context.Random
+	}
+	res := common.KeccakHash(bytes.Join([][]byte{random.Bytes(), caller.Address().Bytes(), common.BigToHash(seed).Bytes()}, nil))
+
+	packed, err := method.Outputs.Pack(res.Big())
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiPackFailed, err.Error())
+	}
+	return packed, nil
+}
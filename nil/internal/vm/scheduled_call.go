@@ -0,0 +1,187 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/common/math"
+	"github.com/NilFoundation/nil/nil/internal/tracing"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// ScheduleCallGas covers the update of the scheduled transactions table.
+	ScheduleCallGas uint64 = 20_000
+	// ScheduleCallDataWordGas is charged for every 32-byte word of the stored call data, as for a storage write.
+	ScheduleCallDataWordGas uint64 = 20_000
+	// ScheduleCallRunGas is charged for every scheduled run, which moves the schedule in the queue when executed.
+	ScheduleCallRunGas uint64 = 5_000
+)
+
+type scheduleCall struct{}
+
+var _ EvmAccessedPrecompiledContract = (*scheduleCall)(nil)
+
+// RequiredGas charges for the storage of the schedule and its call data and for the queue updates of all the runs.
+// Malformed input is charged the base price, Run rejects it.
+func (c *scheduleCall) RequiredGas(input []byte, _ StateDBReadOnly) (uint64, error) {
+	if len(input) < 4 || !bytes.Equal(input[:4], getPrecompiledMethod("precompileScheduleCall").ID) {
+		return ScheduleCallGas, nil
+	}
+	args, err := precompiledArgs("precompileScheduleCall", input, 6)
+	if err != nil {
+		return ScheduleCallGas, nil //nolint:nilerr
+	}
+	count, ok := args[3].(uint64)
+	check.PanicIfNotf(ok, "scheduleCall failed: count argument is not uint64")
+	callData, ok := args[5].([]byte)
+	check.PanicIfNotf(ok, "scheduleCall failed: callData argument is not bytes")
+
+	return ScheduleCallRequiredGas(uint64(len(callData)), count)
+}
+
+// ScheduleCallRequiredGas returns the gas of scheduling `count` runs of the call with the data of the given size.
+func ScheduleCallRequiredGas(dataSize uint64, count uint64) (uint64, error) {
+	dataGas, overflow := math.SafeMul(toWordSize(dataSize), ScheduleCallDataWordGas)
+	if overflow {
+		return 0, ErrGasUintOverflow
+	}
+	runsGas, overflow := math.SafeMul(count, ScheduleCallRunGas)
+	if overflow {
+		return 0, ErrGasUintOverflow
+	}
+	gas, overflow := math.SafeAdd(ScheduleCallGas, dataGas)
+	if overflow {
+		return 0, ErrGasUintOverflow
+	}
+	if gas, overflow = math.SafeAdd(gas, runsGas); overflow {
+		return 0, ErrGasUintOverflow
+	}
+	return gas, nil
+}
+
+// Run needs the number of the current block, so the precompile accesses the EVM.
+func (c *scheduleCall) Run(evm *EVM, input []byte, value *uint256.Int, caller ContractRef) ([]byte, error) {
+	if evm.interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	if len(input) < 4 {
+		return nil, types.NewVmError(types.ErrorPrecompileTooShortCallData)
+	}
+
+	switch {
+	case bytes.Equal(input[:4], getPrecompiledMethod("precompileScheduleCall").ID):
+		return c.schedule(evm, input, caller)
+	case bytes.Equal(input[:4], getPrecompiledMethod("precompileCancelScheduledCall").ID):
+		return c.cancel(evm.StateDB, input, caller)
+	}
+	return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument, "unknown schedule method")
+}
+
+func (c *scheduleCall) schedule(evm *EVM, input []byte, caller ContractRef) ([]byte, error) {
+	method := getPrecompiledMethod("precompileScheduleCall")
+
+	args, err := precompiledArgs("precompileScheduleCall", input, 6)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+
+	// Get `dst` argument
+	dst, ok := args[0].(types.Address)
+	check.PanicIfNotf(ok, "scheduleCall failed: dst argument is not an address")
+
+	// Get `startBlock` argument
+	startBlock, ok := args[1].(uint64)
+	check.PanicIfNotf(ok, "scheduleCall failed: startBlock argument is not uint64")
+
+	// Get `interval` argument
+	interval, ok := args[2].(uint64)
+	check.PanicIfNotf(ok, "scheduleCall failed: interval argument is not uint64")
+
+	// Get `count` argument
+	count, ok := args[3].(uint64)
+	check.PanicIfNotf(ok, "scheduleCall failed: count argument is not uint64")
+
+	// Get `feeCredit` argument
+	feeCredit := extractUintParam(args[4], "scheduleCall", "feeCredit")
+
+	// Get `callData` argument
+	callData, ok := args[5].([]byte)
+	check.PanicIfNotf(ok, "scheduleCall failed: callData argument is not bytes")
+
+	owner := caller.Address()
+	if dst.ShardId() != owner.ShardId() {
+		return nil, types.NewVmVerboseError(types.ErrorCrossShardTransaction, "scheduleCall")
+	}
+	if count == 0 || (count > 1 && interval == 0) {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument,
+			fmt.Sprintf("invalid schedule: count %d, interval %d", count, interval))
+	}
+	if startBlock <= evm.Context.BlockNumber {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument,
+			fmt.Sprintf("start block %d is not in the future", startBlock))
+	}
+
+	prepaid, overflow := new(uint256.Int).MulOverflow(feeCredit.Int(), uint256.NewInt(count))
+	if overflow {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument, "prepaid fee overflow")
+	}
+	if err := evm.StateDB.SubBalance(owner, types.NewValue(prepaid), tracing.BalanceDecreasePrecompile); err != nil {
+		return nil, types.KeepOrWrapError(types.ErrorInsufficientBalance, err)
+	}
+
+	id, err := evm.StateDB.ScheduleTransaction(&types.ScheduledTransaction{
+		Owner:        owner,
+		To:           dst,
+		Data:         callData,
+		NextBlock:    types.BlockNumber(startBlock),
+		Interval:     interval,
+		Remaining:    count,
+		FeeCredit:    feeCredit,
+		MaxFeePerGas: evm.StateDB.GetInTransaction().MaxFeePerGas,
+	})
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+
+	res, err := method.Outputs.Pack(id)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiPackFailed, err.Error())
+	}
+	return res, nil
+}
+
+// cancel removes the schedule and returns the fees prepaid for the remaining runs to the owner.
+func (c *scheduleCall) cancel(state StateDB, input []byte, caller ContractRef) ([]byte, error) {
+	args, err := precompiledArgs("precompileCancelScheduledCall", input, 1)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+
+	// Get `id` argument
+	id, ok := args[0].(uint64)
+	check.PanicIfNotf(ok, "cancelScheduledCall failed: id argument is not uint64")
+
+	scheduled, err := state.GetScheduledTransaction(id)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+	if scheduled == nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument, fmt.Sprintf("unknown schedule %d", id))
+	}
+	if scheduled.Owner != caller.Address() {
+		return nil, types.NewVmError(types.ErrorPrecompileWrongCaller)
+	}
+
+	state.CancelScheduledTransaction(id)
+	if err := state.AddBalance(scheduled.Owner, scheduled.Prepaid(), tracing.BalanceIncreaseRefund); err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+
+	res := make([]byte, 32)
+	res[31] = 1
+
+	return res, nil
+}
@@ -0,0 +1,450 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/internal/config"
+	"github.com/NilFoundation/nil/nil/internal/tracing"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// ManageAllowanceGas covers the storage update of the allowance.
+	ManageAllowanceGas uint64 = 5_000
+	// TokenAllowanceGas covers the storage read of the allowance.
+	TokenAllowanceGas uint64 = 800
+)
+
+// tokenAllowancePrefix separates the allowance slots from the regular storage of the owner account.
+var tokenAllowancePrefix = []byte("nil.token.allowance")
+
+// UnlimitedAllowance is never decreased by transfers, as in ERC-20.
+var UnlimitedAllowance = types.NewValue(new(uint256.Int).SetAllOne())
+
+// TokenAllowanceKey returns the storage slot of the owner account that holds the allowance of the spender.
+// Allowances are kept in the owner storage, so they are journaled and reverted along with the rest of its state.
+func TokenAllowanceKey(spender types.Address, tokenId types.TokenId) common.Hash {
+	return common.KeccakHash(bytes.Join([][]byte{tokenAllowancePrefix, spender.Bytes(), tokenId[:]}, nil))
+}
+
+// GetTokenAllowance returns the amount of the token that the spender is allowed to transfer from the owner.
+func GetTokenAllowance(
+	state StateDB, owner, spender types.Address, tokenId types.TokenId,
+) (types.Value, error) {
+	slot, err := state.GetState(owner, TokenAllowanceKey(spender, tokenId))
+	if err != nil {
+		return types.Value{}, err
+	}
+	return types.NewValueFromBytes(slot[:]), nil
+}
+
+func setTokenAllowance(
+	state StateDB, owner, spender types.Address, tokenId types.TokenId, amount types.Value,
+) error {
+	return state.SetState(owner, TokenAllowanceKey(spender, tokenId), amount.Bytes32())
+}
+
+// emitPrecompileEvent adds a log of the event declared in the precompile ABI. The log is emitted from the address of
+// the allowance precompile, so that the events can't be confused with the events of the token owner contract.
+func emitPrecompileEvent(state StateDB, name string, topics []common.Hash, args ...any) error {
+	event := getPrecompiledEvent(name)
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		return types.NewVmVerboseError(types.ErrorAbiPackFailed, err.Error())
+	}
+	log, err := types.NewLog(ManageAllowanceAddress, data, append([]common.Hash{common.Hash(event.ID)}, topics...))
+	if err != nil {
+		return types.KeepOrWrapError(types.ErrorEmitLogFailed, err)
+	}
+	if err := state.AddLog(log); err != nil {
+		return types.KeepOrWrapError(types.ErrorEmitLogFailed, err)
+	}
+	return nil
+}
+
+func addressTopic(addr types.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+type manageAllowance struct{}
+
+var _ ReadWritePrecompiledContract = (*manageAllowance)(nil)
+
+func (c *manageAllowance) RequiredGas(input []byte, state StateDBReadOnly) (uint64, error) {
+	if len(input) < 4 || !bytes.Equal(input[:4], getPrecompiledMethod("precompileTransferTokenFrom").ID) {
+		return ManageAllowanceGas, nil
+	}
+
+	from, err := extractDstAddress(input, "precompileTransferTokenFrom", 1)
+	if err != nil {
+		return 0, err
+	}
+	to, err := extractDstAddress(input, "precompileTransferTokenFrom", 2)
+	if err != nil {
+		return 0, err
+	}
+	switch shardId := state.GetShardID(); {
+	case from.ShardId() != shardId:
+		// The pull is sent to the shard of the owner.
+		return ManageAllowanceGas + ForwardFee + GetExtraGasForOutboundTransaction(state, from.ShardId()), nil
+	case to.ShardId() != shardId:
+		// Delivery to another shard requires an outbound transaction.
+		return ManageAllowanceGas + ForwardFee + GetExtraGasForOutboundTransaction(state, to.ShardId()), nil
+	}
+	return ManageAllowanceGas, nil
+}
+
+func (c *manageAllowance) Run(state StateDB, input []byte, value *uint256.Int, caller ContractRef) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, types.NewVmError(types.ErrorPrecompileTooShortCallData)
+	}
+
+	switch {
+	case bytes.Equal(input[:4], getPrecompiledMethod("precompileApproveToken").ID):
+		return c.approve(state, input, caller)
+	case bytes.Equal(input[:4], getPrecompiledMethod("precompileTransferTokenFrom").ID):
+		return c.transferFrom(state, input, caller)
+	}
+	return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument, "unknown allowance method")
+}
+
+func (c *manageAllowance) approve(state StateDB, input []byte, caller ContractRef) ([]byte, error) {
+	args, err := getPrecompiledMethod("precompileApproveToken").Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+	if len(args) != 3 {
+		return nil, types.NewVmError(types.ErrorPrecompileWrongNumberOfArguments)
+	}
+
+	// Get `spender` argument
+	spender, ok := args[0].(types.Address)
+	check.PanicIfNotf(ok, "approveToken failed: spender argument is not an address")
+
+	// Get `id` argument
+	tokenId, ok := args[1].(types.Address)
+	check.PanicIfNotf(ok, "approveToken failed: tokenId is not an Address: %v", args[1])
+
+	// Get `amount` argument
+	amount := extractUintParam(args[2], "approveToken", "amount")
+
+	owner := caller.Address()
+	if err := setTokenAllowance(state, owner, spender, types.TokenId(tokenId), amount); err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+
+	topics := []common.Hash{addressTopic(owner), addressTopic(spender), addressTopic(tokenId)}
+	if err := emitPrecompileEvent(state, "TokenApproval", topics, amount.ToBig()); err != nil {
+		return nil, err
+	}
+
+	return successResult(), nil
+}
+
+func (c *manageAllowance) transferFrom(state StateDB, input []byte, caller ContractRef) ([]byte, error) {
+	args, err := getPrecompiledMethod("precompileTransferTokenFrom").Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+	if len(args) != 4 {
+		return nil, types.NewVmError(types.ErrorPrecompileWrongNumberOfArguments)
+	}
+
+	// Get `id` argument
+	id, ok := args[0].(types.Address)
+	check.PanicIfNotf(ok, "transferTokenFrom failed: tokenId is not an Address: %v", args[0])
+	tokenId := types.TokenId(id)
+
+	// Get `from` argument
+	from, ok := args[1].(types.Address)
+	check.PanicIfNotf(ok, "transferTokenFrom failed: from argument is not an address")
+
+	// Get `to` argument
+	to, ok := args[2].(types.Address)
+	check.PanicIfNotf(ok, "transferTokenFrom failed: to argument is not an address")
+
+	// Get `amount` argument
+	amount := extractUintParam(args[3], "transferTokenFrom", "amount")
+
+	// The allowance and the balance of the owner are only accessible in its own shard,
+	// so a spender from another shard sends the same call there.
+	spender := caller.Address()
+	shardId := state.GetShardID()
+	if from.ShardId() != shardId {
+		if err := sendTokenPull(state, spender, from, input); err != nil {
+			return nil, err
+		}
+		return successResult(), nil
+	}
+	if spender.ShardId() != shardId && state.GetInTransaction().To != from {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileBadArgument,
+			fmt.Sprintf("transferTokenFrom: pull from %s is sent to %s", from, state.GetInTransaction().To))
+	}
+	if to.ShardId() != shardId {
+		if err := checkOutboundShard(state, to.ShardId()); err != nil {
+			return nil, err
+		}
+	}
+
+	allowance, err := GetTokenAllowance(state, from, spender, tokenId)
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+	if allowance.Cmp(amount) < 0 {
+		return nil, types.NewVmVerboseError(types.ErrorInsufficientAllowance,
+			fmt.Sprintf("%s < %s, token %s", allowance, amount, tokenId))
+	}
+	if balance := state.GetTokens(from)[tokenId]; balance.Cmp(amount) < 0 {
+		return nil, types.NewVmVerboseError(types.ErrorInsufficientBalance,
+			fmt.Sprintf("%s < %s, token %s", balance, amount, tokenId))
+	}
+	if !allowance.Eq(UnlimitedAllowance) {
+		if err := setTokenAllowance(state, from, spender, tokenId, allowance.Sub(amount)); err != nil {
+			return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+		}
+	}
+
+	if to.ShardId() == shardId {
+		if err := state.SubToken(from, tokenId, amount); err != nil {
+			return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+		}
+		if err := state.AddToken(to, tokenId, amount); err != nil {
+			return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+		}
+	} else if err := sendTokensAsync(state, spender, from, to, tokenId, amount); err != nil {
+		return nil, err
+	}
+
+	topics := []common.Hash{addressTopic(from), addressTopic(to), addressTopic(id)}
+	if err := emitPrecompileEvent(state, "TokenTransfer", topics, amount.ToBig()); err != nil {
+		return nil, err
+	}
+
+	return successResult(), nil
+}
+
+func successResult() []byte {
+	res := make([]byte, 32)
+	res[31] = 1
+	return res
+}
+
+func checkOutboundShard(state StateDB, shardId types.ShardId) error {
+	if shardId.IsMainShard() {
+		return ErrTransactionToMainShard
+	}
+	nShards, err := config.GetParamNShards(state.GetConfigAccessor())
+	if err != nil {
+		return types.NewVmVerboseError(types.ErrorPrecompileConfigGetParamFailed, err.Error())
+	}
+	if uint32(shardId) >= nShards {
+		return ErrShardIdIsTooBig
+	}
+	return nil
+}
+
+// sendTokenPull sends the transferTokenFrom call of the spender to the shard of the owner, where it is executed
+// by the precompile on behalf of the spender. A failed pull changes nothing, so it carries nothing to bounce.
+func sendTokenPull(state StateDB, spender, owner types.Address, input []byte) error {
+	if err := checkOutboundShard(state, owner.ShardId()); err != nil {
+		return err
+	}
+
+	refundTo := types.EmptyAddress
+	setRefundTo(&refundTo, state.GetInTransaction())
+
+	payload := types.InternalTransactionPayload{
+		Kind:        types.ExecutionTransactionKind,
+		ForwardKind: types.ForwardKindRemaining,
+		To:          owner,
+		RefundTo:    refundTo,
+		Data:        bytes.Clone(input),
+	}
+	_, err := state.AddOutTransaction(spender, &payload, 0)
+	return err
+}
+
+// IsTokenPullTransaction reports whether the transaction is a transferTokenFrom call sent by a spender from another
+// shard to the owner. Such transactions are executed by the allowance precompile instead of the owner code.
+func IsTokenPullTransaction(txn *types.Transaction) bool {
+	return txn.IsInternal() && !txn.IsBounce() && !txn.IsResponse() &&
+		txn.From.ShardId() != txn.To.ShardId() &&
+		txn.Value.IsZero() && len(txn.Token) == 0 &&
+		len(txn.Data) >= 4 && bytes.Equal(txn.Data[:4], getPrecompiledMethod("precompileTransferTokenFrom").ID)
+}
+
+// sendTokensAsync delivers the tokens of the owner to another shard. The allowance of the spender stays reserved
+// until the delivery: if it bounces, the bounce comes to the allowance escrow of the owner shard,
+// which returns the tokens to the owner and the reserved amount to the allowance (see ReleaseTokenAllowance).
+func sendTokensAsync(
+	state StateDB, spender, owner, to types.Address, tokenId types.TokenId, amount types.Value,
+) error {
+	// The outbound transaction takes the current seqno of the owner, it identifies the reservation.
+	seqno, err := state.GetSeqno(owner)
+	if err != nil {
+		return types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+	if err := state.SetState(owner, tokenAllowanceReservationKey(seqno), spender.Hash()); err != nil {
+		return types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+
+	refundTo := types.EmptyAddress
+	setRefundTo(&refundTo, state.GetInTransaction())
+
+	payload := types.InternalTransactionPayload{
+		Kind:        types.ExecutionTransactionKind,
+		ForwardKind: types.ForwardKindRemaining,
+		Token:       []types.TokenBalance{{Token: tokenId, Balance: amount}},
+		To:          to,
+		RefundTo:    refundTo,
+		BounceTo:    TokenAllowanceEscrowAddress(owner.ShardId()),
+	}
+	_, err = state.AddOutTransaction(owner, &payload, 0)
+	return err
+}
+
+// tokenAllowanceEscrowHex is the address of the allowance escrow without the shard id.
+const tokenAllowanceEscrowHex = "0x000000000000000000000000000000000000d5e5"
+
+// TokenAllowanceEscrowAddress returns the address that receives the bounced deliveries of transferTokenFrom
+// in the given shard. It has no code, its bounces are handled by ReleaseTokenAllowance.
+func TokenAllowanceEscrowAddress(shardId types.ShardId) types.Address {
+	return types.ShardAndHexToAddress(shardId, tokenAllowanceEscrowHex)
+}
+
+func IsTokenAllowanceEscrow(addr types.Address) bool {
+	return addr == TokenAllowanceEscrowAddress(addr.ShardId())
+}
+
+// tokenAllowanceReservationPrefix separates the reservations of in-flight deliveries in the owner storage.
+var tokenAllowanceReservationPrefix = []byte("nil.token.allowance.reservation")
+
+// tokenAllowanceReservationKey returns the storage slot of the owner account that holds the spender of the delivery
+// sent with the given seqno.
+func tokenAllowanceReservationKey(seqno types.Seqno) common.Hash {
+	return common.KeccakHash(binary.BigEndian.AppendUint64(bytes.Clone(tokenAllowanceReservationPrefix), uint64(seqno)))
+}
+
+// TokenAllowanceBounceData returns the data of the bounce of a transferTokenFrom delivery.
+// It identifies the reservation by the sender and the seqno of the delivery.
+func TokenAllowanceBounceData(owner types.Address, seqno types.Seqno) []byte {
+	return binary.BigEndian.AppendUint64(owner.Bytes(), uint64(seqno))
+}
+
+// ReleaseTokenAllowance handles the bounce of a transferTokenFrom delivery: the tokens are returned to the owner,
+// and the reserved amount is added back to the allowance of the spender.
+func ReleaseTokenAllowance(state StateDB, txn *types.Transaction) error {
+	if len(txn.Data) != types.AddrSize+8 {
+		return types.NewVmVerboseError(types.ErrorPrecompileBadArgument, "invalid allowance bounce data")
+	}
+	owner := types.BytesToAddress(txn.Data[:types.AddrSize])
+	seqno := types.Seqno(binary.BigEndian.Uint64(txn.Data[types.AddrSize:]))
+	if owner.ShardId() != state.GetShardID() {
+		return types.NewVmVerboseError(types.ErrorCrossShardTransaction, "allowance bounce")
+	}
+
+	if err := state.AddBalance(owner, txn.Value, tracing.BalanceIncreaseRefund); err != nil {
+		return err
+	}
+	for _, token := range txn.Token {
+		if err := state.AddToken(owner, token.Token, token.Balance); err != nil {
+			return err
+		}
+	}
+
+	key := tokenAllowanceReservationKey(seqno)
+	reservation, err := state.GetState(owner, key)
+	if err != nil {
+		return err
+	}
+	if reservation.Empty() {
+		// The bounce of an ordinary transaction that was sent with the escrow as a bounce address.
+		return nil
+	}
+	if err := state.SetState(owner, key, common.EmptyHash); err != nil {
+		return err
+	}
+
+	spender := types.BytesToAddress(reservation.Bytes())
+	for _, token := range txn.Token {
+		allowance, err := GetTokenAllowance(state, owner, spender, token.Token)
+		if err != nil {
+			return err
+		}
+		if allowance.Eq(UnlimitedAllowance) {
+			continue
+		}
+		allowance, overflow := allowance.AddOverflow(token.Balance)
+		if overflow {
+			allowance = UnlimitedAllowance
+		}
+		if err := setTokenAllowance(state, owner, spender, token.Token, allowance); err != nil {
+			return err
+		}
+
+		topics := []common.Hash{addressTopic(owner), addressTopic(spender), common.BytesToHash(token.Token[:])}
+		if err := emitPrecompileEvent(state, "TokenApproval", topics, allowance.ToBig()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type tokenAllowance struct{}
+
+var _ EvmAccessedPrecompiledContract = (*tokenAllowance)(nil)
+
+func (c *tokenAllowance) RequiredGas([]byte, StateDBReadOnly) (uint64, error) {
+	return TokenAllowanceGas, nil
+}
+
+// Run reads the owner storage, which is not available through StateDBReadOnly, so the precompile accesses the EVM.
+// It never modifies the state and can be used in static calls.
+func (c *tokenAllowance) Run(evm *EVM, input []byte, value *uint256.Int, caller ContractRef) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, types.NewVmError(types.ErrorPrecompileTooShortCallData)
+	}
+
+	method := getPrecompiledMethod("precompileGetTokenAllowance")
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiUnpackFailed, err.Error())
+	}
+	if len(args) != 3 {
+		return nil, types.NewVmError(types.ErrorPrecompileWrongNumberOfArguments)
+	}
+
+	// Get `owner` argument
+	owner, ok := args[0].(types.Address)
+	check.PanicIfNotf(ok, "tokenAllowance failed: owner argument is not an address")
+
+	// Get `spender` argument
+	spender, ok := args[1].(types.Address)
+	check.PanicIfNotf(ok, "tokenAllowance failed: spender argument is not an address")
+
+	// Get `id` argument
+	tokenId, ok := args[2].(types.Address)
+	check.PanicIfNotf(ok, "tokenAllowance failed: tokenId is not an Address: %v", args[2])
+
+	if owner.ShardId() != caller.Address().ShardId() {
+		return nil, types.NewVmVerboseError(types.ErrorCrossShardTransaction, "tokenAllowance")
+	}
+
+	allowance, err := GetTokenAllowance(evm.StateDB, owner, spender, types.TokenId(tokenId))
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorPrecompileStateDbReturnedError, err.Error())
+	}
+
+	res, err := method.Outputs.Pack(allowance.ToBig())
+	if err != nil {
+		return nil, types.NewVmVerboseError(types.ErrorAbiPackFailed, err.Error())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,151 @@
+package cliservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrPrivateKeyNotSet = errors.New("private key is not set")
+
+// ParseExternalTransaction decodes a transaction either from JSON or from hex-encoded raw bytes
+func ParseExternalTransaction(data []byte) (*types.ExternalTransaction, error) {
+	data = bytes.TrimSpace(data)
+	txn := &types.ExternalTransaction{}
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, txn); err != nil {
+			return nil, fmt.Errorf("failed to parse transaction JSON: %w", err)
+		}
+		return txn, nil
+	}
+
+	raw, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("transaction is neither JSON nor hex-encoded raw bytes: %w", err)
+	}
+	if err := txn.UnmarshalNil(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode raw transaction: %w", err)
+	}
+	return txn, nil
+}
+
+func ReadExternalTransaction(path string) (*types.ExternalTransaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExternalTransaction(data)
+}
+
+// EncodeExternalTransaction returns the transaction as JSON or as hex-encoded raw bytes
+func EncodeExternalTransaction(txn *types.ExternalTransaction, raw bool) ([]byte, error) {
+	if raw {
+		data, err := txn.MarshalNil()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(hexutil.Encode(data) + "\n"), nil
+	}
+
+	data, err := json.MarshalIndent(txn, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// BuildExternalTransaction creates an unsigned external transaction.
+// The cluster is queried only for the values that are not specified: seqno if it is nil and fee if it is zero,
+// so a fully specified transaction can be built offline.
+func (s *Service) BuildExternalTransaction(
+	to types.Address,
+	data types.Code,
+	isDeploy bool,
+	seqno *types.Seqno,
+	fee types.FeePack,
+	chainId types.ChainId,
+) (*types.ExternalTransaction, error) {
+	txn := &types.ExternalTransaction{
+		Kind:    types.ExecutionTransactionKind,
+		To:      to,
+		ChainId: chainId,
+		Data:    data,
+		FeePack: types.NewFeePack(),
+	}
+	if isDeploy {
+		txn.Kind = types.DeployTransactionKind
+	}
+
+	if seqno != nil {
+		txn.Seqno = *seqno
+	} else {
+		var err error
+		txn.Seqno, err = s.client.GetTransactionCount(s.ctx, to, "pending")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch seqno: %w", err)
+		}
+	}
+
+	if fee.FeeCredit.IsZero() {
+		estimatedFee, err := client.EstimateFeeExternal(s.ctx, s.client, txn, "latest")
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate fee: %w", err)
+		}
+		txn.FeeCredit = estimatedFee.FeeCredit
+		txn.MaxPriorityFeePerGas = estimatedFee.AveragePriorityFee
+		txn.MaxFeePerGas = estimatedFee.MaxBasFee.Add(estimatedFee.AveragePriorityFee)
+		return txn, nil
+	}
+
+	txn.FeeCredit = fee.FeeCredit
+	if !fee.MaxPriorityFeePerGas.IsZero() {
+		txn.MaxPriorityFeePerGas = fee.MaxPriorityFeePerGas
+	}
+	txn.MaxFeePerGas = types.MaxFeePerGasDefault
+	if !fee.MaxFeePerGas.IsZero() {
+		txn.MaxFeePerGas = fee.MaxFeePerGas
+	}
+	return txn, nil
+}
+
+// SignExternalTransaction signs the transaction with the private key of the service.
+// It doesn't need the cluster, so it can be done on an offline machine.
+func (s *Service) SignExternalTransaction(txn *types.ExternalTransaction) error {
+	if s.privateKey == nil {
+		return ErrPrivateKeyNotSet
+	}
+	return txn.Sign(s.privateKey)
+}
+
+// BroadcastExternalTransaction sends the raw bytes of the prepared transaction to the cluster
+func (s *Service) BroadcastExternalTransaction(txn *types.ExternalTransaction) (common.Hash, error) {
+	if len(txn.Data) > types.TransactionMaxDataSize {
+		return common.EmptyHash, fmt.Errorf("transaction data is too long: %d bytes", len(txn.Data))
+	}
+	data, err := txn.MarshalNil()
+	if err != nil {
+		return common.EmptyHash, err
+	}
+	txHash, err := s.client.SendRawTransaction(s.ctx, data)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to broadcast transaction")
+		return common.EmptyHash, err
+	}
+	if expected := txn.Hash(); txHash != expected {
+		return txHash, TransactionHashMismatchError{actual: txHash, expected: expected}
+	}
+	s.logger.Info().
+		Stringer(logging.FieldShardId, txn.To.ShardId()).
+		Stringer(logging.FieldTransactionHash, txHash).
+		Send()
+	return txHash, nil
+}
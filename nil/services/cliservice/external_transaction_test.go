@@ -0,0 +1,50 @@
+package cliservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineExternalTransaction(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// neither seqno nor fee is requested from the cluster, so no client is needed
+	service := NewService(context.Background(), nil, key, nil)
+	seqno := types.Seqno(7)
+	txn, err := service.BuildExternalTransaction(
+		types.GenerateRandomAddress(types.BaseShardId), types.Code{0xde, 0xad},
+		false, &seqno, types.NewFeePackFromFeeCredit(types.NewValueFromUint64(1000)), types.DefaultChainId)
+	require.NoError(t, err)
+	require.Equal(t, seqno, txn.Seqno)
+	require.Equal(t, types.MaxFeePerGasDefault, txn.MaxFeePerGas)
+
+	unsigned, err := EncodeExternalTransaction(txn, false)
+	require.NoError(t, err)
+	parsed, err := ParseExternalTransaction(unsigned)
+	require.NoError(t, err)
+	require.Equal(t, txn.Hash(), parsed.Hash())
+	require.Empty(t, parsed.AuthData)
+
+	require.NoError(t, service.SignExternalTransaction(parsed))
+	for _, raw := range []bool{false, true} {
+		signed, err := EncodeExternalTransaction(parsed, raw)
+		require.NoError(t, err)
+		decoded, err := ParseExternalTransaction(signed)
+		require.NoError(t, err)
+		require.Equal(t, parsed.Hash(), decoded.Hash())
+		require.Equal(t, parsed.AuthData, decoded.AuthData)
+	}
+
+	_, err = ParseExternalTransaction([]byte("not a transaction"))
+	require.Error(t, err)
+
+	err = NewService(context.Background(), nil, nil, nil).SignExternalTransaction(parsed)
+	require.ErrorIs(t, err, ErrPrivateKeyNotSet)
+}
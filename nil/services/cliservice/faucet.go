@@ -167,6 +167,16 @@ func (s *Service) CreateSmartAccount(
 	pubKey *ecdsa.PublicKey,
 ) (types.Address, error) {
 	smartAccountCode := contracts.PrepareDefaultSmartAccountForOwnerCode(crypto.FromECDSAPub(pubKey))
+	return s.deploySmartAccount(shardId, salt, balance, fee, smartAccountCode)
+}
+
+func (s *Service) deploySmartAccount(
+	shardId types.ShardId,
+	salt *types.Uint256,
+	balance types.Value,
+	fee types.FeePack,
+	smartAccountCode types.Code,
+) (types.Address, error) {
 	smartAccountAddress := s.ContractAddress(shardId, *salt, smartAccountCode)
 
 	code, err := s.client.GetCode(s.ctx, smartAccountAddress, "latest")
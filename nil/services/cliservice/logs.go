@@ -0,0 +1,71 @@
+package cliservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+)
+
+// GetLogs fetches the logs matching the query.
+// The logs of the blocks that are not yet produced are not returned, even if the range includes them.
+func (s *Service) GetLogs(query *filters.FilterQuery) ([]*jsonrpc.RPCLog, error) {
+	var res []*jsonrpc.RPCLog
+	err := s.pollLogs(query, 0, func(logs []*jsonrpc.RPCLog) error {
+		res = logs
+		return nil
+	})
+	return res, err
+}
+
+// FollowLogs passes the logs matching the query to the handler: first the ones of the already produced
+// blocks, then the new ones as they appear. It polls the node every interval until the context is done.
+func (s *Service) FollowLogs(
+	query *filters.FilterQuery, interval time.Duration, handler func([]*jsonrpc.RPCLog) error,
+) error {
+	err := s.pollLogs(query, interval, handler)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// pollLogs installs a filter and passes its logs to the handler.
+// With a zero interval the filter is polled only once.
+func (s *Service) pollLogs(
+	query *filters.FilterQuery, interval time.Duration, handler func([]*jsonrpc.RPCLog) error,
+) error {
+	id, err := s.client.NewFilter(s.ctx, query)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to create filter")
+		return err
+	}
+	defer func() {
+		// The context may be already canceled, but the filter has to be removed anyway
+		if _, err := s.client.UninstallFilter(context.WithoutCancel(s.ctx), id); err != nil {
+			s.logger.Warn().Err(err).Str("id", id).Msg("Failed to uninstall filter")
+		}
+	}()
+
+	for {
+		logs, err := s.client.GetFilterLogs(s.ctx, id)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to fetch filter logs")
+			return err
+		}
+		if err := handler(logs); err != nil {
+			return err
+		}
+		if interval == 0 {
+			return nil
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
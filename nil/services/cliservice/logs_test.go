@@ -0,0 +1,92 @@
+package cliservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/filters"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogsClientMock(batches ...[]*jsonrpc.RPCLog) *client.ClientMock {
+	return &client.ClientMock{
+		NewFilterFunc: func(ctx context.Context, query *filters.FilterQuery) (string, error) {
+			return "filter", nil
+		},
+		GetFilterLogsFunc: func(ctx context.Context, id string) ([]*jsonrpc.RPCLog, error) {
+			if len(batches) == 0 {
+				return nil, nil
+			}
+			res := batches[0]
+			batches = batches[1:]
+			return res, nil
+		},
+		UninstallFilterFunc: func(ctx context.Context, id string) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+func TestGetLogs(t *testing.T) {
+	t.Parallel()
+
+	logs := []*jsonrpc.RPCLog{{Log: &types.Log{}, BlockNumber: 1}, {Log: &types.Log{}, BlockNumber: 2}}
+	mock := newLogsClientMock(logs)
+	query := &filters.FilterQuery{Addresses: []types.Address{types.MainSmartAccountAddress}}
+
+	res, err := NewService(t.Context(), mock, nil, nil).GetLogs(query)
+	require.NoError(t, err)
+	require.Equal(t, logs, res)
+
+	require.Len(t, mock.NewFilterCalls(), 1)
+	require.Equal(t, query, mock.NewFilterCalls()[0].Query)
+	require.Len(t, mock.GetFilterLogsCalls(), 1)
+	require.Len(t, mock.UninstallFilterCalls(), 1)
+	require.Equal(t, "filter", mock.UninstallFilterCalls()[0].ID)
+}
+
+func TestFollowLogs(t *testing.T) {
+	t.Parallel()
+
+	first := []*jsonrpc.RPCLog{{Log: &types.Log{}, BlockNumber: 1}}
+	second := []*jsonrpc.RPCLog{{Log: &types.Log{}, BlockNumber: 5}}
+
+	t.Run("Canceled", func(t *testing.T) {
+		t.Parallel()
+
+		mock := newLogsClientMock(first, nil, second)
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		var received []*jsonrpc.RPCLog
+		err := NewService(ctx, mock, nil, nil).FollowLogs(
+			&filters.FilterQuery{}, time.Millisecond, func(logs []*jsonrpc.RPCLog) error {
+				received = append(received, logs...)
+				if len(received) == 2 {
+					cancel()
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, append(first, second...), received)
+		require.Len(t, mock.UninstallFilterCalls(), 1)
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		t.Parallel()
+
+		mock := newLogsClientMock(first)
+		errStop := errors.New("stop")
+		err := NewService(t.Context(), mock, nil, nil).FollowLogs(
+			&filters.FilterQuery{}, time.Millisecond, func(logs []*jsonrpc.RPCLog) error {
+				return errStop
+			})
+		require.ErrorIs(t, err, errStop)
+		require.Len(t, mock.UninstallFilterCalls(), 1)
+	})
+}
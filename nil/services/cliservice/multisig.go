@@ -0,0 +1,246 @@
+package cliservice
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/contracts"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrMultisigTransactionMismatch = errors.New("multisig transactions differ")
+
+// MultisigSignature is a signature of a single owner of the multisig smart account
+type MultisigSignature struct {
+	Signer    ethcommon.Address `json:"signer"`
+	Signature hexutil.Bytes     `json:"signature"`
+}
+
+// MultisigTransaction is a portable file used to collect owner signatures offline
+// before the transaction is submitted to the multisig smart account
+type MultisigTransaction struct {
+	Transaction types.ExternalTransaction `json:"transaction"`
+	SigningHash common.Hash               `json:"signingHash"`
+	Signatures  []MultisigSignature       `json:"signatures,omitempty"`
+}
+
+func NewMultisigTransaction(txn *types.ExternalTransaction) (*MultisigTransaction, error) {
+	hash, err := txn.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	return &MultisigTransaction{
+		Transaction: *txn,
+		SigningHash: hash,
+	}, nil
+}
+
+func ReadMultisigTransaction(path string) (*MultisigTransaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m MultisigTransaction
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse multisig transaction %s: %w", path, err)
+	}
+	if err := m.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid multisig transaction %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+func (m *MultisigTransaction) WriteToFile(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Verify checks that the signing hash matches the transaction and every signature matches its signer
+func (m *MultisigTransaction) Verify() error {
+	hash, err := m.Transaction.SigningHash()
+	if err != nil {
+		return err
+	}
+	if hash != m.SigningHash {
+		return fmt.Errorf("signing hash %s does not match the transaction (%s)", m.SigningHash, hash)
+	}
+	for _, sig := range m.Signatures {
+		signer, err := contracts.RecoverMultisigSigner(hash, sig.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature of %s: %w", sig.Signer, err)
+		}
+		if signer != sig.Signer {
+			return fmt.Errorf("signature of %s is made by %s", sig.Signer, signer)
+		}
+	}
+	return nil
+}
+
+func (m *MultisigTransaction) addSignature(sig MultisigSignature) {
+	for i := range m.Signatures {
+		if m.Signatures[i].Signer == sig.Signer {
+			m.Signatures[i] = sig
+			return
+		}
+	}
+	m.Signatures = append(m.Signatures, sig)
+}
+
+// Sign adds the signature of the key owner, replacing the previous one if any
+func (m *MultisigTransaction) Sign(key *ecdsa.PrivateKey) (ethcommon.Address, error) {
+	if err := m.Verify(); err != nil {
+		return ethcommon.Address{}, err
+	}
+	signature, err := crypto.Sign(m.SigningHash.Bytes(), key)
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+	signer := contracts.MultisigOwner(&key.PublicKey)
+	m.addSignature(MultisigSignature{Signer: signer, Signature: signature})
+	return signer, nil
+}
+
+// Merge adds the signatures collected for the same transaction in another file
+func (m *MultisigTransaction) Merge(other *MultisigTransaction) error {
+	if m.SigningHash != other.SigningHash || m.Transaction.Hash() != other.Transaction.Hash() {
+		return ErrMultisigTransactionMismatch
+	}
+	for _, sig := range other.Signatures {
+		m.addSignature(sig)
+	}
+	return nil
+}
+
+func (m *MultisigTransaction) signatures() [][]byte {
+	res := make([][]byte, len(m.Signatures))
+	for i, sig := range m.Signatures {
+		res[i] = sig.Signature
+	}
+	return res
+}
+
+// CreateMultisigSmartAccount deploys a smart account controlled by `threshold` of the `owners`
+func (s *Service) CreateMultisigSmartAccount(
+	shardId types.ShardId,
+	salt *types.Uint256,
+	balance types.Value,
+	fee types.FeePack,
+	owners []ethcommon.Address,
+	threshold uint64,
+) (types.Address, error) {
+	smartAccountCode, err := contracts.PrepareMultisigSmartAccountCode(owners, threshold)
+	if err != nil {
+		return types.EmptyAddress, err
+	}
+	return s.deploySmartAccount(shardId, salt, balance, fee, smartAccountCode)
+}
+
+// PrepareMultisigTransaction creates an unsigned transaction of the multisig smart account
+// calling the contract with the given calldata
+func (s *Service) PrepareMultisigTransaction(
+	smartAccount types.Address,
+	calldata []byte,
+	fee types.FeePack,
+	value types.Value,
+	tokens []types.TokenBalance,
+	contract types.Address,
+) (*MultisigTransaction, error) {
+	payload, err := client.CreateInternalTransactionPayload(calldata, value, tokens, contract, false)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := client.CreateExternalTransaction(s.ctx, s.client, payload, smartAccount, fee, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	return NewMultisigTransaction(txn)
+}
+
+// GetMultisigOwners returns the owners and the threshold of the multisig smart account
+func (s *Service) GetMultisigOwners(smartAccount types.Address) ([]ethcommon.Address, uint64, error) {
+	call := func(method string) (any, error) {
+		calldata, err := contracts.NewCallData(contracts.NameMultisigSmartAccount, method)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.CallContract(smartAccount, types.NewFeePackFromGas(10_000_000), calldata, nil)
+		if err != nil {
+			return nil, err
+		}
+		if res.Error != "" {
+			return nil, fmt.Errorf("failed to call %s: %s", method, res.Error)
+		}
+		unpacked, err := contracts.UnpackData(contracts.NameMultisigSmartAccount, method, res.Data)
+		if err != nil {
+			return nil, err
+		}
+		if len(unpacked) != 1 {
+			return nil, fmt.Errorf("unexpected result of %s", method)
+		}
+		return unpacked[0], nil
+	}
+
+	owners, err := call("getOwners")
+	if err != nil {
+		return nil, 0, err
+	}
+	threshold, err := call("threshold")
+	if err != nil {
+		return nil, 0, err
+	}
+	ownersList, ok := owners.([]ethcommon.Address)
+	if !ok {
+		return nil, 0, errors.New("unexpected owners type")
+	}
+	thresholdValue, ok := threshold.(*big.Int)
+	if !ok || !thresholdValue.IsUint64() {
+		return nil, 0, errors.New("unexpected threshold value")
+	}
+	return ownersList, thresholdValue.Uint64(), nil
+}
+
+// SubmitMultisigTransaction attaches the collected signatures to the transaction and sends it
+func (s *Service) SubmitMultisigTransaction(m *MultisigTransaction) (common.Hash, error) {
+	if err := m.Verify(); err != nil {
+		return common.EmptyHash, err
+	}
+	txn := m.Transaction
+	owners, threshold, err := s.GetMultisigOwners(txn.To)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+	for _, sig := range m.Signatures {
+		if !slices.Contains(owners, sig.Signer) {
+			s.logger.Warn().Stringer("signer", sig.Signer).Msg("Signer is not an owner of the smart account")
+		}
+	}
+	txn.AuthData, err = contracts.EncodeMultisigAuthData(m.SigningHash, m.signatures(), owners, threshold)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	txHash, err := s.client.SendTransaction(s.ctx, &txn)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to send multisig transaction")
+		return common.EmptyHash, err
+	}
+	s.logger.Info().
+		Stringer(logging.FieldShardId, txn.To.ShardId()).
+		Stringer(logging.FieldTransactionHash, txHash).
+		Send()
+	return txHash, nil
+}
@@ -0,0 +1,85 @@
+package cliservice
+
+import (
+	"crypto/ecdsa"
+	"path/filepath"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/contracts"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigTransaction(t *testing.T) {
+	t.Parallel()
+
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owners := []ethcommon.Address{
+		contracts.MultisigOwner(&key1.PublicKey),
+		contracts.MultisigOwner(&key2.PublicKey),
+	}
+
+	m, err := NewMultisigTransaction(&types.ExternalTransaction{
+		Kind:    types.ExecutionTransactionKind,
+		FeePack: types.NewFeePackFromGas(100_000),
+		To:      types.GenerateRandomAddress(types.BaseShardId),
+		Seqno:   5,
+		Data:    types.Code("payload"),
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "tx1.json")
+	file2 := filepath.Join(dir, "tx2.json")
+	require.NoError(t, m.WriteToFile(file1))
+	require.NoError(t, m.WriteToFile(file2))
+
+	sign := func(path string, keys ...*ecdsa.PrivateKey) {
+		t.Helper()
+
+		m, err := ReadMultisigTransaction(path)
+		require.NoError(t, err)
+		for _, key := range keys {
+			_, err := m.Sign(key)
+			require.NoError(t, err)
+		}
+		require.NoError(t, m.WriteToFile(path))
+	}
+	// signing twice replaces the previous signature
+	sign(file1, key1, key1)
+	sign(file2, key2)
+
+	m1, err := ReadMultisigTransaction(file1)
+	require.NoError(t, err)
+	require.Len(t, m1.Signatures, 1)
+	m2, err := ReadMultisigTransaction(file2)
+	require.NoError(t, err)
+	require.NoError(t, m1.Merge(m2))
+	require.Len(t, m1.Signatures, 2)
+
+	authData, err := contracts.EncodeMultisigAuthData(m1.SigningHash, m1.signatures(), owners, 2)
+	require.NoError(t, err)
+	require.Len(t, authData, 2*crypto.SignatureLength)
+
+	t.Run("Mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		other := *m2
+		other.Transaction.Seqno++
+		require.Error(t, other.Verify())
+		require.ErrorIs(t, m1.Merge(&other), ErrMultisigTransactionMismatch)
+	})
+
+	t.Run("ForgedSigner", func(t *testing.T) {
+		t.Parallel()
+
+		forged := *m2
+		forged.Signatures = []MultisigSignature{{Signer: owners[0], Signature: m2.Signatures[0].Signature}}
+		require.Error(t, forged.Verify())
+	})
+}
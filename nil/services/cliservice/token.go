@@ -1,9 +1,11 @@
 package cliservice
 
 import (
+	"github.com/NilFoundation/nil/nil/client/rpc"
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
 )
 
 func (s *Service) handleTokenTx(txHash common.Hash, contractAddr types.Address) error {
@@ -20,10 +22,20 @@ func (s *Service) handleTokenTx(txHash common.Hash, contractAddr types.Address)
 	return nil
 }
 
-func (s *Service) TokenCreate(contractAddr types.Address, amount types.Value, name string) (*types.TokenId, error) {
-	txHash, err := s.client.SetTokenName(s.ctx, contractAddr, name, s.privateKey)
+// TokenMetadata holds the token properties recorded by the minter on token creation.
+type TokenMetadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+func (s *Service) TokenCreate(
+	contractAddr types.Address, amount types.Value, metadata TokenMetadata,
+) (*types.TokenId, error) {
+	txHash, err := s.client.SetTokenMetadata(
+		s.ctx, contractAddr, metadata.Name, metadata.Symbol, metadata.Decimals, s.privateKey)
 	if err != nil {
-		s.logger.Error().Err(err).Msg("Failed to send setTokenName transaction")
+		s.logger.Error().Err(err).Msg("Failed to send setTokenMetadata transaction")
 		return nil, err
 	}
 	if err = s.handleTokenTx(txHash, contractAddr); err != nil {
@@ -40,7 +52,9 @@ func (s *Service) TokenCreate(contractAddr types.Address, amount types.Value, na
 	}
 
 	tokenId := types.TokenIdForAddress(contractAddr)
-	s.logger.Info().Stringer(logging.FieldTokenId, common.BytesToHash(tokenId[:])).Msgf("Created %v:%v", name, amount)
+	s.logger.Info().
+		Stringer(logging.FieldTokenId, common.BytesToHash(tokenId[:])).
+		Msgf("Created %v (%v):%v", metadata.Name, metadata.Symbol, amount)
 	return tokenId, nil
 }
 
@@ -62,3 +76,29 @@ func (s *Service) ChangeTokenAmount(contractAddr types.Address, amount types.Val
 	s.logger.Info().Stringer(logging.FieldTokenId, common.BytesToHash(tokenId[:])).Msgf("%s %v", operation, amount)
 	return txHash, nil
 }
+
+func (s *Service) GetTokenInfo(tokenId types.TokenId) (*jsonrpc.RPCTokenInfo, error) {
+	info, err := s.client.GetTokenInfo(s.ctx, tokenId)
+	if err != nil {
+		s.logger.Error().Err(err).Str(logging.FieldRpcMethod, rpc.Eth_getTokenInfo).Msg("Failed to get token info")
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *Service) GetTokenHolders(tokenId types.TokenId, page uint64) ([]types.TokenHolder, error) {
+	holders, err := s.client.GetTokenHolders(s.ctx, tokenId, page)
+	if err != nil {
+		s.logger.Error().Err(err).Str(logging.FieldRpcMethod, rpc.Eth_getTokenHolders).Msg("Failed to get token holders")
+		return nil, err
+	}
+	return holders, nil
+}
+
+// GetTokenSymbol returns the symbol of the token or an empty string if the token has no metadata.
+func (s *Service) GetTokenSymbol(tokenId types.TokenId) string {
+	if info, err := s.client.GetTokenInfo(s.ctx, tokenId); err == nil && info.Symbol != "" {
+		return info.Symbol
+	}
+	return types.GetTokenName(tokenId)
+}
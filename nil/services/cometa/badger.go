@@ -49,7 +49,7 @@ func (s *StorageBadger) StoreContract(ctx context.Context, contractData *Contrac
 		return err
 	}
 
-	codehash := types.Code(contractData.Code).Hash()
+	codehash := contractData.CodeHash()
 	if err = tx.Set(makeKey(TablePrefixCometaCodeHash, codehash.Bytes()), address.Bytes()); err != nil {
 		logger.Error().Err(err).Msg("failed to write to codehash table")
 	}
@@ -0,0 +1,47 @@
+package cometa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BuildInfo is a compilation record produced by Hardhat (artifacts/build-info) or Foundry (out/build-info).
+// It holds both the standard JSON input and output of solc, so the contract data is created without recompiling.
+type BuildInfo struct {
+	Format      string              `json:"_format,omitempty"` //nolint:tagliatelle
+	SolcVersion string              `json:"solcVersion,omitempty"`
+	Input       *CompilerJsonInput  `json:"input"`
+	Output      *CompilerJsonOutput `json:"output"`
+}
+
+// ContractDataFromBuildInfo creates the data of the contract from the build info.
+// The contract name has the same format as in the compiler task: <file>:<contract>.
+func ContractDataFromBuildInfo(buildInfoJson string, contractName string) (*ContractData, error) {
+	var buildInfo BuildInfo
+	if err := json.Unmarshal([]byte(buildInfoJson), &buildInfo); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal build info: %w", err)
+	}
+	if buildInfo.Input == nil || buildInfo.Output == nil {
+		return nil, errors.New("build info must contain both compiler input and output")
+	}
+	if buildInfo.Input.Language != "" && buildInfo.Input.Language != LanguageSolidity {
+		return nil, fmt.Errorf("unsupported build info language: %s", buildInfo.Input.Language)
+	}
+	// The sources are not read from the file system, since the build info may come from a remote user
+	for name, source := range buildInfo.Input.Sources {
+		if len(source.Content) == 0 {
+			return nil, fmt.Errorf("source %s has no content", name)
+		}
+	}
+	if err := checkCompilerErrors(buildInfo.Output.Errors); err != nil {
+		return nil, err
+	}
+
+	contractData, err := CreateContractData(contractName, buildInfo.Input, buildInfo.Output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contract info: %w", err)
+	}
+	contractData.Name = contractName
+	return contractData, nil
+}
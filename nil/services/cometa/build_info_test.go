@@ -0,0 +1,65 @@
+package cometa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBuildInfo = `{
+	"_format": "hh-sol-build-info-1",
+	"solcVersion": "0.8.28",
+	"input": {
+		"language": "Solidity",
+		"sources": {
+			"contracts/Counter.sol": {"content": "contract Counter { function inc() public {} }"}
+		},
+		"settings": {"optimizer": {"enabled": false, "runs": 200}}
+	},
+	"output": {
+		"sources": {"contracts/Counter.sol": {"id": 0}},
+		"contracts": {
+			"contracts/Counter.sol": {
+				"Counter": {
+					"abi": [{"type": "function", "name": "inc", "inputs": [], "outputs": []}],
+					"metadata": "{\"compiler\":{\"version\":\"0.8.28\"},\"language\":\"Solidity\"}",
+					"evm": {
+						"bytecode": {"object": "6080"},
+						"deployedBytecode": {
+							"object": "60805b00",
+							"sourceMap": "0:46:0;19:24:0;",
+							"functionDebugData": {"@inc_5": {"entryPoint": 2}}
+						},
+						"methodIdentifiers": {"inc()": "371303c0"}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestContractDataFromBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	data, err := ContractDataFromBuildInfo(testBuildInfo, "contracts/Counter.sol:Counter")
+	require.NoError(t, err)
+	require.Equal(t, "contracts/Counter.sol:Counter", data.Name)
+	require.Equal(t, []byte{0x60, 0x80, 0x5b, 0x00}, data.Code)
+	require.Equal(t, []string{"contracts/Counter.sol"}, data.SourceFilesList)
+	require.Equal(t, "0:46:0;19:24:0;", data.SourceMap)
+	require.Equal(t, []FunctionDebugItem{{Name: "@inc_5", EntryPoint: 2}}, data.FunctionDebugData)
+	require.Equal(t, "371303c0", data.MethodIdentifiers["inc()"])
+
+	_, err = ContractDataFromBuildInfo(testBuildInfo, "contracts/Counter.sol:Other")
+	require.Error(t, err)
+
+	_, err = ContractDataFromBuildInfo(`{"input": {"language": "Solidity"}}`, "contracts/Counter.sol:Counter")
+	require.Error(t, err)
+
+	// The sources given by urls are not read from the file system
+	_, err = ContractDataFromBuildInfo(`{
+		"input": {"language": "Solidity", "sources": {"a.sol": {"urls": ["/etc/passwd"]}}},
+		"output": {}
+	}`, "a.sol:A")
+	require.ErrorContains(t, err, "has no content")
+}
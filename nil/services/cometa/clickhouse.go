@@ -85,7 +85,7 @@ func (s *StorageClick) StoreContract(ctx context.Context, contractData *Contract
 	err = s.insertConn.Exec(ctx, `INSERT INTO contracts_metadata
     	(address, data_json, code_hash, abi, source_code, version)
 		VALUES ($1, $2, $3, $4, $5, $6)`,
-		string(address.Bytes()), string(data), string(types.Code(contractData.Code).Hash().Bytes()), contractData.Abi,
+		string(address.Bytes()), string(data), string(contractData.CodeHash().Bytes()), contractData.Abi,
 		contractData.SourceCode, SchemaVersion)
 	if err != nil {
 		return fmt.Errorf("failed to insert contract data: %w", err)
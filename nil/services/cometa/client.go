@@ -14,6 +14,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common/version"
 	"github.com/NilFoundation/nil/nil/internal/abi"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/transport"
 )
 
 type Client struct {
@@ -89,6 +90,24 @@ func (c *Client) GetContractFields(ctx context.Context, address types.Address, f
 	return res, nil
 }
 
+func (c *Client) GetStorageVariables(
+	ctx context.Context, address types.Address, paths []string, blockId any,
+) ([]*StorageValue, error) {
+	blockRef, err := transport.AsBlockReference(blockId)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.sendRequest(ctx, "cometa_getStorageVariables", []any{address, paths, blockRef})
+	if err != nil {
+		return nil, err
+	}
+	var res []*StorageValue
+	if err := json.Unmarshal(response, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage variables: %w", err)
+	}
+	return res, nil
+}
+
 func (c *Client) GetLocation(ctx context.Context, address types.Address, pc uint64) (*Location, error) {
 	response, err := c.sendRequest(ctx, "cometa_getLocation", []any{address, pc})
 	if err != nil {
@@ -170,6 +189,13 @@ func (c *Client) RegisterContract(ctx context.Context, inputJson string, address
 	return err
 }
 
+func (c *Client) RegisterContractBuildInfo(
+	ctx context.Context, buildInfoJson string, contractName string, address types.Address,
+) error {
+	_, err := c.sendRequest(ctx, "cometa_registerContractBuildInfo", []any{buildInfoJson, contractName, address})
+	return err
+}
+
 func (c *Client) RegisterContractData(ctx context.Context, contractData *ContractData, address types.Address) error {
 	_, err := c.sendRequest(ctx, "cometa_registerContractData", []any{contractData, address})
 	return err
@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/fabelx/go-solc-select/pkg/config"
 	"github.com/fabelx/go-solc-select/pkg/installer"
@@ -56,6 +58,30 @@ type ContractData struct {
 
 	// MethodIdentifiers holds a map of method identifiers: {signature -> methodId}. E.g. "test(uint256)": "29e99f07"
 	MethodIdentifiers map[string]string `json:"methodIdentifiers,omitempty"`
+
+	// StorageLayout holds locations of the state variables in the contract storage.
+	// It is absent for the contracts registered before it was stored, or compiled without requesting it.
+	StorageLayout *StorageLayout `json:"storageLayout,omitempty"`
+
+	// ImmutableReferences holds locations of the immutable variables in the runtime bytecode: {astId -> locations}.
+	ImmutableReferences map[string][]ImmutableReference `json:"immutableReferences,omitempty"`
+
+	// VerificationStatus holds how the code deployed at the registered address matches the compiled one.
+	VerificationStatus VerificationStatus `json:"verificationStatus,omitempty"`
+
+	// DeployedCodeHash holds the hash of the code deployed at the registered address. It differs from the hash of
+	// Code if the contract has immutable variables or the metadata differs. Contracts with the same deployed code
+	// share the data.
+	DeployedCodeHash common.Hash `json:"deployedCodeHash,omitempty"`
+}
+
+// CodeHash returns the hash the data is indexed by.
+// It is the hash of the deployed code, or of the compiled one for the data stored before the verification.
+func (d *ContractData) CodeHash() common.Hash {
+	if !d.DeployedCodeHash.Empty() {
+		return d.DeployedCodeHash
+	}
+	return types.Code(d.Code).Hash()
 }
 
 func NewCompilerTask(inputJson string) (*CompilerTask, error) {
@@ -74,14 +100,34 @@ func CompileJson(inputJson string) (*ContractData, error) {
 	return Compile(input)
 }
 
+// Compiler builds the contract data from the sources of the compiler task.
+type Compiler interface {
+	Compile(input *CompilerTask) (*ContractData, error)
+}
+
+// NewCompiler returns the compiler of the language, Solidity is used if it is not set.
+func NewCompiler(language string) (Compiler, error) {
+	switch language {
+	case "", LanguageSolidity:
+		return &solcCompiler{}, nil
+	case LanguageVyper:
+		return &vyperCompiler{}, nil
+	}
+	return nil, fmt.Errorf("unsupported language: %s", language)
+}
+
 func Compile(input *CompilerTask) (*ContractData, error) {
-	logger.Info().Msg("Start contract compilation...")
-	dir, err := os.MkdirTemp("/tmp", "compilation_")
+	compiler, err := NewCompiler(input.Language)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+		return nil, err
 	}
-	defer os.RemoveAll(dir)
+	logger.Info().Msg("Start contract compilation...")
+	return compiler.Compile(input)
+}
+
+type solcCompiler struct{}
 
+func (c *solcCompiler) Compile(input *CompilerTask) (*ContractData, error) {
 	solc, err := findCompiler(input.CompilerVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find compiler: %w", err)
@@ -95,21 +141,8 @@ func Compile(input *CompilerTask) (*ContractData, error) {
 		}
 	}
 
-	compilerInputData, err := json.MarshalIndent(compilerInput, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal compiler input: %w", err)
-	}
-
-	inputFile := dir + "/input.json"
-	if err = os.WriteFile(inputFile, compilerInputData, 0o600); err != nil {
-		return nil, fmt.Errorf("failed to write input file: %w", err)
-	}
-
-	args := []string{"--standard-json", inputFile, "--pretty-json"}
-	cmd := exec.Command(solc, args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runStandardJson(solc, compilerInput, "--pretty-json")
 	if err != nil {
-		logger.Error().Msgf("Compilation failed:\n%s\n", output)
 		return nil, err
 	}
 
@@ -118,17 +151,8 @@ func Compile(input *CompilerTask) (*ContractData, error) {
 		logger.Error().Err(err).Msg("Failed to unmarshal json")
 		return nil, err
 	}
-	if len(outputJson.Errors) != 0 {
-		for _, e := range outputJson.Errors {
-			if e.Severity == "error" {
-				errMsg, err := json.MarshalIndent(outputJson.Errors, "", "  ")
-				if err != nil {
-					errMsg = []byte("failed to marshal errors: " + err.Error())
-				}
-				logger.Error().Msgf("Compilation failed:\n%s\n", errMsg)
-				return nil, errors.New(string(errMsg))
-			}
-		}
+	if err := checkCompilerErrors(outputJson.Errors); err != nil {
+		return nil, err
 	}
 
 	contractData, err := CreateContractData(input.ContractName, compilerInput, &outputJson)
@@ -140,6 +164,48 @@ func Compile(input *CompilerTask) (*ContractData, error) {
 	return contractData, nil
 }
 
+// runStandardJson passes the input to the compiler in the standard JSON format and returns its output.
+func runStandardJson(compiler string, input any, args ...string) ([]byte, error) {
+	dir, err := os.MkdirTemp("/tmp", "compilation_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	compilerInputData, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compiler input: %w", err)
+	}
+
+	inputFile := dir + "/input.json"
+	if err = os.WriteFile(inputFile, compilerInputData, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write input file: %w", err)
+	}
+
+	cmd := exec.Command(compiler, append([]string{"--standard-json", inputFile}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		logger.Error().Msgf("Compilation failed:\n%s\n", output)
+		return nil, err
+	}
+	return output, nil
+}
+
+// checkCompilerErrors returns all the compiler messages as an error if any of them is an error.
+func checkCompilerErrors(compilerErrors []CompilerOutputError) error {
+	for _, e := range compilerErrors {
+		if e.Severity == "error" {
+			errMsg, err := json.MarshalIndent(compilerErrors, "", "  ")
+			if err != nil {
+				errMsg = []byte("failed to marshal errors: " + err.Error())
+			}
+			logger.Error().Msgf("Compilation failed:\n%s\n", errMsg)
+			return errors.New(string(errMsg))
+		}
+	}
+	return nil
+}
+
 func CreateContractData(
 	contractFullName string,
 	input *CompilerJsonInput,
@@ -243,6 +309,8 @@ func CreateContractData(
 	}
 	contractData.Abi = string(abiJson)
 	contractData.MethodIdentifiers = contractDescr.Evm.MethodIdentifiers
+	contractData.StorageLayout = contractDescr.StorageLayout
+	contractData.ImmutableReferences = contractDescr.Evm.DeployedBytecode.ImmutableReferences
 
 	return contractData, nil
 }
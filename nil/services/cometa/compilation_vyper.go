@@ -0,0 +1,276 @@
+package cometa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+type vyperCompiler struct{}
+
+// vyperJsonInput represents the input structure for the vyper compiler.
+type vyperJsonInput struct {
+	Language string             `json:"language"`
+	Sources  map[string]*Source `json:"sources"`
+	Settings vyperSettings      `json:"settings"`
+}
+
+type vyperSettings struct {
+	EvmVersion      string              `json:"evmVersion,omitempty"`
+	Optimize        string              `json:"optimize,omitempty"`
+	OutputSelection map[string][]string `json:"outputSelection"`
+}
+
+// vyperJsonOutput represents the output structure of the vyper compiler.
+type vyperJsonOutput struct {
+	Compiler  string                                    `json:"compiler"`
+	Errors    []CompilerOutputError                     `json:"errors"`
+	Sources   map[string]CompilerOutputSource           `json:"sources"`
+	Contracts map[string]map[string]vyperOutputContract `json:"contracts"`
+}
+
+type vyperOutputContract struct {
+	Abi []any `json:"abi"`
+	Evm struct {
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+		DeployedBytecode struct {
+			Object string `json:"object"`
+			// SourceMap is a string in the solc format in the old versions,
+			// the newer ones return an object with the compressed map in pc_pos_map_compressed.
+			SourceMap json.RawMessage `json:"sourceMap"`
+		} `json:"deployedBytecode"`
+		MethodIdentifiers map[string]string `json:"methodIdentifiers"`
+	} `json:"evm"`
+}
+
+// vyperAstNode is a part of the vyper AST enough to find the functions.
+type vyperAstNode struct {
+	AstType string         `json:"ast_type"` //nolint:tagliatelle
+	Name    string         `json:"name"`
+	Src     string         `json:"src"`
+	Body    []vyperAstNode `json:"body"`
+}
+
+func (c *vyperCompiler) Compile(input *CompilerTask) (*ContractData, error) {
+	vyper, err := findVyperCompiler(input.CompilerVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find compiler: %w", err)
+	}
+	if err := input.CheckResolved(); err != nil {
+		return nil, err
+	}
+
+	fileName, contractName, ok := strings.Cut(input.ContractName, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid contract name: %s, required format: <file>:<contract>", input.ContractName)
+	}
+
+	compilerInput := &vyperJsonInput{
+		Language: LanguageVyper,
+		Sources:  input.Sources,
+		Settings: vyperSettings{
+			EvmVersion: input.Settings.EvmVersion,
+			Optimize:   "none",
+			OutputSelection: map[string][]string{
+				fileName: {
+					"abi",
+					"ast",
+					"evm.bytecode.object",
+					"evm.deployedBytecode.object",
+					"evm.deployedBytecode.sourceMap",
+					"evm.methodIdentifiers",
+				},
+			},
+		},
+	}
+	if input.Settings.Optimizer.Enabled {
+		compilerInput.Settings.Optimize = "gas"
+	}
+
+	output, err := runStandardJson(vyper, compilerInput)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputJson vyperJsonOutput
+	if err := json.Unmarshal(output, &outputJson); err != nil {
+		logger.Error().Err(err).Msg("Failed to unmarshal json")
+		return nil, err
+	}
+	if err := checkCompilerErrors(outputJson.Errors); err != nil {
+		return nil, err
+	}
+
+	contract, ok := outputJson.Contracts[fileName][contractName]
+	if !ok {
+		return nil, errors.New("contract not found in compilation output")
+	}
+
+	contractData := &ContractData{
+		Name:              input.ContractName,
+		SourceCode:        make(map[string]string, len(input.Sources)),
+		SourceFilesList:   make([]string, len(outputJson.Sources)),
+		InitCode:          ethcommon.FromHex(contract.Evm.Bytecode.Object),
+		Code:              ethcommon.FromHex(contract.Evm.DeployedBytecode.Object),
+		MethodIdentifiers: make(map[string]string, len(contract.Evm.MethodIdentifiers)),
+	}
+	if len(contractData.Code) == 0 {
+		return nil, errors.New("evm.deployedBytecode.object is required")
+	}
+	for name, source := range input.Sources {
+		contractData.SourceCode[name] = source.Content
+	}
+	for name, source := range outputJson.Sources {
+		if source.Id < 0 || source.Id >= len(contractData.SourceFilesList) {
+			return nil, fmt.Errorf("invalid id of source %s", name)
+		}
+		contractData.SourceFilesList[source.Id] = name
+	}
+	// Method identifiers are stored without the prefix, like the solc ones
+	for signature, id := range contract.Evm.MethodIdentifiers {
+		contractData.MethodIdentifiers[signature] = strings.TrimPrefix(id, "0x")
+	}
+
+	if contractData.SourceMap, err = parseVyperSourceMap(contract.Evm.DeployedBytecode.SourceMap); err != nil {
+		return nil, err
+	}
+
+	abiJson, err := json.Marshal(contract.Abi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal abi: %w", err)
+	}
+	contractData.Abi = string(abiJson)
+
+	// Vyper has no solc-like metadata, so only the fields used by Cometa are filled
+	metadata, err := json.Marshal(&Metadata{
+		Compiler: CompilerVersion{Version: strings.TrimPrefix(outputJson.Compiler, "vyper-")},
+		Language: LanguageVyper,
+		Settings: MetadataSettings{
+			CompilationTarget: map[string]string{fileName: contractName},
+			EvmVersion:        input.Settings.EvmVersion,
+			Optimizer:         input.Settings.Optimizer,
+		},
+		Version: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	contractData.Metadata = string(metadata)
+
+	if err := fillVyperFunctionDebugData(contractData, outputJson.Sources[fileName]); err != nil {
+		return nil, fmt.Errorf("failed to find functions: %w", err)
+	}
+
+	return contractData, nil
+}
+
+func parseVyperSourceMap(data json.RawMessage) (string, error) {
+	var sourceMap string
+	if err := json.Unmarshal(data, &sourceMap); err == nil {
+		return sourceMap, nil
+	}
+	var maps struct {
+		PcPosMapCompressed string `json:"pc_pos_map_compressed"` //nolint:tagliatelle
+	}
+	if err := json.Unmarshal(data, &maps); err != nil || len(maps.PcPosMapCompressed) == 0 {
+		return "", errors.New("source map not found")
+	}
+	return maps.PcPosMapCompressed, nil
+}
+
+// fillVyperFunctionDebugData finds the entry points of the contract functions, since vyper doesn't report them.
+// The entry point of a function is the first instruction mapped into the function source.
+func fillVyperFunctionDebugData(data *ContractData, source CompilerOutputSource) error {
+	astData, err := json.Marshal(source.Ast)
+	if err != nil {
+		return err
+	}
+	var ast vyperAstNode
+	if err := json.Unmarshal(astData, &ast); err != nil {
+		return err
+	}
+
+	type span struct {
+		name       string
+		start, end int
+	}
+	var functions []span
+	for _, node := range ast.Body {
+		if node.AstType != "FunctionDef" {
+			continue
+		}
+		parts := strings.Split(node.Src, ":")
+		if len(parts) < 2 {
+			return fmt.Errorf("invalid location of function %s", node.Name)
+		}
+		start, err1 := strconv.Atoi(parts[0])
+		length, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("invalid location of function %s", node.Name)
+		}
+		functions = append(functions, span{name: node.Name, start: start, end: start + length})
+	}
+
+	contract := &Contract{Data: data}
+	if err := contract.decodeSourceMap(); err != nil {
+		return err
+	}
+
+	entryPoints := make(map[string]int)
+	for pc, inst := range contract.bytecode2inst {
+		if inst >= len(contract.sourceMap) {
+			break
+		}
+		loc := contract.sourceMap[inst]
+		for _, f := range functions {
+			if _, ok := entryPoints[f.name]; !ok && loc.StartPos >= f.start && loc.StartPos < f.end {
+				entryPoints[f.name] = pc
+			}
+		}
+	}
+
+	data.FunctionDebugData = data.FunctionDebugData[:0]
+	for name, entryPoint := range entryPoints {
+		data.FunctionDebugData = append(data.FunctionDebugData, FunctionDebugItem{Name: name, EntryPoint: entryPoint})
+	}
+	sort.Slice(data.FunctionDebugData, func(i, j int) bool {
+		return data.FunctionDebugData[i].EntryPoint < data.FunctionDebugData[j].EntryPoint
+	})
+	return nil
+}
+
+// findVyperCompiler looks for the compiler of the version in PATH, either as `vyper-<version>` or `vyper`.
+// Unlike solc, vyper is not installed automatically.
+func findVyperCompiler(version string) (string, error) {
+	if len(version) > 0 {
+		if path, err := exec.LookPath("vyper-" + version); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("vyper")
+	if err != nil {
+		return "", fmt.Errorf("vyper is not installed: %w", err)
+	}
+	if len(version) == 0 {
+		return path, nil
+	}
+
+	output, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get vyper version: %w", err)
+	}
+	// The version is printed as 0.3.10+commit.91361694
+	installed, _, _ := strings.Cut(strings.TrimSpace(string(output)), "+")
+	if installed != version {
+		return "", fmt.Errorf("vyper %s is required, but %s is installed", version, installed)
+	}
+	return path, nil
+}
@@ -0,0 +1,66 @@
+package cometa
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVyperSourceMap(t *testing.T) {
+	t.Parallel()
+
+	sourceMap, err := parseVyperSourceMap(json.RawMessage(`"1:2:0;3:4:0"`))
+	require.NoError(t, err)
+	require.Equal(t, "1:2:0;3:4:0", sourceMap)
+
+	sourceMap, err = parseVyperSourceMap(json.RawMessage(`{"pc_pos_map_compressed": "1:2:0;3:4:0"}`))
+	require.NoError(t, err)
+	require.Equal(t, "1:2:0;3:4:0", sourceMap)
+
+	_, err = parseVyperSourceMap(json.RawMessage(`{}`))
+	require.Error(t, err)
+}
+
+func TestFillVyperFunctionDebugData(t *testing.T) {
+	t.Parallel()
+
+	// @external
+	// def foo():
+	//     pass
+	//
+	// @external
+	// def bar():
+	//     pass
+	source := CompilerOutputSource{
+		Ast: map[string]any{
+			"ast_type": "Module",
+			"body": []any{
+				map[string]any{"ast_type": "FunctionDef", "name": "foo", "src": "0:30:0"},
+				map[string]any{"ast_type": "FunctionDef", "name": "bar", "src": "32:30:0"},
+			},
+		},
+	}
+	// PUSH1 0; JUMPDEST; STOP; JUMPDEST; STOP
+	data := &ContractData{
+		Code:      []byte{0x60, 0x00, 0x5b, 0x00, 0x5b, 0x00},
+		SourceMap: "70:5:0;20:4:0;;50:4:0;",
+	}
+
+	require.NoError(t, fillVyperFunctionDebugData(data, source))
+	require.Equal(t, []FunctionDebugItem{
+		{Name: "foo", EntryPoint: 2},
+		{Name: "bar", EntryPoint: 4},
+	}, data.FunctionDebugData)
+}
+
+func TestNewCompiler(t *testing.T) {
+	t.Parallel()
+
+	for _, language := range []string{"", LanguageSolidity, LanguageVyper} {
+		_, err := NewCompiler(language)
+		require.NoError(t, err, language)
+	}
+	_, err := NewCompiler("Yul")
+	require.Error(t, err)
+}
@@ -1,7 +1,6 @@
 package cometa
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -17,6 +16,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/rpc/httpcfg"
 	"github.com/NilFoundation/nil/nil/services/rpc/transport"
 	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/holiman/uint256"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
@@ -37,9 +37,15 @@ type CometaJsonRpc interface {
 	GetLocation(ctx context.Context, address types.Address, pc uint) (*Location, error)
 	GetAbi(ctx context.Context, address types.Address) (string, error)
 	GetSourceCode(ctx context.Context, address types.Address) (map[string]string, error)
+	GetStorageVariables(
+		ctx context.Context, address types.Address, paths []string, blockId transport.BlockNumberOrHash,
+	) ([]*StorageValue, error)
 	CompileContract(ctx context.Context, inputJson string) (*ContractData, error)
 	RegisterContract(ctx context.Context, inputJson string, address types.Address) error
 	RegisterContractData(ctx context.Context, contractData *ContractData, address types.Address) error
+	RegisterContractBuildInfo(
+		ctx context.Context, buildInfoJson string, contractName string, address types.Address,
+	) error
 	GetVersion(ctx context.Context) (string, error)
 	DecodeTransactionsCallData(ctx context.Context, request []TransactionInfo) ([]string, error)
 }
@@ -144,15 +150,27 @@ func (s *Service) RegisterContractData(ctx context.Context, contractData *Contra
 		return fmt.Errorf("contract does not exist at address %s", address)
 	}
 
-	if !bytes.Equal(code, contractData.Code) {
-		return errors.New("compiled bytecode is not equal to the deployed one")
+	status, err := VerifyCode(code, contractData)
+	if err != nil {
+		return err
+	}
+
+	// A perfect match proves the exact sources, so it is not replaced with the ones that only produce the same code
+	if status != VerificationPerfect {
+		if existing, err := s.storage.LoadContractData(ctx, address); err == nil &&
+			existing.VerificationStatus == VerificationPerfect {
+			return ErrAlreadyVerified
+		}
 	}
 
+	contractData.VerificationStatus = status
+	contractData.DeployedCodeHash = code.Hash()
 	if err = s.storage.StoreContract(ctx, contractData, address); err != nil {
 		return err
 	}
+	s.contractsCache.Remove(address)
 
-	logger.Info().Msg("Contract has been deployed.")
+	logger.Info().Str("status", string(status)).Msg("Contract has been verified.")
 
 	return nil
 }
@@ -169,6 +187,21 @@ func (s *Service) RegisterContract(ctx context.Context, inputJson string, addres
 	return err
 }
 
+// RegisterContractBuildInfo registers the contract built by Hardhat or Foundry without recompiling it.
+func (s *Service) RegisterContractBuildInfo(
+	ctx context.Context, buildInfoJson string, contractName string, address types.Address,
+) error {
+	contractData, err := ContractDataFromBuildInfo(buildInfoJson, contractName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.RegisterContractData(ctx, contractData, address); err != nil {
+		return fmt.Errorf("failed to register contract: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) CompileContract(ctx context.Context, inputJson string) (*ContractData, error) {
 	return CompileJson(inputJson)
 }
@@ -248,9 +281,13 @@ func (s *Service) FindContractWithSameCode(ctx context.Context, address types.Ad
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contract by code hash: %w", err)
 	}
-	if !bytes.Equal(contractData.Code, code) {
-		return nil, errors.New("contract not found")
+	// The twin inherits the sources only if they are verified against its own code
+	status, err := VerifyCode(code, contractData)
+	if err != nil {
+		return nil, err
 	}
+	contractData.VerificationStatus = status
+	contractData.DeployedCodeHash = code.Hash()
 	return contractData, nil
 }
 
@@ -290,6 +327,40 @@ func (s *Service) GetSourceCode(ctx context.Context, address types.Address) (map
 	return contract.Data.SourceCode, nil
 }
 
+// GetStorageVariables decodes the storage variables by their paths at the given block.
+// If no paths are given, all the top-level variables are decoded.
+func (s *Service) GetStorageVariables(
+	ctx context.Context, address types.Address, paths []string, blockId transport.BlockNumberOrHash,
+) ([]*StorageValue, error) {
+	contract, err := s.GetContractControl(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	layout := contract.Data.StorageLayout
+	if layout == nil {
+		return nil, ErrStorageLayoutNotFound
+	}
+
+	read := func(slot common.Hash) (common.Hash, error) {
+		value, err := s.client.GetStorageAt(ctx, address, slot, blockId)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to get storage: %w", err)
+		}
+		return (*uint256.Int)(&value).Bytes32(), nil
+	}
+
+	if len(paths) == 0 {
+		return layout.ReadVariables(read)
+	}
+	res := make([]*StorageValue, len(paths))
+	for i, path := range paths {
+		if res[i], err = layout.ReadVariable(path, read); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	return res, nil
+}
+
 func (s *Service) GetSourceCodeForFile(ctx context.Context, address types.Address, fileName string) (string, error) {
 	sourceCode, err := s.GetSourceCode(ctx, address)
 	if err != nil {
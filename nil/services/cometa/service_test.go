@@ -162,6 +162,82 @@ func (s *SuiteServiceTest) TestTwinContracts() {
 	s.Require().Equal(contract, contract2)
 }
 
+// TestVerification checks that the registered code is verified against the deployed one,
+// and the verified sources are shared by the contracts with the same code
+func (s *SuiteServiceTest) TestVerification() {
+	runtime := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x7f, 0, 0, 0, 0, 0x50, 0x00}
+	newContractData := func(metadataHash byte) *ContractData {
+		return &ContractData{
+			Name:                "Test.sol:Test",
+			Abi:                 "[]",
+			Metadata:            "{}",
+			Code:                withMetadata(runtime, metadataHash),
+			ImmutableReferences: map[string][]ImmutableReference{"10": {{Start: 6, Length: 4}}},
+		}
+	}
+	deploy := func(immutable byte) types.Code {
+		code := withMetadata(runtime, 1)
+		copy(code[6:10], []byte{immutable, immutable, immutable, immutable})
+		return code
+	}
+
+	address := types.HexToAddress("0x0001aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	twin := types.HexToAddress("0x0001bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	other := types.HexToAddress("0x0001cccccccccccccccccccccccccccccccccccc")
+	codes := map[types.Address]types.Code{
+		address: deploy(1),
+		twin:    deploy(1),
+		other:   deploy(2),
+	}
+	s.client.GetCodeFunc = func(ctx context.Context, addr types.Address, blockId any) (types.Code, error) {
+		return codes[addr], nil
+	}
+
+	s.Run("Mismatch", func() {
+		data := newContractData(1)
+		data.Code[0] = 0x61
+		s.Require().ErrorIs(s.service.RegisterContractData(s.ctx, data, address), ErrCodeMismatch)
+	})
+
+	s.Run("Partial", func() {
+		s.Require().NoError(s.service.RegisterContractData(s.ctx, newContractData(2), address))
+
+		contract, err := s.service.GetContract(s.ctx, address)
+		s.Require().NoError(err)
+		s.Equal(VerificationPartial, contract.VerificationStatus)
+		s.Equal(codes[address].Hash(), contract.DeployedCodeHash)
+	})
+
+	s.Run("Perfect", func() {
+		s.Require().NoError(s.service.RegisterContractData(s.ctx, newContractData(1), address))
+
+		contract, err := s.service.GetContract(s.ctx, address)
+		s.Require().NoError(err)
+		s.Equal(VerificationPerfect, contract.VerificationStatus)
+	})
+
+	s.Run("PartialAfterPerfect", func() {
+		s.Require().ErrorIs(s.service.RegisterContractData(s.ctx, newContractData(2), address), ErrAlreadyVerified)
+	})
+
+	s.Run("Twin", func() {
+		contract, err := s.service.GetContract(s.ctx, twin)
+		s.Require().NoError(err)
+		s.Equal(VerificationPerfect, contract.VerificationStatus)
+	})
+
+	s.Run("OtherImmutables", func() {
+		// The code differs, so it is not found by the hash, but it can be registered with the same sources
+		_, err := s.service.GetContract(s.ctx, other)
+		s.Require().Error(err)
+
+		s.Require().NoError(s.service.RegisterContractData(s.ctx, newContractData(1), other))
+		contract, err := s.service.GetContract(s.ctx, other)
+		s.Require().NoError(err)
+		s.Equal(VerificationPerfect, contract.VerificationStatus)
+	})
+}
+
 func (s *SuiteServiceTest) TestErrorContract() {
 	task := s.getCompilerTask("input_3")
 
@@ -0,0 +1,492 @@
+package cometa
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+const (
+	storageEncodingMapping      = "mapping"
+	storageEncodingDynamicArray = "dynamic_array"
+	storageEncodingBytes        = "bytes"
+
+	// maxStorageReads limits the number of slots read to decode a single request,
+	// so that large arrays and byte strings have to be inspected by parts.
+	maxStorageReads = 1024
+)
+
+var (
+	ErrStorageLayoutNotFound = errors.New("storage layout not found")
+	ErrTooManyStorageReads   = errors.New("too many storage slots to read, specify an index")
+)
+
+// StorageLayout describes the storage variables of a contract, directly copied from the compiler output.
+// See https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html#json-output
+type StorageLayout struct {
+	Storage []StorageVariable       `json:"storage"`
+	Types   map[string]*StorageType `json:"types"`
+}
+
+type StorageVariable struct {
+	AstId    int    `json:"astId"`
+	Contract string `json:"contract"`
+	Label    string `json:"label"`
+	Offset   int    `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+type StorageType struct {
+	// Encoding is one of "inplace", "mapping", "dynamic_array" or "bytes".
+	// Value types, structs and static arrays are stored in place.
+	Encoding      string `json:"encoding"`
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+	// Base is the element type of arrays.
+	Base string `json:"base,omitempty"`
+	// Key and Value are the types of mappings.
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+	// Members are the fields of structs, their slots are relative to the struct one.
+	Members []StorageVariable `json:"members,omitempty"`
+}
+
+// StorageValue is a decoded value of a storage variable or its part.
+// Numbers are represented as decimal strings, byte arrays as hex, structs as maps and arrays as slices.
+// Mappings can't be enumerated, so their values are nil.
+type StorageValue struct {
+	Path   string      `json:"path"`
+	Type   string      `json:"type"`
+	Slot   common.Hash `json:"slot"`
+	Offset int         `json:"offset"`
+	Value  any         `json:"value"`
+}
+
+// StorageReader returns the value of the storage slot.
+type StorageReader func(slot common.Hash) (common.Hash, error)
+
+type storageLocation struct {
+	slot   uint256.Int
+	offset int
+	tp     *StorageType
+}
+
+// ReadVariable resolves the path (e.g. `balances[0xabc].amount`) and decodes the value it points to.
+// The path starts with a variable name followed by the struct members (`.name`),
+// and the mapping keys or array indexes (`[key]`). String keys may be quoted.
+func (l *StorageLayout) ReadVariable(path string, read StorageReader) (*StorageValue, error) {
+	loc, err := l.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return l.readValue(path, loc, newCachedStorageReader(read))
+}
+
+// ReadVariables decodes all the top-level variables of the contract.
+func (l *StorageLayout) ReadVariables(read StorageReader) ([]*StorageValue, error) {
+	reader := newCachedStorageReader(read)
+	res := make([]*StorageValue, 0, len(l.Storage))
+	for _, v := range l.Storage {
+		loc, err := l.variableLocation(&v, nil)
+		if err != nil {
+			return nil, err
+		}
+		value, err := l.readValue(v.Label, loc, reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variable %s: %w", v.Label, err)
+		}
+		res = append(res, value)
+	}
+	return res, nil
+}
+
+func (l *StorageLayout) readValue(
+	path string, loc *storageLocation, reader *cachedStorageReader,
+) (*StorageValue, error) {
+	value, err := l.decode(loc, reader)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageValue{
+		Path:   path,
+		Type:   loc.tp.Label,
+		Slot:   loc.slot.Bytes32(),
+		Offset: loc.offset,
+		Value:  value,
+	}, nil
+}
+
+func (l *StorageLayout) getType(id string) (*StorageType, error) {
+	tp, ok := l.Types[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", id)
+	}
+	return tp, nil
+}
+
+// variableLocation returns the location of the variable, base is the slot of the struct containing it.
+func (l *StorageLayout) variableLocation(v *StorageVariable, base *uint256.Int) (*storageLocation, error) {
+	tp, err := l.getType(v.Type)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := uint256.FromDecimal(v.Slot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slot of %s: %w", v.Label, err)
+	}
+	if base != nil {
+		slot.Add(slot, base)
+	}
+	return &storageLocation{slot: *slot, offset: v.Offset, tp: tp}, nil
+}
+
+func (l *StorageLayout) resolve(path string) (*storageLocation, error) {
+	name, rest := splitStoragePath(path)
+	var loc *storageLocation
+	for _, v := range l.Storage {
+		if v.Label == name {
+			var err error
+			if loc, err = l.variableLocation(&v, nil); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if loc == nil {
+		return nil, fmt.Errorf("variable %q not found", name)
+	}
+
+	for len(rest) > 0 {
+		var err error
+		switch rest[0] {
+		case '.':
+			name, rest = splitStoragePath(rest[1:])
+			loc, err = l.resolveMember(loc, name)
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket in %q", path)
+			}
+			key := rest[1:end]
+			rest = rest[end+1:]
+			loc, err = l.resolveKey(loc, key)
+		default:
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loc, nil
+}
+
+// splitStoragePath splits the path into the leading name and the rest starting with "." or "[".
+func splitStoragePath(path string) (string, string) {
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return path, ""
+	}
+	return path[:end], path[end:]
+}
+
+func (l *StorageLayout) resolveMember(loc *storageLocation, name string) (*storageLocation, error) {
+	if len(loc.tp.Members) == 0 {
+		return nil, fmt.Errorf("%s is not a struct", loc.tp.Label)
+	}
+	for _, member := range loc.tp.Members {
+		if member.Label == name {
+			return l.variableLocation(&member, &loc.slot)
+		}
+	}
+	return nil, fmt.Errorf("%s has no member %q", loc.tp.Label, name)
+}
+
+func (l *StorageLayout) resolveKey(loc *storageLocation, key string) (*storageLocation, error) {
+	switch {
+	case loc.tp.Encoding == storageEncodingMapping:
+		keyType, err := l.getType(loc.tp.Key)
+		if err != nil {
+			return nil, err
+		}
+		valueType, err := l.getType(loc.tp.Value)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey, err := encodeMappingKey(keyType, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q of %s: %w", key, loc.tp.Label, err)
+		}
+		slot := loc.slot.Bytes32()
+		hash := common.Keccak256Hash(append(encodedKey, slot[:]...))
+		return &storageLocation{slot: *new(uint256.Int).SetBytes32(hash[:]), tp: valueType}, nil
+	case loc.tp.Base != "":
+		index, err := strconv.ParseUint(key, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q of %s: %w", key, loc.tp.Label, err)
+		}
+		return l.elementLocation(loc, index)
+	}
+	return nil, fmt.Errorf("%s can't be indexed", loc.tp.Label)
+}
+
+// elementLocation returns the location of the array element. The length of the array isn't checked.
+func (l *StorageLayout) elementLocation(loc *storageLocation, index uint64) (*storageLocation, error) {
+	elemType, err := l.getType(loc.tp.Base)
+	if err != nil {
+		return nil, err
+	}
+	elemSize, err := strconv.ParseUint(elemType.NumberOfBytes, 10, 64)
+	if err != nil || elemSize == 0 {
+		return nil, fmt.Errorf("invalid size of %s", elemType.Label)
+	}
+
+	base := loc.slot
+	if loc.tp.Encoding == storageEncodingDynamicArray {
+		slot := loc.slot.Bytes32()
+		base.SetBytes32(common.Keccak256Hash(slot[:]).Bytes())
+	}
+
+	// Small elements are packed into slots, other ones start from a new slot each
+	res := &storageLocation{tp: elemType}
+	if elemSize < 32 {
+		perSlot := 32 / elemSize
+		res.slot.AddUint64(&base, index/perSlot)
+		res.offset = int(index % perSlot * elemSize)
+	} else {
+		slots := new(uint256.Int).Mul(uint256.NewInt(index), uint256.NewInt((elemSize+31)/32))
+		res.slot.Add(&base, slots)
+	}
+	return res, nil
+}
+
+// arrayLength returns the number of elements of the array.
+// Static arrays have the length in their type labels (e.g. `uint256[3]`), dynamic ones store it in their slots.
+func (l *StorageLayout) arrayLength(loc *storageLocation, reader *cachedStorageReader) (uint64, error) {
+	if loc.tp.Encoding == storageEncodingDynamicArray {
+		word, err := reader.read(&loc.slot)
+		if err != nil {
+			return 0, err
+		}
+		length := new(uint256.Int).SetBytes32(word[:])
+		if !length.IsUint64() {
+			return 0, ErrTooManyStorageReads
+		}
+		return length.Uint64(), nil
+	}
+
+	start := strings.LastIndexByte(loc.tp.Label, '[')
+	if start < 0 || !strings.HasSuffix(loc.tp.Label, "]") {
+		return 0, fmt.Errorf("invalid array type %s", loc.tp.Label)
+	}
+	return strconv.ParseUint(loc.tp.Label[start+1:len(loc.tp.Label)-1], 10, 64)
+}
+
+func (l *StorageLayout) decode(loc *storageLocation, reader *cachedStorageReader) (any, error) {
+	tp := loc.tp
+	switch {
+	case tp.Encoding == storageEncodingMapping:
+		return nil, nil
+	case tp.Encoding == storageEncodingBytes:
+		data, err := readStorageBytes(&loc.slot, reader)
+		if err != nil {
+			return nil, err
+		}
+		if tp.Label == "string" {
+			return string(data), nil
+		}
+		return hexutil.Bytes(data), nil
+	case tp.Base != "":
+		length, err := l.arrayLength(loc, reader)
+		if err != nil {
+			return nil, err
+		}
+		if length > maxStorageReads {
+			return nil, ErrTooManyStorageReads
+		}
+		res := make([]any, length)
+		for i := range length {
+			elem, err := l.elementLocation(loc, i)
+			if err != nil {
+				return nil, err
+			}
+			if res[i], err = l.decode(elem, reader); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case len(tp.Members) > 0:
+		res := make(map[string]any, len(tp.Members))
+		for _, member := range tp.Members {
+			memberLoc, err := l.variableLocation(&member, &loc.slot)
+			if err != nil {
+				return nil, err
+			}
+			if res[member.Label], err = l.decode(memberLoc, reader); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	size, err := strconv.Atoi(tp.NumberOfBytes)
+	if err != nil || size <= 0 || loc.offset+size > 32 {
+		return nil, fmt.Errorf("invalid size of %s", tp.Label)
+	}
+	word, err := reader.read(&loc.slot)
+	if err != nil {
+		return nil, err
+	}
+	// Values are packed starting from the lower-order bytes of the slot
+	return decodeStorageValueType(tp.Label, word[32-loc.offset-size:32-loc.offset]), nil
+}
+
+func decodeStorageValueType(label string, data []byte) any {
+	switch {
+	case label == "bool":
+		return data[len(data)-1] != 0
+	case strings.HasPrefix(label, "address"), strings.HasPrefix(label, "contract "):
+		return types.BytesToAddress(data)
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(label, "int"):
+		value := new(big.Int).SetBytes(data)
+		// Two's complement of the value of the type size
+		if data[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return value.String()
+	}
+	return hexutil.Bytes(data)
+}
+
+// readStorageBytes reads a byte array or a string. Short ones (up to 31 bytes) are stored in the slot along
+// with the doubled length, long ones store the doubled length plus one and the data from keccak256(slot).
+func readStorageBytes(slot *uint256.Int, reader *cachedStorageReader) ([]byte, error) {
+	word, err := reader.read(slot)
+	if err != nil {
+		return nil, err
+	}
+	if word[31]&1 == 0 {
+		length := int(word[31] / 2)
+		if length > 31 {
+			return nil, errors.New("invalid short byte array length")
+		}
+		return word[:length], nil
+	}
+
+	length := new(uint256.Int).SetBytes32(word[:])
+	length.Rsh(length, 1)
+	if !length.IsUint64() || length.Uint64() > maxStorageReads*32 {
+		return nil, ErrTooManyStorageReads
+	}
+	size := length.Uint64()
+
+	slotBytes := slot.Bytes32()
+	var dataSlot uint256.Int
+	dataSlot.SetBytes32(common.Keccak256Hash(slotBytes[:]).Bytes())
+	data := make([]byte, 0, size+31)
+	for uint64(len(data)) < size {
+		word, err := reader.read(&dataSlot)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, word[:]...)
+		dataSlot.AddUint64(&dataSlot, 1)
+	}
+	return data[:size], nil
+}
+
+// encodeMappingKey encodes the key the way it is hashed with the mapping slot. Value types are padded to 32 bytes,
+// strings and byte arrays are used as is.
+func encodeMappingKey(tp *StorageType, key string) ([]byte, error) {
+	if tp.Encoding == storageEncodingBytes {
+		if tp.Label == "string" {
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				return []byte(unquoted), nil
+			}
+			return []byte(key), nil
+		}
+		return hexutil.Decode(key)
+	}
+
+	var res common.Hash
+	switch label := tp.Label; {
+	case label == "bool":
+		value, err := strconv.ParseBool(key)
+		if err != nil {
+			return nil, err
+		}
+		if value {
+			res[31] = 1
+		}
+	case strings.HasPrefix(label, "address"), strings.HasPrefix(label, "contract "):
+		var address types.Address
+		if err := address.Set(key); err != nil {
+			return nil, err
+		}
+		copy(res[32-types.AddrSize:], address.Bytes())
+	case strings.HasPrefix(label, "bytes"):
+		data, err := hexutil.Decode(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 32 {
+			return nil, errors.New("key is too long")
+		}
+		// Fixed-size byte arrays are aligned to the higher-order bytes
+		copy(res[:], data)
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "int"), strings.HasPrefix(label, "enum "):
+		value, ok := new(big.Int).SetString(key, 0)
+		if !ok {
+			return nil, errors.New("invalid number")
+		}
+		if value.Sign() < 0 && !strings.HasPrefix(label, "int") {
+			return nil, errors.New("negative value of unsigned type")
+		}
+		// Negative values are sign-extended to 32 bytes
+		var word uint256.Int
+		if overflow := word.SetFromBig(value); overflow {
+			return nil, errors.New("value overflows 256 bits")
+		}
+		res = word.Bytes32()
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", label)
+	}
+	return res[:], nil
+}
+
+// cachedStorageReader reads every slot only once and limits the number of the reads.
+type cachedStorageReader struct {
+	fetch StorageReader
+	cache map[uint256.Int]common.Hash
+}
+
+func newCachedStorageReader(read StorageReader) *cachedStorageReader {
+	return &cachedStorageReader{
+		fetch: read,
+		cache: make(map[uint256.Int]common.Hash),
+	}
+}
+
+func (r *cachedStorageReader) read(slot *uint256.Int) (common.Hash, error) {
+	if value, ok := r.cache[*slot]; ok {
+		return value, nil
+	}
+	if len(r.cache) >= maxStorageReads {
+		return common.Hash{}, ErrTooManyStorageReads
+	}
+	value, err := r.fetch(slot.Bytes32())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	r.cache[*slot] = value
+	return value, nil
+}
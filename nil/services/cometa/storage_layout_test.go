@@ -0,0 +1,258 @@
+package cometa
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// testStorageLayout is the layout of the following contract:
+//
+//	contract Test {
+//	    struct Account { uint256 amount; bool active; }
+//	    uint8 a; bool b; int16 c; address owner;
+//	    uint256 total;
+//	    string name;
+//	    bytes data;
+//	    uint64[] list;
+//	    mapping(address => Account) balances;
+//	    uint256[3] fixedList;
+//	    mapping(string => uint256) byName;
+//	}
+const testStorageLayout = `{
+	"storage": [
+		{"label": "a", "offset": 0, "slot": "0", "type": "t_uint8"},
+		{"label": "b", "offset": 1, "slot": "0", "type": "t_bool"},
+		{"label": "c", "offset": 2, "slot": "0", "type": "t_int16"},
+		{"label": "owner", "offset": 4, "slot": "0", "type": "t_address"},
+		{"label": "total", "offset": 0, "slot": "1", "type": "t_uint256"},
+		{"label": "name", "offset": 0, "slot": "2", "type": "t_string_storage"},
+		{"label": "data", "offset": 0, "slot": "3", "type": "t_bytes_storage"},
+		{"label": "list", "offset": 0, "slot": "4", "type": "t_array(t_uint64)dyn_storage"},
+		{"label": "balances", "offset": 0, "slot": "5", "type": "t_mapping(t_address,t_struct(Account)1_storage)"},
+		{"label": "fixedList", "offset": 0, "slot": "6", "type": "t_array(t_uint256)3_storage"},
+		{"label": "byName", "offset": 0, "slot": "9", "type": "t_mapping(t_string_memory_ptr,t_uint256)"}
+	],
+	"types": {
+		"t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_int16": {"encoding": "inplace", "label": "int16", "numberOfBytes": "2"},
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_string_memory_ptr": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_bytes_storage": {"encoding": "bytes", "label": "bytes", "numberOfBytes": "32"},
+		"t_array(t_uint64)dyn_storage": {
+			"encoding": "dynamic_array", "label": "uint64[]", "numberOfBytes": "32", "base": "t_uint64"
+		},
+		"t_array(t_uint256)3_storage": {
+			"encoding": "inplace", "label": "uint256[3]", "numberOfBytes": "96", "base": "t_uint256"
+		},
+		"t_mapping(t_address,t_struct(Account)1_storage)": {
+			"encoding": "mapping", "label": "mapping(address => struct Test.Account)", "numberOfBytes": "32",
+			"key": "t_address", "value": "t_struct(Account)1_storage"
+		},
+		"t_mapping(t_string_memory_ptr,t_uint256)": {
+			"encoding": "mapping", "label": "mapping(string => uint256)", "numberOfBytes": "32",
+			"key": "t_string_memory_ptr", "value": "t_uint256"
+		},
+		"t_struct(Account)1_storage": {
+			"encoding": "inplace", "label": "struct Test.Account", "numberOfBytes": "64",
+			"members": [
+				{"label": "amount", "offset": 0, "slot": "0", "type": "t_uint256"},
+				{"label": "active", "offset": 0, "slot": "1", "type": "t_bool"}
+			]
+		}
+	}
+}`
+
+type testStorage map[common.Hash]common.Hash
+
+func (s testStorage) read(slot common.Hash) (common.Hash, error) {
+	return s[slot], nil
+}
+
+func slotHash(slot uint64) common.Hash {
+	return uint256.NewInt(slot).Bytes32()
+}
+
+func addSlot(slot common.Hash, n uint64) common.Hash {
+	value := new(uint256.Int).SetBytes32(slot[:])
+	return value.AddUint64(value, n).Bytes32()
+}
+
+func TestStorageLayout(t *testing.T) {
+	t.Parallel()
+
+	var layout StorageLayout
+	require.NoError(t, json.Unmarshal([]byte(testStorageLayout), &layout))
+
+	owner := types.HexToAddress("0x0001111111111111111111111111111111111111")
+	longData := []byte(strings.Repeat("0123456789", 5))
+
+	storage := make(testStorage)
+
+	// a = 7, b = true, c = -2, owner are packed into the first slot
+	var packed common.Hash
+	packed[31] = 7
+	packed[30] = 1
+	packed[28], packed[29] = 0xff, 0xfe
+	copy(packed[8:28], owner.Bytes())
+	storage[slotHash(0)] = packed
+
+	storage[slotHash(1)] = slotHash(1_000_000)
+
+	// Short string is stored along with the doubled length
+	var name common.Hash
+	copy(name[:], "nil")
+	name[31] = 3 * 2
+	storage[slotHash(2)] = name
+
+	// Long bytes store the doubled length plus one, the data is at keccak256(slot)
+	storage[slotHash(3)] = slotHash(uint64(len(longData))*2 + 1)
+	dataSlot := common.Keccak256Hash(slotHash(3).Bytes())
+	storage[dataSlot] = common.BytesToHash(longData[:32])
+	var tail common.Hash
+	copy(tail[:], longData[32:])
+	storage[addSlot(dataSlot, 1)] = tail
+
+	// Five uint64 elements occupy two slots, four per slot
+	storage[slotHash(4)] = slotHash(5)
+	listSlot := common.Keccak256Hash(slotHash(4).Bytes())
+	var list0, list1 common.Hash
+	for i := range 4 {
+		list0[31-i*8] = byte(i + 1)
+	}
+	list1[31] = 5
+	storage[listSlot] = list0
+	storage[addSlot(listSlot, 1)] = list1
+
+	accountSlot := common.Keccak256Hash(common.BytesToHash(owner.Bytes()).Bytes(), slotHash(5).Bytes())
+	storage[accountSlot] = slotHash(42)
+	storage[addSlot(accountSlot, 1)] = slotHash(1)
+
+	storage[slotHash(7)] = slotHash(20)
+
+	byNameSlot := common.Keccak256Hash([]byte("key"), slotHash(9).Bytes())
+	storage[byNameSlot] = slotHash(99)
+
+	read := func(t *testing.T, path string) *StorageValue {
+		t.Helper()
+
+		value, err := layout.ReadVariable(path, storage.read)
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("PackedValues", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "7", read(t, "a").Value)
+		require.Equal(t, true, read(t, "b").Value)
+		require.Equal(t, "-2", read(t, "c").Value)
+
+		value := read(t, "owner")
+		require.Equal(t, owner, value.Value)
+		require.Equal(t, "address", value.Type)
+		require.Equal(t, slotHash(0), value.Slot)
+		require.Equal(t, 4, value.Offset)
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "nil", read(t, "name").Value)
+		require.Equal(t, hexutil.Bytes(longData), read(t, "data").Value)
+	})
+
+	t.Run("Arrays", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, []any{"1", "2", "3", "4", "5"}, read(t, "list").Value)
+		value := read(t, "list[4]")
+		require.Equal(t, "5", value.Value)
+		require.Equal(t, addSlot(listSlot, 1), value.Slot)
+
+		require.Equal(t, []any{"0", "20", "0"}, read(t, "fixedList").Value)
+		require.Equal(t, "20", read(t, "fixedList[1]").Value)
+	})
+
+	t.Run("Mappings", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, read(t, "balances").Value)
+		require.Equal(t, map[string]any{"amount": "42", "active": true}, read(t, "balances["+owner.Hex()+"]").Value)
+		require.Equal(t, "42", read(t, "balances["+owner.Hex()+"].amount").Value)
+		require.Equal(t, true, read(t, "balances["+owner.Hex()+"].active").Value)
+
+		require.Equal(t, "99", read(t, "byName[key]").Value)
+		require.Equal(t, "99", read(t, `byName["key"]`).Value)
+		require.Equal(t, "0", read(t, "byName[other]").Value)
+	})
+
+	t.Run("AllVariables", func(t *testing.T) {
+		t.Parallel()
+
+		values, err := layout.ReadVariables(storage.read)
+		require.NoError(t, err)
+		require.Len(t, values, len(layout.Storage))
+		require.Equal(t, "total", values[4].Path)
+		require.Equal(t, "1000000", values[4].Value)
+	})
+
+	t.Run("InvalidPaths", func(t *testing.T) {
+		t.Parallel()
+
+		for _, path := range []string{
+			"unknown",
+			"total.amount",
+			"total[1]",
+			"balances[not-an-address]",
+			"balances[" + owner.Hex() + "].unknown",
+			"list[x]",
+			"list[1",
+		} {
+			_, err := layout.ReadVariable(path, storage.read)
+			require.Error(t, err, path)
+		}
+	})
+
+	t.Run("TooManyReads", func(t *testing.T) {
+		t.Parallel()
+
+		huge := testStorage{slotHash(4): slotHash(maxStorageReads * 10)}
+		_, err := layout.ReadVariable("list", huge.read)
+		require.ErrorIs(t, err, ErrTooManyStorageReads)
+	})
+}
+
+func TestEncodeMappingKey(t *testing.T) {
+	t.Parallel()
+
+	encode := func(label, key string) []byte {
+		t.Helper()
+
+		res, err := encodeMappingKey(&StorageType{Encoding: "inplace", Label: label}, key)
+		require.NoError(t, err)
+		return res
+	}
+
+	minusOne := make([]byte, 32)
+	for i := range minusOne {
+		minusOne[i] = 0xff
+	}
+	require.Equal(t, minusOne, encode("int8", "-1"))
+	require.Equal(t, slotHash(255).Bytes(), encode("uint8", "0xff"))
+	require.Equal(t, slotHash(1).Bytes(), encode("bool", "true"))
+	require.Equal(t, common.RightPadBytes([]byte{0xab, 0xcd}, 32), encode("bytes2", "0xabcd"))
+
+	_, err := encodeMappingKey(&StorageType{Label: "uint256"}, "-1")
+	require.Error(t, err)
+}
@@ -10,6 +10,11 @@ import (
 
 var ErrAbiNotFound = errors.New("abi not found")
 
+const (
+	LanguageSolidity = "Solidity"
+	LanguageVyper    = "Vyper"
+)
+
 // CompilerJsonInput represents the input structure for the solidity compiler.
 type CompilerJsonInput struct {
 	Language string             `json:"language"`
@@ -75,16 +80,16 @@ type CompilerOutputSource struct {
 }
 
 type CompilerOutputContract struct {
-	Abi            []any     `json:"abi"`
-	Metadata       string    `json:"metadata,omitempty"`
-	Userdoc        any       `json:"userdoc,omitempty"`
-	Devdoc         any       `json:"devdoc,omitempty"`
-	Ir             string    `json:"ir,omitempty"`
-	IrAst          any       `json:"irAst,omitempty"`
-	IrOptimized    string    `json:"irOptimized,omitempty"`
-	IrOptimizedAst any       `json:"irOptimizedAst,omitempty"`
-	StorageLayout  any       `json:"storageLayout,omitempty"`
-	Evm            EvmOutput `json:"evm"`
+	Abi            []any          `json:"abi"`
+	Metadata       string         `json:"metadata,omitempty"`
+	Userdoc        any            `json:"userdoc,omitempty"`
+	Devdoc         any            `json:"devdoc,omitempty"`
+	Ir             string         `json:"ir,omitempty"`
+	IrAst          any            `json:"irAst,omitempty"`
+	IrOptimized    string         `json:"irOptimized,omitempty"`
+	IrOptimizedAst any            `json:"irOptimizedAst,omitempty"`
+	StorageLayout  *StorageLayout `json:"storageLayout,omitempty"`
+	Evm            EvmOutput      `json:"evm"`
 }
 
 type EvmOutput struct {
@@ -97,13 +102,13 @@ type EvmOutput struct {
 }
 
 type CompilerOutputEvm struct {
-	Object              string            `json:"object,omitempty"`
-	Opcodes             string            `json:"opcodes,omitempty"`
-	SourceMap           string            `json:"sourceMap,omitempty"`
-	LinkReferences      any               `json:"linkReferences,omitempty"`
-	ImmutableReferences any               `json:"immutableReferences,omitempty"`
-	FunctionDebugData   FunctionDebugData `json:"functionDebugData"`
-	GeneratedSources    []GeneratedSource `json:"generatedSources,omitempty"`
+	Object              string                          `json:"object,omitempty"`
+	Opcodes             string                          `json:"opcodes,omitempty"`
+	SourceMap           string                          `json:"sourceMap,omitempty"`
+	LinkReferences      any                             `json:"linkReferences,omitempty"`
+	ImmutableReferences map[string][]ImmutableReference `json:"immutableReferences,omitempty"`
+	FunctionDebugData   FunctionDebugData               `json:"functionDebugData"`
+	GeneratedSources    []GeneratedSource               `json:"generatedSources,omitempty"`
 }
 
 type GeneratedSource struct {
@@ -137,6 +142,8 @@ type MetadataSettings struct {
 
 // CompilerTask is the input for the service. It contains all information for compilation and deployment.
 type CompilerTask struct {
+	// Language is either Solidity (by default) or Vyper.
+	Language        string             `json:"language,omitempty"`
 	ContractName    string             `json:"contractName,omitempty"`
 	CompilerVersion string             `json:"compilerVersion,omitempty"`
 	BasePath        string             `json:"basePath,omitempty"`
@@ -209,7 +216,7 @@ func (t *CompilerTask) ToCompilerJsonInput() (*CompilerJsonInput, error) {
 		return nil, err
 	}
 	res := &CompilerJsonInput{
-		Language: "Solidity",
+		Language: LanguageSolidity,
 	}
 	res.Sources = t.Sources
 	res.Settings.Optimizer = t.Settings.Optimizer
@@ -230,7 +237,9 @@ func (t *CompilerTask) ToCompilerJsonInput() (*CompilerJsonInput, error) {
 				"evm.deployedBytecode.sourceMap",
 				"evm.deployedBytecode.generatedSources",
 				"evm.deployedBytecode.functionDebugData",
+				"evm.deployedBytecode.immutableReferences",
 				"evm.methodIdentifiers",
+				"storageLayout",
 			},
 		},
 	}
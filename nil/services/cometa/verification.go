@@ -0,0 +1,73 @@
+package cometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+)
+
+// VerificationStatus tells how the compiled code matches the deployed one.
+type VerificationStatus string
+
+const (
+	// VerificationPerfect means that the code matches along with the metadata hash,
+	// so the sources are exactly the same as the deployed ones, including comments.
+	VerificationPerfect VerificationStatus = "perfect"
+	// VerificationPartial means that the code matches except the metadata hash appended to it,
+	// so the sources produce the same code but may differ in comments, file names, etc.
+	VerificationPartial VerificationStatus = "partial"
+)
+
+var (
+	ErrCodeMismatch    = errors.New("compiled bytecode is not equal to the deployed one")
+	ErrAlreadyVerified = errors.New("contract is already verified with perfect match")
+)
+
+// ImmutableReference is a location of an immutable variable in the runtime bytecode.
+// The compiler leaves zeros there, the values are written by the constructor.
+type ImmutableReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// VerifyCode checks that the deployed code is produced by the compiled contract.
+// The immutable variables are excluded from the comparison, since their values are set on deployment.
+func VerifyCode(deployed []byte, data *ContractData) (VerificationStatus, error) {
+	code := slices.Clone(deployed)
+	for _, refs := range data.ImmutableReferences {
+		for _, ref := range refs {
+			if ref.Start < 0 || ref.Length < 0 || ref.Start+ref.Length > len(code) {
+				return "", ErrCodeMismatch
+			}
+			clear(code[ref.Start : ref.Start+ref.Length])
+		}
+	}
+
+	if bytes.Equal(code, data.Code) {
+		return VerificationPerfect, nil
+	}
+	if bytes.Equal(stripCodeMetadata(code), stripCodeMetadata(data.Code)) {
+		return VerificationPartial, nil
+	}
+	return "", ErrCodeMismatch
+}
+
+// stripCodeMetadata removes the CBOR-encoded metadata the compiler appends to the runtime code.
+// The last two bytes of the code hold the length of the metadata.
+// See https://docs.soliditylang.org/en/latest/metadata.html#encoding-of-the-metadata-hash-in-the-bytecode
+func stripCodeMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	size := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	start := len(code) - 2 - size
+	if size == 0 || start < 0 {
+		return code
+	}
+	// The metadata is a CBOR map (major type 5) with a few entries
+	if header := code[start]; header < 0xa1 || header > 0xb7 {
+		return code
+	}
+	return code[:start]
+}
@@ -0,0 +1,83 @@
+package cometa
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withMetadata appends CBOR metadata of the given hash byte and its length to the code.
+func withMetadata(code []byte, hashByte byte) []byte {
+	// {"ipfs": <4 bytes>}
+	metadata := []byte{0xa1, 0x64, 'i', 'p', 'f', 's', 0x44, hashByte, hashByte, hashByte, hashByte}
+	res := slices.Concat(code, metadata)
+	return append(res, 0, byte(len(metadata)))
+}
+
+func TestVerifyCode(t *testing.T) {
+	t.Parallel()
+
+	runtime := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x7f, 0, 0, 0, 0, 0x50, 0x00}
+	data := &ContractData{
+		Code:                withMetadata(runtime, 1),
+		ImmutableReferences: map[string][]ImmutableReference{"10": {{Start: 6, Length: 4}}},
+	}
+
+	deployed := slices.Clone(data.Code)
+	copy(deployed[6:10], []byte{1, 2, 3, 4})
+
+	t.Run("Perfect", func(t *testing.T) {
+		t.Parallel()
+
+		status, err := VerifyCode(deployed, data)
+		require.NoError(t, err)
+		require.Equal(t, VerificationPerfect, status)
+		// The deployed code is not modified
+		require.Equal(t, []byte{1, 2, 3, 4}, deployed[6:10])
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		t.Parallel()
+
+		otherMetadata := withMetadata(runtime, 2)
+		copy(otherMetadata[6:10], []byte{1, 2, 3, 4})
+
+		status, err := VerifyCode(otherMetadata, data)
+		require.NoError(t, err)
+		require.Equal(t, VerificationPartial, status)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		other := slices.Clone(deployed)
+		other[0] = 0x61
+		_, err := VerifyCode(other, data)
+		require.ErrorIs(t, err, ErrCodeMismatch)
+
+		_, err = VerifyCode(deployed[:8], data)
+		require.ErrorIs(t, err, ErrCodeMismatch)
+	})
+
+	t.Run("WithoutImmutables", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifyCode(deployed, &ContractData{Code: data.Code})
+		require.ErrorIs(t, err, ErrCodeMismatch)
+	})
+}
+
+func TestStripCodeMetadata(t *testing.T) {
+	t.Parallel()
+
+	runtime := []byte{0x60, 0x80, 0x60, 0x40}
+	require.Equal(t, runtime, stripCodeMetadata(withMetadata(runtime, 1)))
+
+	// No metadata
+	require.Equal(t, runtime, stripCodeMetadata(runtime))
+	require.Equal(t, []byte{0x01}, stripCodeMetadata([]byte{0x01}))
+	// Too large length
+	code := []byte{0x60, 0x80, 0xff, 0xff}
+	require.Equal(t, code, stripCodeMetadata(code))
+}
@@ -18,10 +18,13 @@ import (
 )
 
 const (
-	blocksTable        db.TableName = "indexer_blocks"
-	actionsTable       db.TableName = "indexer_actions"
-	shardLatestTable   db.TableName = "indexer_shard_latest"
-	shardEarliestTable db.TableName = "indexer_shard_earliest"
+	blocksTable            db.TableName = "indexer_blocks"
+	actionsTable           db.TableName = "indexer_actions"
+	shardLatestTable       db.TableName = "indexer_shard_latest"
+	shardEarliestTable     db.TableName = "indexer_shard_earliest"
+	tokenTransfersTable    db.TableName = "indexer_token_transfers"
+	contractCreationsTable db.TableName = "indexer_contract_creations"
+	contractsTable         db.TableName = "indexer_contracts"
 )
 
 type BadgerDriver struct {
@@ -108,6 +111,12 @@ func (b *BadgerDriver) IndexBlocks(ctx context.Context, blocksToIndex []*driver.
 		if err := b.indexBlockTransactions(tx, block, receipts); err != nil {
 			return fmt.Errorf("failed to index block transactions: %w", err)
 		}
+		if err := indexTokenTransfers(tx, block); err != nil {
+			return fmt.Errorf("failed to index token transfers: %w", err)
+		}
+		if err := indexContractCreations(tx, block); err != nil {
+			return fmt.Errorf("failed to index contract creations: %w", err)
+		}
 	}
 
 	for shardId, latestBlock := range shardLatest {
@@ -169,6 +178,40 @@ func (b *BadgerDriver) indexBlockTransactions(
 	return nil
 }
 
+func indexTokenTransfers(tx db.RwTx, block *driver.BlockWithShardId) error {
+	for _, transfer := range driver.TokenTransfers(block) {
+		value, err := json.Marshal(&transfer)
+		if err != nil {
+			return fmt.Errorf("failed to serialize token transfer: %w", err)
+		}
+		cursor := transfer.Cursor()
+		if err := tx.Put(tokenTransfersTable, makeCursorKey(transfer.From, &cursor), value); err != nil {
+			return err
+		}
+		if err := tx.Put(tokenTransfersTable, makeCursorKey(transfer.To, &cursor), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexContractCreations(tx db.RwTx, block *driver.BlockWithShardId) error {
+	for _, creation := range driver.ContractCreations(block) {
+		value, err := json.Marshal(&creation)
+		if err != nil {
+			return fmt.Errorf("failed to serialize contract creation: %w", err)
+		}
+		cursor := creation.Cursor()
+		if err := tx.Put(contractCreationsTable, makeCursorKey(creation.Deployer, &cursor), value); err != nil {
+			return err
+		}
+		if err := tx.Put(contractsTable, creation.Address.Bytes(), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *BadgerDriver) IndexTxPool(context.Context, []*driver.TxPoolStatus) error {
 	return errors.New("not implemented")
 }
@@ -243,6 +286,131 @@ func (b *BadgerDriver) FetchAddressActions(
 	return actions, nil
 }
 
+// makeCursorKey makes the key of an item of the address, so the items are ordered by their cursors.
+func makeCursorKey(address types.Address, cursor *indexertypes.Cursor) []byte {
+	key := make([]byte, 0, len(address)+8+common.HashSize+len(cursor.Token))
+	key = append(key, address[:]...)
+	key = binary.BigEndian.AppendUint64(key, uint64(cursor.BlockId))
+	key = append(key, cursor.Hash[:]...)
+	return append(key, cursor.Token[:]...)
+}
+
+// fetchAfterCursor reads the values of the address starting right after the cursor.
+func (b *BadgerDriver) fetchAfterCursor(
+	ctx context.Context,
+	table db.TableName,
+	address types.Address,
+	after *indexertypes.Cursor,
+	limit int,
+	handle func(value []byte) (bool, error),
+) error {
+	tx, err := b.db.CreateRoTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	startKey := address.Bytes()
+	if after != nil {
+		// The zero byte makes the key follow the one of the cursor
+		startKey = append(makeCursorKey(address, after), 0)
+	}
+	iter, err := tx.Range(table, startKey, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get range iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for count := 0; iter.HasNext() && count < limit; {
+		key, val, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("iterator error: %w", err)
+		}
+		if !bytes.HasPrefix(key, address[:]) {
+			break
+		}
+		added, err := handle(val)
+		if err != nil {
+			return err
+		}
+		if added {
+			count++
+		}
+	}
+	return nil
+}
+
+func (b *BadgerDriver) FetchTokenTransfers(
+	ctx context.Context,
+	address types.Address,
+	token *types.TokenId,
+	after *indexertypes.Cursor,
+	limit int,
+) ([]indexertypes.TokenTransfer, error) {
+	transfers := make([]indexertypes.TokenTransfer, 0)
+	err := b.fetchAfterCursor(ctx, tokenTransfersTable, address, after, limit, func(value []byte) (bool, error) {
+		var transfer indexertypes.TokenTransfer
+		if err := json.Unmarshal(value, &transfer); err != nil {
+			return false, fmt.Errorf("failed to deserialize token transfer: %w", err)
+		}
+		if token != nil && transfer.Token != *token {
+			return false, nil
+		}
+		transfers = append(transfers, transfer)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (b *BadgerDriver) FetchContractCreations(
+	ctx context.Context,
+	deployer types.Address,
+	after *indexertypes.Cursor,
+	limit int,
+) ([]indexertypes.ContractCreation, error) {
+	creations := make([]indexertypes.ContractCreation, 0)
+	err := b.fetchAfterCursor(ctx, contractCreationsTable, deployer, after, limit, func(value []byte) (bool, error) {
+		var creation indexertypes.ContractCreation
+		if err := json.Unmarshal(value, &creation); err != nil {
+			return false, fmt.Errorf("failed to deserialize contract creation: %w", err)
+		}
+		creations = append(creations, creation)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return creations, nil
+}
+
+func (b *BadgerDriver) FetchContractCreation(
+	ctx context.Context,
+	address types.Address,
+) (*indexertypes.ContractCreation, error) {
+	tx, err := b.db.CreateRoTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	val, err := tx.Get(contractsTable, address.Bytes())
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var creation indexertypes.ContractCreation
+	if err := json.Unmarshal(val, &creation); err != nil {
+		return nil, fmt.Errorf("failed to deserialize contract creation: %w", err)
+	}
+	return &creation, nil
+}
+
 func makeBlockKey(shardId types.ShardId, blockNumber types.BlockNumber) []byte {
 	key := make([]byte, 4+8)
 	binary.BigEndian.PutUint32(key[0:], uint32(shardId))
@@ -395,6 +563,117 @@ func (b *BadgerDriver) HaveBlock(ctx context.Context, id types.ShardId, number t
 	return exists, nil
 }
 
+func (b *BadgerDriver) DeleteBlocks(ctx context.Context, shardId types.ShardId, from types.BlockNumber) error {
+	tx, err := b.db.CreateRwTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	blocks, err := b.collectBlocksFrom(tx, shardId, from)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		for _, txn := range block.InTransactions {
+			hash := txn.Hash()
+			if err := tx.Delete(actionsTable, makeAddressActionKey(txn.From, uint64(block.Id), hash)); err != nil {
+				return fmt.Errorf("failed to delete sender action: %w", err)
+			}
+			if err := tx.Delete(actionsTable, makeAddressActionKey(txn.To, uint64(block.Id), hash)); err != nil {
+				return fmt.Errorf("failed to delete receiver action: %w", err)
+			}
+		}
+		for _, transfer := range driver.TokenTransfers(block) {
+			cursor := transfer.Cursor()
+			if err := tx.Delete(tokenTransfersTable, makeCursorKey(transfer.From, &cursor)); err != nil {
+				return fmt.Errorf("failed to delete token transfer: %w", err)
+			}
+			if err := tx.Delete(tokenTransfersTable, makeCursorKey(transfer.To, &cursor)); err != nil {
+				return fmt.Errorf("failed to delete token transfer: %w", err)
+			}
+		}
+		for _, creation := range driver.ContractCreations(block) {
+			cursor := creation.Cursor()
+			if err := tx.Delete(contractCreationsTable, makeCursorKey(creation.Deployer, &cursor)); err != nil {
+				return fmt.Errorf("failed to delete contract creation: %w", err)
+			}
+			if err := tx.Delete(contractsTable, creation.Address.Bytes()); err != nil {
+				return fmt.Errorf("failed to delete contract creation: %w", err)
+			}
+		}
+		if err := tx.Delete(blocksTable, makeBlockKey(shardId, block.Id)); err != nil {
+			return fmt.Errorf("failed to delete block: %w", err)
+		}
+	}
+
+	if from == 0 {
+		if err := tx.Delete(shardLatestTable, makeShardLatestProcessedKey(shardId)); err != nil {
+			return fmt.Errorf("failed to delete latest processed block: %w", err)
+		}
+		if err := tx.Delete(shardEarliestTable, makeShardEarliestAbsentKey(shardId)); err != nil {
+			return fmt.Errorf("failed to delete earliest absent block: %w", err)
+		}
+		return tx.Commit()
+	}
+
+	latest, hasLatest, err := b.getShardLatestProcessedBlock(tx, shardId)
+	if err != nil {
+		return err
+	}
+	if hasLatest && latest >= from {
+		if err := b.updateShardLatestProcessedBlock(tx, shardId, from-1); err != nil {
+			return fmt.Errorf("failed to update latest processed block: %w", err)
+		}
+	}
+	earliestAbsent, hasEarliest, err := b.getShardEarliestAbsentBlock(tx, shardId)
+	if err != nil {
+		return fmt.Errorf("failed to get earliest absent block: %w", err)
+	}
+	if hasEarliest && earliestAbsent > from {
+		if err := b.updateShardEarliestAbsentBlock(tx, shardId, from); err != nil {
+			return fmt.Errorf("failed to update earliest absent block: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// collectBlocksFrom reads all the blocks of the shard starting from the given one.
+func (b *BadgerDriver) collectBlocksFrom(
+	tx db.RoTx,
+	shardId types.ShardId,
+	from types.BlockNumber,
+) ([]*driver.BlockWithShardId, error) {
+	startKey := makeBlockKey(shardId, from)
+	iter, err := tx.Range(blocksTable, startKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range iterator: %w", err)
+	}
+	defer iter.Close()
+
+	var blocks []*driver.BlockWithShardId
+	for iter.HasNext() {
+		key, val, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("iterator error: %w", err)
+		}
+		if !bytes.HasPrefix(key, startKey[:4]) {
+			break
+		}
+
+		var block blockWithRaw
+		if err := json.Unmarshal(val, &block); err != nil {
+			return nil, fmt.Errorf("failed to deserialize block: %w", err)
+		}
+		if block.Decoded != nil {
+			blocks = append(blocks, block.Decoded)
+		}
+	}
+	return blocks, nil
+}
+
 func (b *BadgerDriver) FetchEarliestAbsentBlockId(ctx context.Context, id types.ShardId) (types.BlockNumber, error) {
 	var earliestAbsent types.BlockNumber
 
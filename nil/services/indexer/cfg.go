@@ -11,4 +11,6 @@ type Cfg struct {
 	BlocksChan    chan *driver.BlockWithShardId
 	AllowDbDrop   bool
 	DoIndexTxpool bool
+	// OnRollback is called after the blocks orphaned by a rollback of the chain are removed from the index.
+	OnRollback func(RollbackEvent)
 }
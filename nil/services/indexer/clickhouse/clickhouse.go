@@ -235,6 +235,61 @@ func NewLogWithBinary(log *types.Log, binary []byte, receipt *types.Receipt) *Lo
 	return res
 }
 
+type TokenTransferRow struct {
+	TransactionHash common.Hash       `ch:"transaction_hash"`
+	ShardId         types.ShardId     `ch:"shard_id"`
+	BlockId         types.BlockNumber `ch:"block_id"`
+	From            types.Address     `ch:"from"`
+	To              types.Address     `ch:"to"`
+	Token           types.TokenId     `ch:"token"`
+	Amount          types.Value       `ch:"amount"`
+	Success         bool              `ch:"success"`
+}
+
+func NewTokenTransferRow(transfer *indexertypes.TokenTransfer) *TokenTransferRow {
+	return &TokenTransferRow{
+		TransactionHash: transfer.Hash,
+		ShardId:         transfer.ShardId,
+		BlockId:         transfer.BlockId,
+		From:            transfer.From,
+		To:              transfer.To,
+		Token:           transfer.Token,
+		Amount:          transfer.Amount,
+		Success:         transfer.Status == indexertypes.Success,
+	}
+}
+
+type ContractCreationRow struct {
+	TransactionHash common.Hash       `ch:"transaction_hash"`
+	ShardId         types.ShardId     `ch:"shard_id"`
+	BlockId         types.BlockNumber `ch:"block_id"`
+	Deployer        types.Address     `ch:"deployer"`
+	Address         types.Address     `ch:"address"`
+	CodeHash        common.Hash       `ch:"code_hash"`
+}
+
+func NewContractCreationRow(creation *indexertypes.ContractCreation) *ContractCreationRow {
+	return &ContractCreationRow{
+		TransactionHash: creation.Hash,
+		ShardId:         creation.ShardId,
+		BlockId:         creation.BlockId,
+		Deployer:        creation.Deployer,
+		Address:         creation.Address,
+		CodeHash:        creation.CodeHash,
+	}
+}
+
+func (r *ContractCreationRow) ContractCreation() indexertypes.ContractCreation {
+	return indexertypes.ContractCreation{
+		Hash:     r.TransactionHash,
+		ShardId:  r.ShardId,
+		BlockId:  r.BlockId,
+		Deployer: r.Deployer,
+		Address:  r.Address,
+		CodeHash: r.CodeHash,
+	}
+}
+
 func NewClickhouseDriver(ctx context.Context, endpoint, login, password, database string) (*ClickhouseDriver, error) {
 	if err := common.CreateClickHouseDbIfNotExists(ctx, database, login, password, endpoint); err != nil {
 		return nil, err
@@ -257,6 +312,16 @@ func NewClickhouseDriver(ctx context.Context, endpoint, login, password, databas
 	if err != nil {
 		return nil, err
 	}
+
+	// The tables added in the newer versions are created in the existing databases.
+	if exists, err := tableExists(ctx, conn, "blocks"); err != nil {
+		return nil, err
+	} else if exists {
+		if err := setupSchemes(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
 	return &ClickhouseDriver{
 		conn:       conn,
 		insertConn: insertConn,
@@ -461,6 +526,39 @@ func exportTransactionsAndLogs(ctx context.Context, conn driver.Conn, blocks []b
 	if err = logBatch.Send(); err != nil {
 		return fmt.Errorf("failed to send logs batch: %w", err)
 	}
+
+	return exportTokenTransfersAndContracts(ctx, conn, blocks)
+}
+
+func exportTokenTransfersAndContracts(ctx context.Context, conn driver.Conn, blocks []blockWithRaw) error {
+	transferBatch, err := conn.PrepareBatch(ctx, "INSERT INTO token_transfers")
+	if err != nil {
+		return fmt.Errorf("failed to prepare token transfer batch: %w", err)
+	}
+	creationBatch, err := conn.PrepareBatch(ctx, "INSERT INTO contract_creations")
+	if err != nil {
+		return fmt.Errorf("failed to prepare contract creation batch: %w", err)
+	}
+
+	for _, block := range blocks {
+		for _, transfer := range indexerdriver.TokenTransfers(block.decoded) {
+			if err := transferBatch.AppendStruct(NewTokenTransferRow(&transfer)); err != nil {
+				return fmt.Errorf("failed to append token transfer to batch: %w", err)
+			}
+		}
+		for _, creation := range indexerdriver.ContractCreations(block.decoded) {
+			if err := creationBatch.AppendStruct(NewContractCreationRow(&creation)); err != nil {
+				return fmt.Errorf("failed to append contract creation to batch: %w", err)
+			}
+		}
+	}
+
+	if err := transferBatch.Send(); err != nil {
+		return fmt.Errorf("failed to send token transfers batch: %w", err)
+	}
+	if err := creationBatch.Send(); err != nil {
+		return fmt.Errorf("failed to send contract creations batch: %w", err)
+	}
 	return nil
 }
 
@@ -503,3 +601,155 @@ func (d *ClickhouseDriver) FetchNextPresentBlockId(ctx context.Context, shardId
 		LIMIT 1
 	`, shardId, number))
 }
+
+func (d *ClickhouseDriver) DeleteBlocks(ctx context.Context, shardId types.ShardId, from types.BlockNumber) error {
+	// The blocks are deleted last, so the orphaned data is found again if the deletion is interrupted.
+	if err := d.conn.Exec(ctx, `
+		DELETE FROM logs
+		WHERE transaction_hash IN (
+			SELECT hash
+			FROM transactions
+			WHERE shard_id = $1 AND block_id >= $2
+		)
+	`, shardId, from); err != nil {
+		return fmt.Errorf("failed to delete logs: %w", err)
+	}
+
+	for _, table := range []string{"token_transfers", "contract_creations"} {
+		if err := d.conn.Exec(ctx, fmt.Sprintf(`
+			DELETE FROM %s
+			WHERE shard_id = $1 AND block_id >= $2
+		`, table), shardId, from); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+
+	if err := d.conn.Exec(ctx, `
+		DELETE FROM transactions
+		WHERE shard_id = $1 AND block_id >= $2
+	`, shardId, from); err != nil {
+		return fmt.Errorf("failed to delete transactions: %w", err)
+	}
+
+	if err := d.conn.Exec(ctx, `
+		DELETE FROM blocks
+		WHERE shard_id = $1 AND id >= $2
+	`, shardId, from); err != nil {
+		return fmt.Errorf("failed to delete blocks: %w", err)
+	}
+	return nil
+}
+
+// afterCursorCondition makes the condition selecting the items after the cursor, the arguments start from argIndex.
+func afterCursorCondition(after *indexertypes.Cursor, argIndex int, withToken bool) (string, []any) {
+	if after == nil {
+		return "", nil
+	}
+	if withToken {
+		condition := fmt.Sprintf(
+			" AND (block_id, transaction_hash, token) > ($%d, $%d, $%d)", argIndex, argIndex+1, argIndex+2)
+		return condition, []any{after.BlockId, after.Hash, after.Token}
+	}
+	return fmt.Sprintf(" AND (block_id, transaction_hash) > ($%d, $%d)", argIndex, argIndex+1),
+		[]any{after.BlockId, after.Hash}
+}
+
+func (d *ClickhouseDriver) FetchTokenTransfers(
+	ctx context.Context,
+	address types.Address,
+	token *types.TokenId,
+	after *indexertypes.Cursor,
+	limit int,
+) ([]indexertypes.TokenTransfer, error) {
+	args := []any{address}
+	condition := ""
+	if token != nil {
+		args = append(args, *token)
+		condition = fmt.Sprintf(" AND token = $%d", len(args))
+	}
+	cursorCondition, cursorArgs := afterCursorCondition(after, len(args)+1, true)
+	condition += cursorCondition
+	args = append(args, cursorArgs...)
+
+	// The sides are selected separately, so that each of them is read from the projection ordered by its column.
+	// UNION DISTINCT keeps a single row of the transfers to self.
+	query := fmt.Sprintf(`
+		SELECT transaction_hash, shard_id, block_id, "from", "to", token, amount, success
+		FROM (
+			SELECT * FROM token_transfers WHERE "from" = $1%[1]s
+			UNION DISTINCT
+			SELECT * FROM token_transfers WHERE "to" = $1%[1]s
+		)
+		ORDER BY block_id, transaction_hash, token
+		LIMIT %[2]d`, condition, limit)
+
+	var rows []TokenTransferRow
+	if err := d.conn.Select(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to query token transfers: %w", err)
+	}
+
+	transfers := make([]indexertypes.TokenTransfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = indexertypes.TokenTransfer{
+			Hash:    row.TransactionHash,
+			ShardId: row.ShardId,
+			BlockId: row.BlockId,
+			From:    row.From,
+			To:      row.To,
+			Token:   row.Token,
+			Amount:  row.Amount,
+			Status:  indexertypes.Failed,
+		}
+		if row.Success {
+			transfers[i].Status = indexertypes.Success
+		}
+	}
+	return transfers, nil
+}
+
+func (d *ClickhouseDriver) FetchContractCreations(
+	ctx context.Context,
+	deployer types.Address,
+	after *indexertypes.Cursor,
+	limit int,
+) ([]indexertypes.ContractCreation, error) {
+	condition, cursorArgs := afterCursorCondition(after, 2, false)
+	query := `
+		SELECT transaction_hash, shard_id, block_id, deployer, address, code_hash
+		FROM contract_creations
+		WHERE deployer = $1` + condition + fmt.Sprintf(`
+		ORDER BY block_id, transaction_hash
+		LIMIT %d`, limit)
+
+	var rows []ContractCreationRow
+	if err := d.conn.Select(ctx, &rows, query, append([]any{deployer}, cursorArgs...)...); err != nil {
+		return nil, fmt.Errorf("failed to query contract creations: %w", err)
+	}
+
+	creations := make([]indexertypes.ContractCreation, len(rows))
+	for i := range rows {
+		creations[i] = rows[i].ContractCreation()
+	}
+	return creations, nil
+}
+
+func (d *ClickhouseDriver) FetchContractCreation(
+	ctx context.Context,
+	address types.Address,
+) (*indexertypes.ContractCreation, error) {
+	var rows []ContractCreationRow
+	if err := d.conn.Select(ctx, &rows, `
+		SELECT transaction_hash, shard_id, block_id, deployer, address, code_hash
+		FROM contract_creations
+		WHERE address = $1
+		ORDER BY block_id DESC
+		LIMIT 1
+	`, address); err != nil {
+		return nil, fmt.Errorf("failed to query contract creation: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	creation := rows[0].ContractCreation()
+	return &creation, nil
+}
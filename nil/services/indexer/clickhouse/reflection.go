@@ -166,8 +166,10 @@ func (s reflectedScheme) Fields() string {
 	return strings.Join(fields, ", ")
 }
 
-func (s reflectedScheme) CreateTableQuery(tableName, engine string, primaryKeys, orderKeys []string) string {
-	query := createTableQuery(tableName, s.Fields(), engine, primaryKeys, orderKeys)
+func (s reflectedScheme) CreateTableQuery(
+	tableName, engine string, primaryKeys, orderKeys []string, projections ...projection,
+) string {
+	query := createTableQuery(tableName, s.Fields(), engine, primaryKeys, orderKeys, projections...)
 	logger.Debug().Msgf("CreateTableQuery: %s", query)
 	return query
 }
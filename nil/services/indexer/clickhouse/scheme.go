@@ -30,6 +30,14 @@ func initSchemeCache() map[string]reflectedScheme {
 	check.PanicIfErr(err)
 	tableScheme["logs"] = logScheme
 
+	tokenTransferScheme, err := reflectSchemeToClickhouse(&TokenTransferRow{})
+	check.PanicIfErr(err)
+	tableScheme["token_transfers"] = tokenTransferScheme
+
+	contractCreationScheme, err := reflectSchemeToClickhouse(&ContractCreationRow{})
+	check.PanicIfErr(err)
+	tableScheme["contract_creations"] = contractCreationScheme
+
 	txpoolStatusScheme, err := reflectSchemeToClickhouse(&indexerdriver.TxPoolStatus{})
 	check.PanicIfErr(err)
 	tableScheme["txpool_status"] = txpoolStatusScheme
@@ -49,13 +57,22 @@ func getScheme(name string) (reflectedScheme, bool) {
 	return scheme, ok
 }
 
-func setupScheme(ctx context.Context, conn driver.Conn, tableName string, keys []string) error {
+// projection keeps the rows of the table in another order,
+// so that the lookups by the columns other than the primary key don't scan the whole table
+type projection struct {
+	name  string
+	order []string
+}
+
+func setupScheme(
+	ctx context.Context, conn driver.Conn, tableName string, keys []string, projections ...projection,
+) error {
 	scheme, ok := getScheme(tableName)
 	if !ok {
 		return fmt.Errorf("scheme for %s not found", tableName)
 	}
 
-	query := scheme.CreateTableQuery(tableName, "ReplacingMergeTree", keys, keys)
+	query := scheme.CreateTableQuery(tableName, "ReplacingMergeTree", keys, keys, projections...)
 	if err := conn.Exec(ctx, query); err != nil {
 		return fmt.Errorf("failed to create table %s: %w", tableName, err)
 	}
@@ -79,6 +96,20 @@ func setupSchemes(ctx context.Context, conn driver.Conn) error {
 		return err
 	}
 
+	if err := setupScheme(ctx, conn,
+		"token_transfers", []string{"shard_id", "block_id", "transaction_hash", "token"},
+		projection{"by_from", []string{`"from"`, "block_id", "transaction_hash", "token"}},
+		projection{"by_to", []string{`"to"`, "block_id", "transaction_hash", "token"}}); err != nil {
+		return err
+	}
+
+	if err := setupScheme(ctx, conn,
+		"contract_creations", []string{"shard_id", "block_id", "transaction_hash"},
+		projection{"by_deployer", []string{"deployer", "block_id", "transaction_hash"}},
+		projection{"by_address", []string{"address", "block_id"}}); err != nil {
+		return err
+	}
+
 	if scheme, ok := getScheme("txpool_status"); ok {
 		query := createTableQuery(
 			"txpool_status",
@@ -96,14 +127,26 @@ func setupSchemes(ctx context.Context, conn driver.Conn) error {
 	return nil
 }
 
-func createTableQuery(tableName, fields, engine string, primaryKeys, orderKeys []string) string {
+func createTableQuery(
+	tableName, fields, engine string, primaryKeys, orderKeys []string, projections ...projection,
+) string {
+	settings := ""
+	for _, p := range projections {
+		fields += fmt.Sprintf(", PROJECTION %s (SELECT * ORDER BY (%s))", p.name, strings.Join(p.order, ", "))
+	}
+	if len(projections) > 0 {
+		// the projections of ReplacingMergeTree must be rebuilt when the duplicate rows are merged away
+		settings = "SETTINGS deduplicate_merge_projection_mode = 'rebuild'"
+	}
+
 	query := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s
 		(%s)
 		ENGINE = %s
 		PRIMARY KEY (%s)
 		ORDER BY (%s)
-`, tableName, fields, engine, strings.Join(primaryKeys, ", "), strings.Join(orderKeys, ", "))
+		%s
+`, tableName, fields, engine, strings.Join(primaryKeys, ", "), strings.Join(orderKeys, ", "), settings)
 	return query
 }
 
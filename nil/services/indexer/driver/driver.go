@@ -16,11 +16,26 @@ type IndexerDriver interface {
 	FetchEarliestAbsentBlockId(context.Context, types.ShardId) (types.BlockNumber, error)
 	FetchNextPresentBlockId(context.Context, types.ShardId, types.BlockNumber) (types.BlockNumber, error)
 	FetchAddressActions(context.Context, types.Address, types.BlockNumber) ([]indexertypes.AddressAction, error)
+	// FetchTokenTransfers returns the transfers sent or received by the address, optionally filtered by the token.
+	// The transfers are ordered by their cursors, starting after the given one.
+	FetchTokenTransfers(
+		ctx context.Context, address types.Address, token *types.TokenId, after *indexertypes.Cursor, limit int,
+	) ([]indexertypes.TokenTransfer, error)
+	// FetchContractCreations returns the contracts deployed by the address, ordered by their cursors,
+	// starting after the given one.
+	FetchContractCreations(
+		ctx context.Context, deployer types.Address, after *indexertypes.Cursor, limit int,
+	) ([]indexertypes.ContractCreation, error)
+	// FetchContractCreation returns the deployment of the contract or nil if it is not indexed.
+	FetchContractCreation(context.Context, types.Address) (*indexertypes.ContractCreation, error)
 	FetchVersion(context.Context) (common.Hash, error)
 	ResetDB(ctx context.Context) error
 	IndexBlocks(context.Context, []*BlockWithShardId) error
 	IndexTxPool(context.Context, []*TxPoolStatus) error
 	HaveBlock(context.Context, types.ShardId, types.BlockNumber) (bool, error)
+	// DeleteBlocks removes the blocks of the shard starting from the given one,
+	// along with their transactions, logs, address actions, token transfers and contract creations.
+	DeleteBlocks(context.Context, types.ShardId, types.BlockNumber) error
 }
 
 type BlockWithShardId struct {
@@ -39,3 +54,50 @@ type TxPoolStatus struct {
 	ShardId   types.ShardId `ch:"shard_id"`
 	Timestamp time.Time     `ch:"timestamp"`
 }
+
+// TokenTransfers returns the tokens moved by the incoming transactions of the block.
+func TokenTransfers(block *BlockWithShardId) []indexertypes.TokenTransfer {
+	var transfers []indexertypes.TokenTransfer
+	for i, txn := range block.InTransactions {
+		status := indexertypes.Failed
+		if i < len(block.Receipts) && block.Receipts[i].Success {
+			status = indexertypes.Success
+		}
+		for _, token := range txn.Token {
+			transfers = append(transfers, indexertypes.TokenTransfer{
+				Hash:    txn.Hash(),
+				ShardId: block.ShardId,
+				BlockId: block.Id,
+				From:    txn.From,
+				To:      txn.To,
+				Token:   token.Token,
+				Amount:  token.Balance,
+				Status:  status,
+			})
+		}
+	}
+	return transfers
+}
+
+// ContractCreations returns the contracts successfully deployed by the incoming transactions of the block.
+func ContractCreations(block *BlockWithShardId) []indexertypes.ContractCreation {
+	var creations []indexertypes.ContractCreation
+	for i, txn := range block.InTransactions {
+		if !txn.IsDeploy() || i >= len(block.Receipts) || !block.Receipts[i].Success {
+			continue
+		}
+		var codeHash common.Hash
+		if payload := types.ParseDeployPayload(txn.Data); payload != nil {
+			codeHash = payload.Code().Hash()
+		}
+		creations = append(creations, indexertypes.ContractCreation{
+			Hash:     txn.Hash(),
+			ShardId:  block.ShardId,
+			BlockId:  block.Id,
+			Deployer: txn.From,
+			Address:  txn.To,
+			CodeHash: codeHash,
+		})
+	}
+	return creations
+}
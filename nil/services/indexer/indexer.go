@@ -28,7 +28,9 @@ type Indexer struct {
 	client      client.Client
 	allowDbDrop bool
 
-	blocksChan chan *driver.BlockWithShardId
+	blocksChan   chan *driver.BlockWithShardId
+	rollbackChan chan *rollbackRequest
+	onRollback   func(RollbackEvent)
 
 	logger logging.Logger
 }
@@ -42,11 +44,13 @@ func NewIndexerWithClient(client client.Client) *Indexer {
 
 func StartIndexer(ctx context.Context, cfg *Cfg) error {
 	e := &Indexer{
-		driver:      cfg.IndexerDriver,
-		client:      cfg.Client,
-		allowDbDrop: cfg.AllowDbDrop,
-		blocksChan:  make(chan *driver.BlockWithShardId, BlockBufferSize),
-		logger:      logging.NewLogger("indexer"),
+		driver:       cfg.IndexerDriver,
+		client:       cfg.Client,
+		allowDbDrop:  cfg.AllowDbDrop,
+		blocksChan:   make(chan *driver.BlockWithShardId, BlockBufferSize),
+		rollbackChan: make(chan *rollbackRequest),
+		onRollback:   cfg.OnRollback,
+		logger:       logging.NewLogger("indexer"),
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -150,6 +154,7 @@ func (e *Indexer) startFetchers(ctx context.Context, shardId types.ShardId) erro
 		return fmt.Errorf("failed to fetch last processed block id: %w", err)
 	}
 
+	var head *chainHead
 	// If the db is empty, add the top block to the queue.
 	if *lastProcessedBlock == types.InvalidBlockNumber {
 		topBlock, err := concurrent.RunWithRetries(
@@ -166,13 +171,22 @@ func (e *Indexer) startFetchers(ctx context.Context, shardId types.ShardId) erro
 		logger.Info().Msgf("No blocks processed yet. Adding the top block %d...", topBlock.Id)
 		e.blocksChan <- &driver.BlockWithShardId{BlockWithExtractedData: topBlock, ShardId: shardId}
 		lastProcessedBlock = &topBlock.Id
+		head = newChainHead(shardId, topBlock.Block)
+	} else {
+		lastBlock, err := concurrent.RunWithRetries(ctx, 1*time.Second, 10, func() (*types.Block, error) {
+			return e.driver.FetchBlock(ctx, shardId, *lastProcessedBlock)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch last processed block: %w", err)
+		}
+		head = newChainHead(shardId, lastBlock)
 	}
 
 	return concurrent.Run(ctx,
 		concurrent.MakeTask(
 			fmt.Sprintf("[%d] top fetcher", shardId),
 			func(ctx context.Context) error {
-				return e.runTopFetcher(ctx, shardId, *lastProcessedBlock+1)
+				return e.runTopFetcher(ctx, shardId, *lastProcessedBlock+1, head)
 			}),
 		concurrent.MakeTask(
 			fmt.Sprintf("[%d] bottom fetcher", shardId),
@@ -215,11 +229,14 @@ func (e *Indexer) runVersionCheckLoop(ctx context.Context) error {
 	})
 }
 
+// pushBlocks passes the blocks to the driver export.
+// If the head is given, the blocks are checked to continue it, and errRollbackDetected is returned otherwise.
 func (e *Indexer) pushBlocks(
 	ctx context.Context,
 	shardId types.ShardId,
 	fromId types.BlockNumber,
 	toId types.BlockNumber,
+	head *chainHead,
 ) (types.BlockNumber, error) {
 	const batchSize = 10
 	for id := fromId; id < toId; id += batchSize {
@@ -232,6 +249,12 @@ func (e *Indexer) pushBlocks(
 			return id, err
 		}
 		for _, b := range blocks {
+			if head != nil {
+				if !head.isParentOf(b.Block) {
+					return b.Id, errRollbackDetected
+				}
+				head.update(b.Block)
+			}
 			e.blocksChan <- &driver.BlockWithShardId{BlockWithExtractedData: b, ShardId: shardId}
 		}
 	}
@@ -239,7 +262,13 @@ func (e *Indexer) pushBlocks(
 }
 
 // runTopFetcher fetches blocks from `from` and indefinitely.
-func (e *Indexer) runTopFetcher(ctx context.Context, shardId types.ShardId, from types.BlockNumber) error {
+// When the chain is rolled back, the orphaned blocks are removed and the canonical ones are fetched again.
+func (e *Indexer) runTopFetcher(
+	ctx context.Context,
+	shardId types.ShardId,
+	from types.BlockNumber,
+	head *chainHead,
+) error {
 	logger := e.logger.With().Stringer(logging.FieldShardId, shardId).Logger()
 	logger.Info().Msgf("Starting top fetcher from %d", from)
 
@@ -253,6 +282,33 @@ func (e *Indexer) runTopFetcher(ctx context.Context, shardId types.ShardId, from
 			return
 		}
 
+		rollback := func() {
+			logger.Warn().Msgf("Rollback detected at block %d, rollback counter %d",
+				topBlock.Id, topBlock.RollbackCounter)
+			next, err := e.requestRollback(ctx, shardId, topBlock.RollbackCounter)
+			if err != nil {
+				logger.Error().Err(err).Msg("Failed to handle rollback")
+				return
+			}
+			var last *types.Block
+			if next > 0 {
+				if last, err = e.driver.FetchBlock(ctx, shardId, next-1); err != nil {
+					logger.Error().Err(err).Msg("Failed to fetch last processed block")
+					return
+				}
+			}
+			from = next
+			head = newChainHead(shardId, last)
+			// The kept blocks still carry the counter of the chain before the rollback,
+			// so the head takes the one of the canonical chain not to detect the same rollback again.
+			head.rollbackCounter = topBlock.RollbackCounter
+		}
+
+		if head.rolledBackBy(topBlock.Block) {
+			rollback()
+			return
+		}
+
 		// totally synced on top level
 		if topBlock.Id < from {
 			skippedTicks++
@@ -266,13 +322,22 @@ func (e *Indexer) runTopFetcher(ctx context.Context, shardId types.ShardId, from
 
 		next := min(topBlock.Id, from+maxFetchSize)
 		logger.Info().Msgf("Fetching blocks from %d to %d", from, next)
-		from, err = e.pushBlocks(ctx, shardId, from, next)
+		from, err = e.pushBlocks(ctx, shardId, from, next, head)
+		if errors.Is(err, errRollbackDetected) {
+			rollback()
+			return
+		}
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to fetch blocks")
 			return
 		}
 
 		if from == topBlock.Id {
+			if !head.isParentOf(topBlock.Block) {
+				rollback()
+				return
+			}
+			head.update(topBlock.Block)
 			e.blocksChan <- &driver.BlockWithShardId{BlockWithExtractedData: topBlock, ShardId: shardId}
 			from++
 		}
@@ -330,7 +395,7 @@ func (e *Indexer) runBottomFetcher(ctx context.Context, shardId types.ShardId, t
 		}
 
 		logger.Debug().Msgf("Fetching blocks from %d to %d", from, next)
-		from, err = e.pushBlocks(ctx, shardId, from, next)
+		from, err = e.pushBlocks(ctx, shardId, from, next, nil)
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to fetch blocks")
 		}
@@ -395,21 +460,47 @@ func (e *Indexer) startDriverIndex(ctx context.Context) error {
 	e.logger.Info().Msg("Starting driver export...")
 
 	var blockBuffer []*driver.BlockWithShardId
+	var rollback *rollbackRequest
 	concurrent.RunTickerLoop(ctx, 1*time.Second, func(ctx context.Context) {
-		// read available blocks
-		for len(e.blocksChan) > 0 && len(blockBuffer) < BlockBufferSize {
-			blockBuffer = append(blockBuffer, <-e.blocksChan)
+		if rollback == nil {
+			select {
+			case rollback = <-e.rollbackChan:
+			default:
+			}
 		}
 
-		if len(blockBuffer) == 0 {
-			return
+		// The blocks fetched before the rollback request must be indexed before the orphaned ones are removed.
+		pending := len(e.blocksChan)
+		for {
+			// read available blocks
+			for ; pending > 0 && len(blockBuffer) < BlockBufferSize; pending-- {
+				blockBuffer = append(blockBuffer, <-e.blocksChan)
+			}
+
+			if len(blockBuffer) == 0 {
+				break
+			}
+
+			if err := e.driver.IndexBlocks(ctx, blockBuffer); err != nil {
+				e.logger.Error().Err(err).Msg("Failed to export blocks; will retry in the next round.")
+				return
+			}
+			blockBuffer = blockBuffer[:0]
+
+			if rollback == nil || pending == 0 {
+				break
+			}
 		}
 
-		if err := e.driver.IndexBlocks(ctx, blockBuffer); err != nil {
-			e.logger.Error().Err(err).Msg("Failed to export blocks; will retry in the next round.")
-			return
+		if rollback != nil {
+			from, err := e.handleRollback(ctx, rollback)
+			if err != nil {
+				e.logger.Error().Err(err).Msg("Failed to handle rollback; will retry in the next round.")
+				return
+			}
+			rollback.result <- from
+			rollback = nil
 		}
-		blockBuffer = blockBuffer[:0]
 	})
 
 	return nil
@@ -0,0 +1,180 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+const rollbackSearchBatchSize = 100
+
+var errRollbackDetected = errors.New("rollback detected")
+
+// RollbackEvent describes the blocks of a shard removed from the index after the chain was rolled back.
+type RollbackEvent struct {
+	ShardId types.ShardId
+	// FromBlockId is the first removed block, the blocks starting from it are re-indexed from the canonical chain.
+	FromBlockId types.BlockNumber
+	// LatestBlockId is the latest indexed block before the rollback.
+	LatestBlockId   types.BlockNumber
+	RollbackCounter uint32
+}
+
+// rollbackRequest is handled by the driver export after all the blocks fetched before it are indexed,
+// so the orphaned blocks are not written after they are removed.
+type rollbackRequest struct {
+	shardId         types.ShardId
+	rollbackCounter uint32
+	// result receives the id of the first block to be fetched again.
+	result chan types.BlockNumber
+}
+
+// chainHead is the last block passed to the driver by the top fetcher.
+type chainHead struct {
+	shardId         types.ShardId
+	id              types.BlockNumber
+	hash            common.Hash
+	rollbackCounter uint32
+}
+
+func newChainHead(shardId types.ShardId, block *types.Block) *chainHead {
+	h := &chainHead{shardId: shardId}
+	if block != nil {
+		h.update(block)
+	}
+	return h
+}
+
+func (h *chainHead) known() bool {
+	return !h.hash.Empty()
+}
+
+func (h *chainHead) update(block *types.Block) {
+	h.id = block.Id
+	h.hash = block.Hash(h.shardId)
+	h.rollbackCounter = block.RollbackCounter
+}
+
+// isParentOf checks that the block continues the indexed chain.
+func (h *chainHead) isParentOf(block *types.Block) bool {
+	return !h.known() || (block.Id == h.id+1 && block.PrevBlock == h.hash)
+}
+
+// rolledBackBy checks whether the top block of the remote chain shows that the indexed chain was rolled back.
+func (h *chainHead) rolledBackBy(top *types.Block) bool {
+	if !h.known() {
+		return false
+	}
+	return top.RollbackCounter != h.rollbackCounter ||
+		top.Id < h.id ||
+		(top.Id == h.id && top.Hash(h.shardId) != h.hash)
+}
+
+// requestRollback asks the driver export to remove the orphaned blocks of the shard
+// and returns the id of the first block to be fetched again.
+func (e *Indexer) requestRollback(
+	ctx context.Context,
+	shardId types.ShardId,
+	rollbackCounter uint32,
+) (types.BlockNumber, error) {
+	request := &rollbackRequest{
+		shardId:         shardId,
+		rollbackCounter: rollbackCounter,
+		result:          make(chan types.BlockNumber, 1),
+	}
+	select {
+	case e.rollbackChan <- request:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	select {
+	case from := <-request.result:
+		return from, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// handleRollback removes the indexed blocks that are not in the canonical chain anymore.
+func (e *Indexer) handleRollback(ctx context.Context, request *rollbackRequest) (types.BlockNumber, error) {
+	logger := e.logger.With().Stringer(logging.FieldShardId, request.shardId).Logger()
+
+	latest, err := e.driver.FetchLatestProcessedBlockId(ctx, request.shardId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch last processed block id: %w", err)
+	}
+	if *latest == types.InvalidBlockNumber {
+		return 0, nil
+	}
+
+	from, err := e.findForkPoint(ctx, request.shardId, *latest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find fork point: %w", err)
+	}
+	if from > *latest {
+		logger.Info().Msg("Indexed blocks are in the canonical chain, nothing to roll back")
+		return from, nil
+	}
+
+	if err := e.driver.DeleteBlocks(ctx, request.shardId, from); err != nil {
+		return 0, fmt.Errorf("failed to delete orphaned blocks: %w", err)
+	}
+
+	event := RollbackEvent{
+		ShardId:         request.shardId,
+		FromBlockId:     from,
+		LatestBlockId:   *latest,
+		RollbackCounter: request.rollbackCounter,
+	}
+	logger.Warn().
+		Uint32("rollbackCounter", event.RollbackCounter).
+		Msgf("Chain was rolled back, removed blocks from %d to %d", from, *latest)
+	if e.onRollback != nil {
+		e.onRollback(event)
+	}
+	return from, nil
+}
+
+// findForkPoint returns the first indexed block that differs from the canonical one.
+// The search starts from the latest indexed block and goes down in batches.
+// A block absent in the index stops the search, since nothing is indexed right below it.
+func (e *Indexer) findForkPoint(
+	ctx context.Context,
+	shardId types.ShardId,
+	latest types.BlockNumber,
+) (types.BlockNumber, error) {
+	for to := latest + 1; to > 0; {
+		from := types.BlockNumber(0)
+		if to > rollbackSearchBatchSize {
+			from = to - rollbackSearchBatchSize
+		}
+
+		remoteBlocks, err := e.FetchBlocks(ctx, shardId, from, to)
+		if err != nil {
+			return 0, err
+		}
+		remote := make(map[types.BlockNumber]*types.Block, len(remoteBlocks))
+		for _, b := range remoteBlocks {
+			remote[b.Id] = b.Block
+		}
+
+		for id := to; id > from; id-- {
+			local, err := e.driver.FetchBlock(ctx, shardId, id-1)
+			if err != nil {
+				return 0, err
+			}
+			if local == nil {
+				return id, nil
+			}
+			if r, ok := remote[id-1]; ok && r.Hash(shardId) == local.Hash(shardId) {
+				return id, nil
+			}
+		}
+		to = from
+	}
+	return 0, nil
+}
@@ -0,0 +1,253 @@
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/indexer/badger"
+	"github.com/NilFoundation/nil/nil/services/indexer/driver"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeChain continues the chain with the blocks up to the given height.
+// The salt makes the blocks differ from the ones of another chain of the same height.
+func makeChain(
+	t *testing.T,
+	base []*types.BlockWithExtractedData,
+	height int,
+	rollbackCounter uint32,
+	salt uint64,
+) []*types.BlockWithExtractedData {
+	t.Helper()
+
+	chain := append([]*types.BlockWithExtractedData(nil), base...)
+	for i := len(base); i < height; i++ {
+		block := &types.BlockWithExtractedData{
+			Block: &types.Block{
+				BlockData: types.BlockData{
+					Id:              types.BlockNumber(i),
+					L1BlockNumber:   salt,
+					RollbackCounter: rollbackCounter,
+				},
+			},
+		}
+		if i > 0 {
+			block.PrevBlock = chain[i-1].Hash(types.MainShardId)
+		}
+		chain = append(chain, block)
+	}
+	return chain
+}
+
+func encodeDebugBlock(block *types.BlockWithExtractedData) (*jsonrpc.DebugRPCBlock, error) {
+	raw, err := block.EncodeToBytes()
+	if err != nil {
+		return nil, err
+	}
+	return jsonrpc.EncodeRawBlockWithExtractedData(raw)
+}
+
+// newChainClient serves the blocks of the remote chain returned by getChain.
+func newChainClient(getChain func() []*types.BlockWithExtractedData) *client.ClientMock {
+	return &client.ClientMock{
+		GetDebugBlockFunc: func(_ context.Context, _ types.ShardId, _ any, _ bool) (*jsonrpc.DebugRPCBlock, error) {
+			chain := getChain()
+			return encodeDebugBlock(chain[len(chain)-1])
+		},
+		GetDebugBlocksRangeFunc: func(
+			_ context.Context, _ types.ShardId, from, to types.BlockNumber, _ bool, _ int,
+		) ([]*jsonrpc.DebugRPCBlock, error) {
+			chain := getChain()
+			var res []*jsonrpc.DebugRPCBlock
+			for id := from; id < to && int(id) < len(chain); id++ {
+				encoded, err := encodeDebugBlock(chain[id])
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, encoded)
+			}
+			return res, nil
+		},
+	}
+}
+
+func TestChainHead(t *testing.T) {
+	t.Parallel()
+
+	chain := makeChain(t, nil, 3, 0, 0)
+	other := makeChain(t, chain[:1], 3, 1, 1)
+
+	head := newChainHead(types.MainShardId, nil)
+	require.True(t, head.isParentOf(chain[2].Block))
+	require.False(t, head.rolledBackBy(other[2].Block))
+
+	head.update(chain[0].Block)
+	require.True(t, head.isParentOf(chain[1].Block))
+	require.False(t, head.isParentOf(chain[2].Block))
+	require.False(t, head.rolledBackBy(chain[2].Block))
+
+	head.update(chain[1].Block)
+	require.False(t, head.isParentOf(other[2].Block))
+	// The rollback counter is changed
+	require.True(t, head.rolledBackBy(other[2].Block))
+	// The chain is shorter
+	require.True(t, head.rolledBackBy(chain[0].Block))
+	// Another block of the same height
+	require.True(t, head.rolledBackBy(makeChain(t, chain[:1], 2, 0, 2)[1].Block))
+}
+
+func TestHandleRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tmpDir, err := os.MkdirTemp("", "badger-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	badgerDriver, err := badger.NewBadgerDriver(filepath.Join(tmpDir, "test.db"))
+	require.NoError(t, err)
+
+	sender := types.HexToAddress("0x0001111111111111111111111111111111111111")
+	txn := &types.Transaction{From: sender, Value: types.NewValueFromUint64(1)}
+
+	orphaned := makeChain(t, nil, 5, 0, 0)
+	orphaned[3].InTransactions = []*types.Transaction{txn}
+	orphaned[3].Receipts = []*types.Receipt{{Success: true, TxnHash: txn.Hash()}}
+	canonical := makeChain(t, orphaned[:3], 6, 1, 1)
+
+	blocks := make([]*driver.BlockWithShardId, len(orphaned))
+	for i, b := range orphaned {
+		blocks[i] = &driver.BlockWithShardId{BlockWithExtractedData: b, ShardId: types.MainShardId}
+	}
+	require.NoError(t, badgerDriver.IndexBlocks(ctx, blocks))
+
+	var events []RollbackEvent
+	e := &Indexer{
+		driver: badgerDriver,
+		client: newChainClient(func() []*types.BlockWithExtractedData { return canonical }),
+		onRollback: func(event RollbackEvent) {
+			events = append(events, event)
+		},
+		logger: logging.NewLogger("indexer"),
+	}
+
+	actions, err := badgerDriver.FetchAddressActions(ctx, sender, 0)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	request := &rollbackRequest{shardId: types.MainShardId, rollbackCounter: 1}
+	from, err := e.handleRollback(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, types.BlockNumber(3), from)
+	require.Equal(t, []RollbackEvent{{
+		ShardId:         types.MainShardId,
+		FromBlockId:     3,
+		LatestBlockId:   4,
+		RollbackCounter: 1,
+	}}, events)
+
+	latest, err := badgerDriver.FetchLatestProcessedBlockId(ctx, types.MainShardId)
+	require.NoError(t, err)
+	require.Equal(t, types.BlockNumber(2), *latest)
+
+	for id, expected := range []bool{true, true, true, false, false} {
+		have, err := badgerDriver.HaveBlock(ctx, types.MainShardId, types.BlockNumber(id))
+		require.NoError(t, err)
+		require.Equal(t, expected, have, id)
+	}
+
+	actions, err = badgerDriver.FetchAddressActions(ctx, sender, 0)
+	require.NoError(t, err)
+	require.Empty(t, actions)
+
+	// Nothing is left to roll back
+	from, err = e.handleRollback(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, types.BlockNumber(3), from)
+	require.Len(t, events, 1)
+}
+
+func TestTopFetcherContinuesAfterRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	badgerDriver, err := badger.NewBadgerDriver(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+
+	orphaned := makeChain(t, nil, 5, 0, 0)
+	blocks := make([]*driver.BlockWithShardId, len(orphaned))
+	for i, b := range orphaned {
+		blocks[i] = &driver.BlockWithShardId{BlockWithExtractedData: b, ShardId: types.MainShardId}
+	}
+	require.NoError(t, badgerDriver.IndexBlocks(ctx, blocks))
+
+	var mu sync.Mutex
+	canonical := makeChain(t, orphaned[:3], 8, 1, 1)
+	getChain := func() []*types.BlockWithExtractedData {
+		mu.Lock()
+		defer mu.Unlock()
+		return canonical
+	}
+
+	var events []RollbackEvent
+	e := &Indexer{
+		driver:       badgerDriver,
+		client:       newChainClient(getChain),
+		blocksChan:   make(chan *driver.BlockWithShardId, BlockBufferSize),
+		rollbackChan: make(chan *rollbackRequest),
+		onRollback: func(event RollbackEvent) {
+			events = append(events, event)
+		},
+		logger: logging.NewLogger("indexer"),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, e.startDriverIndex(ctx))
+	}()
+	go func() {
+		defer wg.Done()
+		head := newChainHead(types.MainShardId, orphaned[4].Block)
+		assert.NoError(t, e.runTopFetcher(ctx, types.MainShardId, 5, head))
+	}()
+
+	indexedUpTo := func(id types.BlockNumber) func() bool {
+		return func() bool {
+			latest, err := badgerDriver.FetchLatestProcessedBlockId(ctx, types.MainShardId)
+			require.NoError(t, err)
+			if *latest != id {
+				return false
+			}
+			block, err := badgerDriver.FetchBlock(ctx, types.MainShardId, id)
+			require.NoError(t, err)
+			return block.Hash(types.MainShardId) == getChain()[id].Hash(types.MainShardId)
+		}
+	}
+
+	require.Eventually(t, indexedUpTo(7), 10*time.Second, 100*time.Millisecond)
+
+	// the chain grows after the rollback with the same counter
+	mu.Lock()
+	canonical = makeChain(t, canonical, 10, 1, 1)
+	mu.Unlock()
+	require.Eventually(t, indexedUpTo(9), 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+	require.Len(t, events, 1)
+	require.Equal(t, types.BlockNumber(3), events[0].FromBlockId)
+}
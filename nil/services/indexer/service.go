@@ -27,6 +27,13 @@ type Config struct {
 	DbPath      string `yaml:"db-path,omitempty"`      //nolint:tagliatelle
 }
 
+const (
+	// DefaultPageSize is the number of items returned if the limit is not set.
+	DefaultPageSize = 100
+	// MaxPageSize is the maximum number of items returned in a page.
+	MaxPageSize = 1000
+)
+
 const (
 	OwnEndpointDefault = "tcp://127.0.0.1:8528"
 	DbEndpointDefault  = "127.0.0.1:9000"
@@ -56,6 +63,20 @@ type IndexerJsonRpc interface {
 		address types.Address,
 		since types.BlockNumber,
 	) ([]indexertypes.AddressAction, error)
+	GetTokenTransfers(
+		ctx context.Context,
+		address types.Address,
+		token *types.TokenId,
+		cursor string,
+		limit uint64,
+	) (*indexertypes.TokenTransfersPage, error)
+	GetContractCreations(
+		ctx context.Context,
+		deployer types.Address,
+		cursor string,
+		limit uint64,
+	) (*indexertypes.ContractCreationsPage, error)
+	GetContractCreation(ctx context.Context, address types.Address) (*indexertypes.ContractCreation, error)
 }
 
 func (c *Config) InitFromFile(cfgFile string) bool {
@@ -102,6 +123,77 @@ func (s *Service) GetAddressActions(
 	return s.Driver.FetchAddressActions(ctx, address, since)
 }
 
+// GetTokenTransfers returns the token transfers sent or received by the address, optionally filtered by the token.
+// The cursor is taken from the previous page, the empty one starts from the earliest transfer.
+func (s *Service) GetTokenTransfers(
+	ctx context.Context,
+	address types.Address,
+	token *types.TokenId,
+	cursor string,
+	limit uint64,
+) (*indexertypes.TokenTransfersPage, error) {
+	after, err := indexertypes.ParseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := normalizePageSize(limit)
+
+	// One more item tells whether there is the next page
+	transfers, err := s.Driver.FetchTokenTransfers(ctx, address, token, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &indexertypes.TokenTransfersPage{Items: transfers}
+	if len(transfers) > pageSize {
+		page.Items = transfers[:pageSize]
+		page.NextCursor = page.Items[pageSize-1].Cursor().String()
+	}
+	return page, nil
+}
+
+// GetContractCreations returns the contracts deployed by the address.
+// The cursor is taken from the previous page, the empty one starts from the earliest deployment.
+func (s *Service) GetContractCreations(
+	ctx context.Context,
+	deployer types.Address,
+	cursor string,
+	limit uint64,
+) (*indexertypes.ContractCreationsPage, error) {
+	after, err := indexertypes.ParseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := normalizePageSize(limit)
+
+	creations, err := s.Driver.FetchContractCreations(ctx, deployer, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &indexertypes.ContractCreationsPage{Items: creations}
+	if len(creations) > pageSize {
+		page.Items = creations[:pageSize]
+		page.NextCursor = page.Items[pageSize-1].Cursor().String()
+	}
+	return page, nil
+}
+
+// GetContractCreation returns the deployment of the contract or nil if it is not indexed.
+func (s *Service) GetContractCreation(
+	ctx context.Context,
+	address types.Address,
+) (*indexertypes.ContractCreation, error) {
+	return s.Driver.FetchContractCreation(ctx, address)
+}
+
+func normalizePageSize(limit uint64) int {
+	if limit == 0 {
+		return DefaultPageSize
+	}
+	return int(min(limit, MaxPageSize))
+}
+
 func (s *Service) Run(ctx context.Context, cfg *Config) error {
 	return s.startRpcServer(ctx, cfg.OwnEndpoint)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/NilFoundation/nil/nil/services/indexer/driver"
 	indexertypes "github.com/NilFoundation/nil/nil/services/indexer/types"
@@ -179,6 +180,135 @@ func (s *SuiteServiceTest) TestGetAddressActions() {
 	}
 }
 
+func (s *SuiteServiceTest) TestTokenTransfers() {
+	sender := types.HexToAddress("0x0001111111111111111111111111111111111111")
+	receiver := types.HexToAddress("0x0001222222222222222222222222222222222222")
+	token1 := *types.TokenIdForAddress(types.HexToAddress("0x0001333333333333333333333333333333333333"))
+	token2 := *types.TokenIdForAddress(types.HexToAddress("0x0001444444444444444444444444444444444444"))
+
+	makeBlock := func(id types.BlockNumber, seqno types.Seqno, tokens ...types.TokenBalance) *driver.BlockWithShardId {
+		txn := &types.Transaction{
+			TransactionDigest: types.TransactionDigest{To: receiver, Seqno: seqno},
+			From:              sender,
+			Token:             tokens,
+		}
+		return &driver.BlockWithShardId{
+			BlockWithExtractedData: &types.BlockWithExtractedData{
+				Block:          &types.Block{BlockData: types.BlockData{Id: id}},
+				InTransactions: []*types.Transaction{txn},
+				Receipts:       []*types.Receipt{{Success: true, TxnHash: txn.Hash()}},
+			},
+			ShardId: types.BaseShardId,
+		}
+	}
+
+	blocks := []*driver.BlockWithShardId{
+		makeBlock(1, 0,
+			types.TokenBalance{Token: token1, Balance: types.NewValueFromUint64(10)},
+			types.TokenBalance{Token: token2, Balance: types.NewValueFromUint64(20)}),
+		makeBlock(2, 1),
+		makeBlock(3, 2, types.TokenBalance{Token: token1, Balance: types.NewValueFromUint64(30)}),
+	}
+	s.Require().NoError(s.service.Driver.IndexBlocks(s.ctx, blocks))
+
+	s.Run("Pagination", func() {
+		var amounts []uint64
+		cursor := ""
+		for range 3 {
+			page, err := s.service.GetTokenTransfers(s.ctx, receiver, nil, cursor, 2)
+			s.Require().NoError(err)
+			for _, transfer := range page.Items {
+				s.Equal(sender, transfer.From)
+				s.Equal(indexertypes.Success, transfer.Status)
+				amounts = append(amounts, transfer.Amount.Uint64())
+			}
+			cursor = page.NextCursor
+			if cursor == "" {
+				break
+			}
+		}
+		s.ElementsMatch([]uint64{10, 20, 30}, amounts)
+		s.Equal(uint64(30), amounts[2])
+	})
+
+	s.Run("FilterByToken", func() {
+		page, err := s.service.GetTokenTransfers(s.ctx, sender, &token1, "", 0)
+		s.Require().NoError(err)
+		s.Require().Len(page.Items, 2)
+		s.Empty(page.NextCursor)
+		s.Equal(types.BlockNumber(1), page.Items[0].BlockId)
+		s.Equal(types.BlockNumber(3), page.Items[1].BlockId)
+	})
+
+	s.Run("InvalidCursor", func() {
+		_, err := s.service.GetTokenTransfers(s.ctx, sender, nil, "xyz", 0)
+		s.Require().Error(err)
+	})
+
+	s.Run("Rollback", func() {
+		s.Require().NoError(s.service.Driver.DeleteBlocks(s.ctx, types.BaseShardId, 2))
+		page, err := s.service.GetTokenTransfers(s.ctx, sender, &token1, "", 0)
+		s.Require().NoError(err)
+		s.Require().Len(page.Items, 1)
+	})
+}
+
+func (s *SuiteServiceTest) TestContractCreations() {
+	deployer := types.HexToAddress("0x0001111111111111111111111111111111111111")
+	code := types.Code{0x60, 0x80}
+
+	var blocks []*driver.BlockWithShardId
+	var contracts []types.Address
+	for i := range 3 {
+		payload := types.BuildDeployPayload(code, common.BytesToHash([]byte{byte(i)}))
+		address := types.CreateAddress(types.BaseShardId, payload)
+		contracts = append(contracts, address)
+
+		txn := &types.Transaction{
+			TransactionDigest: types.TransactionDigest{
+				Flags: types.NewTransactionFlags(types.TransactionFlagDeploy),
+				To:    address,
+				Data:  payload.Bytes(),
+			},
+			From: deployer,
+		}
+		// The failed deployment is not indexed
+		success := i != 1
+		blocks = append(blocks, &driver.BlockWithShardId{
+			BlockWithExtractedData: &types.BlockWithExtractedData{
+				Block:          &types.Block{BlockData: types.BlockData{Id: types.BlockNumber(i + 1)}},
+				InTransactions: []*types.Transaction{txn},
+				Receipts:       []*types.Receipt{{Success: success, TxnHash: txn.Hash()}},
+			},
+			ShardId: types.BaseShardId,
+		})
+	}
+	s.Require().NoError(s.service.Driver.IndexBlocks(s.ctx, blocks))
+
+	page, err := s.service.GetContractCreations(s.ctx, deployer, "", 1)
+	s.Require().NoError(err)
+	s.Require().Len(page.Items, 1)
+	s.Equal(contracts[0], page.Items[0].Address)
+	s.Equal(code.Hash(), page.Items[0].CodeHash)
+	s.NotEmpty(page.NextCursor)
+
+	page, err = s.service.GetContractCreations(s.ctx, deployer, page.NextCursor, 1)
+	s.Require().NoError(err)
+	s.Require().Len(page.Items, 1)
+	s.Equal(contracts[2], page.Items[0].Address)
+	s.Empty(page.NextCursor)
+
+	creation, err := s.service.GetContractCreation(s.ctx, contracts[2])
+	s.Require().NoError(err)
+	s.Require().NotNil(creation)
+	s.Equal(deployer, creation.Deployer)
+	s.Equal(types.BlockNumber(3), creation.BlockId)
+
+	creation, err = s.service.GetContractCreation(s.ctx, contracts[1])
+	s.Require().NoError(err)
+	s.Nil(creation)
+}
+
 func TestServiceSuite(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -58,3 +60,79 @@ func (k *AddressActionStatus) Set(input string) error {
 	}
 	return nil
 }
+
+// TokenTransfer is a movement of a single token attached to a transaction.
+type TokenTransfer struct {
+	Hash    common.Hash         `json:"hash"`
+	ShardId types.ShardId       `json:"shardId"`
+	BlockId types.BlockNumber   `json:"blockId"`
+	From    types.Address       `json:"from"`
+	To      types.Address       `json:"to"`
+	Token   types.TokenId       `json:"token"`
+	Amount  types.Value         `json:"amount"`
+	Status  AddressActionStatus `json:"status"`
+}
+
+func (t *TokenTransfer) Cursor() Cursor {
+	return Cursor{BlockId: t.BlockId, Hash: t.Hash, Token: t.Token}
+}
+
+// ContractCreation is a successful deployment of a contract.
+type ContractCreation struct {
+	Hash     common.Hash       `json:"hash"`
+	ShardId  types.ShardId     `json:"shardId"`
+	BlockId  types.BlockNumber `json:"blockId"`
+	Deployer types.Address     `json:"deployer"`
+	Address  types.Address     `json:"address"`
+	// CodeHash is the hash of the code passed in the deploy transaction, i.e., the init code of the contract.
+	CodeHash common.Hash `json:"codeHash"`
+}
+
+func (c *ContractCreation) Cursor() Cursor {
+	return Cursor{BlockId: c.BlockId, Hash: c.Hash}
+}
+
+type TokenTransfersPage struct {
+	Items []TokenTransfer `json:"items"`
+	// NextCursor is empty if there are no more items.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type ContractCreationsPage struct {
+	Items []ContractCreation `json:"items"`
+	// NextCursor is empty if there are no more items.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Cursor is the position of the last item of a page, the next page starts right after it.
+// The items are ordered by block, transaction hash and token.
+type Cursor struct {
+	BlockId types.BlockNumber
+	Hash    common.Hash
+	Token   types.TokenId
+}
+
+const cursorSize = 8 + common.HashSize + types.AddrSize
+
+func (c Cursor) String() string {
+	buf := make([]byte, 0, cursorSize)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(c.BlockId))
+	buf = append(buf, c.Hash[:]...)
+	buf = append(buf, c.Token[:]...)
+	return hex.EncodeToString(buf)
+}
+
+// ParseCursor decodes the cursor returned in a page. The empty string means the beginning of the list.
+func ParseCursor(s string) (*Cursor, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	buf, err := hex.DecodeString(s)
+	if err != nil || len(buf) != cursorSize {
+		return nil, fmt.Errorf("invalid cursor: %s", s)
+	}
+	c := &Cursor{BlockId: types.BlockNumber(binary.BigEndian.Uint64(buf))}
+	copy(c.Hash[:], buf[8:])
+	copy(c.Token[:], buf[8+common.HashSize:])
+	return c, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/execution"
 	"github.com/NilFoundation/nil/nil/internal/keys"
+	"github.com/NilFoundation/nil/nil/internal/keystore"
 	"github.com/NilFoundation/nil/nil/internal/network"
 	"github.com/NilFoundation/nil/nil/internal/telemetry"
 	"github.com/NilFoundation/nil/nil/internal/tracing"
@@ -61,6 +62,16 @@ type Config struct {
 	ValidatorKeysPath    string                     `yaml:"validatorKeysPath,omitempty"`
 	ValidatorKeysManager *keys.ValidatorKeysManager `yaml:"-"`
 
+	// If ValidatorKeystoreDir is set, the validator key is kept encrypted in the keystore instead of ValidatorKeysPath.
+	// The passphrase is read from KeystorePassphraseFile or, if it is empty, from keystore.PassphraseEnv.
+	ValidatorKeystoreDir   string `yaml:"validatorKeystoreDir,omitempty"`
+	ValidatorKeyName       string `yaml:"validatorKeyName,omitempty"`
+	KeystorePassphraseFile string `yaml:"keystorePassphraseFile,omitempty"`
+
+	// If RemoteSignerEndpoint is set, consensus messages are signed by the remote signer
+	// and the validator key is not loaded into the node.
+	RemoteSignerEndpoint string `yaml:"remoteSignerEndpoint,omitempty"`
+
 	// HttpUrl is calculated from RPCPort
 	HttpUrl string `yaml:"-"`
 
@@ -104,6 +115,7 @@ func NewDefaultConfig() *Config {
 		NShards:           uint32(DefaultNShards),
 		MainKeysPath:      "keys.yaml",
 		ValidatorKeysPath: "validator-keys.yaml",
+		ValidatorKeyName:  "validator",
 
 		GracefulShutdown:  true,
 		Topology:          collate.TrivialShardTopologyId,
@@ -195,18 +207,25 @@ func (c *Config) Validate() error {
 }
 
 func (c *Config) LoadValidatorKeys() error {
-	if c.ValidatorKeysPath == "" {
+	if c.ValidatorKeysManager != nil {
 		return nil
 	}
 
-	if c.ValidatorKeysManager == nil {
-		c.ValidatorKeysManager = keys.NewValidatorKeyManager(c.ValidatorKeysPath)
-		if err := c.ValidatorKeysManager.InitKey(); err != nil {
-			return err
+	switch {
+	case c.ValidatorKeystoreDir != "":
+		passphrase, err := keystore.ReadPassphrase(c.KeystorePassphraseFile)
+		if err != nil {
+			return fmt.Errorf("failed to unlock validator keystore: %w", err)
 		}
+		c.ValidatorKeysManager = keys.NewValidatorKeyManagerWithKeystore(
+			keystore.New(c.ValidatorKeystoreDir), c.ValidatorKeyName, passphrase)
+	case c.ValidatorKeysPath != "":
+		c.ValidatorKeysManager = keys.NewValidatorKeyManager(c.ValidatorKeysPath)
+	default:
+		return nil
 	}
 
-	return nil
+	return c.ValidatorKeysManager.InitKey()
 }
 
 func (c *Config) LoadValidatorPrivateKey() (bls.PrivateKey, error) {
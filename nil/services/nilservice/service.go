@@ -17,6 +17,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/collate"
 	"github.com/NilFoundation/nil/nil/internal/config"
 	"github.com/NilFoundation/nil/nil/internal/consensus/ibft"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/execution"
 	"github.com/NilFoundation/nil/nil/internal/network"
@@ -26,6 +27,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/cometa"
 	"github.com/NilFoundation/nil/nil/services/faucet"
 	"github.com/NilFoundation/nil/nil/services/indexer"
+	"github.com/NilFoundation/nil/nil/services/remotesigner"
 	"github.com/NilFoundation/nil/nil/services/rollup"
 	"github.com/NilFoundation/nil/nil/services/rpc"
 	"github.com/NilFoundation/nil/nil/services/rpc/httpcfg"
@@ -397,12 +399,13 @@ func runNormalOrCollatorsOnly(
 	networkManager network.Manager,
 	logger logging.Logger,
 ) ([]concurrent.Task, map[types.ShardId]txnpool.Pool, error) {
-	if err := cfg.LoadValidatorKeys(); err != nil {
+	validatorSigner, err := createSigner(ctx, cfg, logger)
+	if err != nil {
 		return nil, nil, err
 	}
 
 	if !cfg.SplitShards && len(cfg.ZeroState.GetValidators()) == 0 {
-		if err := initDefaultValidator(cfg); err != nil {
+		if err := initDefaultValidator(cfg, validatorSigner); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -418,7 +421,7 @@ func runNormalOrCollatorsOnly(
 	}
 	funcs = append(funcs, syncersResult.funcs...)
 
-	shardFuncs, err := createShards(cfg, validators, syncersResult, database, networkManager, logger)
+	shardFuncs, err := createShards(cfg, validators, validatorSigner, syncersResult, database, networkManager, logger)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to create collators")
 		return nil, nil, err
@@ -684,14 +687,31 @@ func CreateNetworkManager(ctx context.Context, cfg *Config, database db.DB) (net
 	return network.NewManager(ctx, cfg.Network, database)
 }
 
-func initDefaultValidator(cfg *Config) error {
+// createSigner returns the remote signer if it is configured, otherwise the validator key is loaded into the node.
+// Nil is returned if no validator key is configured.
+func createSigner(ctx context.Context, cfg *Config, logger logging.Logger) (signer.Signer, error) {
+	if cfg.RemoteSignerEndpoint != "" {
+		return remotesigner.NewRemoteSigner(ctx, cfg.RemoteSignerEndpoint, logger)
+	}
+
+	if err := cfg.LoadValidatorKeys(); err != nil {
+		return nil, err
+	}
 	if cfg.ValidatorKeysManager == nil {
-		return errors.New("validator keys manager is nil")
+		return nil, nil
 	}
-	pubkey, err := cfg.ValidatorKeysManager.GetPublicKey()
+	pKey, err := cfg.ValidatorKeysManager.GetKey()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return signer.NewLocalSigner(pKey, nil)
+}
+
+func initDefaultValidator(cfg *Config, validatorSigner signer.Signer) error {
+	if validatorSigner == nil {
+		return errors.New("validator signer is not configured")
+	}
+	pubkey := validatorSigner.PublicKey()
 	validators := make([]config.ListValidators, cfg.NShards-1)
 	for i := range validators {
 		validators[i] = config.ListValidators{List: []config.ValidatorInfo{{PublicKey: config.Pubkey(pubkey)}}}
@@ -733,6 +753,7 @@ func createValidators(
 func createShards(
 	cfg *Config,
 	validators []*collate.Validator,
+	validatorSigner signer.Signer,
 	syncers *syncersResult,
 	database db.DB,
 	networkManager network.Manager,
@@ -750,9 +771,8 @@ func createShards(
 		shardId := types.ShardId(i)
 
 		if cfg.IsShardActive(shardId) {
-			pKey, err := cfg.ValidatorKeysManager.GetKey()
-			if err != nil {
-				return nil, err
+			if validatorSigner == nil {
+				return nil, errors.New("validator signer is not configured")
 			}
 
 			consensus, err := ibft.NewConsensus(&ibft.ConsensusParams{
@@ -760,7 +780,7 @@ func createShards(
 				Db:         database,
 				Validator:  validators[i],
 				NetManager: networkManager,
-				PrivateKey: pKey,
+				Signer:     validatorSigner,
 			})
 			if err != nil {
 				return nil, err
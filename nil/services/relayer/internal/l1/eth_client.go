@@ -1,6 +1,9 @@
 package l1
 
 import (
+	"context"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
@@ -8,4 +11,7 @@ type EthClient interface {
 	bind.ContractBackend
 	bind.ContractFilterer
 	bind.ContractTransactor
+	bind.DeployBackend
+
+	ChainID(ctx context.Context) (*big.Int, error)
 }
@@ -326,6 +326,10 @@ func (el *EventListener) eventProcessor(
 }
 
 func (el *EventListener) processEvent(ctx context.Context, ethEvent *L1MessageSent) error {
+	if ethEvent.Raw.Removed {
+		return el.dropRemovedEvent(ctx, ethEvent)
+	}
+
 	event := el.convertEvent(ethEvent)
 
 	if err := event.validate(); err != nil {
@@ -358,6 +362,47 @@ func (el *EventListener) processEvent(ctx context.Context, ethEvent *L1MessageSe
 	return nil
 }
 
+// subscription delivers already received logs once again with Removed flag if their block is reorged out
+func (el *EventListener) dropRemovedEvent(ctx context.Context, ethEvent *L1MessageSent) error {
+	eventHash := ethcommon.Hash(ethEvent.MessageHash)
+	deleted, err := el.eventStorage.DeleteEventFromBlock(ctx, eventHash, ethEvent.Raw.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	el.logger.Warn().
+		Stringer("event_hash", eventHash).
+		Uint64("block_number", ethEvent.Raw.BlockNumber).
+		Stringer("block_hash", ethEvent.Raw.BlockHash).
+		Bool("deleted", deleted).
+		Msg("event is removed from L1 due to chain reorganization")
+
+	el.metrics.AddRemovedEvent(ctx)
+	return nil
+}
+
+// FetchBlockEvents returns events from the actual version of the block,
+// used to re-fetch events after the block is found to be orphaned
+func (el *EventListener) FetchBlockEvents(ctx context.Context, blockNumber uint64) ([]*Event, error) {
+	ethEvents, err := el.contractBinding.GetEventsFromBlockRange(ctx, blockNumber, &blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(ethEvents))
+	for _, ethEvent := range ethEvents {
+		if ethEvent.Raw.Removed || ethEvent.Raw.BlockNumber != blockNumber {
+			continue
+		}
+		event := el.convertEvent(ethEvent)
+		if err := event.validate(); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
 func (el *EventListener) convertEvent(ethEvent *L1MessageSent) *Event {
 	event := &Event{
 		Hash:        ethEvent.MessageHash,
@@ -407,6 +407,119 @@ func (s *EventListenerTestSuite) TestSmoke() {
 	s.Require().NoError(err)
 }
 
+func (s *EventListenerTestSuite) TestRemovedEventIsDropped() {
+	s.ethClientMock.HeaderByNumberFunc = func(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+		return &ethtypes.Header{Number: big.NewInt(1024)}, nil
+	}
+
+	msgHash := getMsgHash(msgSourceSubscription, 1)
+	orphanedBlockHash := ethcommon.BytesToHash([]byte{1, 2, 3, 4})
+	actualBlockHash := ethcommon.BytesToHash([]byte{5, 6, 7, 8})
+
+	s.l1ContractMock.SubscribeToEventsFunc = func(
+		ctx context.Context,
+		sink chan<- *L1MessageSent,
+	) (event.Subscription, error) {
+		sub := event.NewSubscription(func(<-chan struct{}) error {
+			<-ctx.Done()
+			return nil
+		})
+
+		makeEvent := func(blockNumber uint64, blockHash ethcommon.Hash, removed bool) *L1MessageSent {
+			return &L1MessageSent{
+				MessageHash:       msgHash,
+				MessageExpiryTime: big.NewInt(1),
+				MessageNonce:      big.NewInt(2),
+				Raw: ethtypes.Log{
+					BlockNumber: blockNumber,
+					BlockHash:   blockHash,
+					Removed:     removed,
+				},
+			}
+		}
+
+		go func() {
+			// the event is received, reverted by reorg and included to another block
+			sink <- makeEvent(1025, orphanedBlockHash, false)
+			sink <- makeEvent(1025, orphanedBlockHash, true)
+			sink <- makeEvent(1026, actualBlockHash, false)
+		}()
+
+		return sub, nil
+	}
+
+	awaiter := s.waitForEvents(2)
+	s.runListener()
+	<-awaiter
+
+	err := s.storage.IterateEventsByBatch(s.ctx, 100, func(events []*Event) error {
+		s.Require().Len(events, 1)
+		s.EqualValues(1026, events[0].BlockNumber)
+		s.Equal(actualBlockHash, events[0].BlockHash)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	// the log is already deleted, nothing must be broken by its late removal
+	deleted, err := s.storage.DeleteEventFromBlock(s.ctx, msgHash, orphanedBlockHash)
+	s.Require().NoError(err)
+	s.False(deleted)
+}
+
+func (s *EventListenerTestSuite) TestFetchBlockEvents() {
+	s.ethClientMock.HeaderByNumberFunc = func(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+		return &ethtypes.Header{Number: big.NewInt(1024)}, nil
+	}
+	s.l1ContractMock.SubscribeToEventsFunc = func(
+		ctx context.Context,
+		sink chan<- *L1MessageSent,
+	) (event.Subscription, error) {
+		return event.NewSubscription(func(<-chan struct{}) error {
+			return nil
+		}), nil
+	}
+	s.l1ContractMock.GetEventsFromBlockRangeFunc = func(
+		ctx context.Context,
+		from uint64,
+		to *uint64,
+	) ([]*L1MessageSent, error) {
+		s.EqualValues(1000, from)
+		if s.NotNil(to) {
+			s.EqualValues(1000, *to)
+		}
+
+		return []*L1MessageSent{
+			{
+				MessageHash:       getMsgHash(msgSourceFetcher, 1),
+				MessageExpiryTime: big.NewInt(1),
+				MessageNonce:      big.NewInt(2),
+				Raw: ethtypes.Log{
+					BlockNumber: from,
+					BlockHash:   ethcommon.BytesToHash([]byte{1, 2, 3, 4}),
+					Removed:     true,
+				},
+			},
+			{
+				MessageHash:       getMsgHash(msgSourceFetcher, 2),
+				MessageExpiryTime: big.NewInt(1),
+				MessageNonce:      big.NewInt(2),
+				Raw: ethtypes.Log{
+					BlockNumber: from,
+					BlockHash:   ethcommon.BytesToHash([]byte{5, 6, 7, 8}),
+				},
+			},
+		}, nil
+	}
+
+	s.runListener()
+
+	events, err := s.listener.FetchBlockEvents(s.ctx, 1000)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(ethcommon.Hash(getMsgHash(msgSourceFetcher, 2)), events[0].Hash)
+	s.Equal(ethcommon.BytesToHash([]byte{5, 6, 7, 8}), events[0].BlockHash)
+}
+
 type msgSource byte
 
 const (
@@ -8,6 +8,7 @@ import (
 	"iter"
 	"maps"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -59,6 +60,7 @@ func DefaultFinalityEnsurerConfig() *FinalityEnsurerConfig {
 
 type eventProvider interface {
 	EventReceived() <-chan struct{}
+	FetchBlockEvents(ctx context.Context, blockNumber uint64) ([]*Event, error)
 }
 
 type FinalityEnsurer struct {
@@ -184,13 +186,15 @@ func (fe *FinalityEnsurer) blockFetcher(ctx context.Context, started chan struct
 				continue
 			}
 
-			if fe.finalizedBlock == nil ||
-				fe.finalizedBlock.BlockNumber != header.Number.Uint64() {
+			newFinalizedBlock := &ProcessedBlock{
+				BlockNumber: header.Number.Uint64(),
+				BlockHash:   header.Hash(),
+			}
+			fe.checkFinalityViolation(ctx, newFinalizedBlock)
+
+			if fe.finalizedBlock == nil || *fe.finalizedBlock != *newFinalizedBlock {
 				fe.finalizedBlockLock.Lock()
-				fe.finalizedBlock = &ProcessedBlock{
-					BlockNumber: header.Number.Uint64(),
-					BlockHash:   header.Hash(),
-				}
+				fe.finalizedBlock = newFinalizedBlock
 				fe.finalizedBlockLock.Unlock()
 			}
 
@@ -205,6 +209,35 @@ func (fe *FinalityEnsurer) blockFetcher(ctx context.Context, started chan struct
 	}
 }
 
+// checkFinalityViolation detects reorgs deeper than L1 finality window: finalized block is not expected
+// to go back or to change its hash. Events relayed from the rewritten blocks could be not a part of L1 anymore,
+// the relayer is not able to revert them, so the only thing to do is to raise an alert and drop stale cache.
+func (fe *FinalityEnsurer) checkFinalityViolation(ctx context.Context, newBlock *ProcessedBlock) {
+	prevBlock := fe.finalizedBlock
+	if prevBlock == nil {
+		return
+	}
+
+	violated := newBlock.BlockNumber < prevBlock.BlockNumber ||
+		(newBlock.BlockNumber == prevBlock.BlockNumber && newBlock.BlockHash != prevBlock.BlockHash)
+	if cached, ok := fe.finBlockCache.Peek(newBlock.BlockNumber); ok && cached.BlockHash != newBlock.BlockHash {
+		violated = true
+	}
+	if !violated {
+		return
+	}
+
+	fe.logger.Error().
+		Uint64("prev_finalized_block_number", prevBlock.BlockNumber).
+		Stringer("prev_finalized_block_hash", prevBlock.BlockHash).
+		Uint64("new_finalized_block_number", newBlock.BlockNumber).
+		Stringer("new_finalized_block_hash", newBlock.BlockHash).
+		Msg("L1 reorg beyond finality window detected, already relayed events might be reverted")
+
+	fe.metrics.AddFinalityViolation(ctx)
+	fe.finBlockCache.Purge()
+}
+
 func (fe *FinalityEnsurer) pendingEventPoller(ctx context.Context, started chan struct{}) error {
 	fe.logger.Info().Msg("started l1 pending event processor")
 
@@ -283,6 +316,14 @@ func (fe *FinalityEnsurer) forwardFinalizedEvents(ctx context.Context) error {
 		Int("orphaned_blocks_count", len(orphaned)).
 		Msg("checked blocks finality")
 
+	var refetchedEvents []*Event
+	if len(orphaned) > 0 {
+		refetchedEvents, err = fe.refetchOrphanedBlocks(ctx, orphaned, eventByBlock)
+		if err != nil {
+			return fmt.Errorf("failed to re-fetch events from orphaned blocks: %w", err)
+		}
+	}
+
 	var (
 		finalizedEventCount int
 		orphanedEventCount  = len(events)
@@ -320,6 +361,7 @@ func (fe *FinalityEnsurer) forwardFinalizedEvents(ctx context.Context) error {
 
 	fe.logger.Info().
 		Int("dropping_events_count", len(events)).
+		Int("refetched_events_count", len(refetchedEvents)).
 		Msg("dropping pending events from L1 storage")
 
 	droppingEvents := make([]ethcommon.Hash, 0, len(events))
@@ -327,13 +369,69 @@ func (fe *FinalityEnsurer) forwardFinalizedEvents(ctx context.Context) error {
 		droppingEvents = append(droppingEvents, evt.Hash)
 	}
 
-	if err := fe.l1Storage.DeleteEvents(ctx, droppingEvents); err != nil {
+	// events from the actual versions of orphaned blocks are going to be forwarded on the next iteration
+	if err := fe.l1Storage.ReplaceEvents(ctx, droppingEvents, refetchedEvents); err != nil {
 		return fmt.Errorf("failed to cleanup events from l1 storage: %w", err)
 	}
 
+	fe.metrics.AddRefetchedEvents(ctx, uint64(len(refetchedEvents)))
+
 	return nil
 }
 
+// refetchOrphanedBlocks collects events from the canonical versions of blocks
+// which pending events were received from before the reorg
+func (fe *FinalityEnsurer) refetchOrphanedBlocks(
+	ctx context.Context,
+	orphaned []ProcessedBlock,
+	eventByBlock map[ProcessedBlock][]*Event,
+) ([]*Event, error) {
+	blockNumbers := make([]uint64, 0, len(orphaned))
+	orphanedEventCount := 0
+	for _, blk := range orphaned {
+		blockNumbers = append(blockNumbers, blk.BlockNumber)
+		orphanedEventCount += len(eventByBlock[blk])
+	}
+	slices.Sort(blockNumbers)
+	blockNumbers = slices.Compact(blockNumbers)
+
+	fe.logger.Error().
+		Uints64("orphaned_block_numbers", blockNumbers).
+		Int("orphaned_event_count", orphanedEventCount).
+		Msg("L1 reorg detected: pending events belong to blocks which are not canonical anymore")
+
+	fe.metrics.AddReorgedBlocks(ctx, uint64(len(blockNumbers)))
+
+	var refetched []*Event
+	for _, blkNum := range blockNumbers {
+		actualBlock, err := fe.getBlockHeader(ctx, blkNum)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := fe.eventProvider.FetchBlockEvents(ctx, blkNum)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, evt := range events {
+			if evt.BlockHash != actualBlock.BlockHash {
+				// L1 provider is not consistent yet, orphaned events are kept until the next attempt
+				return nil, fmt.Errorf(
+					"re-fetched event %s belongs to block %s instead of finalized %s (block number %d)",
+					evt.Hash, evt.BlockHash, actualBlock.BlockHash, blkNum)
+			}
+		}
+		refetched = append(refetched, events...)
+	}
+
+	fe.logger.Info().
+		Int("refetched_event_count", len(refetched)).
+		Msg("re-fetched events from orphaned blocks")
+
+	return refetched, nil
+}
+
 func (fe *FinalityEnsurer) checkBlocksFinality(
 	ctx context.Context,
 	base *ProcessedBlock,
@@ -355,7 +453,7 @@ func (fe *FinalityEnsurer) checkBlocksFinality(
 		}
 
 		if finalizedBlock.BlockHash != blk.BlockHash {
-			fe.logger.Debug().
+			fe.logger.Warn().
 				Uint64("orphaned_block_num", blk.BlockNumber).
 				Stringer("actual_hash", blk.BlockHash).
 				Stringer("expected_hash", finalizedBlock.BlockHash).
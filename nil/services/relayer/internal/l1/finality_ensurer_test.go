@@ -3,6 +3,7 @@ package l1
 import (
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,14 +23,31 @@ import (
 
 type eventListenerStub struct {
 	emitter chan struct{}
+
+	mu sync.Mutex
+	// events returned for re-fetched blocks
+	blockEvents map[uint64][]*Event
 }
 
 func newEventListenerStub() *eventListenerStub {
 	return &eventListenerStub{
-		emitter: make(chan struct{}),
+		emitter:     make(chan struct{}),
+		blockEvents: make(map[uint64][]*Event),
 	}
 }
 
+func (els *eventListenerStub) FetchBlockEvents(ctx context.Context, blockNumber uint64) ([]*Event, error) {
+	els.mu.Lock()
+	defer els.mu.Unlock()
+	return els.blockEvents[blockNumber], nil
+}
+
+func (els *eventListenerStub) setBlockEvents(blockNumber uint64, events ...*Event) {
+	els.mu.Lock()
+	defer els.mu.Unlock()
+	els.blockEvents[blockNumber] = events
+}
+
 // Can be used by reading routine to look for updates without further delay
 func (els *eventListenerStub) EventReceived() <-chan struct{} {
 	return els.emitter
@@ -301,3 +319,123 @@ func (s *FinalityEnsurerTestSuite) TestOrphanedBlock() {
 	})
 	s.Require().NoError(err)
 }
+
+func (s *FinalityEnsurerTestSuite) TestOrphanedBlockIsRefetched() {
+	const N = 1000
+
+	orphanedHeader := ethtypes.Header{
+		Number:     big.NewInt(N + 1),
+		ParentHash: ethcommon.HexToHash("0xDEADBEEF"),
+	}
+	actualHeader := ethtypes.Header{
+		Number: big.NewInt(N + 1),
+	}
+	s.setBlockHeaderOnL1(actualHeader)
+
+	err := s.l1Storage.StoreEvent(s.ctx, &Event{
+		Hash:        getMsgHash(msgSourceSubscription, 1),
+		BlockNumber: N + 1,
+		BlockHash:   orphanedHeader.Hash(),
+	})
+	s.Require().NoError(err)
+
+	// the same message is included to the canonical block along with the new one
+	s.eventListenerStub.setBlockEvents(N+1,
+		&Event{
+			Hash:        getMsgHash(msgSourceSubscription, 1),
+			BlockNumber: N + 1,
+			BlockHash:   actualHeader.Hash(),
+		},
+		&Event{
+			Hash:        getMsgHash(msgSourceFetcher, 2),
+			BlockNumber: N + 1,
+			BlockHash:   actualHeader.Hash(),
+		},
+	)
+
+	s.advanceFinalizedBlockNumberTo(N + 100)
+	s.eventListenerStub.waitForEnsurerLoop()
+
+	// re-fetched events are relayed on the next iteration
+	s.eventListenerStub.waitForEnsurerLoop()
+
+	var relayed []ethcommon.Hash
+	err = s.l2Storage.IterateEventsByBatch(s.ctx, 100, func(events []*l2.Event) error {
+		for _, evt := range events {
+			relayed = append(relayed, evt.Hash)
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.ElementsMatch([]ethcommon.Hash{
+		getMsgHash(msgSourceSubscription, 1),
+		getMsgHash(msgSourceFetcher, 2),
+	}, relayed)
+
+	err = s.l1Storage.IterateEventsByBatch(s.ctx, 100, func(events []*Event) error {
+		s.Fail("some events unexpectedly left in L1 storage", "found %d events", len(events))
+		return nil
+	})
+	s.Require().NoError(err)
+}
+
+func (s *FinalityEnsurerTestSuite) TestInconsistentRefetchKeepsEvents() {
+	const N = 1000
+
+	orphanedHeader := ethtypes.Header{
+		Number:     big.NewInt(N + 1),
+		ParentHash: ethcommon.HexToHash("0xDEADBEEF"),
+	}
+	s.setBlockHeaderOnL1(ethtypes.Header{
+		Number: big.NewInt(N + 1),
+	})
+
+	err := s.l1Storage.StoreEvent(s.ctx, &Event{
+		Hash:        getMsgHash(msgSourceSubscription, 1),
+		BlockNumber: N + 1,
+		BlockHash:   orphanedHeader.Hash(),
+	})
+	s.Require().NoError(err)
+
+	// L1 provider still returns logs from the orphaned block
+	s.eventListenerStub.setBlockEvents(N+1, &Event{
+		Hash:        getMsgHash(msgSourceSubscription, 1),
+		BlockNumber: N + 1,
+		BlockHash:   orphanedHeader.Hash(),
+	})
+
+	s.advanceFinalizedBlockNumberTo(N + 100)
+	s.eventListenerStub.waitForEnsurerLoop()
+
+	pendingCount := 0
+	err = s.l1Storage.IterateEventsByBatch(s.ctx, 100, func(events []*Event) error {
+		pendingCount += len(events)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(1, pendingCount, "orphaned event must be kept until its block is re-fetched")
+}
+
+func (s *FinalityEnsurerTestSuite) TestFinalityViolationDropsBlockCache() {
+	const N = 1000
+
+	hdr := ethtypes.Header{
+		Number: big.NewInt(N),
+	}
+	s.setBlockHeaderOnL1(hdr)
+	err := s.l1Storage.StoreEvent(s.ctx, &Event{
+		Hash:        getMsgHash(msgSourceSubscription, 1),
+		BlockNumber: N,
+		BlockHash:   hdr.Hash(),
+	})
+	s.Require().NoError(err)
+
+	s.advanceFinalizedBlockNumberTo(N + 100)
+	s.eventListenerStub.waitForEnsurerLoop()
+	s.checkL2StorageContent(N)
+	s.Positive(s.ensurer.finBlockCache.Len())
+
+	// finalized block is not expected to go back
+	s.advanceFinalizedBlockNumberTo(N + 50)
+	s.Zero(s.ensurer.finBlockCache.Len())
+}
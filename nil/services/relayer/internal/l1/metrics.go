@@ -16,6 +16,7 @@ type EventListenerMetrics interface {
 	AddEventFromFetcher(ctx context.Context)
 	AddEventFromSubscriber(ctx context.Context)
 	AddSubscriptionError(ctx context.Context)
+	AddRemovedEvent(ctx context.Context)
 }
 
 const (
@@ -26,6 +27,7 @@ const (
 	eventStatusLabel     = "event_status"
 	eventStatusFinalized = "finalized"
 	eventStatusOrphaned  = "orphaned"
+	eventStatusRefetched = "refetched"
 )
 
 type eventListenerMetrics struct {
@@ -34,6 +36,7 @@ type eventListenerMetrics struct {
 	fetcherRunStatus telemetry.Gauge // 0 if fetcher is inactive
 	subsciptionError telemetry.Counter
 	eventsProcessed  telemetry.Counter
+	eventsRemoved    telemetry.Counter // events reverted by L1 reorg before they were finalized
 }
 
 func NewEventListenerMetrics() (EventListenerMetrics, error) {
@@ -62,6 +65,11 @@ func (elm *eventListenerMetrics) Init(name string, meter telemetry.Meter, attrs
 		return err
 	}
 
+	elm.eventsRemoved, err = meter.Int64Counter(name + ".events_removed")
+	if err != nil {
+		return err
+	}
+
 	elm.attrs = attrs
 	return nil
 }
@@ -88,11 +96,18 @@ func (elm *eventListenerMetrics) AddSubscriptionError(ctx context.Context) {
 	elm.subsciptionError.Add(ctx, 1, elm.attrs)
 }
 
+func (elm *eventListenerMetrics) AddRemovedEvent(ctx context.Context) {
+	elm.eventsRemoved.Add(ctx, 1, elm.attrs)
+}
+
 type FinalityEnsurerMetrics interface {
 	SetTimeSinceFinalizedBlockNumberUpdate(ctx context.Context, sec uint64)
 	AddRelayError(ctx context.Context)
 	AddFinalizedEvents(ctx context.Context, count uint64)
 	AddOrphanedEvents(ctx context.Context, count uint64)
+	AddRefetchedEvents(ctx context.Context, count uint64)
+	AddReorgedBlocks(ctx context.Context, count uint64)
+	AddFinalityViolation(ctx context.Context)
 }
 
 type finalityEnsurerMetrics struct {
@@ -101,6 +116,8 @@ type finalityEnsurerMetrics struct {
 	finalizedBlockUpdateLag telemetry.Gauge
 	relayErrors             telemetry.Counter
 	processedEvents         telemetry.Counter
+	reorgedBlocks           telemetry.Counter
+	finalityViolations      telemetry.Counter
 }
 
 func NewFinalityEnsurerMetrics() (FinalityEnsurerMetrics, error) {
@@ -129,6 +146,16 @@ func (fem *finalityEnsurerMetrics) Init(name string, meter telemetry.Meter, attr
 		return err
 	}
 
+	fem.reorgedBlocks, err = meter.Int64Counter(name + ".reorged_blocks")
+	if err != nil {
+		return err
+	}
+
+	fem.finalityViolations, err = meter.Int64Counter(name + ".finality_violation")
+	if err != nil {
+		return err
+	}
+
 	fem.attrs = attrs
 	return nil
 }
@@ -154,3 +181,18 @@ func (fem *finalityEnsurerMetrics) AddOrphanedEvents(ctx context.Context, count
 		fem.attrs,
 	)
 }
+
+func (fem *finalityEnsurerMetrics) AddRefetchedEvents(ctx context.Context, count uint64) {
+	fem.processedEvents.Add(ctx, int64(count),
+		telattr.With(attribute.String(eventStatusLabel, eventStatusRefetched)),
+		fem.attrs,
+	)
+}
+
+func (fem *finalityEnsurerMetrics) AddReorgedBlocks(ctx context.Context, count uint64) {
+	fem.reorgedBlocks.Add(ctx, int64(count), fem.attrs)
+}
+
+func (fem *finalityEnsurerMetrics) AddFinalityViolation(ctx context.Context) {
+	fem.finalityViolations.Add(ctx, 1, fem.attrs)
+}
@@ -57,6 +57,9 @@ func (es *EventStorage) StoreEvent(ctx context.Context, evt *Event) error {
 	if evt.Hash == emptyHash {
 		return errors.New("cannot store event without hash")
 	}
+	if evt.BlockHash == emptyHash {
+		return errors.New("cannot store event without block hash")
+	}
 
 	return es.RetryRunner.Do(ctx, func(ctx context.Context) error {
 		var err error
@@ -139,6 +142,96 @@ func (es *EventStorage) DeleteEvents(ctx context.Context, hashes []ethcommon.Has
 	})
 }
 
+// ReplaceEvents atomically drops events with given hashes and stores new ones instead of them,
+// it is used to substitute events from orphaned blocks with their actual versions re-fetched from L1
+func (es *EventStorage) ReplaceEvents(ctx context.Context, dropping []ethcommon.Hash, replacing []*Event) error {
+	var emptyHash ethcommon.Hash
+	for _, evt := range replacing {
+		if evt.Hash == emptyHash || evt.BlockHash == emptyHash {
+			return errors.New("cannot store event without hash or block hash")
+		}
+	}
+
+	return es.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := es.Database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		for _, hash := range dropping {
+			if err := tx.Delete(pendingEventsTable, hash.Bytes()); err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+				return err
+			}
+		}
+
+		for _, evt := range replacing {
+			evt.SequenceNumber, err = es.eventsSequencer.Next()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(evt)
+			if err != nil {
+				return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+			}
+			if err := tx.Put(pendingEventsTable, evt.Hash.Bytes(), data); err != nil {
+				return err
+			}
+		}
+
+		return es.Commit(tx, func() {
+			es.Metrics.RecordDeletes(ctx, pendingEventsTable, len(dropping))
+			es.Metrics.RecordInserts(ctx, pendingEventsTable, len(replacing))
+		})
+	})
+}
+
+// DeleteEventFromBlock drops pending event only if it was stored from the given block,
+// returns false if there is no such event
+func (es *EventStorage) DeleteEventFromBlock(
+	ctx context.Context,
+	hash ethcommon.Hash,
+	blockHash ethcommon.Hash,
+) (bool, error) {
+	var deleted bool
+	err := es.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		deleted = false
+
+		tx, err := es.Database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		data, err := tx.Get(pendingEventsTable, hash.Bytes())
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var evt Event
+		if err := json.Unmarshal(data, &evt); err != nil {
+			return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+		}
+		if evt.BlockHash != blockHash {
+			// event has been already re-stored from another block
+			return nil
+		}
+
+		if err := tx.Delete(pendingEventsTable, hash.Bytes()); err != nil {
+			return err
+		}
+
+		return es.Commit(tx, func() {
+			deleted = true
+			es.Metrics.RecordDeletes(ctx, pendingEventsTable, 1)
+		})
+	})
+	return deleted, err
+}
+
 func (es *EventStorage) GetLastProcessedBlock(ctx context.Context) (*ProcessedBlock, error) {
 	var ret *ProcessedBlock
 	err := es.RetryRunner.Do(ctx, func(ctx context.Context) error {
@@ -5,21 +5,34 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/NilFoundation/nil/nil/client"
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/internal/abi"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/txnpool"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+var ErrFeeCreditCeilingExceeded = errors.New("estimated fee credit exceeds configured ceiling")
+
 type ContractConfig struct {
 	Endpoint            string
 	SmartAccountAddress string
 	ContractAddress     string
 	PrivateKeyPath      string
 
+	// Fee management
+	// Max fee credit which can be spent by a single relaying transaction
+	FeeCreditCeiling types.Value
+	// Percent of MaxPriorityFeePerGas increase for resending underpriced transaction
+	FeeBumpPercent  uint64
+	MaxSendAttempts int
+
 	// Testing only
 	DebugMode        bool
 	SmartAccountSalt string
@@ -28,8 +41,11 @@ type ContractConfig struct {
 
 func DefaultContractConfig() *ContractConfig {
 	return &ContractConfig{
-		PrivateKeyPath: "relayer_key.ecdsa",
-		DebugMode:      false,
+		PrivateKeyPath:   "relayer_key.ecdsa",
+		FeeCreditCeiling: types.NewValueFromUint64(10_000_000_000_000_000),
+		FeeBumpPercent:   2 * txnpool.FeeBumpPercentage,
+		MaxSendAttempts:  5,
+		DebugMode:        false,
 	}
 }
 
@@ -46,15 +62,37 @@ func (cfg *ContractConfig) Validate() error {
 	if len(cfg.ContractAddress) == 0 {
 		return errors.New("empty L2BridgeMessenger contract address")
 	}
+	if cfg.FeeCreditCeiling.IsZero() {
+		return errors.New("fee credit ceiling is not set")
+	}
+	if cfg.FeeBumpPercent < txnpool.FeeBumpPercentage {
+		return fmt.Errorf("fee bump percent must be at least %d to replace pending transaction",
+			txnpool.FeeBumpPercentage)
+	}
+	if cfg.MaxSendAttempts <= 0 {
+		return errors.New("max send attempts must be positive")
+	}
 	return nil
 }
 
 type L2Contract interface {
-	RelayMessage(ctx context.Context, event *Event) (common.Hash, error)
+	// RelayMessages forwards events to L2BridgeMessenger in a single transaction
+	RelayMessages(ctx context.Context, events []*Event) (common.Hash, error)
+}
+
+// relayMessageParams mirrors IRelayMessage.RelayMessageParams struct
+type relayMessageParams struct {
+	MessageSender     ethcommon.Address
+	MessageTarget     ethcommon.Address
+	MessageType       uint8
+	MessageNonce      *big.Int
+	Message           []byte
+	MessageExpiryTime *big.Int
 }
 
 type l2ContractWrapper struct {
 	nilClient        client.Client
+	config           *ContractConfig
 	privateKey       *ecdsa.PrivateKey
 	smartAccountAddr types.Address
 	contractAddr     types.Address
@@ -102,6 +140,7 @@ func NewL2ContractWrapper(
 
 	return &l2ContractWrapper{
 		nilClient:        nilClient,
+		config:           config,
 		privateKey:       pk,
 		smartAccountAddr: smartAccountAddr,
 		contractAddr:     contractAddr,
@@ -110,35 +149,151 @@ func NewL2ContractWrapper(
 	}, nil
 }
 
-func (w *l2ContractWrapper) RelayMessage(
+func (w *l2ContractWrapper) RelayMessages(
 	ctx context.Context,
-	evt *Event,
+	events []*Event,
 ) (common.Hash, error) {
-	const methodName = "relayMessage"
-	calldata, err := w.abi.Pack(methodName,
-		evt.Sender,
-		evt.Target,
-		evt.Type,
-		evt.Nonce,
-		evt.Message,
-		evt.ExpiryTime,
-	)
+	calldata, err := w.packRelayCall(events)
 	if err != nil {
 		return common.EmptyHash, err
 	}
 
-	w.logger.Trace().Stringer("event_hash", evt.Hash).Msg("relaying event")
-
-	return client.SendTransactionViaSmartAccount(
-		ctx,
-		w.nilClient,
-		w.smartAccountAddr,
-		calldata,
-		evt.FeePack,
-		types.Value0,
-		nil,
-		w.contractAddr,
-		w.privateKey,
-		false,
-	)
+	payload, err := client.CreateInternalTransactionPayload(calldata, types.Value0, nil, w.contractAddr, false)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	seqno, err := w.nilClient.GetTransactionCount(ctx, w.smartAccountAddr, "pending")
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	txn := &types.ExternalTransaction{
+		Kind:  types.ExecutionTransactionKind,
+		To:    w.smartAccountAddr,
+		Data:  payload,
+		Seqno: seqno,
+	}
+
+	txn.FeePack, err = w.estimateFee(ctx, txn)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	w.logger.Trace().
+		Int("event_count", len(events)).
+		Stringer("fee_credit", txn.FeeCredit).
+		Msg("relaying events")
+
+	return w.sendWithFeeBump(ctx, txn)
+}
+
+// packRelayCall uses single message call if possible since it is supported by all L2BridgeMessenger versions
+func (w *l2ContractWrapper) packRelayCall(events []*Event) ([]byte, error) {
+	if len(events) == 0 {
+		return nil, errors.New("no events to relay")
+	}
+
+	if len(events) == 1 {
+		evt := events[0]
+		return w.abi.Pack("relayMessage",
+			evt.Sender,
+			evt.Target,
+			evt.Type,
+			evt.Nonce,
+			evt.Message,
+			evt.ExpiryTime,
+		)
+	}
+
+	params := make([]relayMessageParams, 0, len(events))
+	for _, evt := range events {
+		params = append(params, relayMessageParams{
+			MessageSender:     evt.Sender,
+			MessageTarget:     evt.Target,
+			MessageType:       evt.Type,
+			MessageNonce:      evt.Nonce,
+			Message:           evt.Message,
+			MessageExpiryTime: evt.ExpiryTime,
+		})
+	}
+	return w.abi.Pack("relayMessages", params)
+}
+
+func (w *l2ContractWrapper) estimateFee(ctx context.Context, txn *types.ExternalTransaction) (types.FeePack, error) {
+	estimated, err := client.EstimateFeeExternal(ctx, w.nilClient, txn, "latest")
+	if err != nil {
+		return types.FeePack{}, fmt.Errorf("failed to estimate fee: %w", err)
+	}
+	if estimated == nil {
+		return types.FeePack{}, errors.New("empty fee estimation")
+	}
+
+	if estimated.FeeCredit.Cmp(w.config.FeeCreditCeiling) > 0 {
+		return types.FeePack{}, fmt.Errorf("%w: %s > %s",
+			ErrFeeCreditCeilingExceeded, estimated.FeeCredit, w.config.FeeCreditCeiling)
+	}
+
+	return types.FeePack{
+		FeeCredit:            estimated.FeeCredit,
+		MaxPriorityFeePerGas: estimated.AveragePriorityFee,
+		MaxFeePerGas:         estimated.MaxBasFee.Add(estimated.AveragePriorityFee),
+	}, nil
+}
+
+// sendWithFeeBump resends the transaction with increased priority fee
+// if it is rejected by txpool as underpriced or conflicting with another transaction by seqno
+func (w *l2ContractWrapper) sendWithFeeBump(
+	ctx context.Context,
+	txn *types.ExternalTransaction,
+) (common.Hash, error) {
+	var err error
+	for attempt := range w.config.MaxSendAttempts {
+		if err := txn.Sign(w.privateKey); err != nil {
+			return common.EmptyHash, err
+		}
+
+		var txHash common.Hash
+		txHash, err = w.nilClient.SendTransaction(ctx, txn)
+		if err == nil {
+			return txHash, nil
+		}
+
+		switch {
+		case isTxnPoolError(err, txnpool.SeqnoTooLow):
+			seqno, seqnoErr := w.nilClient.GetTransactionCount(ctx, w.smartAccountAddr, "pending")
+			if seqnoErr != nil {
+				return common.EmptyHash, seqnoErr
+			}
+			txn.Seqno = seqno
+		case isTxnPoolError(err, txnpool.NotReplaced), isTxnPoolError(err, txnpool.TooSmallMaxFee):
+		default:
+			return common.EmptyHash, err
+		}
+
+		txn.FeePack = bumpFee(txn.FeePack, w.config.FeeBumpPercent)
+
+		w.logger.Warn().Err(err).
+			Int("attempt", attempt+1).
+			Uint64("seqno", uint64(txn.Seqno)).
+			Stringer("max_priority_fee_per_gas", txn.MaxPriorityFeePerGas).
+			Msg("relaying transaction is rejected, resending with bumped fee")
+	}
+	return common.EmptyHash, fmt.Errorf("failed to send transaction in %d attempts: %w", w.config.MaxSendAttempts, err)
+}
+
+func isTxnPoolError(err error, reason txnpool.DiscardReason) bool {
+	return strings.Contains(err.Error(), reason.String())
+}
+
+func bumpFee(fee types.FeePack, percent uint64) types.FeePack {
+	priorityFee := fee.MaxPriorityFeePerGas.Mul64(100 + percent).Div64(100)
+	if priorityFee.Cmp(fee.MaxPriorityFeePerGas) <= 0 {
+		priorityFee = fee.MaxPriorityFeePerGas.Add64(1)
+	}
+	return types.FeePack{
+		FeeCredit:            fee.FeeCredit,
+		MaxPriorityFeePerGas: priorityFee,
+		MaxFeePerGas:         fee.MaxFeePerGas.Add(priorityFee.Sub(fee.MaxPriorityFeePerGas)),
+	}
 }
@@ -2,70 +2,154 @@ package l2
 
 import (
 	"context"
+	"errors"
 	"math/big"
-	"os"
 	"path"
 	"testing"
 
 	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
 	nilcrypto "github.com/NilFoundation/nil/nil/internal/crypto"
 	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+	"github.com/NilFoundation/nil/nil/services/txnpool"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
-func TestL2BridgeMessengerABI(t *testing.T) {
-	t.Parallel()
-
-	clMock := &client.ClientMock{}
-	clMock.GetCodeFunc = func(context.Context, types.Address, any) (types.Code, error) {
-		return []byte("code"), nil
+func newClientMock(estimatedFeeCredit uint64) *client.ClientMock {
+	return &client.ClientMock{
+		GetCodeFunc: func(context.Context, types.Address, any) (types.Code, error) {
+			return []byte("code"), nil
+		},
+		GetTransactionCountFunc: func(context.Context, types.Address, any) (types.Seqno, error) {
+			return 10, nil
+		},
+		EstimateFeeFunc: func(context.Context, *jsonrpc.CallArgs, any) (*jsonrpc.EstimateFeeRes, error) {
+			return &jsonrpc.EstimateFeeRes{
+				FeeCredit:          types.NewValueFromUint64(estimatedFeeCredit),
+				AveragePriorityFee: types.NewValueFromUint64(100),
+				MaxBasFee:          types.NewValueFromUint64(1000),
+			}, nil
+		},
 	}
+}
+
+func newTestContractWrapper(t *testing.T, clMock *client.ClientMock) *l2ContractWrapper {
+	t.Helper()
 
 	key, _, err := nilcrypto.GenerateKeyPair()
 	require.NoError(t, err)
 
-	keyPath := path.Join(os.TempDir(), "test_key.ecdsa")
-
+	keyPath := path.Join(t.TempDir(), "test_key.ecdsa")
 	require.NoError(t, crypto.SaveECDSA(keyPath, key))
-	defer os.Remove(keyPath)
 
 	logger := logging.NewLogger("relayer_l2_contract_wrapper_test")
 
-	wrapper, err := NewL2ContractWrapper(
-		t.Context(),
-		&ContractConfig{
-			Endpoint:            "localhost:8545",
-			SmartAccountAddress: "0xDEADBEEF",
-			ContractAddress:     "0xC0FFEE",
-			PrivateKeyPath:      keyPath,
-		},
-		clMock,
-		logger,
-	)
+	config := DefaultContractConfig()
+	config.Endpoint = "localhost:8545"
+	config.SmartAccountAddress = "0xDEADBEEF"
+	config.ContractAddress = "0xC0FFEE"
+	config.PrivateKeyPath = keyPath
+
+	wrapper, err := NewL2ContractWrapper(t.Context(), config, clMock, logger)
 	require.NoError(t, err)
+	return wrapper
+}
 
-	_, err = wrapper.RelayMessage(
-		t.Context(),
-		&Event{
-			BlockNumber:    1,
-			Hash:           ethcommon.Hash{},
-			SequenceNumber: 1,
-			FeePack: types.FeePack{
-				FeeCredit:            types.NewValueFromUint64(1),
-				MaxPriorityFeePerGas: types.NewValueFromUint64(1),
-				MaxFeePerGas:         types.NewValueFromUint64(1),
-			},
-			L2Limit:    types.NewValueFromUint64(2),
-			Sender:     ethcommon.BigToAddress(big.NewInt(0x111)),
-			Target:     ethcommon.BigToAddress(big.NewInt(0x222)),
-			Message:    []byte("some very important deposit data"),
-			Nonce:      big.NewInt(2),
-			Type:       1,
-			ExpiryTime: big.NewInt(1),
+func makeTestEvent(nonce int64) *Event {
+	return &Event{
+		BlockNumber:    1,
+		Hash:           ethcommon.Hash{},
+		SequenceNumber: 1,
+		FeePack: types.FeePack{
+			FeeCredit:            types.NewValueFromUint64(1),
+			MaxPriorityFeePerGas: types.NewValueFromUint64(1),
+			MaxFeePerGas:         types.NewValueFromUint64(1),
 		},
-	)
+		L2Limit:    types.NewValueFromUint64(2),
+		Sender:     ethcommon.BigToAddress(big.NewInt(0x111)),
+		Target:     ethcommon.BigToAddress(big.NewInt(0x222)),
+		Message:    []byte("some very important deposit data"),
+		Nonce:      big.NewInt(nonce),
+		Type:       1,
+		ExpiryTime: big.NewInt(1),
+	}
+}
+
+func TestL2BridgeMessengerABI(t *testing.T) {
+	t.Parallel()
+
+	clMock := newClientMock(1000)
+	wrapper := newTestContractWrapper(t, clMock)
+
+	_, err := wrapper.RelayMessages(t.Context(), []*Event{makeTestEvent(2)})
+	require.NoError(t, err)
+
+	_, err = wrapper.RelayMessages(t.Context(), []*Event{makeTestEvent(3), makeTestEvent(4)})
 	require.NoError(t, err)
+
+	require.Len(t, clMock.SendTransactionCalls(), 2)
+	for _, call := range clMock.SendTransactionCalls() {
+		require.Equal(t, types.NewValueFromUint64(1000), call.Txn.FeeCredit)
+		require.Equal(t, types.NewValueFromUint64(100), call.Txn.MaxPriorityFeePerGas)
+		require.Equal(t, types.NewValueFromUint64(1100), call.Txn.MaxFeePerGas)
+	}
+}
+
+func TestRelayMessagesFeeCeiling(t *testing.T) {
+	t.Parallel()
+
+	clMock := newClientMock(1000)
+	wrapper := newTestContractWrapper(t, clMock)
+	wrapper.config.FeeCreditCeiling = types.NewValueFromUint64(999)
+
+	_, err := wrapper.RelayMessages(t.Context(), []*Event{makeTestEvent(2)})
+	require.ErrorIs(t, err, ErrFeeCreditCeilingExceeded)
+	require.Empty(t, clMock.SendTransactionCalls())
+}
+
+func TestRelayMessagesFeeBump(t *testing.T) {
+	t.Parallel()
+
+	clMock := newClientMock(1000)
+	wrapper := newTestContractWrapper(t, clMock)
+
+	rejections := []txnpool.DiscardReason{txnpool.NotReplaced, txnpool.SeqnoTooLow}
+	var sent []types.ExternalTransaction
+	clMock.SendTransactionFunc = func(ctx context.Context, txn *types.ExternalTransaction) (common.Hash, error) {
+		sent = append(sent, *txn)
+		if len(rejections) > 0 {
+			reason := rejections[0]
+			rejections = rejections[1:]
+			return common.EmptyHash, errors.New(reason.String())
+		}
+		return common.BytesToHash([]byte{1}), nil
+	}
+
+	_, err := wrapper.RelayMessages(t.Context(), []*Event{makeTestEvent(2)})
+	require.NoError(t, err)
+
+	require.Len(t, sent, 3)
+	for i := 1; i < len(sent); i++ {
+		prev, cur := sent[i-1], sent[i]
+		bumped := prev.MaxPriorityFeePerGas.Mul64(100 + txnpool.FeeBumpPercentage).Div64(100)
+		require.GreaterOrEqual(t, cur.MaxPriorityFeePerGas.Cmp(bumped), 0, "fee is not bumped on attempt %d", i)
+		require.Equal(t, prev.FeeCredit, cur.FeeCredit)
+	}
+
+	// seqno is re-read after SeqnoTooLow rejection only
+	require.Len(t, clMock.GetTransactionCountCalls(), 2)
+
+	// non-retryable errors are returned as is
+	clMock.SendTransactionFunc = func(ctx context.Context, txn *types.ExternalTransaction) (common.Hash, error) {
+		return common.EmptyHash, errors.New(txnpool.Unverified.String())
+	}
+	clMock.ResetSendTransactionCalls()
+
+	_, err = wrapper.RelayMessages(t.Context(), []*Event{makeTestEvent(2)})
+	require.Error(t, err)
+	require.Len(t, clMock.SendTransactionCalls(), 1)
 }
@@ -11,6 +11,7 @@ import (
 type TransactionSenderMetrics interface {
 	AddRelayedEvents(ctx context.Context, count uint64)
 	AddRelayError(ctx context.Context)
+	AddSentTransaction(ctx context.Context)
 }
 
 type transactionSenderMetrics struct {
@@ -18,6 +19,7 @@ type transactionSenderMetrics struct {
 
 	relayErrors   telemetry.Counter
 	relayedEvents telemetry.Counter
+	sentTxs       telemetry.Counter
 }
 
 func NewTransactionSenderMetrics() (TransactionSenderMetrics, error) {
@@ -41,6 +43,11 @@ func (tsm *transactionSenderMetrics) Init(name string, meter telemetry.Meter, at
 		return err
 	}
 
+	tsm.sentTxs, err = meter.Int64Counter(name + ".sent_transactions")
+	if err != nil {
+		return err
+	}
+
 	tsm.attrs = attrs
 	return nil
 }
@@ -52,3 +59,7 @@ func (tsm *transactionSenderMetrics) AddRelayError(ctx context.Context) {
 func (tsm *transactionSenderMetrics) AddRelayedEvents(ctx context.Context, count uint64) {
 	tsm.relayedEvents.Add(ctx, int64(count), tsm.attrs)
 }
+
+func (tsm *transactionSenderMetrics) AddSentTransaction(ctx context.Context) {
+	tsm.sentTxs.Add(ctx, 1, tsm.attrs)
+}
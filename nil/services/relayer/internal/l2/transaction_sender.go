@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/NilFoundation/nil/nil/common/heap"
@@ -15,6 +16,8 @@ import (
 type TransactionSenderConfig struct {
 	DbPollInterval  time.Duration
 	EventBufferSize int
+	// Max number of events relayed by a single L2 transaction
+	MaxBatchSize int
 }
 
 func (cfg *TransactionSenderConfig) Validate() error {
@@ -24,6 +27,9 @@ func (cfg *TransactionSenderConfig) Validate() error {
 	if cfg.EventBufferSize == 0 {
 		return errors.New("no event buffer size for the poll heap is set")
 	}
+	if cfg.MaxBatchSize <= 0 {
+		return errors.New("max batch size must be positive")
+	}
 	return nil
 }
 
@@ -31,6 +37,7 @@ func DefaultTransactionSenderConfig() *TransactionSenderConfig {
 	return &TransactionSenderConfig{
 		DbPollInterval:  time.Second * 10,
 		EventBufferSize: 500,
+		MaxBatchSize:    20,
 	}
 }
 
@@ -145,21 +152,55 @@ func (ts *TransactionSender) relayEvents(ctx context.Context) error {
 		}
 	}()
 
-	for i, evt := range events {
-		if _, err := ts.contractBinding.RelayMessage(ctx, evt); err != nil {
-			ts.logger.Error().Err(err).
-				Int("event_index", i).
-				Uint64("event_seqno", evt.SequenceNumber).
-				Stringer("event_hash", evt.Hash).
-				Msg("failed to relay event to L2")
-
+	// batches are sent sequentially to keep L1 ordering of the events
+	for batch := range slices.Chunk(events, ts.config.MaxBatchSize) {
+		err := ts.relayBatch(ctx, batch, &droppingEvents)
+		if err == nil {
+			continue
+		}
+		if len(batch) == 1 {
 			return err
 		}
-		ts.logger.Debug().Stringer("event_hash", evt.Hash).Msg("event relayed to L2")
-		droppingEvents = append(droppingEvents, evt.Hash)
 
-		ts.metrics.AddRelayedEvents(ctx, 1)
+		// A single failing event (e.g. the one exceeding the fee ceiling) must not block the others,
+		// so the batch is relayed event by event up to the failing one
+		ts.logger.Warn().Err(err).
+			Int("batch_size", len(batch)).
+			Msg("falling back to relaying events of the failed batch one by one")
+
+		for _, evt := range batch {
+			if err := ts.relayBatch(ctx, []*Event{evt}, &droppingEvents); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// relayBatch sends the events in a single L2 transaction, relayed events are appended to droppingEvents
+func (ts *TransactionSender) relayBatch(ctx context.Context, batch []*Event, droppingEvents *[]common.Hash) error {
+	txHash, err := ts.contractBinding.RelayMessages(ctx, batch)
+	if err != nil {
+		ts.logger.Error().Err(err).
+			Int("batch_size", len(batch)).
+			Uint64("first_event_seqno", batch[0].SequenceNumber).
+			Stringer("first_event_hash", batch[0].Hash).
+			Msg("failed to relay events to L2")
+
+		return err
+	}
+
+	ts.logger.Debug().
+		Int("batch_size", len(batch)).
+		Stringer("tx_hash", txHash).
+		Msg("events relayed to L2")
+
+	for _, evt := range batch {
+		*droppingEvents = append(*droppingEvents, evt.Hash)
 	}
 
+	ts.metrics.AddRelayedEvents(ctx, uint64(len(batch)))
+	ts.metrics.AddSentTransaction(ctx)
 	return nil
 }
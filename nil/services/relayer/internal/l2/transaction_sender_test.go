@@ -90,6 +90,7 @@ func (s *TransactionSenderTestSuite) SetupTest() {
 	s.l2Storage = NewEventStorage(s.ctx, s.database, s.clockMock, s.storageMetrics, s.logger)
 
 	cfg := DefaultTransactionSenderConfig()
+	cfg.MaxBatchSize = 2
 
 	s.eventFinalizer = newEventFinalizerStub()
 
@@ -141,16 +142,22 @@ func (s *TransactionSenderTestSuite) runSenderWithExpectedEvents(sequenceNumbers
 	cancel, stopped := s.runSender()
 
 	var seqNoIdx int
-	s.contractMock.RelayMessageFunc = func(ctx context.Context, event *Event) (common.Hash, error) {
-		if failOnSeqNo != nil && event.SequenceNumber == *failOnSeqNo {
-			return common.EmptyHash, fmt.Errorf("managed failure on %d seqno", event.SequenceNumber)
+	s.contractMock.RelayMessagesFunc = func(ctx context.Context, events []*Event) (common.Hash, error) {
+		s.Require().LessOrEqual(len(events), s.transactionSender.config.MaxBatchSize)
+		for _, event := range events {
+			// the whole batch is failed
+			if failOnSeqNo != nil && event.SequenceNumber == *failOnSeqNo {
+				return common.EmptyHash, fmt.Errorf("managed failure on %d seqno", event.SequenceNumber)
+			}
+		}
+		for _, event := range events {
+			s.Require().Equal(
+				sequenceNumbers[seqNoIdx], event.SequenceNumber,
+				"unexpected order of events (seq number %d)", seqNoIdx,
+			)
+			seqNoIdx++
+			set[event.SequenceNumber] = true
 		}
-		s.Require().Equal(
-			sequenceNumbers[seqNoIdx], event.SequenceNumber,
-			"unexpected order of events (seq number %d)", seqNoIdx,
-		)
-		seqNoIdx++
-		set[event.SequenceNumber] = true
 		return common.EmptyHash, nil
 	}
 
@@ -217,9 +224,17 @@ func (s *TransactionSenderTestSuite) TestFailure() {
 
 	s.Require().NoError(s.l2Storage.StoreEvents(s.ctx, l2Events))
 
+	// events are relayed by batches of 2, the batch of 3 and 4 fails,
+	// then the event 3 is relayed alone and the sender stops on the event 4
 	var failOnSeqNo uint64 = 4
 	s.runSenderWithExpectedEvents([]uint64{1, 2, 3}, &failOnSeqNo)
 
+	var batchSizes []int
+	for _, call := range s.contractMock.RelayMessagesCalls() {
+		batchSizes = append(batchSizes, len(call.Events))
+	}
+	s.Require().Equal([]int{2, 2, 1, 1}, batchSizes[:4])
+
 	err := s.l2Storage.IterateEventsByBatch(s.ctx, 3, func(events []*Event) error {
 		s.Require().Len(events, 2)
 		s.Require().EqualValues(4, events[0].SequenceNumber)
@@ -237,6 +252,25 @@ func (s *TransactionSenderTestSuite) TestFailure() {
 	s.Require().NoError(err)
 }
 
+func (s *TransactionSenderTestSuite) TestBatching() {
+	var l2Events []*Event
+	for i := 1; i <= 5; i++ {
+		l2Events = append(l2Events, &Event{
+			Hash:           getMsgHash(i),
+			SequenceNumber: uint64(i),
+		})
+	}
+	s.Require().NoError(s.l2Storage.StoreEvents(s.ctx, l2Events))
+
+	s.runSenderWithExpectedEvents([]uint64{1, 2, 3, 4, 5}, nil)
+
+	var batchSizes []int
+	for _, call := range s.contractMock.RelayMessagesCalls() {
+		batchSizes = append(batchSizes, len(call.Events))
+	}
+	s.Equal([]int{2, 2, 1}, batchSizes)
+}
+
 func getMsgHash(seqNo int) [32]byte {
 	var hash [32]byte
 	for i := range hash {
@@ -0,0 +1,9 @@
+package withdrawal
+
+import "errors"
+
+var (
+	ErrWithdrawalAlreadyClaimed = errors.New("withdrawal is already claimed on L1")
+	ErrInvalidWithdrawal        = errors.New("invalid withdrawal event from L2")
+	ErrClaimTxFailed            = errors.New("withdrawal claim transaction failed")
+)
@@ -0,0 +1,160 @@
+package withdrawal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/jonboulle/clockwork"
+)
+
+type EventListenerConfig struct {
+	// L2 block to start scanning from, usually the block L2BridgeMessenger was deployed in
+	StartBlock uint64
+
+	PollInterval      time.Duration
+	EmitEventCapacity int
+}
+
+func DefaultEventListenerConfig() *EventListenerConfig {
+	return &EventListenerConfig{
+		PollInterval:      time.Second * 5,
+		EmitEventCapacity: 0, // recommended for production usage
+	}
+}
+
+func (cfg *EventListenerConfig) Validate() error {
+	if cfg.PollInterval == 0 {
+		return errors.New("empty poll interval for fetching L2 withdrawals")
+	}
+	return nil
+}
+
+// EventListener scans blocks of the L2BridgeMessenger shard for sent withdrawals and stores them
+// along with the leaves of the L2->L1 message tree
+type EventListener struct {
+	config   *EventListenerConfig
+	clock    clockwork.Clock
+	contract L2Contract
+	storage  *Storage
+	metrics  EventListenerMetrics
+	emitter  chan struct{} // signals when new withdrawals are put to storage
+	logger   logging.Logger
+}
+
+func NewEventListener(
+	config *EventListenerConfig,
+	clock clockwork.Clock,
+	contract L2Contract,
+	storage *Storage,
+	metrics EventListenerMetrics,
+	logger logging.Logger,
+) (*EventListener, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	el := &EventListener{
+		config:   config,
+		clock:    clock,
+		contract: contract,
+		storage:  storage,
+		metrics:  metrics,
+		emitter:  make(chan struct{}, config.EmitEventCapacity),
+	}
+	el.logger = logger.With().Str(logging.FieldComponent, el.Name()).Logger()
+	return el, nil
+}
+
+func (el *EventListener) Name() string {
+	return "withdrawal-event-listener"
+}
+
+// Can be used by reading routine to look for updates without further delay
+func (el *EventListener) WithdrawalReceived() <-chan struct{} {
+	return el.emitter
+}
+
+func (el *EventListener) Run(ctx context.Context, started chan<- struct{}) error {
+	el.logger.Info().Msg("initializing component")
+
+	ticker := el.clock.NewTicker(el.config.PollInterval)
+	defer ticker.Stop()
+	close(started)
+
+	for {
+		if err := el.fetchWithdrawals(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			el.logger.Error().Err(err).Msg("failed to fetch withdrawals from L2")
+			el.metrics.AddFetchError(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.Chan():
+		}
+	}
+}
+
+func (el *EventListener) fetchWithdrawals(ctx context.Context) error {
+	from := el.config.StartBlock
+	lastProcessed, err := el.storage.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if lastProcessed != nil {
+		from = max(from, lastProcessed.BlockNumber+1)
+	}
+
+	latest, err := el.contract.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if from > latest {
+		el.logger.Trace().Uint64("latest_block", latest).Msg("no new L2 blocks")
+		return nil
+	}
+
+	el.logger.Debug().
+		Uint64("from_block", from).
+		Uint64("to_block", latest).
+		Msg("fetching withdrawals from L2 blocks")
+
+	for blockNumber := from; blockNumber <= latest; blockNumber++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		blk, withdrawals, err := el.contract.GetWithdrawals(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		if err := el.storage.StoreBlockWithdrawals(ctx, blk, withdrawals); err != nil {
+			return err
+		}
+		el.metrics.SetLastProcessedBlock(ctx, blockNumber)
+
+		if len(withdrawals) == 0 {
+			continue
+		}
+
+		for _, w := range withdrawals {
+			el.logger.Info().
+				Stringer("message_hash", w.Hash).
+				Uint64("leaf_index", w.LeafIndex).
+				Uint64("block_number", blockNumber).
+				Msg("withdrawal received from L2")
+		}
+		el.metrics.AddWithdrawals(ctx, uint64(len(withdrawals)))
+
+		select {
+		case el.emitter <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package withdrawal
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/storage"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/jonboulle/clockwork"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventListenerTestSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	storage *Storage
+	logger  logging.Logger
+
+	contractMock *L2ContractMock
+	latestBlock  uint64
+	// withdrawals by L2 block number
+	blocks map[uint64][]*Withdrawal
+
+	listener *EventListener
+}
+
+func TestEventListener(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(EventListenerTestSuite))
+}
+
+func (s *EventListenerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.logger = logging.NewFromZerolog(zerolog.New(zerolog.NewConsoleWriter()))
+
+	database, err := db.NewBadgerDbInMemory()
+	s.Require().NoError(err)
+
+	storageMetrics, err := storage.NewTableMetrics()
+	s.Require().NoError(err)
+
+	clock := clockwork.NewFakeClock()
+	s.storage = NewStorage(s.ctx, database, clock, storageMetrics, s.logger)
+
+	s.latestBlock = 0
+	s.blocks = make(map[uint64][]*Withdrawal)
+	s.contractMock = &L2ContractMock{
+		GetLatestBlockNumberFunc: func(ctx context.Context) (uint64, error) {
+			return s.latestBlock, nil
+		},
+		GetWithdrawalsFunc: func(ctx context.Context, blockNumber uint64) (*ProcessedBlock, []*Withdrawal, error) {
+			return &ProcessedBlock{
+				BlockHash:   ethcommon.BigToHash(big.NewInt(int64(blockNumber) + 1)),
+				BlockNumber: blockNumber,
+			}, s.blocks[blockNumber], nil
+		},
+	}
+
+	metrics, err := NewEventListenerMetrics()
+	s.Require().NoError(err)
+
+	config := DefaultEventListenerConfig()
+	config.StartBlock = 5
+	config.EmitEventCapacity = 1
+
+	s.listener, err = NewEventListener(config, clock, s.contractMock, s.storage, metrics, s.logger)
+	s.Require().NoError(err)
+}
+
+func (s *EventListenerTestSuite) addWithdrawal(blockNumber uint64, leafIndex uint64) {
+	s.blocks[blockNumber] = append(s.blocks[blockNumber], &Withdrawal{
+		BlockNumber: blockNumber,
+		Hash:        ethcommon.BigToHash(big.NewInt(int64(leafIndex) + 100)),
+		LeafIndex:   leafIndex,
+		Nonce:       big.NewInt(int64(leafIndex)),
+	})
+}
+
+func (s *EventListenerTestSuite) requestedBlocks() []uint64 {
+	var ret []uint64
+	for _, call := range s.contractMock.GetWithdrawalsCalls() {
+		ret = append(ret, call.BlockNumber)
+	}
+	return ret
+}
+
+func (s *EventListenerTestSuite) TestFetchWithdrawals() {
+	s.addWithdrawal(6, 0)
+	s.addWithdrawal(6, 1)
+	s.addWithdrawal(8, 2)
+	s.latestBlock = 8
+
+	s.Require().NoError(s.listener.fetchWithdrawals(s.ctx))
+	s.Equal([]uint64{5, 6, 7, 8}, s.requestedBlocks(), "blocks before the start block must be skipped")
+
+	select {
+	case <-s.listener.WithdrawalReceived():
+	default:
+		s.Fail("listener didn't notify about new withdrawals")
+	}
+
+	leaves, err := s.storage.GetLeaves(s.ctx, 0)
+	s.Require().NoError(err)
+	s.Len(leaves, 3)
+
+	lastBlock, err := s.storage.GetLastProcessedBlock(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(lastBlock)
+	s.Equal(uint64(8), lastBlock.BlockNumber)
+
+	// the listener continues from the last processed block
+	s.contractMock.ResetGetWithdrawalsCalls()
+	s.addWithdrawal(10, 3)
+	s.latestBlock = 10
+
+	s.Require().NoError(s.listener.fetchWithdrawals(s.ctx))
+	s.Equal([]uint64{9, 10}, s.requestedBlocks())
+
+	leaves, err = s.storage.GetLeaves(s.ctx, 0)
+	s.Require().NoError(err)
+	s.Len(leaves, 4)
+}
+
+func (s *EventListenerTestSuite) TestLeavesAreCutAtGap() {
+	s.addWithdrawal(5, 0)
+	s.addWithdrawal(6, 2)
+	s.latestBlock = 6
+
+	s.Require().NoError(s.listener.fetchWithdrawals(s.ctx))
+
+	leaves, err := s.storage.GetLeaves(s.ctx, 0)
+	s.Require().NoError(err)
+	s.Len(leaves, 1)
+}
+
+func (s *EventListenerTestSuite) TestConflictingLeafIsRejected() {
+	s.addWithdrawal(5, 0)
+	s.latestBlock = 5
+	s.Require().NoError(s.listener.fetchWithdrawals(s.ctx))
+
+	err := s.storage.StoreBlockWithdrawals(s.ctx, &ProcessedBlock{
+		BlockHash:   ethcommon.HexToHash("0x02"),
+		BlockNumber: 6,
+	}, []*Withdrawal{{Hash: ethcommon.HexToHash("0xdead"), LeafIndex: 0}})
+	s.Require().Error(err)
+}
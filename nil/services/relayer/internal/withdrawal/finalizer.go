@@ -0,0 +1,282 @@
+package withdrawal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/jonboulle/clockwork"
+)
+
+type FinalizerConfig struct {
+	// address of NilRollup contract on L1, withdrawal relaying is disabled if empty
+	RollupContractAddress string
+
+	// key of the L1 account paying for the claiming transactions
+	PrivateKeyPath string
+
+	PollInterval time.Duration
+	TxTimeout    time.Duration
+}
+
+func DefaultFinalizerConfig() *FinalizerConfig {
+	return &FinalizerConfig{
+		PrivateKeyPath: "relayer_l1_key.ecdsa",
+		PollInterval:   time.Second * 30,
+		TxTimeout:      time.Minute * 5,
+	}
+}
+
+func (cfg *FinalizerConfig) Enabled() bool {
+	return cfg.RollupContractAddress != ""
+}
+
+func (cfg *FinalizerConfig) Validate() error {
+	if cfg.RollupContractAddress == "" {
+		return errors.New("empty NilRollup contract address")
+	}
+	if cfg.PrivateKeyPath == "" {
+		return errors.New("empty L1 private key file path")
+	}
+	if cfg.PollInterval == 0 {
+		return errors.New("empty poll interval for the rollup contract")
+	}
+	if cfg.TxTimeout == 0 {
+		return errors.New("empty L1 transaction timeout")
+	}
+	return nil
+}
+
+type withdrawalReceivedProvider interface {
+	WithdrawalReceived() <-chan struct{}
+}
+
+// Finalizer waits until the batches containing withdrawals are finalized by the rollup contract,
+// then builds inclusion proofs against the finalized L2->L1 root and claims the withdrawals on L1
+type Finalizer struct {
+	config   *FinalizerConfig
+	clock    clockwork.Clock
+	contract L1Contract
+	storage  *Storage
+	metrics  FinalizerMetrics
+	provider withdrawalReceivedProvider
+	logger   logging.Logger
+
+	state struct {
+		finalizedBatch     string
+		finalizedLeafCount uint64
+		resolved           bool
+
+		// follows the message tree up to the last known leaf
+		tracker rootTracker
+	}
+}
+
+func NewFinalizer(
+	config *FinalizerConfig,
+	clock clockwork.Clock,
+	contract L1Contract,
+	storage *Storage,
+	metrics FinalizerMetrics,
+	provider withdrawalReceivedProvider,
+	logger logging.Logger,
+) (*Finalizer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := &Finalizer{
+		config:   config,
+		clock:    clock,
+		contract: contract,
+		storage:  storage,
+		metrics:  metrics,
+		provider: provider,
+	}
+	f.logger = logger.With().Str(logging.FieldComponent, f.Name()).Logger()
+	return f, nil
+}
+
+func (f *Finalizer) Name() string {
+	return "withdrawal-finalizer"
+}
+
+func (f *Finalizer) Run(ctx context.Context, started chan<- struct{}) error {
+	f.logger.Info().Msg("initializing component")
+
+	ticker := f.clock.NewTicker(f.config.PollInterval)
+	defer ticker.Stop()
+	close(started)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.Chan():
+			f.logger.Debug().Msg("wake up by timer")
+		case <-f.provider.WithdrawalReceived():
+			f.logger.Debug().Msg("wake up by withdrawal listener")
+		}
+
+		if err := f.claimFinalizedWithdrawals(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			f.logger.Error().Err(err).Msg("error occurred during claiming withdrawals on L1")
+			f.metrics.AddClaimError(ctx)
+		}
+	}
+}
+
+func (f *Finalizer) claimFinalizedWithdrawals(ctx context.Context) error {
+	leafCount, ok, err := f.getFinalizedLeafCount(ctx)
+	if err != nil || !ok || leafCount == 0 {
+		return err
+	}
+
+	var withdrawals []*Withdrawal
+	if err := f.storage.IteratePendingWithdrawals(ctx, func(w *Withdrawal) (bool, error) {
+		if w.LeafIndex >= leafCount {
+			return false, nil
+		}
+		withdrawals = append(withdrawals, w)
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	if len(withdrawals) == 0 {
+		f.logger.Debug().Msg("no finalized withdrawals to be claimed on L1")
+		return nil
+	}
+
+	leaves, err := f.storage.GetLeaves(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if uint64(len(leaves)) < leafCount {
+		return fmt.Errorf("message tree has %d leaves, %d are finalized", len(leaves), leafCount)
+	}
+	tree, err := NewMessageTree(leaves[:leafCount])
+	if err != nil {
+		return err
+	}
+
+	f.logger.Info().
+		Int("withdrawal_count", len(withdrawals)).
+		Uint64("finalized_leaf_count", leafCount).
+		Msg("claiming finalized withdrawals on L1")
+
+	claimed := make([]uint64, 0, len(withdrawals))
+	defer func() {
+		if len(claimed) == 0 {
+			return
+		}
+		if err := f.storage.DeletePendingWithdrawals(ctx, claimed); err != nil {
+			f.logger.Warn().Err(err).Msg("failed to drop claimed withdrawals from storage")
+		}
+	}()
+
+	for _, w := range withdrawals {
+		proof, err := tree.Proof(w.LeafIndex)
+		if err != nil {
+			return err
+		}
+
+		txHash, err := f.contract.ClaimWithdrawal(ctx, w, proof)
+		if errors.Is(err, ErrWithdrawalAlreadyClaimed) {
+			f.logger.Info().Stringer("message_hash", w.Hash).Msg("withdrawal is already claimed, skipping")
+			claimed = append(claimed, w.LeafIndex)
+			f.metrics.AddAlreadyClaimedWithdrawals(ctx, 1)
+			continue
+		}
+		if err != nil {
+			f.logger.Error().Err(err).
+				Stringer("message_hash", w.Hash).
+				Uint64("leaf_index", w.LeafIndex).
+				Msg("failed to claim withdrawal on L1")
+			return err
+		}
+
+		f.logger.Info().
+			Stringer("message_hash", w.Hash).
+			Stringer("tx_hash", txHash).
+			Msg("withdrawal claimed on L1")
+		claimed = append(claimed, w.LeafIndex)
+		f.metrics.AddClaimedWithdrawals(ctx, 1)
+	}
+	return nil
+}
+
+// getFinalizedLeafCount returns the number of message tree leaves covered by the L2->L1 root
+// of the last finalized batch. The second return value is false if the root doesn't match
+// any prefix of the known leaves, e.g. when the withdrawal listener lags behind the rollup.
+func (f *Finalizer) getFinalizedLeafCount(ctx context.Context) (uint64, bool, error) {
+	batchIndex, err := f.contract.GetLastFinalizedBatchIndex(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	if f.state.resolved && batchIndex == f.state.finalizedBatch {
+		return f.state.finalizedLeafCount, true, nil
+	}
+
+	root, err := f.contract.GetCurrentL2ToL1Root(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	leafCount, ok, err := f.resolveLeafCount(ctx, root)
+	if err != nil || !ok {
+		if err == nil {
+			f.logger.Warn().
+				Str("batch_index", batchIndex).
+				Stringer("l2_to_l1_root", root).
+				Msg("finalized L2->L1 root doesn't match known withdrawals, waiting for L2 listener")
+		}
+		return 0, false, err
+	}
+
+	if batchIndex != f.state.finalizedBatch {
+		f.logger.Info().
+			Str("batch_index", batchIndex).
+			Uint64("finalized_leaf_count", leafCount).
+			Msg("new batch finalized on L1")
+	}
+	f.state.finalizedBatch = batchIndex
+	f.state.finalizedLeafCount = leafCount
+	f.state.resolved = true
+	f.metrics.SetFinalizedLeafCount(ctx, leafCount)
+
+	return leafCount, true, nil
+}
+
+func (f *Finalizer) resolveLeafCount(ctx context.Context, root ethcommon.Hash) (uint64, bool, error) {
+	var emptyHash ethcommon.Hash
+	if root == emptyHash {
+		// no withdrawals are finalized yet
+		return 0, true, nil
+	}
+
+	tracker := &f.state.tracker
+	if tracker.leafCount > 0 && tracker.root == root {
+		return tracker.leafCount, true, nil
+	}
+
+	leaves, err := f.storage.GetLeaves(ctx, tracker.leafCount)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, leaf := range leaves {
+		if tracker.append(leaf) == root {
+			return tracker.leafCount, true, nil
+		}
+	}
+
+	// the root is not found among the known prefixes (e.g. the rollup state was reset),
+	// next attempt starts over from the first leaf
+	*tracker = rootTracker{}
+	return 0, false, nil
+}
@@ -0,0 +1,273 @@
+package withdrawal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/storage"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/jonboulle/clockwork"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+type withdrawalListenerStub struct {
+	emitter chan struct{}
+}
+
+func (wls *withdrawalListenerStub) WithdrawalReceived() <-chan struct{} {
+	return wls.emitter
+}
+
+func (wls *withdrawalListenerStub) waitForFinalizerLoop() {
+	// channel is not buffered, so the second send completes only after
+	// the finalizer has finished the iteration started by the first one
+	wls.emitter <- struct{}{}
+	wls.emitter <- struct{}{}
+}
+
+type FinalizerTestSuite struct {
+	suite.Suite
+
+	database db.DB
+	storage  *Storage
+	logger   logging.Logger
+
+	finalizer    *Finalizer
+	contractMock *L1ContractMock
+	listenerStub *withdrawalListenerStub
+
+	mu             sync.Mutex
+	finalizedBatch string
+	finalizedRoot  ethcommon.Hash
+	claimed        map[uint64]bool
+	claimErr       error
+
+	leaves []ethcommon.Hash
+
+	ctx              context.Context
+	canceler         context.CancelFunc
+	finalizerStopped chan struct{}
+}
+
+func TestFinalizer(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FinalizerTestSuite))
+}
+
+func (s *FinalizerTestSuite) SetupTest() {
+	var err error
+
+	s.ctx, s.canceler = context.WithCancel(context.Background())
+	s.logger = logging.NewFromZerolog(zerolog.New(zerolog.NewConsoleWriter()))
+
+	s.database, err = db.NewBadgerDbInMemory()
+	s.Require().NoError(err, "failed to initialize database")
+
+	storageMetrics, err := storage.NewTableMetrics()
+	s.Require().NoError(err)
+
+	clock := clockwork.NewFakeClock()
+	s.storage = NewStorage(s.ctx, s.database, clock, storageMetrics, s.logger)
+
+	s.leaves = makeLeaves(5)
+	withdrawals := make([]*Withdrawal, len(s.leaves))
+	for i, leaf := range s.leaves {
+		withdrawals[i] = &Withdrawal{
+			Hash:      leaf,
+			LeafIndex: uint64(i),
+			Nonce:     big.NewInt(int64(i)),
+		}
+	}
+	s.Require().NoError(s.storage.StoreBlockWithdrawals(s.ctx, &ProcessedBlock{
+		BlockHash:   ethcommon.HexToHash("0x01"),
+		BlockNumber: 10,
+	}, withdrawals))
+
+	s.finalizedBatch = ""
+	s.finalizedRoot = ethcommon.Hash{}
+	s.claimed = make(map[uint64]bool)
+	s.claimErr = nil
+
+	s.contractMock = &L1ContractMock{
+		GetLastFinalizedBatchIndexFunc: func(ctx context.Context) (string, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.finalizedBatch, nil
+		},
+		GetCurrentL2ToL1RootFunc: func(ctx context.Context) (ethcommon.Hash, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.finalizedRoot, nil
+		},
+		ClaimWithdrawalFunc: func(
+			ctx context.Context, w *Withdrawal, proof []ethcommon.Hash,
+		) (ethcommon.Hash, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.claimErr != nil {
+				return ethcommon.Hash{}, s.claimErr
+			}
+			if !VerifyProof(s.finalizedRoot, w.Hash, w.LeafIndex, proof) {
+				return ethcommon.Hash{}, errors.New("invalid claim proof")
+			}
+			if s.claimed[w.LeafIndex] {
+				return ethcommon.Hash{}, ErrWithdrawalAlreadyClaimed
+			}
+			s.claimed[w.LeafIndex] = true
+			return ethcommon.BigToHash(big.NewInt(int64(w.LeafIndex) + 1)), nil
+		},
+	}
+
+	metrics, err := NewFinalizerMetrics()
+	s.Require().NoError(err)
+
+	s.listenerStub = &withdrawalListenerStub{emitter: make(chan struct{})}
+
+	s.finalizer, err = NewFinalizer(
+		&FinalizerConfig{
+			RollupContractAddress: "0x01",
+			PrivateKeyPath:        "unused",
+			PollInterval:          DefaultFinalizerConfig().PollInterval,
+			TxTimeout:             DefaultFinalizerConfig().TxTimeout,
+		},
+		clock,
+		s.contractMock,
+		s.storage,
+		metrics,
+		s.listenerStub,
+		s.logger,
+	)
+	s.Require().NoError(err)
+
+	started := make(chan struct{})
+	s.finalizerStopped = make(chan struct{})
+	go func() {
+		defer close(s.finalizerStopped)
+		err := s.finalizer.Run(s.ctx, started)
+		if err != nil {
+			s.ErrorIs(err, context.Canceled)
+		}
+	}()
+	<-started
+}
+
+func (s *FinalizerTestSuite) TearDownTest() {
+	s.canceler()
+	<-s.finalizerStopped
+}
+
+func (s *FinalizerTestSuite) finalizeBatch(batchIndex string, leafCount int) {
+	s.T().Helper()
+
+	tree, err := NewMessageTree(s.leaves[:leafCount])
+	s.Require().NoError(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finalizedBatch = batchIndex
+	s.finalizedRoot = tree.Root()
+}
+
+func (s *FinalizerTestSuite) checkClaimed(leafIndexes ...uint64) {
+	s.T().Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Len(s.claimed, len(leafIndexes))
+	for _, idx := range leafIndexes {
+		s.True(s.claimed[idx], "withdrawal %d is not claimed", idx)
+	}
+}
+
+func (s *FinalizerTestSuite) checkPending(leafIndexes ...uint64) {
+	s.T().Helper()
+
+	var pending []uint64
+	err := s.storage.IteratePendingWithdrawals(s.ctx, func(w *Withdrawal) (bool, error) {
+		pending = append(pending, w.LeafIndex)
+		return true, nil
+	})
+	s.Require().NoError(err)
+	if len(leafIndexes) == 0 {
+		s.Empty(pending)
+		return
+	}
+	s.Equal(leafIndexes, pending)
+}
+
+func (s *FinalizerTestSuite) TestNothingFinalized() {
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed()
+	s.checkPending(0, 1, 2, 3, 4)
+}
+
+func (s *FinalizerTestSuite) TestClaimFinalizedWithdrawals() {
+	s.finalizeBatch("batch-1", 3)
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed(0, 1, 2)
+	s.checkPending(3, 4)
+
+	s.finalizeBatch("batch-2", 5)
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed(0, 1, 2, 3, 4)
+	s.checkPending()
+}
+
+func (s *FinalizerTestSuite) TestAlreadyClaimedWithdrawalIsDropped() {
+	s.mu.Lock()
+	s.claimed[1] = true
+	s.mu.Unlock()
+
+	s.finalizeBatch("batch-1", 2)
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed(0, 1)
+	s.checkPending(2, 3, 4)
+}
+
+func (s *FinalizerTestSuite) TestUnknownRootIsNotClaimed() {
+	// root covering leaves which are not received from L2 yet
+	leaves := append(makeLeaves(5), ethcommon.HexToHash("0xabcd"))
+	tree, err := NewMessageTree(leaves)
+	s.Require().NoError(err)
+
+	s.mu.Lock()
+	s.finalizedBatch = "batch-1"
+	s.finalizedRoot = tree.Root()
+	s.mu.Unlock()
+
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed()
+	s.checkPending(0, 1, 2, 3, 4)
+}
+
+func (s *FinalizerTestSuite) TestFailedClaimIsRetried() {
+	s.mu.Lock()
+	s.claimErr = errors.New("L1 is unavailable")
+	s.mu.Unlock()
+
+	s.finalizeBatch("batch-1", 2)
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed()
+	s.checkPending(0, 1, 2, 3, 4)
+
+	s.mu.Lock()
+	s.claimErr = nil
+	s.mu.Unlock()
+
+	s.listenerStub.waitForFinalizerLoop()
+
+	s.checkClaimed(0, 1)
+	s.checkPending(2, 3, 4)
+}
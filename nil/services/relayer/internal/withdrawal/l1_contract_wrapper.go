@@ -0,0 +1,169 @@
+package withdrawal
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/l1"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type L1Contract interface {
+	GetLastFinalizedBatchIndex(ctx context.Context) (string, error)
+	GetCurrentL2ToL1Root(ctx context.Context) (ethcommon.Hash, error)
+	ClaimWithdrawal(ctx context.Context, withdrawal *Withdrawal, proof []ethcommon.Hash) (ethcommon.Hash, error)
+}
+
+type l1ContractWrapper struct {
+	ethClient  l1.EthClient
+	rollup     *NilRollup
+	messenger  *L1BridgeMessenger
+	privateKey *ecdsa.PrivateKey
+	chainId    *big.Int
+	txTimeout  time.Duration
+	logger     logging.Logger
+
+	// selector of the error returned by L1BridgeMessenger if the withdrawal is already claimed
+	duplicateClaimSelector []byte
+}
+
+var _ L1Contract = (*l1ContractWrapper)(nil)
+
+func NewL1ContractWrapper(
+	ctx context.Context,
+	ethClient l1.EthClient,
+	config *FinalizerConfig,
+	l1BridgeMessengerAddr string,
+	logger logging.Logger,
+) (*l1ContractWrapper, error) {
+	rollup, err := NewNilRollup(ethcommon.HexToAddress(config.RollupContractAddress), ethClient)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := NewL1BridgeMessenger(ethcommon.HexToAddress(l1BridgeMessengerAddr), ethClient)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := crypto.LoadECDSA(config.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L1 private key: %w", err)
+	}
+
+	chainId, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 chain id: %w", err)
+	}
+
+	messengerABI, err := L1BridgeMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	duplicateClaimErr, ok := messengerABI.Errors["ErrorDuplicateWithdrawalClaim"]
+	if !ok {
+		return nil, errors.New("L1BridgeMessenger ABI has no ErrorDuplicateWithdrawalClaim error")
+	}
+
+	return &l1ContractWrapper{
+		ethClient:              ethClient,
+		rollup:                 rollup,
+		messenger:              messenger,
+		privateKey:             pk,
+		chainId:                chainId,
+		txTimeout:              config.TxTimeout,
+		logger:                 logger,
+		duplicateClaimSelector: duplicateClaimErr.ID[:4],
+	}, nil
+}
+
+func (w *l1ContractWrapper) GetLastFinalizedBatchIndex(ctx context.Context) (string, error) {
+	return w.rollup.GetLastFinalizedBatchIndex(&bind.CallOpts{Context: ctx})
+}
+
+func (w *l1ContractWrapper) GetCurrentL2ToL1Root(ctx context.Context) (ethcommon.Hash, error) {
+	root, err := w.rollup.GetCurrentL2ToL1Root(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return root, nil
+}
+
+// ClaimWithdrawal sends the claiming transaction to L1BridgeMessenger and waits until it is mined
+func (w *l1ContractWrapper) ClaimWithdrawal(
+	ctx context.Context,
+	withdrawal *Withdrawal,
+	proof []ethcommon.Hash,
+) (ethcommon.Hash, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(w.privateKey, w.chainId)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	opts.Context = ctx
+
+	withdrawalProof := make([][32]byte, len(proof))
+	for i, p := range proof {
+		withdrawalProof[i] = p
+	}
+
+	// gas estimation performed by the binding simulates the call, so the claims done by someone else
+	// are detected before sending the transaction
+	tx, err := w.messenger.ClaimWithdrawal(opts, IL1BridgeMessengerWithdrawalRequestParams{
+		MessageType:     withdrawal.Type,
+		MessageSender:   withdrawal.Sender,
+		MessageTarget:   withdrawal.Target,
+		MessageNonce:    withdrawal.Nonce,
+		MerkleLeafIndex: new(big.Int).SetUint64(withdrawal.LeafIndex),
+		Message:         withdrawal.Message,
+		MessageHash:     withdrawal.Hash,
+		WithdrawalProof: withdrawalProof,
+	})
+	if err != nil {
+		if w.isDuplicateClaim(err) {
+			return ethcommon.Hash{}, ErrWithdrawalAlreadyClaimed
+		}
+		return ethcommon.Hash{}, err
+	}
+
+	w.logger.Debug().
+		Stringer("tx_hash", tx.Hash()).
+		Stringer("message_hash", withdrawal.Hash).
+		Msg("withdrawal claim transaction sent to L1")
+
+	waitCtx, cancel := context.WithTimeout(ctx, w.txTimeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(waitCtx, w.ethClient, tx)
+	if err != nil {
+		return tx.Hash(), fmt.Errorf("failed to wait for withdrawal claim transaction %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return tx.Hash(), fmt.Errorf("%w: tx=%s", ErrClaimTxFailed, tx.Hash())
+	}
+	return tx.Hash(), nil
+}
+
+func (w *l1ContractWrapper) isDuplicateClaim(err error) bool {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return false
+	}
+	return bytes.HasPrefix(data, w.duplicateClaimSelector)
+}
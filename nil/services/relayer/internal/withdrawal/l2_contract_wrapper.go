@@ -0,0 +1,150 @@
+package withdrawal
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"math/big"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common/check"
+	"github.com/NilFoundation/nil/nil/internal/abi"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed IL2BridgeMessenger.abi
+var l2BridgeMessengerABIData []byte
+
+var messageSentEvent abi.Event
+
+func init() {
+	parsed, err := abi.JSON(bytes.NewReader(l2BridgeMessengerABIData))
+	check.PanicIfErr(err)
+
+	var ok bool
+	messageSentEvent, ok = parsed.Events["MessageSent"]
+	check.PanicIfNot(ok)
+}
+
+type L2Contract interface {
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+	GetWithdrawals(ctx context.Context, blockNumber uint64) (*ProcessedBlock, []*Withdrawal, error)
+}
+
+type l2ContractWrapper struct {
+	nilClient    client.Client
+	contractAddr types.Address
+}
+
+var _ L2Contract = (*l2ContractWrapper)(nil)
+
+func NewL2ContractWrapper(nilClient client.Client, l2ContractAddr string) *l2ContractWrapper {
+	return &l2ContractWrapper{
+		nilClient:    nilClient,
+		contractAddr: types.HexToAddress(l2ContractAddr),
+	}
+}
+
+func (w *l2ContractWrapper) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	block, err := w.nilClient.GetBlock(ctx, w.contractAddr.ShardId(), "latest", false)
+	if err != nil {
+		return 0, err
+	}
+	if block == nil {
+		return 0, fmt.Errorf("latest block of shard %d is not found", w.contractAddr.ShardId())
+	}
+	return uint64(block.Number), nil
+}
+
+// GetWithdrawals scans receipts of the block in the shard of L2BridgeMessenger for MessageSent events
+func (w *l2ContractWrapper) GetWithdrawals(
+	ctx context.Context,
+	blockNumber uint64,
+) (*ProcessedBlock, []*Withdrawal, error) {
+	shardId := w.contractAddr.ShardId()
+	rawBlock, err := w.nilClient.GetDebugBlock(ctx, shardId, blockNumber, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rawBlock == nil {
+		return nil, nil, fmt.Errorf("block %d of shard %d is not found", blockNumber, shardId)
+	}
+	block, err := rawBlock.DecodeBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	processed := &ProcessedBlock{
+		BlockHash:   ethcommon.Hash(block.Hash(shardId)),
+		BlockNumber: blockNumber,
+	}
+
+	var withdrawals []*Withdrawal
+	for _, receipt := range block.Receipts {
+		if !receipt.Success {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if log.Address != w.contractAddr || len(log.Topics) == 0 || log.Topics[0] != messageSentEvent.ID {
+				continue
+			}
+			withdrawal, err := decodeWithdrawal(log)
+			if err != nil {
+				return nil, nil, err
+			}
+			withdrawal.BlockNumber = processed.BlockNumber
+			withdrawal.BlockHash = processed.BlockHash
+			withdrawals = append(withdrawals, withdrawal)
+		}
+	}
+	return processed, withdrawals, nil
+}
+
+func decodeWithdrawal(log *types.Log) (*Withdrawal, error) {
+	var event struct {
+		MessageSender       types.Address
+		MessageTarget       types.Address
+		MessageNonce        *big.Int
+		MerkleTreeLeafIndex *big.Int
+		Message             []byte
+		MessageHash         [32]byte
+		MessageType         uint8
+		MessageCreatedAt    *big.Int
+	}
+
+	nonIndexed := messageSentEvent.Inputs.NonIndexed()
+	values, err := nonIndexed.Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWithdrawal, err)
+	}
+	if err := nonIndexed.Copy(&event, values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWithdrawal, err)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range messageSentEvent.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(&event, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWithdrawal, err)
+	}
+
+	if !event.MerkleTreeLeafIndex.IsUint64() {
+		return nil, fmt.Errorf("%w: leaf index %s overflows", ErrInvalidWithdrawal, event.MerkleTreeLeafIndex)
+	}
+
+	return &Withdrawal{
+		Hash:      ethcommon.Hash(event.MessageHash),
+		LeafIndex: event.MerkleTreeLeafIndex.Uint64(),
+		Sender:    ethcommon.Address(event.MessageSender),
+		Target:    ethcommon.Address(event.MessageTarget),
+		Nonce:     event.MessageNonce,
+		Type:      event.MessageType,
+		Message:   event.Message,
+		CreatedAt: event.MessageCreatedAt,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package withdrawal
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxTreeHeight matches MAX_TREE_HEIGHT of AppendOnlyMerkleTree contract
+const maxTreeHeight = 40
+
+var zeroHashes = func() [maxTreeHeight]ethcommon.Hash {
+	var ret [maxTreeHeight]ethcommon.Hash
+	for height := 0; height+1 < maxTreeHeight; height++ {
+		ret[height+1] = hashPair(ret[height], ret[height])
+	}
+	return ret
+}()
+
+func hashPair(a, b ethcommon.Hash) ethcommon.Hash {
+	return crypto.Keccak256Hash(a[:], b[:])
+}
+
+// treeHeight returns the height of the tree built by the contract over leafCount leaves,
+// which is the number of bits in the index of the last appended leaf
+func treeHeight(leafCount int) int {
+	return bits.Len64(uint64(leafCount - 1))
+}
+
+// MessageTree rebuilds the L2->L1 message tree kept by L2BridgeMessenger
+// (see AppendOnlyMerkleTree contract) and produces inclusion proofs accepted by NilMerkleProofVerifier.
+type MessageTree struct {
+	// levels[0] contains leaves, levels[h] contains nodes of height h,
+	// a missing right child is substituted with the zero hash of its height
+	levels [][]ethcommon.Hash
+}
+
+func NewMessageTree(leaves []ethcommon.Hash) (*MessageTree, error) {
+	if len(leaves) == 0 {
+		return nil, errors.New("cannot build message tree without leaves")
+	}
+	height := treeHeight(len(leaves))
+	if height >= maxTreeHeight {
+		return nil, fmt.Errorf("too many leaves in message tree: %d", len(leaves))
+	}
+
+	levels := make([][]ethcommon.Hash, 0, height+1)
+	levels = append(levels, leaves)
+	for h := range height {
+		prev := levels[h]
+		next := make([]ethcommon.Hash, (len(prev)+1)/2)
+		for i := range next {
+			right := zeroHashes[h]
+			if 2*i+1 < len(prev) {
+				right = prev[2*i+1]
+			}
+			next[i] = hashPair(prev[2*i], right)
+		}
+		levels = append(levels, next)
+	}
+
+	return &MessageTree{levels: levels}, nil
+}
+
+func (t *MessageTree) Root() ethcommon.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+func (t *MessageTree) LeafCount() int {
+	return len(t.levels[0])
+}
+
+// Proof returns sibling hashes on the path from the leaf to the root, bottom to top
+func (t *MessageTree) Proof(leafIndex uint64) ([]ethcommon.Hash, error) {
+	if leafIndex >= uint64(t.LeafCount()) {
+		return nil, fmt.Errorf("leaf index %d is out of tree bounds (%d leaves)", leafIndex, t.LeafCount())
+	}
+
+	proof := make([]ethcommon.Hash, 0, len(t.levels)-1)
+	idx := leafIndex
+	for h, level := range t.levels[:len(t.levels)-1] {
+		sibling := zeroHashes[h]
+		if siblingIdx := idx ^ 1; siblingIdx < uint64(len(level)) {
+			sibling = level[siblingIdx]
+		}
+		proof = append(proof, sibling)
+		idx /= 2
+	}
+	return proof, nil
+}
+
+// VerifyProof mirrors NilMerkleProofVerifier.verifyMerkleProof
+func VerifyProof(root, leaf ethcommon.Hash, leafIndex uint64, proof []ethcommon.Hash) bool {
+	hash := leaf
+	for _, item := range proof {
+		if leafIndex%2 == 0 {
+			hash = hashPair(hash, item)
+		} else {
+			hash = hashPair(item, hash)
+		}
+		leafIndex /= 2
+	}
+	return hash == root
+}
+
+// rootTracker appends leaves the same way AppendOnlyMerkleTree contract does,
+// it is used to find out how many leaves were covered by a given root without building the whole tree each time
+type rootTracker struct {
+	branches  [maxTreeHeight]ethcommon.Hash
+	leafCount uint64
+	root      ethcommon.Hash
+}
+
+func (rt *rootTracker) append(leaf ethcommon.Hash) ethcommon.Hash {
+	idx := rt.leafCount
+	hash := leaf
+	height := 0
+	for idx != 0 {
+		if idx%2 == 0 {
+			rt.branches[height] = hash
+			hash = hashPair(hash, zeroHashes[height])
+		} else {
+			hash = hashPair(rt.branches[height], hash)
+		}
+		height++
+		idx >>= 1
+	}
+	rt.branches[height] = hash
+	rt.root = hash
+	rt.leafCount++
+	return hash
+}
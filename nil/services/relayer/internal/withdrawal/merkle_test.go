@@ -0,0 +1,72 @@
+package withdrawal
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func makeLeaves(n int) []ethcommon.Hash {
+	leaves := make([]ethcommon.Hash, n)
+	for i := range leaves {
+		leaves[i] = crypto.Keccak256Hash([]byte{byte(i), byte(i >> 8)})
+	}
+	return leaves
+}
+
+func TestMessageTreeMatchesContract(t *testing.T) {
+	t.Parallel()
+
+	leaves := makeLeaves(70)
+
+	var tracker rootTracker
+	for n := 1; n <= len(leaves); n++ {
+		contractRoot := tracker.append(leaves[n-1])
+
+		tree, err := NewMessageTree(leaves[:n])
+		require.NoError(t, err)
+		require.Equal(t, contractRoot, tree.Root(), "root mismatch for %d leaves", n)
+
+		for i := range n {
+			proof, err := tree.Proof(uint64(i))
+			require.NoError(t, err)
+			require.Len(t, proof, treeHeight(n))
+			require.True(t, VerifyProof(tree.Root(), leaves[i], uint64(i), proof),
+				"invalid proof for leaf %d of %d", i, n)
+		}
+	}
+}
+
+func TestMessageTreeSingleLeaf(t *testing.T) {
+	t.Parallel()
+
+	leaves := makeLeaves(1)
+	tree, err := NewMessageTree(leaves)
+	require.NoError(t, err)
+	require.Equal(t, leaves[0], tree.Root())
+
+	proof, err := tree.Proof(0)
+	require.NoError(t, err)
+	require.Empty(t, proof)
+
+	_, err = tree.Proof(1)
+	require.Error(t, err)
+
+	_, err = NewMessageTree(nil)
+	require.Error(t, err)
+}
+
+func TestMessageTreeRejectsWrongProof(t *testing.T) {
+	t.Parallel()
+
+	leaves := makeLeaves(5)
+	tree, err := NewMessageTree(leaves)
+	require.NoError(t, err)
+
+	proof, err := tree.Proof(2)
+	require.NoError(t, err)
+	require.False(t, VerifyProof(tree.Root(), leaves[2], 3, proof))
+	require.False(t, VerifyProof(tree.Root(), leaves[3], 2, proof))
+}
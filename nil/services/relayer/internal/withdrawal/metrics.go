@@ -0,0 +1,140 @@
+package withdrawal
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/internal/telemetry"
+	"github.com/NilFoundation/nil/nil/internal/telemetry/telattr"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type EventListenerMetrics interface {
+	SetLastProcessedBlock(ctx context.Context, blockNumber uint64)
+	AddWithdrawals(ctx context.Context, count uint64)
+	AddFetchError(ctx context.Context)
+}
+
+const (
+	claimStatusLabel   = "claim_status"
+	claimStatusClaimed = "claimed"
+	claimStatusSkipped = "already_claimed"
+)
+
+type eventListenerMetrics struct {
+	attrs metric.MeasurementOption
+
+	lastProcessedBlock telemetry.Gauge
+	withdrawals        telemetry.Counter
+	fetchErrors        telemetry.Counter
+}
+
+func NewEventListenerMetrics() (EventListenerMetrics, error) {
+	elm := &eventListenerMetrics{}
+	if err := metrics.InitMetrics(elm, "relayer", "withdrawal_listener"); err != nil {
+		return nil, err
+	}
+	return elm, nil
+}
+
+func (elm *eventListenerMetrics) Init(name string, meter telemetry.Meter, attrs metric.MeasurementOption) error {
+	var err error
+
+	elm.lastProcessedBlock, err = meter.Int64Gauge(name + ".last_processed_block")
+	if err != nil {
+		return err
+	}
+
+	elm.withdrawals, err = meter.Int64Counter(name + ".withdrawals_received")
+	if err != nil {
+		return err
+	}
+
+	elm.fetchErrors, err = meter.Int64Counter(name + ".fetch_error")
+	if err != nil {
+		return err
+	}
+
+	elm.attrs = attrs
+	return nil
+}
+
+func (elm *eventListenerMetrics) SetLastProcessedBlock(ctx context.Context, blockNumber uint64) {
+	elm.lastProcessedBlock.Record(ctx, int64(blockNumber), elm.attrs)
+}
+
+func (elm *eventListenerMetrics) AddWithdrawals(ctx context.Context, count uint64) {
+	elm.withdrawals.Add(ctx, int64(count), elm.attrs)
+}
+
+func (elm *eventListenerMetrics) AddFetchError(ctx context.Context) {
+	elm.fetchErrors.Add(ctx, 1, elm.attrs)
+}
+
+type FinalizerMetrics interface {
+	SetFinalizedLeafCount(ctx context.Context, count uint64)
+	AddClaimError(ctx context.Context)
+	AddClaimedWithdrawals(ctx context.Context, count uint64)
+	AddAlreadyClaimedWithdrawals(ctx context.Context, count uint64)
+}
+
+type finalizerMetrics struct {
+	attrs metric.MeasurementOption
+
+	finalizedLeafCount telemetry.Gauge
+	claimErrors        telemetry.Counter
+	processed          telemetry.Counter
+}
+
+func NewFinalizerMetrics() (FinalizerMetrics, error) {
+	fm := &finalizerMetrics{}
+	if err := metrics.InitMetrics(fm, "relayer", "withdrawal_finalizer"); err != nil {
+		return nil, err
+	}
+	return fm, nil
+}
+
+func (fm *finalizerMetrics) Init(name string, meter telemetry.Meter, attrs metric.MeasurementOption) error {
+	var err error
+
+	fm.finalizedLeafCount, err = meter.Int64Gauge(name + ".finalized_leaf_count")
+	if err != nil {
+		return err
+	}
+
+	fm.claimErrors, err = meter.Int64Counter(name + ".claim_error")
+	if err != nil {
+		return err
+	}
+
+	fm.processed, err = meter.Int64Counter(name + ".processed_withdrawals")
+	if err != nil {
+		return err
+	}
+
+	fm.attrs = attrs
+	return nil
+}
+
+func (fm *finalizerMetrics) SetFinalizedLeafCount(ctx context.Context, count uint64) {
+	fm.finalizedLeafCount.Record(ctx, int64(count), fm.attrs)
+}
+
+func (fm *finalizerMetrics) AddClaimError(ctx context.Context) {
+	fm.claimErrors.Add(ctx, 1, fm.attrs)
+}
+
+func (fm *finalizerMetrics) AddClaimedWithdrawals(ctx context.Context, count uint64) {
+	fm.processed.Add(ctx, int64(count),
+		telattr.With(attribute.String(claimStatusLabel, claimStatusClaimed)),
+		fm.attrs,
+	)
+}
+
+func (fm *finalizerMetrics) AddAlreadyClaimedWithdrawals(ctx context.Context, count uint64) {
+	fm.processed.Add(ctx, int64(count),
+		telattr.With(attribute.String(claimStatusLabel, claimStatusSkipped)),
+		fm.attrs,
+	)
+}
@@ -0,0 +1,238 @@
+package withdrawal
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/storage"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/jonboulle/clockwork"
+)
+
+const (
+	// pendingWithdrawalsTable stores withdrawals sent from L2BridgeMessenger which are not claimed on L1 yet
+	// Key: big-endian leaf index of the withdrawal in the L2->L1 message tree
+	pendingWithdrawalsTable = "pending_withdrawals"
+
+	// messageTreeLeavesTable stores hashes of all withdrawals ever sent, they are required to build inclusion proofs
+	// Key: big-endian leaf index, Value: message hash
+	messageTreeLeavesTable = "l2_to_l1_message_tree_leaves"
+
+	// lastProcessedL2BlockTable stores the last L2 block withdrawals from which were stored (single value)
+	// Key: lastProcessedL2BlockKey
+	lastProcessedL2BlockTable = "last_processed_l2_block"
+	lastProcessedL2BlockKey   = "last_processed_l2_block_key"
+)
+
+type Storage struct {
+	*storage.BaseStorage
+}
+
+func NewStorage(
+	ctx context.Context,
+	database db.DB,
+	clock clockwork.Clock,
+	metrics storage.TableMetrics,
+	logger logging.Logger,
+) *Storage {
+	return &Storage{
+		BaseStorage: storage.NewBaseStorage(ctx, database, clock, logger, metrics),
+	}
+}
+
+func leafKey(leafIndex uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, leafIndex)
+}
+
+// StoreBlockWithdrawals atomically saves withdrawals sent in the block along with the block as the last processed one
+func (s *Storage) StoreBlockWithdrawals(ctx context.Context, blk *ProcessedBlock, withdrawals []*Withdrawal) error {
+	var emptyHash ethcommon.Hash
+	if blk.BlockHash == emptyHash {
+		return errors.New("empty last processed block hash")
+	}
+	for _, w := range withdrawals {
+		if w.Hash == emptyHash {
+			return errors.New("cannot store withdrawal without hash")
+		}
+	}
+
+	blkData, err := json.Marshal(blk)
+	if err != nil {
+		return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+	}
+
+	return s.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.Database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		for _, w := range withdrawals {
+			data, err := json.Marshal(w)
+			if err != nil {
+				return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+			}
+
+			key := leafKey(w.LeafIndex)
+			stored, err := tx.Get(messageTreeLeavesTable, key)
+			switch {
+			case errors.Is(err, db.ErrKeyNotFound):
+			case err != nil:
+				return err
+			case ethcommon.BytesToHash(stored) != w.Hash:
+				return fmt.Errorf("leaf %d of message tree is already occupied by another message", w.LeafIndex)
+			default:
+				// the block is processed again, e.g. after a restart
+				continue
+			}
+
+			if err := tx.Put(messageTreeLeavesTable, key, w.Hash.Bytes()); err != nil {
+				return err
+			}
+			if err := tx.Put(pendingWithdrawalsTable, key, data); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Put(lastProcessedL2BlockTable, []byte(lastProcessedL2BlockKey), blkData); err != nil {
+			return err
+		}
+
+		return s.Commit(tx, func() {
+			s.Metrics.RecordInserts(ctx, pendingWithdrawalsTable, len(withdrawals))
+			s.Metrics.RecordInserts(ctx, messageTreeLeavesTable, len(withdrawals))
+		})
+	})
+}
+
+func (s *Storage) GetLastProcessedBlock(ctx context.Context) (*ProcessedBlock, error) {
+	var ret *ProcessedBlock
+	err := s.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.Database.CreateRoTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		data, err := tx.Get(lastProcessedL2BlockTable, []byte(lastProcessedL2BlockKey))
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var blk ProcessedBlock
+		if err := json.Unmarshal(data, &blk); err != nil {
+			return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+		}
+		ret = &blk
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetLeaves returns consecutive message tree leaves starting from the given index,
+// the result is cut at the first missing leaf
+func (s *Storage) GetLeaves(ctx context.Context, from uint64) ([]ethcommon.Hash, error) {
+	var ret []ethcommon.Hash
+	err := s.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		ret = nil
+
+		tx, err := s.Database.CreateRoTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		iter, err := tx.Range(messageTreeLeavesTable, leafKey(from), nil)
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		expected := from
+		for iter.HasNext() {
+			key, val, err := iter.Next()
+			if err != nil {
+				return err
+			}
+			if binary.BigEndian.Uint64(key) != expected {
+				break
+			}
+			ret = append(ret, ethcommon.BytesToHash(val))
+			expected++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// IteratePendingWithdrawals calls the callback for pending withdrawals in ascending leaf index order
+// until the callback returns false
+func (s *Storage) IteratePendingWithdrawals(ctx context.Context, callback func(*Withdrawal) (bool, error)) error {
+	return s.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.Database.CreateRoTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		iter, err := tx.Range(pendingWithdrawalsTable, nil, nil)
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		for iter.HasNext() {
+			_, val, err := iter.Next()
+			if err != nil {
+				return err
+			}
+
+			var w Withdrawal
+			if err := json.Unmarshal(val, &w); err != nil {
+				return fmt.Errorf("%w: %w", storage.ErrSerializationFailed, err)
+			}
+
+			next, err := callback(&w)
+			if err != nil || !next {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Storage) DeletePendingWithdrawals(ctx context.Context, leafIndexes []uint64) error {
+	return s.RetryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.Database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		for _, idx := range leafIndexes {
+			err := tx.Delete(pendingWithdrawalsTable, leafKey(idx))
+			if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+				return err
+			}
+		}
+
+		return s.Commit(tx, func() {
+			s.Metrics.RecordDeletes(ctx, pendingWithdrawalsTable, len(leafIndexes))
+		})
+	})
+}
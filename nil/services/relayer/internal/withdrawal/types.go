@@ -0,0 +1,27 @@
+package withdrawal
+
+import (
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Withdrawal is a message sent by L2BridgeMessenger to L1, it can be claimed on L1
+// once the batch containing it is finalized by the rollup contract
+type Withdrawal struct {
+	BlockNumber uint64            `json:"blockNumber"`
+	BlockHash   ethcommon.Hash    `json:"blockHash"`
+	Hash        ethcommon.Hash    `json:"messageHash"`
+	LeafIndex   uint64            `json:"leafIndex"`
+	Sender      ethcommon.Address `json:"sender"`
+	Target      ethcommon.Address `json:"target"`
+	Nonce       *big.Int          `json:"nonce"`
+	Type        uint8             `json:"messageType"`
+	Message     []byte            `json:"message"`
+	CreatedAt   *big.Int          `json:"createdAt"`
+}
+
+type ProcessedBlock struct {
+	BlockHash   ethcommon.Hash `json:"blkHash"`
+	BlockNumber uint64         `json:"blkNum"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/NilFoundation/nil/nil/client"
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/internal/telemetry"
@@ -12,26 +13,31 @@ import (
 	"github.com/NilFoundation/nil/nil/services/relayer/internal/l1"
 	"github.com/NilFoundation/nil/nil/services/relayer/internal/l2"
 	"github.com/NilFoundation/nil/nil/services/relayer/internal/storage"
+	"github.com/NilFoundation/nil/nil/services/relayer/internal/withdrawal"
 	"github.com/jonboulle/clockwork"
 	"golang.org/x/sync/errgroup"
 )
 
 type RelayerConfig struct {
-	EventListenerConfig     *l1.EventListenerConfig
-	FinalityEnsurerConfig   *l1.FinalityEnsurerConfig
-	TransactionSenderConfig *l2.TransactionSenderConfig
-	L2ContractConfig        *l2.ContractConfig
-	TelemetryConfig         *telemetry.Config
-	DebugAPIConfig          *debug.Config
-	HeartbeatConfig         *debug.HeartbeatConfig
+	EventListenerConfig       *l1.EventListenerConfig
+	FinalityEnsurerConfig     *l1.FinalityEnsurerConfig
+	TransactionSenderConfig   *l2.TransactionSenderConfig
+	L2ContractConfig          *l2.ContractConfig
+	WithdrawalListenerConfig  *withdrawal.EventListenerConfig
+	WithdrawalFinalizerConfig *withdrawal.FinalizerConfig
+	TelemetryConfig           *telemetry.Config
+	DebugAPIConfig            *debug.Config
+	HeartbeatConfig           *debug.HeartbeatConfig
 }
 
 func DefaultRelayerConfig() *RelayerConfig {
 	return &RelayerConfig{
-		EventListenerConfig:     l1.DefaultEventListenerConfig(),
-		FinalityEnsurerConfig:   l1.DefaultFinalityEnsurerConfig(),
-		TransactionSenderConfig: l2.DefaultTransactionSenderConfig(),
-		L2ContractConfig:        l2.DefaultContractConfig(),
+		EventListenerConfig:       l1.DefaultEventListenerConfig(),
+		FinalityEnsurerConfig:     l1.DefaultFinalityEnsurerConfig(),
+		TransactionSenderConfig:   l2.DefaultTransactionSenderConfig(),
+		L2ContractConfig:          l2.DefaultContractConfig(),
+		WithdrawalListenerConfig:  withdrawal.DefaultEventListenerConfig(),
+		WithdrawalFinalizerConfig: withdrawal.DefaultFinalizerConfig(),
 		TelemetryConfig: &telemetry.Config{
 			ServiceName: "relayer",
 		},
@@ -46,6 +52,8 @@ type RelayerService struct {
 	L1EventListener     *l1.EventListener
 	L1FinalityEnsurer   *l1.FinalityEnsurer
 	L2TransactionSender *l2.TransactionSender
+	WithdrawalListener  *withdrawal.EventListener
+	WithdrawalFinalizer *withdrawal.Finalizer
 	DebugListener       *debug.RPCListener
 	HeartbeatSender     *debug.HeartbeatSender
 }
@@ -176,6 +184,14 @@ func New(
 		return nil, err
 	}
 
+	if config.WithdrawalFinalizerConfig.Enabled() {
+		if err := rs.initWithdrawalRelaying(ctx, database, clock, storageMetrics, l1Client, l2Client); err != nil {
+			return nil, err
+		}
+	} else {
+		rs.Logger.Warn().Msg("NilRollup contract address is not set, withdrawals are not going to be relayed to L1")
+	}
+
 	rs.DebugListener = debug.NewRPCListener(
 		config.DebugAPIConfig,
 		l1Storage,
@@ -200,6 +216,61 @@ func New(
 	return rs, nil
 }
 
+func (rs *RelayerService) initWithdrawalRelaying(
+	ctx context.Context,
+	database db.DB,
+	clock clockwork.Clock,
+	storageMetrics storage.TableMetrics,
+	l1Client l1.EthClient,
+	l2Client client.Client,
+) error {
+	withdrawalStorage := withdrawal.NewStorage(ctx, database, clock, storageMetrics, rs.Logger)
+
+	listenerMetrics, err := withdrawal.NewEventListenerMetrics()
+	if err != nil {
+		return err
+	}
+
+	rs.WithdrawalListener, err = withdrawal.NewEventListener(
+		rs.Config.WithdrawalListenerConfig,
+		clock,
+		withdrawal.NewL2ContractWrapper(l2Client, rs.Config.L2ContractConfig.ContractAddress),
+		withdrawalStorage,
+		listenerMetrics,
+		rs.Logger,
+	)
+	if err != nil {
+		return err
+	}
+
+	l1Contract, err := withdrawal.NewL1ContractWrapper(
+		ctx,
+		l1Client,
+		rs.Config.WithdrawalFinalizerConfig,
+		rs.Config.EventListenerConfig.BridgeMessengerContractAddress,
+		rs.Logger,
+	)
+	if err != nil {
+		return err
+	}
+
+	finalizerMetrics, err := withdrawal.NewFinalizerMetrics()
+	if err != nil {
+		return err
+	}
+
+	rs.WithdrawalFinalizer, err = withdrawal.NewFinalizer(
+		rs.Config.WithdrawalFinalizerConfig,
+		clock,
+		l1Contract,
+		withdrawalStorage,
+		finalizerMetrics,
+		rs.WithdrawalListener,
+		rs.Logger,
+	)
+	return err
+}
+
 func (rs *RelayerService) Run(ctx context.Context) error {
 	eg, gCtx := errgroup.WithContext(ctx)
 
@@ -228,6 +299,21 @@ func (rs *RelayerService) Run(ctx context.Context) error {
 	<-finalityEnsurerStarted
 	<-transactionSenderStarted
 
+	if rs.WithdrawalListener != nil {
+		withdrawalListenerStarted := make(chan struct{})
+		eg.Go(func() error {
+			return rs.WithdrawalListener.Run(gCtx, withdrawalListenerStarted)
+		})
+
+		withdrawalFinalizerStarted := make(chan struct{})
+		eg.Go(func() error {
+			return rs.WithdrawalFinalizer.Run(gCtx, withdrawalFinalizerStarted)
+		})
+
+		<-withdrawalListenerStarted
+		<-withdrawalFinalizerStarted
+	}
+
 	// start debug api after all other services are up and running
 	eg.Go(func() error {
 		started := make(chan struct{})
@@ -0,0 +1,53 @@
+package remotesigner
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	Namespace = "signer"
+
+	methodPublicKey      = Namespace + "_publicKey"
+	methodSignMessage    = Namespace + "_signMessage"
+	methodSignCommitSeal = Namespace + "_signCommitSeal"
+)
+
+// API is the protocol between a validator node and the remote signer.
+// All the signing requests are checked by the slashing protection of the signer.
+type API interface {
+	PublicKey(ctx context.Context) (hexutil.Bytes, error)
+	SignMessage(ctx context.Context, shardId types.ShardId, rawMessage hexutil.Bytes) (types.BlsSignature, error)
+	SignCommitSeal(
+		ctx context.Context, shardId types.ShardId, height, round uint64, proposalHash hexutil.Bytes,
+	) (types.BlsSignature, error)
+}
+
+type APIImpl struct {
+	signer signer.Signer
+}
+
+var _ API = (*APIImpl)(nil)
+
+func NewAPI(signer signer.Signer) *APIImpl {
+	return &APIImpl{signer: signer}
+}
+
+func (api *APIImpl) PublicKey(context.Context) (hexutil.Bytes, error) {
+	return api.signer.PublicKey(), nil
+}
+
+func (api *APIImpl) SignMessage(
+	ctx context.Context, shardId types.ShardId, rawMessage hexutil.Bytes,
+) (types.BlsSignature, error) {
+	return api.signer.SignMessage(ctx, shardId, rawMessage)
+}
+
+func (api *APIImpl) SignCommitSeal(
+	ctx context.Context, shardId types.ShardId, height, round uint64, proposalHash hexutil.Bytes,
+) (types.BlsSignature, error) {
+	return api.signer.SignCommitSeal(ctx, shardId, height, round, proposalHash)
+}
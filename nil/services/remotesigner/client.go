@@ -0,0 +1,66 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/client"
+	rpc_client "github.com/NilFoundation/nil/nil/client/rpc"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RemoteSigner forwards the signing requests to the remote signer.
+type RemoteSigner struct {
+	client    client.RawClient
+	publicKey []byte
+}
+
+var _ signer.Signer = (*RemoteSigner)(nil)
+
+// NewRemoteSigner connects to the remote signer, the public key is requested once on creation.
+func NewRemoteSigner(ctx context.Context, endpoint string, logger logging.Logger) (*RemoteSigner, error) {
+	s := &RemoteSigner{
+		client: rpc_client.NewRawClient(endpoint, logger),
+	}
+
+	publicKey, err := call[hexutil.Bytes](ctx, s.client, methodPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key from remote signer %s: %w", endpoint, err)
+	}
+	s.publicKey = publicKey
+	return s, nil
+}
+
+func (s *RemoteSigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) SignMessage(
+	ctx context.Context, shardId types.ShardId, rawMessage []byte,
+) (types.BlsSignature, error) {
+	return call[types.BlsSignature](ctx, s.client, methodSignMessage, shardId, hexutil.Bytes(rawMessage))
+}
+
+func (s *RemoteSigner) SignCommitSeal(
+	ctx context.Context, shardId types.ShardId, height, round uint64, proposalHash []byte,
+) (types.BlsSignature, error) {
+	return call[types.BlsSignature](
+		ctx, s.client, methodSignCommitSeal, shardId, height, round, hexutil.Bytes(proposalHash),
+	)
+}
+
+func call[T any](ctx context.Context, client client.RawClient, method string, params ...any) (T, error) {
+	var result T
+	raw, err := client.RawCall(ctx, method, params...)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal %s response: %w", method, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package remotesigner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
+	"github.com/NilFoundation/nil/nil/internal/crypto/bls"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.NewLogger("remote_signer_test")
+
+	database, err := db.NewBadgerDbInMemory()
+	require.NoError(t, err)
+	defer database.Close()
+
+	key := bls.NewRandomKey()
+	localSigner, err := signer.NewLocalSigner(key, signer.NewProtection(database, signer.DefaultRetainHeights))
+	require.NoError(t, err)
+
+	endpoint := rpc.GetSockPath(t)
+	started := make(chan struct{})
+	go func() {
+		_ = NewService(localSigner, logger).Run(ctx, endpoint, started)
+	}()
+	<-started
+
+	remoteSigner, err := NewRemoteSigner(ctx, endpoint, logger)
+	require.NoError(t, err)
+	require.Equal(t, localSigner.PublicKey(), remoteSigner.PublicKey())
+
+	proposalHash := []byte{1, 2, 3}
+	sig, err := remoteSigner.SignCommitSeal(ctx, types.MainShardId, 1, 0, proposalHash)
+	require.NoError(t, err)
+
+	signature, err := bls.SignatureFromBytes(sig)
+	require.NoError(t, err)
+	require.NoError(t, signature.Verify(key.PublicKey(), proposalHash))
+
+	// the slashing protection of the remote side rejects the conflicting vote
+	_, err = remoteSigner.SignCommitSeal(ctx, types.MainShardId, 1, 0, []byte{4, 5, 6})
+	require.ErrorContains(t, err, signer.ErrDoubleSign.Error())
+}
+
+func TestRemoteSignerEndpoint(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkEndpoint("unix:///tmp/nil_signer.sock"))
+	require.NoError(t, checkEndpoint("tcp://127.0.0.1:8530"))
+	require.NoError(t, checkEndpoint("tcp://localhost:8530"))
+	require.NoError(t, checkEndpoint("tcp://[::1]:8530"))
+	require.Error(t, checkEndpoint("tcp://0.0.0.0:8530"))
+	require.Error(t, checkEndpoint("tcp://:8530"))
+	require.Error(t, checkEndpoint("tcp://10.0.0.1:8530"))
+}
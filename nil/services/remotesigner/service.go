@@ -0,0 +1,74 @@
+// Package remotesigner implements the remote signer: a standalone process holding the validator BLS key,
+// which signs consensus messages for a node over JSON-RPC, usually on a unix socket.
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/consensus/signer"
+	"github.com/NilFoundation/nil/nil/services/rpc"
+	"github.com/NilFoundation/nil/nil/services/rpc/httpcfg"
+	"github.com/NilFoundation/nil/nil/services/rpc/transport"
+)
+
+type Service struct {
+	impl   API
+	logger logging.Logger
+}
+
+// NewService creates the remote signer service.
+// The signer is expected to have slashing protection enabled, see signer.NewProtection.
+func NewService(signer signer.Signer, logger logging.Logger) *Service {
+	return &Service{
+		impl:   NewAPI(signer),
+		logger: logger,
+	}
+}
+
+func (s *Service) GetRpcApi() transport.API {
+	return transport.API{
+		Namespace: Namespace,
+		Public:    true,
+		Service:   s.impl,
+		Version:   "1.0",
+	}
+}
+
+// Run serves the signing requests on the endpoint until the context is done.
+// Unlike other services, no CORS domains are allowed, the signer is not supposed to be reachable from browsers.
+func (s *Service) Run(ctx context.Context, endpoint string, started chan<- struct{}) error {
+	if err := checkEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	httpConfig := &httpcfg.HttpCfg{
+		HttpURL:      endpoint,
+		HTTPTimeouts: httpcfg.DefaultHTTPTimeouts,
+	}
+	return rpc.StartRpcServer(ctx, httpConfig, []transport.API{s.GetRpcApi()}, s.logger, started)
+}
+
+// checkEndpoint refuses to serve on a non-loopback TCP address: the API has neither TLS nor authentication,
+// so anyone reaching it could make the validator sign arbitrary messages.
+func checkEndpoint(endpoint string) error {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid remote signer endpoint %q: %w", endpoint, err)
+	}
+	if endpointUrl.Scheme == "unix" {
+		return nil
+	}
+
+	host := endpointUrl.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("remote signer must listen on a unix socket or a loopback address, got %q", endpoint)
+}
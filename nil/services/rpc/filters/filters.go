@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -26,14 +27,28 @@ import (
 
 var logger = logging.NewLogger("filters")
 
+// MaxBlocksRange is the maximum number of blocks of a shard scanned for the logs of a filter
+const MaxBlocksRange = 1024
+
+var ErrBlocksRangeTooLarge = errors.New("blocks range is too large")
+
 type MetaLog struct {
 	Log     *types.Log
 	BlockId types.BlockNumber
 }
 
 type Filter struct {
-	query  *FilterQuery
-	output chan *MetaLog
+	query *FilterQuery
+
+	// output holds the logs of the new blocks until they are taken.
+	// The logs are appended while the blocks are processed, so they are visible to the readers right after it.
+	outputMutex sync.Mutex
+	output      []*MetaLog
+
+	// history holds the logs of the blocks range of the query,
+	// scanned holds the last block of every shard included in it
+	history []*MetaLog
+	scanned map[types.ShardId]types.BlockNumber
 }
 
 // FilterQuery contains options for contract log filtering.
@@ -64,12 +79,13 @@ type (
 type FiltersManager struct {
 	ctx       context.Context
 	db        db.ReadOnlyDB
-	shardId   types.ShardId
 	filters   map[SubscriptionID]*Filter
 	blockSubs map[SubscriptionID]chan<- *types.Block
 	mutex     sync.RWMutex
-	lastHash  common.Hash
-	wg        sync.WaitGroup
+	// lastHash holds the last processed block of every polled shard.
+	// The main shard is always polled, other shards are polled once there are filters for their contracts.
+	lastHash map[types.ShardId]common.Hash
+	wg       sync.WaitGroup
 }
 
 func NewFiltersManager(ctx context.Context, db db.ReadOnlyDB, noPolling bool) *FiltersManager {
@@ -78,7 +94,7 @@ func NewFiltersManager(ctx context.Context, db db.ReadOnlyDB, noPolling bool) *F
 		db:        db,
 		filters:   make(map[SubscriptionID]*Filter),
 		blockSubs: make(map[SubscriptionID]chan<- *types.Block),
-		lastHash:  common.EmptyHash,
+		lastHash:  map[types.ShardId]common.Hash{types.MainShardId: common.EmptyHash},
 	}
 
 	if !noPolling {
@@ -93,27 +109,80 @@ func (m *FiltersManager) WaitForShutdown() {
 	m.wg.Wait()
 }
 
-func (f *Filter) LogsChannel() <-chan *MetaLog {
-	return f.output
+// TakeLogs returns the logs of the new blocks added since the previous call
+func (f *Filter) TakeLogs() []*MetaLog {
+	f.outputMutex.Lock()
+	defer f.outputMutex.Unlock()
+
+	logs := f.output
+	f.output = nil
+	return logs
 }
 
-func (m *FiltersManager) NewFilter(query *FilterQuery) (SubscriptionID, *Filter) {
+// History returns the logs of the blocks range requested by the query.
+// They precede the logs returned by TakeLogs.
+func (f *Filter) History() []*MetaLog {
+	return f.history
+}
+
+func (m *FiltersManager) NewFilter(query *FilterQuery) (SubscriptionID, *Filter, error) {
+	filter := &Filter{query: query}
+
+	// The range is scanned without blocking the polling,
+	// the blocks processed by the polling meanwhile are caught up below.
+	if query.FromBlock != nil || query.ToBlock != nil {
+		if err := m.processBlocksRange(filter); err != nil {
+			return "", nil, err
+		}
+	}
+
 	id := generateSubscriptionID()
 
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	filter := &Filter{query: query, output: make(chan *MetaLog, 100)}
+	m.trackShards(query)
+	if filter.scanned != nil {
+		if err := m.catchUp(filter); err != nil {
+			return "", nil, err
+		}
+	}
 	m.filters[id] = filter
 
-	if query.FromBlock != nil || query.ToBlock != nil {
-		if err := m.processBlocksRange(filter); err != nil {
-			logger.Error().Err(err).Msg("Filter processing blocks failed")
-			return "", nil
+	return id, filter, nil
+}
+
+// shards returns the shards with the logs matching the query.
+// The query without addresses matches only the logs of the main shard.
+func (q *FilterQuery) shards() []types.ShardId {
+	if len(q.Addresses) == 0 {
+		return []types.ShardId{types.MainShardId}
+	}
+	shards := make([]types.ShardId, 0, 1)
+	for _, addr := range q.Addresses {
+		if !slices.Contains(shards, addr.ShardId()) {
+			shards = append(shards, addr.ShardId())
 		}
 	}
+	return shards
+}
 
-	return id, filter
+// trackShards starts polling the shards of the query from their current last blocks,
+// so the new filter gets only the logs of the new blocks
+func (m *FiltersManager) trackShards(query *FilterQuery) {
+	for _, shardId := range query.shards() {
+		if _, ok := m.lastHash[shardId]; ok {
+			continue
+		}
+		lastHash, err := m.getLastBlockHash(shardId)
+		if err != nil {
+			if !errors.Is(err, db.ErrKeyNotFound) {
+				logger.Warn().Err(err).Stringer(logging.FieldShardId, shardId).Msg("getLastBlockHash failed")
+			}
+			continue
+		}
+		m.lastHash[shardId] = lastHash
+	}
 }
 
 func (m *FiltersManager) RemoveFilter(id SubscriptionID) bool {
@@ -122,12 +191,32 @@ func (m *FiltersManager) RemoveFilter(id SubscriptionID) bool {
 
 	filter, exist := m.filters[id]
 	if exist {
-		close(filter.output)
 		delete(m.filters, id)
+		m.untrackShards(filter.query)
 	}
 	return exist
 }
 
+// untrackShards stops polling the shards of the removed query that no other filter references.
+// The main shard is always polled for the block listeners.
+func (m *FiltersManager) untrackShards(query *FilterQuery) {
+	for _, shardId := range query.shards() {
+		if shardId == types.MainShardId {
+			continue
+		}
+		referenced := false
+		for _, filter := range m.filters {
+			if slices.Contains(filter.query.shards(), shardId) {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			delete(m.lastHash, shardId)
+		}
+	}
+}
+
 func (m *FiltersManager) AddBlocksListener() (SubscriptionID, <-chan *types.Block) {
 	id := generateSubscriptionID()
 	ch := make(chan *types.Block, 100)
@@ -160,150 +249,247 @@ func (m *FiltersManager) PollBlocks(delay time.Duration) {
 		case <-time.After(delay):
 		}
 
-		lastHash, err := m.getLastBlockHash()
-		if err != nil {
-			if !errors.Is(err, db.ErrKeyNotFound) {
-				logger.Warn().Err(err).Msg("getLastBlockHash failed")
-			}
-			continue
+		m.mutex.RLock()
+		shards := slices.Collect(maps.Keys(m.lastHash))
+		m.mutex.RUnlock()
+
+		for _, shardId := range shards {
+			m.pollShard(shardId)
 		}
+	}
+}
 
-		if m.lastHash != lastHash {
-			m.mutex.Lock()
-			for currHash := lastHash; m.lastHash != currHash; {
-				block, err := m.processBlockHash(currHash)
-				if err != nil {
-					logger.Warn().Err(err).Msg("processBlockHash failed")
-					continue
-				}
-				for _, ch := range m.blockSubs {
-					// Don't send if the channel is full.
-					// Probably subscriber just disconnected, and it shouldn't block us.
-					if len(ch) < cap(ch) {
-						ch <- block
-					}
-				}
-				currHash = block.PrevBlock
-				if currHash == common.EmptyHash {
-					break
+func (m *FiltersManager) pollShard(shardId types.ShardId) {
+	lastHash, err := m.getLastBlockHash(shardId)
+	if err != nil {
+		if !errors.Is(err, db.ErrKeyNotFound) {
+			logger.Warn().Err(err).Stringer(logging.FieldShardId, shardId).Msg("getLastBlockHash failed")
+		}
+		return
+	}
+
+	m.mutex.RLock()
+	prevHash, ok := m.lastHash[shardId]
+	m.mutex.RUnlock()
+	if !ok || prevHash == lastHash {
+		return
+	}
+
+	// The blocks are read without the lock. If any of them fails, none is processed and the next poll retries.
+	blocks, err := m.readNewBlocks(shardId, prevHash, lastHash)
+	if err != nil {
+		logger.Warn().Err(err).Stringer(logging.FieldShardId, shardId).Msg("readNewBlocks failed")
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// The shard could have been dropped or tracked anew meanwhile
+	if currHash, ok := m.lastHash[shardId]; !ok || currHash != prevHash {
+		return
+	}
+	for _, block := range blocks {
+		if err := m.process(shardId, block.block, block.receipts); err != nil {
+			logger.Warn().Err(err).Stringer(logging.FieldShardId, shardId).Msg("process failed")
+			break
+		}
+		// Block listeners are notified only about the blocks of the main shard
+		if shardId == types.MainShardId {
+			for _, ch := range m.blockSubs {
+				// Don't send if the channel is full.
+				// Probably subscriber just disconnected, and it shouldn't block us.
+				if len(ch) < cap(ch) {
+					ch <- block.block
 				}
 			}
-			m.lastHash = lastHash
-			m.mutex.Unlock()
 		}
 	}
+	m.lastHash[shardId] = lastHash
 }
 
-// / If FromBlock is set in the filter, then processBlocksRange processes all blocks in the range [FromBlock..ToBlock].
-func (m *FiltersManager) processBlocksRange(filter *Filter) error {
+type blockWithReceipts struct {
+	block    *types.Block
+	receipts types.Receipts
+}
+
+// readNewBlocks returns the blocks after prevHash up to lastHash with their receipts, starting from the last one
+func (m *FiltersManager) readNewBlocks(
+	shardId types.ShardId, prevHash, lastHash common.Hash,
+) ([]blockWithReceipts, error) {
 	tx, err := m.db.CreateRoTx(m.ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
 
-	var fromBlockNum, lastBlockNum uint64
+	var blocks []blockWithReceipts
+	for currHash := lastHash; currHash != prevHash && currHash != common.EmptyHash; {
+		block, err := db.ReadBlock(tx, shardId, currHash)
+		if err != nil {
+			return nil, err
+		}
+		receipts, err := m.readReceipts(tx, shardId, block)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, blockWithReceipts{block: block, receipts: receipts})
+		currHash = block.PrevBlock
+	}
+	return blocks, nil
+}
 
-	if filter.query.FromBlock != nil {
-		fromBlockNum = filter.query.FromBlock.Uint64()
-	} else {
-		fromBlockNum = 0
+// processBlocksRange collects the logs of the blocks in the range [FromBlock..ToBlock]
+// of the shards matched by the filter into its history.
+// The range is limited by the last block of the shard and can't exceed MaxBlocksRange.
+func (m *FiltersManager) processBlocksRange(filter *Filter) error {
+	tx, err := m.db.CreateRoTx(m.ctx)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	if filter.query.ToBlock != nil {
-		lastBlockNum = filter.query.ToBlock.Uint64()
-	} else {
-		lastBlock, _, err := db.ReadLastBlock(tx, m.shardId)
+	query := filter.query
+	filter.scanned = make(map[types.ShardId]types.BlockNumber)
+	for _, shardId := range query.shards() {
+		lastBlock, _, err := db.ReadLastBlock(tx, shardId)
 		if err != nil {
 			return err
 		}
-		lastBlockNum = uint64(lastBlock.Id)
+
+		var fromBlockNum uint64
+		if query.FromBlock != nil {
+			fromBlockNum = query.FromBlock.Uint64()
+		}
+		lastBlockNum := uint64(lastBlock.Id)
+		if query.ToBlock != nil && query.ToBlock.Uint64() < lastBlockNum {
+			lastBlockNum = query.ToBlock.Uint64()
+		}
+		if fromBlockNum <= lastBlockNum && lastBlockNum-fromBlockNum >= MaxBlocksRange {
+			return fmt.Errorf("%w: %d blocks of shard %d, at most %d are allowed",
+				ErrBlocksRangeTooLarge, lastBlockNum-fromBlockNum+1, shardId, MaxBlocksRange)
+		}
+
+		if err := m.scanBlocks(tx, filter, shardId, fromBlockNum, lastBlockNum); err != nil {
+			return err
+		}
+		filter.scanned[shardId] = lastBlock.Id
 	}
+	return nil
+}
 
-	for ; fromBlockNum <= lastBlockNum; fromBlockNum++ {
-		block, err := db.ReadBlockByNumber(tx, m.shardId, types.BlockNumber(fromBlockNum))
+// catchUp adds the logs of the blocks processed by the polling after the blocks range was scanned
+func (m *FiltersManager) catchUp(filter *Filter) error {
+	tx, err := m.db.CreateRoTx(m.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for shardId, scanned := range filter.scanned {
+		lastHash := m.lastHash[shardId]
+		if lastHash == common.EmptyHash {
+			continue
+		}
+		lastBlock, err := db.ReadBlock(tx, shardId, lastHash)
 		if err != nil {
 			return err
 		}
-		receipts, err := m.readReceipts(tx, block)
+		if lastBlock.Id <= scanned {
+			continue
+		}
+		if err := m.scanBlocks(tx, filter, shardId, uint64(scanned)+1, uint64(lastBlock.Id)); err != nil {
+			return err
+		}
+		filter.scanned[shardId] = lastBlock.Id
+	}
+	return nil
+}
+
+// scanBlocks adds the matching logs of the blocks in the range [from..to] to the filter history
+func (m *FiltersManager) scanBlocks(tx db.RoTx, filter *Filter, shardId types.ShardId, from, to uint64) error {
+	for ; from <= to; from++ {
+		block, err := db.ReadBlockByNumber(tx, shardId, types.BlockNumber(from))
 		if err != nil {
 			return err
 		}
-		if err = m.processFilter(block, filter, receipts); err != nil {
+		receipts, err := m.readReceipts(tx, shardId, block)
+		if err != nil {
 			return err
 		}
+		matchLogs(filter.query, block, receipts, func(log *MetaLog) {
+			filter.history = append(filter.history, log)
+		})
 	}
 	return nil
 }
 
-func (m *FiltersManager) readReceipts(tx db.RoTx, block *types.Block) ([]*types.Receipt, error) {
-	reader := execution.NewDbReceiptTrieReader(tx, m.shardId)
+func (m *FiltersManager) readReceipts(
+	tx db.RoTx, shardId types.ShardId, block *types.Block,
+) ([]*types.Receipt, error) {
+	reader := execution.NewDbReceiptTrieReader(tx, shardId)
 	if err := reader.SetRootHash(block.ReceiptsRoot); err != nil {
 		return nil, err
 	}
 	return reader.Values()
 }
 
-func (m *FiltersManager) processBlockHash(lastHash common.Hash) (*types.Block, error) {
-	tx, err := m.db.CreateRoTx(m.ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
-	block, err := db.ReadBlock(tx, m.shardId, lastHash)
-	if err != nil {
-		return nil, err
-	}
-
-	receipts, err := m.readReceipts(tx, block)
-	if err != nil {
-		return nil, err
+func (m *FiltersManager) processFilter(
+	shardId types.ShardId, block *types.Block, filter *Filter, receipts types.Receipts,
+) error {
+	// the logs of the block are already in the filter history
+	if scanned, ok := filter.scanned[shardId]; ok && block.Id <= scanned {
+		return nil
 	}
+	filter.outputMutex.Lock()
+	defer filter.outputMutex.Unlock()
 
-	return block, m.process(block, receipts)
+	matchLogs(filter.query, block, receipts, func(log *MetaLog) {
+		filter.output = append(filter.output, log)
+	})
+	return nil
 }
 
-func (m *FiltersManager) processFilter(block *types.Block, filter *Filter, receipts types.Receipts) error {
-	if filter.query.ToBlock != nil && uint64(block.Id) > filter.query.ToBlock.Uint64() {
-		return nil
+// matchLogs passes the logs of the block matching the query to emit
+func matchLogs(query *FilterQuery, block *types.Block, receipts types.Receipts, emit func(*MetaLog)) {
+	if query.FromBlock != nil && uint64(block.Id) < query.FromBlock.Uint64() {
+		return
+	}
+	if query.ToBlock != nil && uint64(block.Id) > query.ToBlock.Uint64() {
+		return
 	}
 	for _, receipt := range receipts {
-		if len(filter.query.Addresses) != 0 && !slices.Contains(filter.query.Addresses, receipt.ContractAddress) {
+		if len(query.Addresses) == 0 {
+			// the query without addresses matches only the logs of the main shard
+			if receipt.ContractAddress.ShardId() != types.MainShardId {
+				continue
+			}
+		} else if !slices.Contains(query.Addresses, receipt.ContractAddress) {
 			continue
 		}
 		for _, log := range receipt.Logs {
 			found := true
-			for i, topics := range filter.query.Topics {
+			for i, topics := range query.Topics {
 				if i >= log.TopicsNum() {
 					found = false
 					break
 				}
-				switch len(topics) {
-				case 0:
-					continue
-				case 1: // valid case, process after switch block
-				default:
-					panic("TODO: Topics disjunction isn't supported yet")
-				}
-
-				logTopic := log.Topics[i]
-				if logTopic != topics[0] {
+				// an empty list matches any topic, otherwise any of the listed topics has to match
+				if len(topics) != 0 && !slices.Contains(topics, log.Topics[i]) {
 					found = false
 					break
 				}
 			}
 			if found {
-				filter.output <- &MetaLog{log, block.Id}
+				emit(&MetaLog{log, block.Id})
 			}
 		}
 	}
-	return nil
 }
 
-func (m *FiltersManager) process(block *types.Block, receipts types.Receipts) error {
+func (m *FiltersManager) process(shardId types.ShardId, block *types.Block, receipts types.Receipts) error {
 	for _, filter := range m.filters {
-		err := m.processFilter(block, filter, receipts)
+		err := m.processFilter(shardId, block, filter, receipts)
 		if err != nil {
 			return err
 		}
@@ -315,14 +501,14 @@ func (m *FiltersManager) process(block *types.Block, receipts types.Receipts) er
 func (m *FiltersManager) OnNewBlock(block *types.Block) {
 }
 
-func (m *FiltersManager) getLastBlockHash() (common.Hash, error) {
+func (m *FiltersManager) getLastBlockHash(shardId types.ShardId) (common.Hash, error) {
 	tx, err := m.db.CreateRoTx(m.ctx)
 	if err != nil {
 		return common.EmptyHash, err
 	}
 	defer tx.Rollback()
 
-	return db.ReadLastBlockHash(tx, m.shardId)
+	return db.ReadLastBlockHash(tx, shardId)
 }
 
 var globalSubscriptionId uint64
@@ -371,7 +557,8 @@ func (args *FilterQuery) UnmarshalJSON(data []byte) error {
 			args.FromBlock = uint256.NewInt(raw.FromBlock.Uint64())
 		}
 
-		if raw.ToBlock != nil {
+		// tags like "latest" mean the range up to the last block
+		if raw.ToBlock != nil && !raw.ToBlock.IsSpecial() {
 			args.ToBlock = uint256.NewInt(raw.ToBlock.Uint64())
 		}
 	}
@@ -448,6 +635,40 @@ func (args *FilterQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (args FilterQuery) MarshalJSON() ([]byte, error) {
+	type output struct {
+		BlockHash *common.Hash    `json:"blockHash,omitempty"`
+		FromBlock *hexutil.Uint64 `json:"fromBlock,omitempty"`
+		ToBlock   *hexutil.Uint64 `json:"toBlock,omitempty"`
+		Addresses []types.Address `json:"address,omitempty"`
+		Topics    []any           `json:"topics,omitempty"`
+	}
+
+	res := output{
+		BlockHash: args.BlockHash,
+		Addresses: args.Addresses,
+	}
+	if args.FromBlock != nil {
+		from := hexutil.Uint64(args.FromBlock.Uint64())
+		res.FromBlock = &from
+	}
+	if args.ToBlock != nil {
+		to := hexutil.Uint64(args.ToBlock.Uint64())
+		res.ToBlock = &to
+	}
+	for _, topics := range args.Topics {
+		switch len(topics) {
+		case 0:
+			res.Topics = append(res.Topics, nil)
+		case 1:
+			res.Topics = append(res.Topics, topics[0])
+		default:
+			res.Topics = append(res.Topics, topics)
+		}
+	}
+	return json.Marshal(res)
+}
+
 func decodeAddress(s string) (types.Address, error) {
 	b, err := hexutil.Decode(s)
 	if err == nil && len(b) != types.AddrSize {
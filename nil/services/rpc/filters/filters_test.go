@@ -2,6 +2,7 @@ package filters
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/NilFoundation/nil/nil/common"
@@ -10,6 +11,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/mpt"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -65,36 +67,40 @@ func (s *SuiteFilters) TestMatcherOneReceipt() {
 	receipts = append(receipts, &types.Receipt{ContractAddress: address1, Logs: logs})
 
 	// All logs with Address == address1
-	id, f := filters.NewFilter(&FilterQuery{Addresses: []types.Address{address1}})
+	id, f, err := filters.NewFilter(&FilterQuery{Addresses: []types.Address{address1}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 3)
-	s.Equal((<-f.LogsChannel()).Log, logs[0])
-	s.Equal((<-f.LogsChannel()).Log, logs[1])
-	s.Equal((<-f.LogsChannel()).Log, logs[2])
+	s.Equal(popLog(f).Log, logs[0])
+	s.Equal(popLog(f).Log, logs[1])
+	s.Equal(popLog(f).Log, logs[2])
 	filters.RemoveFilter(id)
 
 	// Only logs with [1, 2] topics
-	id, f = filters.NewFilter(
+	id, f, err = filters.NewFilter(
 		&FilterQuery{Addresses: []types.Address{address1}, Topics: [][]common.Hash{{{0x01}}, {{0x02}}}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 1)
-	s.Equal((<-f.LogsChannel()).Log, logs[0])
+	s.Equal(popLog(f).Log, logs[0])
 	filters.RemoveFilter(id)
 
 	// Only logs with [any, 2] topics
-	id, f = filters.NewFilter(&FilterQuery{Addresses: []types.Address{address1}, Topics: [][]common.Hash{{}, {{0x02}}}})
+	id, f, err = filters.NewFilter(
+		&FilterQuery{Addresses: []types.Address{address1}, Topics: [][]common.Hash{{}, {{0x02}}}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 2)
-	s.Equal((<-f.LogsChannel()).Log, logs[0])
-	s.Equal((<-f.LogsChannel()).Log, logs[1])
+	s.Equal(popLog(f).Log, logs[0])
+	s.Equal(popLog(f).Log, logs[1])
 	filters.RemoveFilter(id)
 }
 
@@ -149,104 +155,112 @@ func (s *SuiteFilters) TestMatcherTwoReceipts() {
 	receipts = append(receipts, &types.Receipt{ContractAddress: address2, Logs: logs2})
 
 	// All logs
-	id, f := filters.NewFilter(&FilterQuery{})
+	id, f, err := filters.NewFilter(&FilterQuery{})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 6)
-	s.Equal((<-f.LogsChannel()).Log, logs1[0])
-	s.Equal((<-f.LogsChannel()).Log, logs1[1])
-	s.Equal((<-f.LogsChannel()).Log, logs1[2])
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
-	s.Equal((<-f.LogsChannel()).Log, logs2[0])
-	s.Equal((<-f.LogsChannel()).Log, logs2[1])
+	s.Equal(popLog(f).Log, logs1[0])
+	s.Equal(popLog(f).Log, logs1[1])
+	s.Equal(popLog(f).Log, logs1[2])
+	s.Equal(popLog(f).Log, logs1[3])
+	s.Equal(popLog(f).Log, logs2[0])
+	s.Equal(popLog(f).Log, logs2[1])
 	filters.RemoveFilter(id)
 
 	// All logs of address1
-	id, f = filters.NewFilter(&FilterQuery{Addresses: []types.Address{address1}})
+	id, f, err = filters.NewFilter(&FilterQuery{Addresses: []types.Address{address1}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 4)
-	s.Equal((<-f.LogsChannel()).Log, logs1[0])
-	s.Equal((<-f.LogsChannel()).Log, logs1[1])
-	s.Equal((<-f.LogsChannel()).Log, logs1[2])
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
+	s.Equal(popLog(f).Log, logs1[0])
+	s.Equal(popLog(f).Log, logs1[1])
+	s.Equal(popLog(f).Log, logs1[2])
+	s.Equal(popLog(f).Log, logs1[3])
 	filters.RemoveFilter(id)
 
 	// All logs of address2
-	id, f = filters.NewFilter(&FilterQuery{Addresses: []types.Address{address2}})
+	id, f, err = filters.NewFilter(&FilterQuery{Addresses: []types.Address{address2}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
 	s.Len(f.output, 2)
-	s.Equal((<-f.LogsChannel()).Log, logs2[0])
-	s.Equal((<-f.LogsChannel()).Log, logs2[1])
+	s.Equal(popLog(f).Log, logs2[0])
+	s.Equal(popLog(f).Log, logs2[1])
 	filters.RemoveFilter(id)
 
 	// address1: nil, nil, 3
-	id, f = filters.NewFilter(&FilterQuery{
+	id, f, err = filters.NewFilter(&FilterQuery{
 		Addresses: []types.Address{address1},
 		Topics:    [][]common.Hash{{}, {}, {{0x03}}},
 	})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
-	s.Require().Len(f.LogsChannel(), 2)
-	s.Equal((<-f.LogsChannel()).Log, logs1[0])
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 2)
+	s.Equal(popLog(f).Log, logs1[0])
+	s.Equal(popLog(f).Log, logs1[3])
 	filters.RemoveFilter(id)
 
 	// any address: nil, 2
-	id, f = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{}, {{2}}}})
+	id, f, err = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{}, {{2}}}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
-	s.Require().Len(f.LogsChannel(), 2)
-	s.Equal((<-f.LogsChannel()).Log, logs1[0])
-	s.Equal((<-f.LogsChannel()).Log, logs2[0])
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 2)
+	s.Equal(popLog(f).Log, logs1[0])
+	s.Equal(popLog(f).Log, logs2[0])
 	filters.RemoveFilter(id)
 
 	// any address: nil, 2
-	id, f = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{{3}}, {}, {{3}}}})
+	id, f, err = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{{3}}, {}, {{3}}}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
-	s.Require().Len(f.LogsChannel(), 2)
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
-	s.Equal((<-f.LogsChannel()).Log, logs2[1])
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 2)
+	s.Equal(popLog(f).Log, logs1[3])
+	s.Equal(popLog(f).Log, logs2[1])
 	filters.RemoveFilter(id)
 
 	// address1: 3
-	id, f = filters.NewFilter(&FilterQuery{
+	id, f, err = filters.NewFilter(&FilterQuery{
 		Addresses: []types.Address{address1},
 		Topics:    [][]common.Hash{{{0x03}}},
 	})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
-	s.Require().Len(f.LogsChannel(), 2)
-	s.Equal((<-f.LogsChannel()).Log, logs1[1])
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 2)
+	s.Equal(popLog(f).Log, logs1[1])
+	s.Equal(popLog(f).Log, logs1[3])
 	filters.RemoveFilter(id)
 
 	// any address: 3
-	id, f = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{{0x03}}}})
+	id, f, err = filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{{0x03}}}})
+	s.Require().NoError(err)
 	s.NotEmpty(id)
 	s.NotNil(f)
 
-	s.Require().NoError(filters.process(&block, receipts))
-	s.Require().Len(f.LogsChannel(), 3)
-	s.Equal((<-f.LogsChannel()).Log, logs1[1])
-	s.Equal((<-f.LogsChannel()).Log, logs1[3])
-	s.Equal((<-f.LogsChannel()).Log, logs2[1])
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 3)
+	s.Equal(popLog(f).Log, logs1[1])
+	s.Equal(popLog(f).Log, logs1[3])
+	s.Equal(popLog(f).Log, logs2[1])
 	filters.RemoveFilter(id)
 }
 
@@ -339,13 +353,17 @@ func (s *SuiteFilters) TestBlocksRange() {
 		Addresses: []types.Address{address},
 		Topics:    topics,
 	}
-	id1, filter1 := filters.NewFilter(query)
+	id1, filter1, err := filters.NewFilter(query)
+	s.Require().NoError(err)
 	s.Require().NotNil(filter1)
 	s.Require().NotEmpty(id1)
 
-	s.Len(filter1.output, 2)
-	s.Equal(logsInput[0], (<-filter1.LogsChannel()).Log)
-	s.Equal(logsInput[0], (<-filter1.LogsChannel()).Log)
+	s.Empty(filter1.output)
+	s.Require().Len(filter1.History(), 2)
+	s.Equal(logsInput[0], filter1.History()[0].Log)
+	s.Equal(types.BlockNumber(1), filter1.History()[0].BlockId)
+	s.Equal(logsInput[0], filter1.History()[1].Log)
+	s.Equal(types.BlockNumber(2), filter1.History()[1].BlockId)
 
 	topics = [][]common.Hash{{{3}}}
 	query = &FilterQuery{
@@ -355,14 +373,15 @@ func (s *SuiteFilters) TestBlocksRange() {
 		Addresses: []types.Address{address},
 		Topics:    topics,
 	}
-	id2, filter2 := filters.NewFilter(query)
+	id2, filter2, err := filters.NewFilter(query)
+	s.Require().NoError(err)
 	s.Require().NotNil(filter2)
 	s.Require().NotEmpty(id2)
 
-	s.Len(filter2.output, 3)
-	s.Equal(logsInput[0], (<-filter2.LogsChannel()).Log)
-	s.Equal(logsInput[0], (<-filter2.LogsChannel()).Log)
-	s.Equal(logsInput[0], (<-filter2.LogsChannel()).Log)
+	s.Require().Len(filter2.History(), 3)
+	for _, log := range filter2.History() {
+		s.Equal(logsInput[0], log.Log)
+	}
 
 	// Check with toBlock but without fromBlock
 	query = &FilterQuery{
@@ -372,12 +391,13 @@ func (s *SuiteFilters) TestBlocksRange() {
 		Addresses: []types.Address{address},
 		Topics:    topics,
 	}
-	id3, filter3 := filters.NewFilter(query)
+	id3, filter3, err := filters.NewFilter(query)
+	s.Require().NoError(err)
 	s.Require().NotNil(filter3)
 	s.Require().NotEmpty(id3)
 
-	s.Len(filter3.output, 1)
-	s.Equal(logsInput[0], (<-filter3.LogsChannel()).Log)
+	s.Require().Len(filter3.History(), 1)
+	s.Equal(logsInput[0], filter3.History()[0].Log)
 
 	tx, err = s.db.CreateRwTx(s.ctx)
 	s.Require().NoError(err)
@@ -396,9 +416,234 @@ func (s *SuiteFilters) TestBlocksRange() {
 	s.Require().NoError(tx.Commit())
 
 	// Check that only filter2 can get new logs, because it doesn't have `ToBlock` field
-	s.Require().NoError(filters.process(&block, []*types.Receipt{receipt}))
+	s.Require().NoError(filters.process(types.MainShardId, &block, []*types.Receipt{receipt}))
 	s.Empty(filter1.output)
-	s.GreaterOrEqual(len(filter2.output), 1)
+	s.Len(filter2.output, 1)
+
+	// the scanned blocks are not sent again
+	s.Require().NoError(filters.process(types.MainShardId, &types.Block{BlockData: types.BlockData{Id: 3}},
+		[]*types.Receipt{receipt}))
+	s.Len(filter2.output, 1)
+}
+
+func (s *SuiteFilters) TestMatcherShardsAndDisjunction() {
+	filters := NewFiltersManager(s.ctx, s.db, true)
+	s.NotNil(filters)
+	s.filters = filters
+
+	block := types.Block{BlockData: types.BlockData{Id: 1}}
+
+	mainAddress := types.HexToAddress("0x1111111111")
+	baseAddress := types.GenerateRandomAddress(types.BaseShardId)
+	mainLogs := []*types.Log{
+		{Address: mainAddress, Topics: []common.Hash{{0x01}}},
+		{Address: mainAddress, Topics: []common.Hash{{0x02}}},
+		{Address: mainAddress, Topics: []common.Hash{{0x03}}},
+	}
+	baseLogs := []*types.Log{
+		{Address: baseAddress, Topics: []common.Hash{{0x01}}},
+	}
+	receipts := []*types.Receipt{
+		{ContractAddress: mainAddress, Logs: mainLogs},
+		{ContractAddress: baseAddress, Logs: baseLogs},
+	}
+
+	// without addresses only the main shard is matched
+	id, f, err := filters.NewFilter(&FilterQuery{Topics: [][]common.Hash{{{0x01}, {0x03}}}})
+	s.Require().NoError(err)
+	s.NotEmpty(id)
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 2)
+	s.Equal(mainLogs[0], popLog(f).Log)
+	s.Equal(mainLogs[2], popLog(f).Log)
+	filters.RemoveFilter(id)
+
+	// logs of other shards are matched by the address
+	id, f, err = filters.NewFilter(&FilterQuery{Addresses: []types.Address{baseAddress}})
+	s.Require().NoError(err)
+	s.NotEmpty(id)
+	s.Require().NoError(filters.process(types.MainShardId, &block, receipts))
+	s.Require().Len(f.output, 1)
+	s.Equal(baseLogs[0], popLog(f).Log)
+	filters.RemoveFilter(id)
+}
+
+func (s *SuiteFilters) writeBlock(
+	tx db.RwTx, id types.BlockNumber, prevBlock, receiptsRoot common.Hash,
+) common.Hash {
+	s.T().Helper()
+
+	block := &types.Block{BlockData: types.BlockData{Id: id, PrevBlock: prevBlock, ReceiptsRoot: receiptsRoot}}
+	blockHash := block.Hash(types.MainShardId)
+	s.Require().NoError(db.WriteBlock(tx, types.MainShardId, blockHash, block))
+	blockResult := &execution.BlockGenerationResult{BlockHash: blockHash, Block: block}
+	s.Require().NoError(execution.PostprocessBlock(tx, types.MainShardId, blockResult, execution.ModeVerify))
+	return blockHash
+}
+
+func (s *SuiteFilters) writeReceipts(tx db.RwTx, receipts ...*types.Receipt) common.Hash {
+	s.T().Helper()
+
+	receiptsMpt := mpt.NewDbMPT(tx, types.MainShardId, db.ReceiptTrieTable)
+	for _, receipt := range receipts {
+		encoded, err := receipt.MarshalNil()
+		s.Require().NoError(err)
+		key := receipt.Hash()
+		s.Require().NoError(receiptsMpt.Set(key[:], encoded))
+	}
+	root, err := receiptsMpt.Commit()
+	s.Require().NoError(err)
+	return root
+}
+
+func (s *SuiteFilters) TestBlocksRangeLimit() {
+	filters := NewFiltersManager(s.ctx, s.db, true)
+	s.filters = filters
+
+	tx, err := s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	receiptsRoot := s.writeReceipts(tx)
+	prevBlock := common.EmptyHash
+	for id := range types.BlockNumber(MaxBlocksRange + 1) {
+		prevBlock = s.writeBlock(tx, id, prevBlock, receiptsRoot)
+	}
+	s.Require().NoError(tx.Commit())
+
+	_, _, err = filters.NewFilter(&FilterQuery{FromBlock: uint256.NewInt(0)})
+	s.Require().ErrorIs(err, ErrBlocksRangeTooLarge)
+
+	_, _, err = filters.NewFilter(&FilterQuery{FromBlock: uint256.NewInt(0), ToBlock: uint256.NewInt(5000)})
+	s.Require().ErrorIs(err, ErrBlocksRangeTooLarge)
+
+	id, _, err := filters.NewFilter(&FilterQuery{FromBlock: uint256.NewInt(1)})
+	s.Require().NoError(err)
+	s.NotEmpty(id)
+
+	id, _, err = filters.NewFilter(&FilterQuery{ToBlock: uint256.NewInt(MaxBlocksRange - 1)})
+	s.Require().NoError(err)
+	s.NotEmpty(id)
+}
+
+func (s *SuiteFilters) TestPollShard() {
+	filters := NewFiltersManager(s.ctx, s.db, true)
+	s.filters = filters
+
+	address := types.HexToAddress("0x1111111111")
+	receipt := &types.Receipt{ContractAddress: address, Logs: []*types.Log{{Address: address}}}
+
+	tx, err := s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+	receiptsRoot := s.writeReceipts(tx, receipt)
+	hash0 := s.writeBlock(tx, 0, common.EmptyHash, receiptsRoot)
+	s.Require().NoError(tx.Commit())
+
+	_, f, err := filters.NewFilter(&FilterQuery{Addresses: []types.Address{address}})
+	s.Require().NoError(err)
+	_, blocks := filters.AddBlocksListener()
+
+	filters.pollShard(types.MainShardId)
+	s.Require().Equal(hash0, filters.lastHash[types.MainShardId])
+	s.Require().Len(f.output, 1)
+	s.Equal(types.BlockNumber(0), popLog(f).BlockId)
+	s.Len(blocks, 1)
+	<-blocks
+
+	// the new blocks are processed from the newest one
+	tx, err = s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+	hash1 := s.writeBlock(tx, 1, hash0, receiptsRoot)
+	hash2 := s.writeBlock(tx, 2, hash1, receiptsRoot)
+	s.Require().NoError(tx.Commit())
+
+	filters.pollShard(types.MainShardId)
+	s.Require().Equal(hash2, filters.lastHash[types.MainShardId])
+	s.Require().Len(f.output, 2)
+	s.Equal(types.BlockNumber(2), popLog(f).BlockId)
+	s.Equal(types.BlockNumber(1), popLog(f).BlockId)
+	s.Require().Len(blocks, 2)
+	s.Equal(types.BlockNumber(2), (<-blocks).Id)
+	s.Equal(types.BlockNumber(1), (<-blocks).Id)
+
+	// the receipts of a block are missing, so none of the new blocks is processed and the next poll retries them
+	tx, err = s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+	hash3 := s.writeBlock(tx, 3, hash2, common.Hash{0x01})
+	s.writeBlock(tx, 4, hash3, receiptsRoot)
+	s.Require().NoError(tx.Commit())
+
+	filters.pollShard(types.MainShardId)
+	s.Require().Equal(hash2, filters.lastHash[types.MainShardId])
+	s.Empty(f.output)
+	s.Empty(blocks)
+}
+
+func (s *SuiteFilters) TestUntrackShards() {
+	filters := NewFiltersManager(s.ctx, s.db, true)
+	s.filters = filters
+
+	const shardId = types.ShardId(1)
+	address := types.ShardAndHexToAddress(shardId, "0x1111111111")
+	id1, _, err := filters.NewFilter(&FilterQuery{Addresses: []types.Address{address}})
+	s.Require().NoError(err)
+	id2, _, err := filters.NewFilter(&FilterQuery{Addresses: []types.Address{address, types.MainSmartAccountAddress}})
+	s.Require().NoError(err)
+	// the shard has no blocks in the test db, so it is not tracked by NewFilter
+	filters.lastHash[shardId] = common.EmptyHash
+
+	s.Require().True(filters.RemoveFilter(id1))
+	s.Contains(filters.lastHash, shardId)
+
+	s.Require().True(filters.RemoveFilter(id2))
+	s.NotContains(filters.lastHash, shardId)
+	s.Contains(filters.lastHash, types.MainShardId)
+}
+
+func (s *SuiteFilters) TestBlocksRangeCatchUp() {
+	filters := NewFiltersManager(s.ctx, s.db, true)
+	s.filters = filters
+
+	address := types.HexToAddress("0x1111111111")
+	receipt := &types.Receipt{ContractAddress: address, Logs: []*types.Log{{Address: address}}}
+
+	tx, err := s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+	receiptsRoot := s.writeReceipts(tx, receipt)
+	hash0 := s.writeBlock(tx, 0, common.EmptyHash, receiptsRoot)
+	s.Require().NoError(tx.Commit())
+	filters.pollShard(types.MainShardId)
+
+	query := &FilterQuery{FromBlock: uint256.NewInt(0), Addresses: []types.Address{address}}
+	f := &Filter{query: query}
+	s.Require().NoError(filters.processBlocksRange(f))
+
+	// the block is polled after the range is scanned, but before the filter is added
+	tx, err = s.db.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+	s.writeBlock(tx, 1, hash0, receiptsRoot)
+	s.Require().NoError(tx.Commit())
+	filters.pollShard(types.MainShardId)
+
+	filters.mutex.Lock()
+	s.Require().NoError(filters.catchUp(f))
+	filters.mutex.Unlock()
+
+	s.Require().Len(f.History(), 2)
+	s.Equal(types.BlockNumber(0), f.History()[0].BlockId)
+	s.Equal(types.BlockNumber(1), f.History()[1].BlockId)
+}
+
+// popLog takes the oldest new log of the filter
+func popLog(f *Filter) *MetaLog {
+	log := f.output[0]
+	f.output = f.output[1:]
+	return log
 }
 
 func TestFilters(t *testing.T) {
@@ -406,3 +651,20 @@ func TestFilters(t *testing.T) {
 
 	suite.Run(t, new(SuiteFilters))
 }
+
+func TestFilterQueryJSON(t *testing.T) {
+	t.Parallel()
+
+	query := FilterQuery{
+		FromBlock: uint256.NewInt(5),
+		ToBlock:   uint256.NewInt(10),
+		Addresses: []types.Address{types.HexToAddress("0x1111111111")},
+		Topics:    [][]common.Hash{{{0x01}}, nil, {{0x02}, {0x03}}},
+	}
+	data, err := json.Marshal(query)
+	require.NoError(t, err)
+
+	var decoded FilterQuery
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, query, decoded)
+}
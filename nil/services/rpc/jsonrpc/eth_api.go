@@ -347,6 +347,27 @@ type EthAPIRo interface {
 		blockNrOrHash transport.BlockNumberOrHash,
 	) (map[types.TokenId]types.Value, error)
 
+	/*
+		@name GetTokenInfo
+		@summary Returns the metadata of the token with the given ID.
+		@description Implements eth_getTokenInfo. The metadata is read from the minter of the token.
+		@tags [Accounts]
+		@param tokenId TokenId
+		@returns tokenInfo RPCTokenInfo
+	*/
+	GetTokenInfo(ctx context.Context, tokenId types.TokenId) (*RPCTokenInfo, error)
+
+	/*
+		@name GetTokenHolders
+		@summary Returns a page of the accounts holding the token with the given ID.
+		@description Implements eth_getTokenHolders. The holders are ordered by shard and address.
+		@tags [Accounts]
+		@param tokenId TokenId
+		@param page Page
+		@returns holders TokenHolders
+	*/
+	GetTokenHolders(ctx context.Context, tokenId types.TokenId, page uint64) ([]types.TokenHolder, error)
+
 	/*
 		@name GetProof
 		@summary Returns the account and storage values, along with Merkle proofs,
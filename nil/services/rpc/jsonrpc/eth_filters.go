@@ -13,16 +13,18 @@ import (
 )
 
 type LogsAggregator struct {
-	filters   *filters.FiltersManager
-	logsMap   *concurrent.Map[filters.SubscriptionID, []*filters.MetaLog]
-	blocksMap *concurrent.Map[filters.SubscriptionID, []*types.Block]
+	filters    *filters.FiltersManager
+	filtersMap *concurrent.Map[filters.SubscriptionID, *filters.Filter]
+	logsMap    *concurrent.Map[filters.SubscriptionID, []*filters.MetaLog]
+	blocksMap  *concurrent.Map[filters.SubscriptionID, []*types.Block]
 }
 
 func NewLogsAggregator(ctx context.Context, db db.ReadOnlyDB, pollBlocksForLogs bool) *LogsAggregator {
 	return &LogsAggregator{
-		filters:   filters.NewFiltersManager(ctx, db, !pollBlocksForLogs),
-		logsMap:   concurrent.NewMap[filters.SubscriptionID, []*filters.MetaLog](),
-		blocksMap: concurrent.NewMap[filters.SubscriptionID, []*types.Block](),
+		filters:    filters.NewFiltersManager(ctx, db, !pollBlocksForLogs),
+		filtersMap: concurrent.NewMap[filters.SubscriptionID, *filters.Filter](),
+		logsMap:    concurrent.NewMap[filters.SubscriptionID, []*filters.MetaLog](),
+		blocksMap:  concurrent.NewMap[filters.SubscriptionID, []*types.Block](),
 	}
 }
 
@@ -31,18 +33,14 @@ func (l *LogsAggregator) WaitForShutdown() {
 }
 
 func (l *LogsAggregator) CreateFilter(query *filters.FilterQuery) (filters.SubscriptionID, error) {
-	id, filter := l.filters.NewFilter(query)
-	if len(id) == 0 || filter == nil {
-		return "", errors.New("cannot create new filter")
+	id, filter, err := l.filters.NewFilter(query)
+	if err != nil {
+		return "", fmt.Errorf("cannot create new filter: %w", err)
 	}
 
-	go func() {
-		for log := range filter.LogsChannel() {
-			l.logsMap.DoAndStore(id, func(st []*filters.MetaLog, ok bool) []*filters.MetaLog {
-				return append(st, log)
-			})
-		}
-	}()
+	// the logs of the blocks range go before the logs of the new blocks
+	l.logsMap.Put(id, filter.History())
+	l.filtersMap.Put(id, filter)
 
 	return id, nil
 }
@@ -73,8 +71,20 @@ func (l *LogsAggregator) RemoveBlocksListener(id filters.SubscriptionID) error {
 	return errors.New("cannot remove blocks listener")
 }
 
+func (l *LogsAggregator) RemoveFilter(id filters.SubscriptionID) bool {
+	l.filtersMap.Delete(id)
+	l.logsMap.Delete(id)
+	return l.filters.RemoveFilter(id)
+}
+
+// GetLogs returns the logs of the filter since the previous call
 func (l *LogsAggregator) GetLogs(id filters.SubscriptionID) ([]*filters.MetaLog, bool) {
-	return l.logsMap.Delete(id)
+	filter, ok := l.filtersMap.Get(id)
+	if !ok {
+		return nil, false
+	}
+	history, _ := l.logsMap.Delete(id)
+	return append(history, filter.TakeLogs()...), true
 }
 
 // NewPendingTransactionFilter implements eth_newPendingTransactionFilter. It creates new transaction filter.
@@ -103,7 +113,7 @@ func (api *APIImplRo) NewFilter(_ context.Context, query filters.FilterQuery) (s
 func (api *APIImplRo) UninstallFilter(_ context.Context, id string) (isDeleted bool, err error) {
 	id = strings.TrimPrefix(id, "0x")
 	deleted := false
-	if ok := api.logs.filters.RemoveFilter(filters.SubscriptionID(id)); ok {
+	if ok := api.logs.RemoveFilter(filters.SubscriptionID(id)); ok {
 		deleted = true
 	}
 	if err := api.logs.RemoveBlocksListener(filters.SubscriptionID(id)); err == nil {
@@ -0,0 +1,113 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NilFoundation/nil/nil/internal/contracts"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	rawapitypes "github.com/NilFoundation/nil/nil/services/rpc/rawapi/types"
+	rpctypes "github.com/NilFoundation/nil/nil/services/rpc/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TokenHoldersPageSize is the maximum number of holders returned by eth_getTokenHolders.
+const TokenHoldersPageSize = 100
+
+var errNoTokenMetadata = errors.New("token metadata is not available")
+
+// GetTokenInfo implements eth_getTokenInfo.
+// The metadata is read from the minter contract which implements the NilTokenBase interface.
+// Faucet tokens fall back to their well-known names.
+func (api *APIImplRo) GetTokenInfo(ctx context.Context, tokenId types.TokenId) (*RPCTokenInfo, error) {
+	minter := types.Address(tokenId)
+	calldata, err := contracts.NewCallData(contracts.NameNilTokenBase, "getTokenMetadata")
+	if err != nil {
+		return nil, err
+	}
+
+	args := rpctypes.CallArgs{
+		To:   minter,
+		Data: (*hexutil.Bytes)(&calldata),
+		Fee:  types.NewFeePackFromGas(10_000_000),
+	}
+	blockRef := rawapitypes.BlockReferenceAsBlockReferenceOrHashWithChildren(
+		rawapitypes.NamedBlockIdentifierAsBlockReference(rawapitypes.LatestBlock))
+	res, err := api.rawapi.Call(ctx, args, blockRef, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("%w: %s", errNoTokenMetadata, res.Error)
+	}
+
+	values, err := contracts.UnpackData(contracts.NameNilTokenBase, "getTokenMetadata", res.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errNoTokenMetadata, err)
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("%w: unexpected number of values %d", errNoTokenMetadata, len(values))
+	}
+
+	info := &RPCTokenInfo{
+		Id:     tokenId,
+		Minter: minter,
+	}
+	var ok bool
+	if info.Name, ok = values[0].(string); !ok {
+		return nil, fmt.Errorf("%w: invalid name", errNoTokenMetadata)
+	}
+	if info.Symbol, ok = values[1].(string); !ok {
+		return nil, fmt.Errorf("%w: invalid symbol", errNoTokenMetadata)
+	}
+	if info.Decimals, ok = values[2].(uint8); !ok {
+		return nil, fmt.Errorf("%w: invalid decimals", errNoTokenMetadata)
+	}
+	supply, ok := values[3].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid total supply", errNoTokenMetadata)
+	}
+	if info.TotalSupply, ok = types.NewValueFromBig(supply); !ok {
+		return nil, fmt.Errorf("%w: total supply overflow", errNoTokenMetadata)
+	}
+
+	if name := types.GetTokenName(tokenId); name != "" {
+		if info.Name == "" {
+			info.Name = name
+		}
+		if info.Symbol == "" {
+			info.Symbol = name
+		}
+	}
+	return info, nil
+}
+
+// GetTokenHolders implements eth_getTokenHolders.
+// It returns the page with the given number, holders from all shards are enumerated in the order of shard ids.
+func (api *APIImplRo) GetTokenHolders(
+	ctx context.Context, tokenId types.TokenId, page uint64,
+) ([]types.TokenHolder, error) {
+	shardIds, err := api.rawapi.GetShardIdList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := page * TokenHoldersPageSize
+	holders := make([]types.TokenHolder, 0)
+	for _, shardId := range append([]types.ShardId{types.MainShardId}, shardIds...) {
+		limit := uint64(TokenHoldersPageSize - len(holders))
+		if limit == 0 {
+			break
+		}
+
+		res, err := api.rawapi.GetTokenHolders(ctx, shardId, tokenId, skip, limit)
+		if err != nil {
+			return nil, err
+		}
+		holders = append(holders, res.Holders...)
+		skip -= min(skip, res.Total)
+	}
+	return holders, nil
+}
@@ -95,6 +95,10 @@ type RPCBlock struct {
 	LogsBloom           hexutil.Bytes       `json:"logsBloom,omitempty"`
 	GasUsed             types.Gas           `json:"gasUsed,omitempty"`
 	Coinbase            types.Address       `json:"miner"`
+	// PrevRandao is the block randomness derived from the main shard block, RandaoSignature proves that
+	// the main shard block is finalized and can be verified against the keys of the main shard validators.
+	PrevRandao      common.Hash                  `json:"prevRandao"`
+	RandaoSignature *types.BlsAggregateSignature `json:"randaoSignature,omitempty"`
 }
 
 type ShardCount struct {
@@ -438,6 +442,8 @@ func NewRPCBlock(shardId types.ShardId, data *BlockWithEntities, fullTx bool) (*
 		L1Number:            block.L1BlockNumber,
 		GasUsed:             block.GasUsed,
 		Coinbase:            block.Coinbase,
+		PrevRandao:          block.PrevRandao(shardId),
+		RandaoSignature:     block.RandaoSignature,
 	}, nil
 }
 
@@ -525,6 +531,22 @@ type DebugRPCContract struct {
 	AsyncContext map[types.TransactionIndex]types.AsyncContext `json:"asyncContext"`
 }
 
+// @component RPCTokenInfo rpcTokenInfo object "The metadata of a token."
+// @componentprop Id id string true "The ID of the token."
+// @componentprop Minter minter string true "The address of the contract that mints the token."
+// @componentprop Name name string true "The name of the token."
+// @componentprop Symbol symbol string true "The symbol of the token."
+// @componentprop Decimals decimals integer true "The number of decimals used to display the token amounts."
+// @componentprop TotalSupply totalSupply string true "The total supply of the token."
+type RPCTokenInfo struct {
+	Id          types.TokenId `json:"id"`
+	Minter      types.Address `json:"minter"`
+	Name        string        `json:"name"`
+	Symbol      string        `json:"symbol"`
+	Decimals    uint8         `json:"decimals"`
+	TotalSupply types.Value   `json:"totalSupply"`
+}
+
 // @component OutTransaction outTransaction object "Outbound transaction produced by eth_call and result of its execution."
 // @componentprop Transaction transaction object true "Transaction data"
 // @componentprop Data data string false "Result of VM execution."
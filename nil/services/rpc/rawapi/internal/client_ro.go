@@ -80,6 +80,13 @@ func (api *shardApiClientRo) GetTokens(
 		ctx, api, "GetTokens", address, blockReference)
 }
 
+func (api *shardApiClientRo) GetTokenHolders(
+	ctx context.Context, tokenId types.TokenId, offset uint64, limit uint64,
+) (*rawapitypes.TokenHolders, error) {
+	return sendRequestAndGetResponseWithCallerMethodName[*rawapitypes.TokenHolders](
+		ctx, api, "GetTokenHolders", tokenId, offset, limit)
+}
+
 func (api *shardApiClientRo) GetStorageAt(
 	ctx context.Context, address types.Address, key common.Hash, blockReference rawapitypes.BlockReference,
 ) (types.Uint256, error) {
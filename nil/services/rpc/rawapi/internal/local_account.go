@@ -117,6 +117,27 @@ func (api *localShardApiRo) GetTokens(
 		}), nil
 }
 
+// GetTokenHolders returns the holders of the token in the shard according to the token holders index.
+// The index reflects the latest state only.
+func (api *localShardApiRo) GetTokenHolders(
+	ctx context.Context,
+	tokenId types.TokenId,
+	offset uint64,
+	limit uint64,
+) (*rawapitypes.TokenHolders, error) {
+	tx, err := api.db.CreateRoTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open tx to read token holders: %w", err)
+	}
+	defer tx.Rollback()
+
+	holders, total, err := db.ReadTokenHolders(tx, api.shardId(), tokenId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &rawapitypes.TokenHolders{Holders: holders, Total: total}, nil
+}
+
 func (api *localShardApiRo) contractToRawEnriched(
 	tx db.RoTx,
 	contract *types.SmartContract,
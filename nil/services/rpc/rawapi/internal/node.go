@@ -149,6 +149,25 @@ func (api *nodeApiOverShardApis) GetTokens(
 	return result, nil
 }
 
+func (api *nodeApiOverShardApis) GetTokenHolders(
+	ctx context.Context,
+	shardId types.ShardId,
+	tokenId types.TokenId,
+	offset uint64,
+	limit uint64,
+) (*rawapitypes.TokenHolders, error) {
+	methodName := methodNameChecked("GetTokenHolders")
+	shardApi, ok := api.apisRo[shardId]
+	if !ok {
+		return nil, makeShardNotFoundError(methodName, shardId)
+	}
+	result, err := shardApi.GetTokenHolders(ctx, tokenId, offset, limit)
+	if err != nil {
+		return nil, makeCallError(methodName, shardId, err)
+	}
+	return result, nil
+}
+
 func (api *nodeApiOverShardApis) GetStorageAt(
 	ctx context.Context,
 	address types.Address,
@@ -43,6 +43,13 @@ type NodeApi interface {
 		address types.Address,
 		blockReference rawapitypes.BlockReference,
 	) (map[types.TokenId]types.Value, error)
+	GetTokenHolders(
+		ctx context.Context,
+		shardId types.ShardId,
+		tokenId types.TokenId,
+		offset uint64,
+		limit uint64,
+	) (*rawapitypes.TokenHolders, error)
 	GetTransactionCount(
 		ctx context.Context, address types.Address, blockReference rawapitypes.BlockReference) (uint64, error)
 	GetStorageAt(
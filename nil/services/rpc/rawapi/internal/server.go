@@ -29,6 +29,7 @@ type NetworkTransportProtocolRo interface {
 	GetBalance(request pb.AccountRequest) pb.BalanceResponse
 	GetCode(request pb.AccountRequest) pb.CodeResponse
 	GetTokens(request pb.AccountRequest) pb.TokensResponse
+	GetTokenHolders(request pb.TokenHoldersRequest) pb.TokenHoldersResponse
 	GetStorageAt(request pb.StorageAtRequest) pb.StorageAtResponse
 	GetContract(request pb.FullAccountRequest) pb.RawContractResponse
 
@@ -41,6 +41,12 @@ type shardApiRo interface {
 		address types.Address,
 		blockReference rawapitypes.BlockReference,
 	) (map[types.TokenId]types.Value, error)
+	GetTokenHolders(
+		ctx context.Context,
+		tokenId types.TokenId,
+		offset uint64,
+		limit uint64,
+	) (*rawapitypes.TokenHolders, error)
 	GetStorageAt(
 		ctx context.Context,
 		address types.Address,
@@ -522,6 +522,57 @@ func (cr *TokensResponse) UnpackProtoMessage() (map[types.TokenId]types.Value, e
 	return nil, errors.New("unexpected response type")
 }
 
+// Token holders converters
+func (r *TokenHoldersRequest) PackProtoMessage(tokenId types.TokenId, offset uint64, limit uint64) error {
+	r.TokenId = new(Address).PackProtoMessage(types.Address(tokenId))
+	r.Offset = offset
+	r.Limit = limit
+	return nil
+}
+
+func (r *TokenHoldersRequest) UnpackProtoMessage() (types.TokenId, uint64, uint64, error) {
+	return types.TokenId(r.GetTokenId().UnpackProtoMessage()), r.GetOffset(), r.GetLimit(), nil
+}
+
+func (r *TokenHoldersResponse) PackProtoMessage(holders *rawapitypes.TokenHolders, err error) error {
+	if err != nil {
+		r.Result = &TokenHoldersResponse_Error{Error: new(Error).PackProtoMessage(err)}
+		return nil
+	}
+
+	data := &TokenHolders{
+		Holders: make([]*TokenHolder, len(holders.Holders)),
+		Total:   holders.Total,
+	}
+	for i, holder := range holders.Holders {
+		data.Holders[i] = &TokenHolder{
+			Address: new(Address).PackProtoMessage(holder.Address),
+			Balance: new(Uint256).PackProtoMessage(*holder.Balance.Uint256),
+		}
+	}
+	r.Result = &TokenHoldersResponse_Data{Data: data}
+	return nil
+}
+
+func (r *TokenHoldersResponse) UnpackProtoMessage() (*rawapitypes.TokenHolders, error) {
+	switch r.GetResult().(type) {
+	case *TokenHoldersResponse_Error:
+		return nil, r.GetError().UnpackProtoMessage()
+
+	case *TokenHoldersResponse_Data:
+		data := r.GetData()
+		holders := make([]types.TokenHolder, len(data.GetHolders()))
+		for i, holder := range data.GetHolders() {
+			holders[i] = types.TokenHolder{
+				Address: holder.GetAddress().UnpackProtoMessage(),
+				Balance: newValueFromUint256(holder.GetBalance()),
+			}
+		}
+		return &rawapitypes.TokenHolders{Holders: holders, Total: data.GetTotal()}, nil
+	}
+	return nil, errors.New("unexpected response type")
+}
+
 // StorageAt converters
 func (ar *StorageAtRequest) PackProtoMessage(
 	address types.Address,
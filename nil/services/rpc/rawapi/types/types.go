@@ -164,3 +164,10 @@ type SmartContractRange struct {
 	Contracts []*SmartContract
 	Next      *common.Hash
 }
+
+// TokenHolders is a page of the token holders in a shard.
+type TokenHolders struct {
+	Holders []types.TokenHolder
+	// Total is the number of the token holders in the shard.
+	Total uint64
+}
@@ -30,6 +30,33 @@ func NewCommitment(sidecar *ethtypes.BlobTxSidecar, dataProofs types.DataProofs)
 	}
 }
 
+// VerifyLocally performs the check of the L1 point evaluation precompile without calling L1.
+func (c *Commitment) VerifyLocally() error {
+	sidecar := c.Sidecar
+	if sidecar == nil || len(sidecar.Blobs) == 0 {
+		return errors.New("commitment has no blobs")
+	}
+	if len(c.DataProofs) != len(sidecar.Commitments) {
+		return fmt.Errorf(
+			"data proofs count %d doesn't match blob count %d", len(c.DataProofs), len(sidecar.Commitments),
+		)
+	}
+
+	for i, dataProof := range c.DataProofs {
+		point, claim, blobCommitment, proof := dataProof.Unpack()
+
+		// The precompile compares the commitment with the versioned hash of the blob
+		if blobCommitment != sidecar.Commitments[i] {
+			return fmt.Errorf("data proof %d refers to a commitment of another blob", i)
+		}
+
+		if err := kzg4844.VerifyProof(blobCommitment, point, claim, proof); err != nil {
+			return fmt.Errorf("data proof %d verification failed: %w", i, err)
+		}
+	}
+	return nil
+}
+
 type CommitPreparerConfig struct {
 	MaxBlobsInTx uint
 }
@@ -9,47 +9,61 @@ import (
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
 	v1 "github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode/v1"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/jonboulle/clockwork"
 )
 
-type RollupContract interface {
+// DataAvailabilityBackend makes the data of committed batches available for verifiers.
+type DataAvailabilityBackend interface {
+	// Name returns the name of the backend, used for logging
+	Name() string
+
+	// VerifyDataProofs checks that the data proofs of the commitment are valid against its blobs
 	VerifyDataProofs(ctx context.Context, commitment *Commitment) error
-	CommitBatch(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error
+
+	// Publish stores the batch data and records its commitment
+	Publish(ctx context.Context, batchId types.BatchId, commitment *Commitment) error
 }
 
 type CommitterMetrics interface {
 	RecordBatchCommitted(ctx context.Context, batch *types.BlockBatch, commitment *Commitment)
 }
 
+// CommitObserver is notified about every batch whose data was successfully published via DA backend
+type CommitObserver interface {
+	OnBatchCommitted(batch *types.BlockBatch, commitment *Commitment)
+}
+
 type committer struct {
-	preparer       *commitPreparer
-	rollupContract RollupContract
-	clock          clockwork.Clock
-	metrics        CommitterMetrics
-	logger         logging.Logger
+	preparer  *commitPreparer
+	daBackend DataAvailabilityBackend
+	clock     clockwork.Clock
+	metrics   CommitterMetrics
+	observers []CommitObserver
+	logger    logging.Logger
 }
 
 func NewCommitter(
-	rollupContract RollupContract,
+	daBackend DataAvailabilityBackend,
 	clock clockwork.Clock,
 	config CommitPreparerConfig,
 	metrics CommitterMetrics,
 	logger logging.Logger,
+	observers ...CommitObserver,
 ) *committer {
 	encoder := v1.NewEncoder(logger)
 	builder := blob.NewBuilder()
 	return &committer{
-		rollupContract: rollupContract,
+		daBackend: daBackend,
 		preparer: NewCommitPreparer(
 			encoder,
 			builder,
 			config,
 			logger,
 		),
-		clock:   clock,
-		metrics: metrics,
-		logger:  logger,
+		clock:     clock,
+		metrics:   metrics,
+		observers: observers,
+		logger:    logger,
 	}
 }
 
@@ -74,15 +88,18 @@ func (c *committer) Commit(ctx context.Context, batch *types.BlockBatch) (commit
 		return nil, fmt.Errorf("failed to seal batch: %w", err)
 	}
 
-	if err := c.rollupContract.VerifyDataProofs(ctx, commitment); err != nil {
+	if err := c.daBackend.VerifyDataProofs(ctx, commitment); err != nil {
 		return nil, fmt.Errorf("data proofs verification failed: %w", err)
 	}
 
-	if err := c.rollupContract.CommitBatch(ctx, sealedBatch.Id, commitment.Sidecar); err != nil {
-		return nil, fmt.Errorf("failed to commit batch: %w", err)
+	if err := c.daBackend.Publish(ctx, sealedBatch.Id, commitment); err != nil {
+		return nil, fmt.Errorf("failed to publish batch via %s DA backend: %w", c.daBackend.Name(), err)
 	}
 
 	c.metrics.RecordBatchCommitted(ctx, sealedBatch, commitment)
+	for _, observer := range c.observers {
+		observer.OnBatchCommitted(sealedBatch, commitment)
+	}
 
 	return sealedBatch, nil
 }
@@ -0,0 +1,38 @@
+package da
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+type BlobRollupContract interface {
+	VerifyDataProofs(ctx context.Context, commitment *batches.Commitment) error
+	CommitBatch(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error
+}
+
+// blobBackend ships the blob sidecar of the commitment within the CommitBatch transaction,
+// data proofs are checked by the rollup contract via the point evaluation precompile.
+type blobBackend struct {
+	contract BlobRollupContract
+}
+
+var _ batches.DataAvailabilityBackend = (*blobBackend)(nil)
+
+func NewBlobBackend(contract BlobRollupContract) *blobBackend {
+	return &blobBackend{contract: contract}
+}
+
+func (*blobBackend) Name() string {
+	return string(BackendBlob)
+}
+
+func (b *blobBackend) VerifyDataProofs(ctx context.Context, commitment *batches.Commitment) error {
+	return b.contract.VerifyDataProofs(ctx, commitment)
+}
+
+func (b *blobBackend) Publish(ctx context.Context, batchId types.BatchId, commitment *batches.Commitment) error {
+	return b.contract.CommitBatch(ctx, batchId, commitment.Sidecar)
+}
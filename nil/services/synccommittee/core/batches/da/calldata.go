@@ -0,0 +1,182 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/google/uuid"
+)
+
+// CalldataRollupContract posts the batch data to the rollup contract and commits the batch bound to it.
+type CalldataRollupContract interface {
+	PostBatchData(ctx context.Context, batchId types.BatchId, part int, data []byte) error
+	CommitBatchWithCalldata(
+		ctx context.Context, batchId types.BatchId, versionedHashes []ethcommon.Hash, partCount int,
+	) error
+}
+
+const (
+	calldataVersion = 1
+
+	// calldataHeaderSize is the size of the header prepended to each part of the batch data:
+	// version (1 byte) | batch id (16 bytes) | blob count (1 byte) | part index (2 bytes) | part count (2 bytes)
+	calldataHeaderSize = 1 + len(uuid.UUID{}) + 1 + 2 + 2
+
+	blobSize = len(kzg4844.Blob{})
+)
+
+// calldataBackend posts the blobs of the commitment to the rollup contract as calldata split into parts
+// fitting into L1 transactions. Readers restore the blobs with DecodeCalldata and get the same versioned hashes
+// as the blob backend would. Once all the parts are posted, the batch is committed on the rollup contract,
+// which binds it to the hashes of the posted parts.
+type calldataBackend struct {
+	contract  CalldataRollupContract
+	chunkSize int
+	logger    logging.Logger
+}
+
+var _ batches.DataAvailabilityBackend = (*calldataBackend)(nil)
+
+func NewCalldataBackend(
+	contract CalldataRollupContract,
+	chunkSize int,
+	logger logging.Logger,
+) *calldataBackend {
+	return &calldataBackend{
+		contract:  contract,
+		chunkSize: chunkSize,
+		logger:    logger,
+	}
+}
+
+func (*calldataBackend) Name() string {
+	return string(BackendCalldata)
+}
+
+func (*calldataBackend) VerifyDataProofs(_ context.Context, commitment *batches.Commitment) error {
+	return commitment.VerifyLocally()
+}
+
+func (b *calldataBackend) Publish(ctx context.Context, batchId types.BatchId, commitment *batches.Commitment) error {
+	parts, err := EncodeCalldata(batchId, commitment.Sidecar.Blobs, b.chunkSize)
+	if err != nil {
+		return err
+	}
+
+	for i, part := range parts {
+		if err := b.contract.PostBatchData(ctx, batchId, i, part); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info().
+		Stringer(logging.FieldBatchId, batchId).
+		Int("partCount", len(parts)).
+		Int("blobCount", len(commitment.Sidecar.Blobs)).
+		Msg("Batch data is posted as calldata")
+
+	return b.contract.CommitBatchWithCalldata(ctx, batchId, commitment.Sidecar.BlobHashes(), len(parts))
+}
+
+// EncodeCalldata splits the blobs into parts of at most chunkSize bytes each, including the header.
+// Trailing zeroes of the last blob are not sent.
+func EncodeCalldata(batchId types.BatchId, blobs []kzg4844.Blob, chunkSize int) ([][]byte, error) {
+	if len(blobs) == 0 || len(blobs) > math.MaxUint8 {
+		return nil, fmt.Errorf("unexpected blob count: %d", len(blobs))
+	}
+	payloadSize := chunkSize - calldataHeaderSize
+	if payloadSize <= 0 {
+		return nil, fmt.Errorf("chunk size %d is too small", chunkSize)
+	}
+
+	data := make([]byte, 0, len(blobs)*blobSize)
+	for _, blob := range blobs {
+		data = append(data, blob[:]...)
+	}
+	data = bytes.TrimRight(data, "\x00")
+
+	partCount := max((len(data)+payloadSize-1)/payloadSize, 1)
+	if partCount > math.MaxUint16 {
+		return nil, fmt.Errorf("batch data is too large: %d parts", partCount)
+	}
+
+	parts := make([][]byte, 0, partCount)
+	for i := range partCount {
+		payload := data[min(i*payloadSize, len(data)):min((i+1)*payloadSize, len(data))]
+
+		part := make([]byte, calldataHeaderSize, calldataHeaderSize+len(payload))
+		part[0] = calldataVersion
+		copy(part[1:], batchId[:])
+		offset := 1 + len(batchId)
+		part[offset] = byte(len(blobs))
+		binary.BigEndian.PutUint16(part[offset+1:], uint16(i))
+		binary.BigEndian.PutUint16(part[offset+3:], uint16(partCount))
+
+		parts = append(parts, append(part, payload...))
+	}
+	return parts, nil
+}
+
+// DecodeCalldata restores the blobs of a batch from all of its calldata parts given in any order.
+func DecodeCalldata(parts [][]byte) (types.BatchId, []kzg4844.Blob, error) {
+	if len(parts) == 0 {
+		return types.BatchId{}, nil, errors.New("no calldata parts")
+	}
+
+	var batchId types.BatchId
+	var blobCount, partCount int
+	payloads := make([][]byte, len(parts))
+
+	for _, part := range parts {
+		if len(part) < calldataHeaderSize {
+			return types.BatchId{}, nil, fmt.Errorf("calldata part is too short: %d bytes", len(part))
+		}
+		if part[0] != calldataVersion {
+			return types.BatchId{}, nil, fmt.Errorf("unsupported calldata version: %d", part[0])
+		}
+
+		var partBatchId types.BatchId
+		copy(partBatchId[:], part[1:])
+		offset := 1 + len(partBatchId)
+		partBlobCount := int(part[offset])
+		partIndex := int(binary.BigEndian.Uint16(part[offset+1:]))
+		partTotal := int(binary.BigEndian.Uint16(part[offset+3:]))
+
+		if partCount == 0 {
+			batchId, blobCount, partCount = partBatchId, partBlobCount, partTotal
+		}
+		if partBatchId != batchId || partBlobCount != blobCount || partTotal != partCount {
+			return types.BatchId{}, nil, errors.New("calldata parts belong to different batches")
+		}
+		if partCount != len(parts) {
+			return types.BatchId{}, nil, fmt.Errorf("expected %d calldata parts, got %d", partCount, len(parts))
+		}
+		if partIndex >= partCount {
+			return types.BatchId{}, nil, fmt.Errorf("calldata part index %d is out of range", partIndex)
+		}
+		if payloads[partIndex] != nil {
+			return types.BatchId{}, nil, fmt.Errorf("duplicate calldata part %d", partIndex)
+		}
+		payloads[partIndex] = part[calldataHeaderSize:]
+	}
+
+	data := bytes.Join(payloads, nil)
+	if len(data) > blobCount*blobSize {
+		return types.BatchId{}, nil, fmt.Errorf("batch data doesn't fit into %d blobs", blobCount)
+	}
+
+	blobs := make([]kzg4844.Blob, blobCount)
+	for i := range blobs {
+		copy(blobs[i][:], data[min(i*blobSize, len(data)):])
+	}
+	return batchId, blobs, nil
+}
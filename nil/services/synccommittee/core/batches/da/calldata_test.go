@@ -0,0 +1,90 @@
+package da
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalldataTestSuite struct {
+	suite.Suite
+}
+
+func TestCalldata(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CalldataTestSuite))
+}
+
+func (s *CalldataTestSuite) newBlobs(count int, lastBlobLen int) []kzg4844.Blob {
+	s.T().Helper()
+	blobs := make([]kzg4844.Blob, count)
+	for i := range blobs {
+		length := blobSize
+		if i == count-1 {
+			length = lastBlobLen
+		}
+		copy(blobs[i][:], bytes.Repeat([]byte{byte(i + 1)}, length))
+	}
+	return blobs
+}
+
+func (s *CalldataTestSuite) Test_Encode_Decode_Round_Trip() {
+	testCases := []struct {
+		name        string
+		blobs       []kzg4844.Blob
+		chunkSize   int
+		expectParts int
+	}{
+		{"SingleSmallBlob", s.newBlobs(1, 100), DefaultCalldataChunkSize, 1},
+		{"EmptyBlob", s.newBlobs(1, 0), DefaultCalldataChunkSize, 1},
+		{"SeveralBlobs", s.newBlobs(3, 1000), DefaultCalldataChunkSize, 3},
+		{"TrailingZeroBlob", append(s.newBlobs(2, blobSize), kzg4844.Blob{}), DefaultCalldataChunkSize, 3},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.name, func() {
+			batchId := types.NewBatchId()
+			parts, err := EncodeCalldata(batchId, testCase.blobs, testCase.chunkSize)
+			s.Require().NoError(err)
+			s.Require().Len(parts, testCase.expectParts)
+			for _, part := range parts {
+				s.Require().LessOrEqual(len(part), testCase.chunkSize)
+			}
+
+			// Parts might be read from L1 in any order
+			slices.Reverse(parts)
+
+			decodedId, decodedBlobs, err := DecodeCalldata(parts)
+			s.Require().NoError(err)
+			s.Require().Equal(batchId, decodedId)
+			s.Require().Equal(testCase.blobs, decodedBlobs)
+		})
+	}
+}
+
+func (s *CalldataTestSuite) Test_Decode_Rejects_Inconsistent_Parts() {
+	blobs := s.newBlobs(3, 100)
+	parts, err := EncodeCalldata(types.NewBatchId(), blobs, DefaultCalldataChunkSize)
+	s.Require().NoError(err)
+	s.Require().Len(parts, 3)
+
+	_, _, err = DecodeCalldata(parts[:2])
+	s.Require().ErrorContains(err, "expected 3 calldata parts")
+
+	otherParts, err := EncodeCalldata(types.NewBatchId(), blobs, DefaultCalldataChunkSize)
+	s.Require().NoError(err)
+	_, _, err = DecodeCalldata([][]byte{parts[0], parts[1], otherParts[2]})
+	s.Require().ErrorContains(err, "different batches")
+
+	_, _, err = DecodeCalldata([][]byte{parts[0], parts[1], parts[1]})
+	s.Require().ErrorContains(err, "duplicate calldata part")
+}
+
+func (s *CalldataTestSuite) Test_Encode_Rejects_Small_Chunks() {
+	_, err := EncodeCalldata(types.NewBatchId(), s.newBlobs(1, 10), calldataHeaderSize)
+	s.Require().Error(err)
+}
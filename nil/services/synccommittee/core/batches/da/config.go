@@ -0,0 +1,140 @@
+package da
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+)
+
+type BackendType string
+
+const (
+	// BackendBlob sends batches to L1 as EIP-4844 blobs committed by the rollup contract
+	BackendBlob BackendType = "blob"
+
+	// BackendCalldata posts batches as calldata to the rollup contract, for L1 chains without blob support.
+	// The rollup contract has to be switched to the calldata DA mode by its owner.
+	BackendCalldata BackendType = "calldata"
+
+	// BackendLocal keeps batches in a local directory or a DA server, intended for devnets.
+	// The rollup contract has to be switched to the external DA mode by its owner.
+	BackendLocal BackendType = "local"
+)
+
+var BackendTypes = map[string]BackendType{
+	string(BackendBlob):     BackendBlob,
+	string(BackendCalldata): BackendCalldata,
+	string(BackendLocal):    BackendLocal,
+}
+
+func (t *BackendType) String() string {
+	return string(*t)
+}
+
+func (t *BackendType) Set(str string) error {
+	value, ok := BackendTypes[str]
+	if !ok {
+		return fmt.Errorf("unknown DA backend: %s", str)
+	}
+	*t = value
+	return nil
+}
+
+func (*BackendType) Type() string {
+	return "BackendType"
+}
+
+func (*BackendType) PossibleValues() []string {
+	return slices.Sorted(maps.Keys(BackendTypes))
+}
+
+const DefaultCalldataChunkSize = 96 * 1024
+
+type Config struct {
+	Backend BackendType `yaml:"daBackend,omitempty"`
+
+	// CalldataChunkSize limits the size of batch data sent in a single transaction,
+	// L1 nodes reject transactions larger than 128 KiB
+	CalldataChunkSize int `yaml:"daCalldataChunkSize,omitempty"`
+
+	// LocalDir is the directory used by the local backend and the DA server
+	LocalDir string `yaml:"daLocalDir,omitempty"`
+
+	// LocalEndpoint is the URL of the DA server, if set, the local backend uploads batches to it
+	// instead of writing them to LocalDir
+	LocalEndpoint string `yaml:"daLocalEndpoint,omitempty"`
+
+	// ServerListenAddr makes the sync committee serve LocalDir over HTTP, e.g. "127.0.0.1:8531"
+	ServerListenAddr string `yaml:"daServerListenAddr,omitempty"`
+
+	// ServerToken authorizes uploads to the DA server: the server requires it and the local backend sends it
+	// to LocalEndpoint. The server listens only on the loopback interface if it is not set.
+	ServerToken string `yaml:"daServerToken,omitempty"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Backend:           BackendBlob,
+		CalldataChunkSize: DefaultCalldataChunkSize,
+	}
+}
+
+func (c *Config) Validate() error {
+	switch c.Backend {
+	case BackendBlob:
+	case BackendCalldata:
+		if c.CalldataChunkSize <= calldataHeaderSize {
+			return fmt.Errorf("calldata chunk size is too small: %d", c.CalldataChunkSize)
+		}
+	case BackendLocal:
+		if c.LocalDir == "" && c.LocalEndpoint == "" {
+			return errors.New("either local DA directory or DA server endpoint must be set")
+		}
+	default:
+		return fmt.Errorf("unknown DA backend: %s", c.Backend)
+	}
+
+	if c.ServerListenAddr != "" {
+		if c.LocalDir == "" {
+			return errors.New("local DA directory must be set to run DA server")
+		}
+		if err := checkServerListenAddr(c.ServerListenAddr, c.ServerToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollupContract is the subset of the rollup contract wrapper used by L1 backends.
+type RollupContract interface {
+	BlobRollupContract
+	CalldataRollupContract
+	VersionedHashesRollupContract
+}
+
+// NewBackend creates the backend selected by the config.
+func NewBackend(
+	config Config,
+	contract RollupContract,
+	logger logging.Logger,
+) (batches.DataAvailabilityBackend, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid DA config: %w", err)
+	}
+
+	switch config.Backend {
+	case BackendCalldata:
+		return NewCalldataBackend(contract, config.CalldataChunkSize, logger), nil
+	case BackendLocal:
+		if config.LocalEndpoint != "" {
+			return NewLocalBackend(NewHttpStore(config.LocalEndpoint, config.ServerToken), contract, logger), nil
+		}
+		return NewLocalBackend(NewFsStore(config.LocalDir), contract, logger), nil
+	default:
+		return NewBlobBackend(contract), nil
+	}
+}
@@ -0,0 +1,265 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+var (
+	ErrBatchNotFound      = errors.New("batch data not found")
+	ErrBatchAlreadyExists = errors.New("different batch data is already stored")
+)
+
+// Store keeps blob sidecars of the batches published by the local backend.
+// Stored batches are never overwritten: putting the same data again succeeds,
+// putting different data for the same batch fails with ErrBatchAlreadyExists.
+type Store interface {
+	Put(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error
+	Get(ctx context.Context, batchId types.BatchId) (*ethtypes.BlobTxSidecar, error)
+}
+
+// storedBatch is the JSON representation of the batch data used both on disk and by the DA server.
+type storedBatch struct {
+	BatchId     types.BatchId        `json:"batchId"`
+	Blobs       []kzg4844.Blob       `json:"blobs"`
+	Commitments []kzg4844.Commitment `json:"commitments"`
+	Proofs      []kzg4844.Proof      `json:"proofs"`
+}
+
+func newStoredBatch(batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) *storedBatch {
+	return &storedBatch{
+		BatchId:     batchId,
+		Blobs:       sidecar.Blobs,
+		Commitments: sidecar.Commitments,
+		Proofs:      sidecar.Proofs,
+	}
+}
+
+func (b *storedBatch) sidecar() *ethtypes.BlobTxSidecar {
+	return &ethtypes.BlobTxSidecar{
+		Blobs:       b.Blobs,
+		Commitments: b.Commitments,
+		Proofs:      b.Proofs,
+	}
+}
+
+// VersionedHashesRollupContract commits batches whose data is published off-chain.
+type VersionedHashesRollupContract interface {
+	CommitBatchWithVersionedHashes(ctx context.Context, batchId types.BatchId, versionedHashes []ethcommon.Hash) error
+}
+
+// localBackend publishes batches to a Store, so that the rollup pipeline could run without an L1 supporting blobs.
+// Data proofs are verified locally, the batch is committed on the rollup contract with the versioned hashes
+// of the stored blobs.
+type localBackend struct {
+	store    Store
+	contract VersionedHashesRollupContract
+	logger   logging.Logger
+}
+
+var _ batches.DataAvailabilityBackend = (*localBackend)(nil)
+
+func NewLocalBackend(store Store, contract VersionedHashesRollupContract, logger logging.Logger) *localBackend {
+	return &localBackend{
+		store:    store,
+		contract: contract,
+		logger:   logger,
+	}
+}
+
+func (*localBackend) Name() string {
+	return string(BackendLocal)
+}
+
+func (*localBackend) VerifyDataProofs(_ context.Context, commitment *batches.Commitment) error {
+	return commitment.VerifyLocally()
+}
+
+func (b *localBackend) Publish(ctx context.Context, batchId types.BatchId, commitment *batches.Commitment) error {
+	if err := b.store.Put(ctx, batchId, commitment.Sidecar); err != nil {
+		return err
+	}
+
+	b.logger.Info().
+		Stringer(logging.FieldBatchId, batchId).
+		Int("blobCount", len(commitment.Sidecar.Blobs)).
+		Msg("Batch data is stored by local DA")
+
+	return b.contract.CommitBatchWithVersionedHashes(ctx, batchId, commitment.Sidecar.BlobHashes())
+}
+
+type fsStore struct {
+	dir string
+}
+
+var _ Store = (*fsStore)(nil)
+
+func NewFsStore(dir string) *fsStore {
+	return &fsStore{dir: dir}
+}
+
+func (s *fsStore) Put(_ context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create DA directory: %w", err)
+	}
+
+	data, err := json.Marshal(newStoredBatch(batchId, sidecar))
+	if err != nil {
+		return fmt.Errorf("failed to encode batch data, batchId=%s: %w", batchId, err)
+	}
+
+	existing, err := os.ReadFile(s.path(batchId))
+	switch {
+	case err == nil:
+		if bytes.Equal(existing, data) {
+			return nil
+		}
+		return fmt.Errorf("%w: batchId=%s", ErrBatchAlreadyExists, batchId)
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	// Write to a temporary file first, so that readers never observe partially written batches
+	tmpFile, err := os.CreateTemp(s.dir, batchId.String()+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write batch data, batchId=%s: %w", batchId, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	// Link fails if the batch has been stored concurrently, unlike rename it never replaces the target
+	if err := os.Link(tmpFile.Name(), s.path(batchId)); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: batchId=%s", ErrBatchAlreadyExists, batchId)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *fsStore) Get(_ context.Context, batchId types.BatchId) (*ethtypes.BlobTxSidecar, error) {
+	data, err := os.ReadFile(s.path(batchId))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: batchId=%s", ErrBatchNotFound, batchId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedBatch
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode batch data, batchId=%s: %w", batchId, err)
+	}
+	return stored.sidecar(), nil
+}
+
+func (s *fsStore) path(batchId types.BatchId) string {
+	return filepath.Join(s.dir, batchId.String()+".json")
+}
+
+// httpStore uploads batches to the DA server and downloads them from it.
+// The token, if set, is sent with uploads to authorize them.
+type httpStore struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+var _ Store = (*httpStore)(nil)
+
+func NewHttpStore(endpoint string, token string) *httpStore {
+	return &httpStore{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		token:    token,
+		client:   &http.Client{Timeout: defaultHttpTimeout},
+	}
+}
+
+func (s *httpStore) Put(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error {
+	data, err := json.Marshal(newStoredBatch(batchId, sidecar))
+	if err != nil {
+		return fmt.Errorf("failed to encode batch data, batchId=%s: %w", batchId, err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(batchId), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		request.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to upload batch data, batchId=%s: %w", batchId, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		return fmt.Errorf("%w: batchId=%s", ErrBatchAlreadyExists, batchId)
+	default:
+		return fmt.Errorf("DA server responded with %s: %s", response.Status, readErrorBody(response.Body))
+	}
+	return nil
+}
+
+func (s *httpStore) Get(ctx context.Context, batchId types.BatchId) (*ethtypes.BlobTxSidecar, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url(batchId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download batch data, batchId=%s: %w", batchId, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: batchId=%s", ErrBatchNotFound, batchId)
+	default:
+		return nil, fmt.Errorf("DA server responded with %s: %s", response.Status, readErrorBody(response.Body))
+	}
+
+	var stored storedBatch
+	if err := json.NewDecoder(response.Body).Decode(&stored); err != nil {
+		return nil, fmt.Errorf("failed to decode batch data, batchId=%s: %w", batchId, err)
+	}
+	return stored.sidecar(), nil
+}
+
+func (s *httpStore) url(batchId types.BatchId) string {
+	return s.endpoint + "/batches/" + batchId.String()
+}
+
+func readErrorBody(body io.Reader) string {
+	const maxErrorLen = 1024
+	data, _ := io.ReadAll(io.LimitReader(body, maxErrorLen))
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,165 @@
+package da
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
+	v1 "github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode/v1"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/suite"
+)
+
+// committingContract records the versioned hashes the batches are committed with.
+type committingContract struct {
+	committed map[types.BatchId][]ethcommon.Hash
+}
+
+func (c *committingContract) CommitBatchWithVersionedHashes(
+	_ context.Context, batchId types.BatchId, versionedHashes []ethcommon.Hash,
+) error {
+	c.committed[batchId] = versionedHashes
+	return nil
+}
+
+type LocalBackendTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	logger     logging.Logger
+	commitment *batches.Commitment
+}
+
+func TestLocalBackend(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LocalBackendTestSuite))
+}
+
+func (s *LocalBackendTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.logger = logging.NewLogger("local_da_test")
+
+	preparer := batches.NewCommitPreparer(
+		v1.NewEncoder(s.logger), blob.NewBuilder(), batches.DefaultCommitConfig(), s.logger,
+	)
+	commitment, err := preparer.PrepareBatchCommitment(testaide.NewBlockBatch(testaide.ShardsCount))
+	s.Require().NoError(err)
+	s.commitment = commitment
+}
+
+func (s *LocalBackendTestSuite) Test_Fs_Store() {
+	s.testBackend(NewFsStore(s.T().TempDir()))
+}
+
+func (s *LocalBackendTestSuite) Test_Http_Store() {
+	const token = "secret"
+	server := NewServer("", token, NewFsStore(s.T().TempDir()), s.logger)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	s.testBackend(NewHttpStore(httpServer.URL, token))
+}
+
+func (s *LocalBackendTestSuite) Test_Http_Store_Requires_Token() {
+	server := NewServer("", "secret", NewFsStore(s.T().TempDir()), s.logger)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	for _, token := range []string{"", "wrong"} {
+		err := NewHttpStore(httpServer.URL, token).Put(s.ctx, types.NewBatchId(), s.commitment.Sidecar)
+		s.Require().ErrorContains(err, "401 Unauthorized")
+	}
+}
+
+func (s *LocalBackendTestSuite) Test_Server_Rejects_Invalid_Blob_Proofs() {
+	server := NewServer("", "", NewFsStore(s.T().TempDir()), s.logger)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	corrupted := *s.commitment.Sidecar
+	corrupted.Blobs = append([]kzg4844.Blob{}, s.commitment.Sidecar.Blobs...)
+	corrupted.Blobs[0][100] ^= 0x01
+
+	store := NewHttpStore(httpServer.URL, "")
+	batchId := types.NewBatchId()
+	s.Require().ErrorContains(store.Put(s.ctx, batchId, &corrupted), "invalid proof of blob 0")
+
+	_, err := store.Get(s.ctx, batchId)
+	s.Require().ErrorIs(err, ErrBatchNotFound)
+}
+
+func (s *LocalBackendTestSuite) Test_Stores_Refuse_Overwrite() {
+	server := NewServer("", "", NewFsStore(s.T().TempDir()), s.logger)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	other, err := batches.NewCommitPreparer(
+		v1.NewEncoder(s.logger), blob.NewBuilder(), batches.DefaultCommitConfig(), s.logger,
+	).PrepareBatchCommitment(testaide.NewBlockBatch(testaide.ShardsCount))
+	s.Require().NoError(err)
+
+	for name, store := range map[string]Store{
+		"Fs":   NewFsStore(s.T().TempDir()),
+		"Http": NewHttpStore(httpServer.URL, ""),
+	} {
+		s.Run(name, func() {
+			batchId := types.NewBatchId()
+			s.Require().NoError(store.Put(s.ctx, batchId, s.commitment.Sidecar))
+			// Putting the same data again is a no-op, so that publishing could be retried
+			s.Require().NoError(store.Put(s.ctx, batchId, s.commitment.Sidecar))
+			s.Require().ErrorIs(store.Put(s.ctx, batchId, other.Sidecar), ErrBatchAlreadyExists)
+
+			sidecar, err := store.Get(s.ctx, batchId)
+			s.Require().NoError(err)
+			s.Require().Equal(s.commitment.Sidecar, sidecar)
+		})
+	}
+}
+
+func (s *LocalBackendTestSuite) Test_Server_Listen_Addr_Requires_Token() {
+	s.Require().NoError(checkServerListenAddr("127.0.0.1:8531", ""))
+	s.Require().NoError(checkServerListenAddr("localhost:8531", ""))
+	s.Require().NoError(checkServerListenAddr("[::1]:8531", ""))
+	s.Require().Error(checkServerListenAddr(":8531", ""))
+	s.Require().Error(checkServerListenAddr("0.0.0.0:8531", ""))
+	s.Require().NoError(checkServerListenAddr("0.0.0.0:8531", "secret"))
+}
+
+func (s *LocalBackendTestSuite) testBackend(store Store) {
+	s.T().Helper()
+	contract := &committingContract{committed: make(map[types.BatchId][]ethcommon.Hash)}
+	backend := NewLocalBackend(store, contract, s.logger)
+	batchId := types.NewBatchId()
+
+	_, err := store.Get(s.ctx, batchId)
+	s.Require().ErrorIs(err, ErrBatchNotFound)
+
+	s.Require().NoError(backend.VerifyDataProofs(s.ctx, s.commitment))
+	s.Require().NoError(backend.Publish(s.ctx, batchId, s.commitment))
+
+	sidecar, err := store.Get(s.ctx, batchId)
+	s.Require().NoError(err)
+	s.Require().Equal(s.commitment.Sidecar, sidecar)
+	s.Require().Equal(s.commitment.Sidecar.BlobHashes(), sidecar.BlobHashes())
+	s.Require().Equal(s.commitment.Sidecar.BlobHashes(), contract.committed[batchId])
+}
+
+func (s *LocalBackendTestSuite) Test_Verify_Rejects_Corrupted_Proofs() {
+	backend := NewLocalBackend(NewFsStore(s.T().TempDir()), nil, s.logger)
+
+	corrupted := *s.commitment
+	corrupted.DataProofs = append(types.DataProofs{}, s.commitment.DataProofs...)
+	// Flip a byte of the claim of the first proof
+	corrupted.DataProofs[0][40] ^= 0xff
+
+	s.Require().Error(backend.VerifyDataProofs(s.ctx, &corrupted))
+
+	corrupted.DataProofs = corrupted.DataProofs[1:]
+	s.Require().Error(backend.VerifyDataProofs(s.ctx, &corrupted))
+}
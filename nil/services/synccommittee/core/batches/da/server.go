@@ -0,0 +1,200 @@
+package da
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/srv"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	defaultHttpTimeout = 30 * time.Second
+
+	// maxBatchRequestSize is enough for the JSON of a batch with the maximum number of blobs in a block
+	maxBatchRequestSize = 16 << 20
+)
+
+// Server exposes the batches of the local backend over HTTP:
+//
+//	PUT /batches/{batchId} stores the batch data, it requires the bearer token if one is configured,
+//	GET /batches/{batchId} returns it.
+//
+// Without a token the server only listens on the loopback interface.
+type Server struct {
+	listenAddr string
+	token      string
+	store      Store
+	logger     logging.Logger
+}
+
+var _ srv.Worker = (*Server)(nil)
+
+func NewServer(listenAddr string, token string, store Store, logger logging.Logger) *Server {
+	return &Server{
+		listenAddr: listenAddr,
+		token:      token,
+		store:      store,
+		logger:     logger,
+	}
+}
+
+// checkServerListenAddr refuses to expose uploads on a non-loopback address without a token.
+func checkServerListenAddr(listenAddr string, token string) error {
+	if token != "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return fmt.Errorf("invalid DA server listen address %q: %w", listenAddr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("DA server token must be set to listen on non-loopback address %q", listenAddr)
+}
+
+func (*Server) Name() string {
+	return "da_server"
+}
+
+func (s *Server) Run(ctx context.Context, started chan<- struct{}) error {
+	if err := checkServerListenAddr(s.listenAddr, s.token); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+
+	server := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: defaultHttpTimeout}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	s.logger.Info().Msgf("Serving DA batches at %s", listener.Addr())
+	close(started)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second) //nolint:contextcheck
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil { //nolint:contextcheck
+		return fmt.Errorf("failed to stop DA server: %w", err)
+	}
+	return nil
+}
+
+// Handler returns the HTTP handler of the server, it is exposed for embedding into other servers and for tests.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /batches/{batchId}", s.putBatch)
+	mux.HandleFunc("GET /batches/{batchId}", s.getBatch)
+	return mux
+}
+
+func (s *Server) putBatch(w http.ResponseWriter, r *http.Request) {
+	if !s.isAuthorized(r) {
+		http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	batchId, ok := s.parseBatchId(w, r)
+	if !ok {
+		return
+	}
+
+	var stored storedBatch
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBatchRequestSize)).Decode(&stored); err != nil {
+		http.Error(w, fmt.Sprintf("invalid batch data: %s", err), http.StatusBadRequest)
+		return
+	}
+	if stored.BatchId != batchId {
+		http.Error(w, "batch id in the path doesn't match the body", http.StatusBadRequest)
+		return
+	}
+	blobCount := len(stored.Blobs)
+	if blobCount == 0 || blobCount != len(stored.Commitments) || blobCount != len(stored.Proofs) {
+		http.Error(w, "blobs, commitments and proofs must be non-empty and of the same length", http.StatusBadRequest)
+		return
+	}
+	for i := range stored.Blobs {
+		if err := kzg4844.VerifyBlobProof(&stored.Blobs[i], stored.Commitments[i], stored.Proofs[i]); err != nil {
+			http.Error(w, fmt.Sprintf("invalid proof of blob %d: %s", i, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	err := s.store.Put(r.Context(), batchId, stored.sidecar())
+	if errors.Is(err, ErrBatchAlreadyExists) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Stringer(logging.FieldBatchId, batchId).Msg("Failed to store batch data")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Debug().Stringer(logging.FieldBatchId, batchId).Msg("Batch data stored")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getBatch(w http.ResponseWriter, r *http.Request) {
+	batchId, ok := s.parseBatchId(w, r)
+	if !ok {
+		return
+	}
+
+	sidecar, err := s.store.Get(r.Context(), batchId)
+	if errors.Is(err, ErrBatchNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Stringer(logging.FieldBatchId, batchId).Msg("Failed to read batch data")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newStoredBatch(batchId, sidecar)); err != nil {
+		s.logger.Warn().Err(err).Stringer(logging.FieldBatchId, batchId).Msg("Failed to send batch data")
+	}
+}
+
+func (s *Server) isAuthorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func (*Server) parseBatchId(w http.ResponseWriter, r *http.Request) (types.BatchId, bool) {
+	var batchId types.BatchId
+	if err := batchId.Set(r.PathValue("batchId")); err != nil {
+		http.Error(w, fmt.Sprintf("invalid batch id: %s", err), http.StatusBadRequest)
+		return types.BatchId{}, false
+	}
+	return batchId, true
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
 	protoTypes "github.com/NilFoundation/nil/nil/services/synccommittee/internal/types/proto"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -32,24 +33,14 @@ func NewDecoder(logger logging.Logger) *decoder {
 // in case of need to access decoded data programmatically (from sync_committee or other cluster parts)
 // this decoder might be extended with returning something like types.BlockBatch functionality
 func (d *decoder) DecodeIntermediate(from io.Reader, to io.Writer) error {
-	if err := encode.CheckBatchVersion(from, version); err != nil {
-		return err
-	}
-
-	var decompressed bytes.Buffer
-	if err := d.decompressor.Decompress(from, &decompressed); err != nil {
-		return err
-	}
-
-	var protoBatch protoTypes.Batch
-
-	if err := proto.Unmarshal(decompressed.Bytes(), &protoBatch); err != nil {
+	protoBatch, err := d.decodeProto(from)
+	if err != nil {
 		return err
 	}
 
 	humanReadableForm, err := protojson.MarshalOptions{
 		Multiline: true,
-	}.Marshal(&protoBatch)
+	}.Marshal(protoBatch)
 	if err != nil {
 		return err
 	}
@@ -65,3 +56,29 @@ func (d *decoder) DecodeIntermediate(from io.Reader, to io.Writer) error {
 		Msg("serialized batch to protojson")
 	return nil
 }
+
+// Decode restores the pruned batch from its binary form
+func (d *decoder) Decode(from io.Reader) (*types.PrunedBatch, error) {
+	protoBatch, err := d.decodeProto(from)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertFromProto(protoBatch)
+}
+
+func (d *decoder) decodeProto(from io.Reader) (*protoTypes.Batch, error) {
+	if err := encode.CheckBatchVersion(from, version); err != nil {
+		return nil, err
+	}
+
+	var decompressed bytes.Buffer
+	if err := d.decompressor.Decompress(from, &decompressed); err != nil {
+		return nil, err
+	}
+
+	var protoBatch protoTypes.Batch
+	if err := proto.Unmarshal(decompressed.Bytes(), &protoBatch); err != nil {
+		return nil, err
+	}
+	return &protoBatch, nil
+}
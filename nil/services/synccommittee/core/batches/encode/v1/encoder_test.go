@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
@@ -56,3 +57,22 @@ func TestEncoderSimple(t *testing.T) {
 	assert.Equal(t, batch.Id, deserializedBatch.BatchId)
 	assert.ElementsMatch(t, prunedBatch.Blocks, deserializedBatch.Blocks)
 }
+
+func TestDecodeFromBlobs(t *testing.T) {
+	t.Parallel()
+
+	batch := testaide.NewBlockBatch(3)
+	logger := logging.NewLogger("sc_batch_decoder_test")
+	prunedBatch := types.NewPrunedBatch(batch)
+
+	var encoded bytes.Buffer
+	require.NoError(t, NewEncoder(logger).Encode(prunedBatch, &encoded))
+
+	blobs, err := blob.NewBuilder().MakeBlobs(&encoded, 6)
+	require.NoError(t, err)
+
+	decoded, err := NewDecoder(logger).Decode(blob.NewReader(blobs))
+	require.NoError(t, err)
+	assert.Equal(t, batch.Id, decoded.BatchId)
+	assert.True(t, proto.Equal(ConvertToProto(prunedBatch), ConvertToProto(decoded)))
+}
@@ -87,10 +87,10 @@ func ConvertFromProto(batch *proto.Batch) (*types.PrunedBatch, error) {
 			pValue := protoUint256ToUint256(ptx.GetValue())
 			tx.Value = coreTypes.Value{Uint256: &pValue}
 			if ptx.GetAddrRefundTo() != nil {
-				tx.RefundTo = coreTypes.BytesToAddress(ptx.GetAddrFrom().GetAddressBytes())
+				tx.RefundTo = coreTypes.BytesToAddress(ptx.GetAddrRefundTo().GetAddressBytes())
 			}
 			if ptx.GetAddrBounceTo() != nil {
-				tx.BounceTo = coreTypes.BytesToAddress(ptx.GetAddrFrom().GetAddressBytes())
+				tx.BounceTo = coreTypes.BytesToAddress(ptx.GetAddrBounceTo().GetAddressBytes())
 			}
 			b.Transactions = append(b.Transactions, tx)
 		}
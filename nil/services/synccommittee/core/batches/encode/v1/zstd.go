@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"errors"
 	"io"
 
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -72,6 +73,11 @@ func (zd *zstdDecompressor) Decompress(in io.Reader, out io.Writer) error {
 	defer impl.Close()
 
 	n, err := impl.WriteTo(out)
+	if errors.Is(err, zstd.ErrMagicMismatch) && n > 0 {
+		// data restored from blobs is followed by zero padding up to the end of the last blob
+		zd.logger.Debug().Int64("decompressed_size", n).Msg("ignored trailing data after zstd frame")
+		err = nil
+	}
 	if err != nil {
 		return err
 	}
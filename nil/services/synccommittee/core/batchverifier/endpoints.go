@@ -0,0 +1,63 @@
+package batchverifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	coreTypes "github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/bridgecontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/fetching"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/rpc"
+	"github.com/jonboulle/clockwork"
+)
+
+// EndpointsConfig defines remote services used by the verifier
+type EndpointsConfig struct {
+	Config
+
+	OwnRpcEndpoint string
+	NilRpcEndpoint string
+
+	// L1 is used for UpdateState dry-run, the check is skipped if it's not set
+	L1                       *rollupcontract.WrapperConfig
+	L2BridgeMessengerAddress string
+}
+
+// NewVerifierWithEndpoints creates a verifier talking to the sync committee, L2 and (optionally) L1 via RPC
+func NewVerifierWithEndpoints(
+	ctx context.Context,
+	config EndpointsConfig,
+	blobStore BlobStore,
+	logger logging.Logger,
+) (*Verifier, error) {
+	nilClient := rpc.NewRetryClient(config.NilRpcEndpoint, logger)
+	batchSource := rpc.NewBlockDebugRpcClient(config.OwnRpcEndpoint, logger)
+	fetcher := fetching.NewFetcher(nilClient, logger)
+
+	var (
+		stateUpdater      StateUpdater
+		bridgeStateGetter bridgecontract.BridgeStateGetter
+	)
+	if config.L1 != nil {
+		wrapper, err := rollupcontract.NewWrapper(ctx, *config.L1, nil, clockwork.NewRealClock(), logger)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing rollup contract wrapper: %w", err)
+		}
+		stateUpdater = wrapper
+
+		l2BridgeMessengerAddress := coreTypes.HexToAddress(config.L2BridgeMessengerAddress)
+		bridgeStateGetter = bridgecontract.NewBridgeStateGetter(nilClient, l2BridgeMessengerAddress, logger)
+	}
+
+	return NewVerifier(
+		config.Config,
+		batchSource,
+		fetcher,
+		blobStore,
+		stateUpdater,
+		bridgeStateGetter,
+		logger,
+	), nil
+}
@@ -0,0 +1,78 @@
+package batchverifier
+
+import (
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+)
+
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "passed"
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped"
+)
+
+const (
+	CheckBlocks          = "blocks"
+	CheckCommitment      = "commitment"
+	CheckDataProofs      = "data proofs"
+	CheckStoredBlobs     = "stored blobs"
+	CheckStoredBatch     = "stored batch content"
+	CheckUpdateStateCall = "updateState dry-run"
+)
+
+type CheckResult struct {
+	Name    string
+	Status  CheckStatus
+	Details string
+}
+
+// Report contains the results of all checks performed for a single batch, in the order of their execution
+type Report struct {
+	BatchId types.BatchId
+	Checks  []CheckResult
+}
+
+func newReport(batchId types.BatchId) *Report {
+	return &Report{BatchId: batchId}
+}
+
+func (r *Report) HasFailures() bool {
+	for _, check := range r.Checks {
+		if check.Status == CheckFailed {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) Get(name string) (CheckResult, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return CheckResult{}, false
+}
+
+func (r *Report) passed(name string, format string, args ...any) {
+	r.add(name, CheckPassed, format, args...)
+}
+
+func (r *Report) failed(name string, format string, args ...any) {
+	r.add(name, CheckFailed, format, args...)
+}
+
+func (r *Report) skipped(name string, format string, args ...any) {
+	r.add(name, CheckSkipped, format, args...)
+}
+
+func (r *Report) add(name string, status CheckStatus, format string, args ...any) {
+	r.Checks = append(r.Checks, CheckResult{
+		Name:    name,
+		Status:  status,
+		Details: fmt.Sprintf(format, args...),
+	})
+}
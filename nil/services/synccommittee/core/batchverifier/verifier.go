@@ -0,0 +1,340 @@
+package batchverifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	coreTypes "github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
+	v1 "github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode/v1"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/bridgecontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types/proto"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// BatchSource provides batches persisted by the sync committee
+type BatchSource interface {
+	GetBatchView(ctx context.Context, batchId public.BatchId) (*public.BatchViewDetailed, error)
+}
+
+// BlockFetcher re-fetches batch blocks from L2
+type BlockFetcher interface {
+	FetchSegments(ctx context.Context, hashes map[coreTypes.ShardId][]common.Hash) (types.ChainSegments, error)
+}
+
+// BlobStore provides the blobs of a batch published via the local DA backend
+type BlobStore interface {
+	Get(ctx context.Context, batchId types.BatchId) (*ethtypes.BlobTxSidecar, error)
+}
+
+// StateUpdater is the part of the rollup contract used for UpdateState dry-run
+type StateUpdater interface {
+	GetLatestFinalizedStateRoot(ctx context.Context) (common.Hash, error)
+	SimulateUpdateState(ctx context.Context, data *types.UpdateStateData) error
+}
+
+type Config struct {
+	CommitConfig             batches.CommitPreparerConfig
+	BridgeStateKeeperShardId coreTypes.ShardId
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		CommitConfig:             batches.DefaultCommitConfig(),
+		BridgeStateKeeperShardId: coreTypes.BaseShardId,
+	}
+}
+
+// Verifier re-derives batch data from L2 blocks and compares it with everything the sync committee
+// has stored or is about to submit for the batch.
+type Verifier struct {
+	config            Config
+	batchSource       BatchSource
+	fetcher           BlockFetcher
+	blobStore         BlobStore
+	stateUpdater      StateUpdater
+	bridgeStateGetter bridgecontract.BridgeStateGetter
+	logger            logging.Logger
+}
+
+// NewVerifier creates a batch verifier.
+// blobStore and stateUpdater are optional, corresponding checks are skipped if they are not set.
+// bridgeStateGetter is required only along with stateUpdater.
+func NewVerifier(
+	config Config,
+	batchSource BatchSource,
+	fetcher BlockFetcher,
+	blobStore BlobStore,
+	stateUpdater StateUpdater,
+	bridgeStateGetter bridgecontract.BridgeStateGetter,
+	logger logging.Logger,
+) *Verifier {
+	return &Verifier{
+		config:            config,
+		batchSource:       batchSource,
+		fetcher:           fetcher,
+		blobStore:         blobStore,
+		stateUpdater:      stateUpdater,
+		bridgeStateGetter: bridgeStateGetter,
+		logger:            logger,
+	}
+}
+
+// Verify runs all configured checks for the batch.
+// Mismatches are reported via the returned Report, error is returned only if the checks could not be performed.
+func (v *Verifier) Verify(ctx context.Context, batchId types.BatchId) (*Report, error) {
+	view, err := v.batchSource.GetBatchView(ctx, batchId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch with id=%s: %w", batchId, err)
+	}
+	if view == nil {
+		return nil, fmt.Errorf("batch with id=%s is not found", batchId)
+	}
+
+	report := newReport(batchId)
+
+	batch, err := v.rederiveBatch(ctx, view)
+	switch {
+	case errors.Is(err, types.ErrBlockNotFound):
+		report.failed(CheckBlocks, "%s", err)
+		v.skipRemaining(report, "batch blocks are not available on L2")
+		return report, nil
+	case err != nil:
+		return nil, err
+	}
+	report.passed(CheckBlocks, "%d blocks re-fetched from L2", batch.Blocks.BlocksCount())
+
+	commitment, err := v.recomputeCommitment(batch)
+	if err != nil {
+		report.failed(CheckCommitment, "%s", err)
+		v.skipRemaining(report, "commitment could not be recomputed")
+		return report, nil
+	}
+	report.passed(CheckCommitment, "%d blobs, KZG proofs are valid", len(commitment.Sidecar.Blobs))
+
+	v.compareDataProofs(report, view, commitment)
+
+	if err := v.checkStoredBlobs(ctx, report, batch, commitment); err != nil {
+		return nil, err
+	}
+
+	if err := v.simulateUpdateState(ctx, report, batch, commitment); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (*Verifier) skipRemaining(report *Report, reason string) {
+	remaining := []string{CheckCommitment, CheckDataProofs, CheckStoredBlobs, CheckStoredBatch, CheckUpdateStateCall}
+	for _, name := range remaining {
+		if _, ok := report.Get(name); !ok {
+			report.skipped(name, "%s", reason)
+		}
+	}
+}
+
+func (v *Verifier) rederiveBatch(ctx context.Context, view *public.BatchViewDetailed) (*types.BlockBatch, error) {
+	segments, err := v.fetcher.FetchSegments(ctx, view.Blocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocks of batch with id=%s: %w", view.Id, err)
+	}
+
+	return types.ReconstructExistingBlockBatch(
+		view.Id,
+		view.ParentId,
+		segments,
+		view.DataProofs,
+		view.IsSealed,
+		view.CreatedAt,
+		view.UpdatedAt,
+	), nil
+}
+
+func (v *Verifier) recomputeCommitment(batch *types.BlockBatch) (*batches.Commitment, error) {
+	preparer := batches.NewCommitPreparer(v1.NewEncoder(v.logger), blob.NewBuilder(), v.config.CommitConfig, v.logger)
+
+	commitment, err := preparer.PrepareBatchCommitment(batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare commitment: %w", err)
+	}
+	if err := commitment.VerifyLocally(); err != nil {
+		return nil, fmt.Errorf("recomputed commitment is invalid: %w", err)
+	}
+	return commitment, nil
+}
+
+func (*Verifier) compareDataProofs(report *Report, view *public.BatchViewDetailed, commitment *batches.Commitment) {
+	if !view.IsSealed {
+		report.skipped(CheckDataProofs, "batch is not sealed yet")
+		return
+	}
+
+	stored, recomputed := view.DataProofs, commitment.DataProofs
+	if len(stored) != len(recomputed) {
+		report.failed(CheckDataProofs, "stored %d data proofs, recomputed %d", len(stored), len(recomputed))
+		return
+	}
+	for i := range stored {
+		if stored[i] != recomputed[i] {
+			report.failed(CheckDataProofs, "data proof %d differs from the recomputed one", i)
+			return
+		}
+	}
+	report.passed(CheckDataProofs, "%d stored data proofs match", len(stored))
+}
+
+func (v *Verifier) checkStoredBlobs(
+	ctx context.Context,
+	report *Report,
+	batch *types.BlockBatch,
+	commitment *batches.Commitment,
+) error {
+	if v.blobStore == nil {
+		report.skipped(CheckStoredBlobs, "blob store is not configured")
+		report.skipped(CheckStoredBatch, "blob store is not configured")
+		return nil
+	}
+
+	sidecar, err := v.blobStore.Get(ctx, batch.Id)
+	if errors.Is(err, da.ErrBatchNotFound) {
+		report.failed(CheckStoredBlobs, "%s", err)
+		report.skipped(CheckStoredBatch, "batch blobs are not stored")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get stored blobs of batch with id=%s: %w", batch.Id, err)
+	}
+
+	v.compareBlobs(report, sidecar, commitment.Sidecar)
+	v.compareDecodedBatch(report, sidecar, batch)
+	return nil
+}
+
+func (*Verifier) compareBlobs(report *Report, stored, recomputed *ethtypes.BlobTxSidecar) {
+	if len(stored.Blobs) != len(recomputed.Blobs) {
+		report.failed(CheckStoredBlobs, "stored %d blobs, recomputed %d", len(stored.Blobs), len(recomputed.Blobs))
+		return
+	}
+	for i := range stored.Blobs {
+		if stored.Blobs[i] != recomputed.Blobs[i] {
+			report.failed(CheckStoredBlobs, "blob %d content differs from the recomputed one", i)
+			return
+		}
+		if i < len(stored.Commitments) && stored.Commitments[i] != recomputed.Commitments[i] {
+			report.failed(CheckStoredBlobs, "blob %d commitment differs from the recomputed one", i)
+			return
+		}
+	}
+	report.passed(CheckStoredBlobs, "%d stored blobs match", len(stored.Blobs))
+}
+
+func (v *Verifier) compareDecodedBatch(report *Report, sidecar *ethtypes.BlobTxSidecar, batch *types.BlockBatch) {
+	decoded, err := v1.NewDecoder(v.logger).Decode(blob.NewReader(sidecar.Blobs))
+	if err != nil {
+		report.failed(CheckStoredBatch, "failed to decode stored blobs: %s", err)
+		return
+	}
+
+	expected := v1.ConvertToProto(types.NewPrunedBatch(batch))
+	actual := v1.ConvertToProto(decoded)
+
+	if actual.GetBatchId() != expected.GetBatchId() {
+		report.failed(CheckStoredBatch, "stored batch id is %s", actual.GetBatchId())
+		return
+	}
+	if len(actual.GetBlocks()) != len(expected.GetBlocks()) {
+		report.failed(
+			CheckStoredBatch, "stored %d blocks, chain has %d", len(actual.GetBlocks()), len(expected.GetBlocks()),
+		)
+		return
+	}
+
+	var mismatched []string
+	for i, block := range expected.GetBlocks() {
+		if !protobuf.Equal(block, actual.GetBlocks()[i]) {
+			mismatched = append(mismatched, blockName(block))
+		}
+	}
+	if len(mismatched) > 0 {
+		report.failed(CheckStoredBatch, "blocks differ from the chain: %v", mismatched)
+		return
+	}
+	report.passed(CheckStoredBatch, "%d decoded blocks match the chain", len(expected.GetBlocks()))
+}
+
+func blockName(block *proto.BlobBlock) string {
+	return fmt.Sprintf("%d:%d", block.GetShardId(), block.GetBlockNumber())
+}
+
+func (v *Verifier) simulateUpdateState(
+	ctx context.Context,
+	report *Report,
+	batch *types.BlockBatch,
+	commitment *batches.Commitment,
+) error {
+	if v.stateUpdater == nil {
+		report.skipped(CheckUpdateStateCall, "L1 endpoint is not configured")
+		return nil
+	}
+
+	data, err := v.updateStateData(ctx, batch, commitment)
+	if err != nil {
+		return err
+	}
+
+	if err := v.stateUpdater.SimulateUpdateState(ctx, data); err != nil {
+		report.failed(CheckUpdateStateCall, "%s", err)
+		return nil
+	}
+	report.passed(
+		CheckUpdateStateCall, "transition %s -> %s is accepted", data.OldProvedStateRoot, data.NewProvedStateRoot,
+	)
+	return nil
+}
+
+// updateStateData builds the same call arguments the proposer would submit for the batch
+func (v *Verifier) updateStateData(
+	ctx context.Context,
+	batch *types.BlockBatch,
+	commitment *batches.Commitment,
+) (*types.UpdateStateData, error) {
+	oldStateRoot, err := v.stateUpdater.GetLatestFinalizedStateRoot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest finalized state root from L1: %w", err)
+	}
+
+	bridgeBlock, ok := batch.LatestBlocks()[v.config.BridgeStateKeeperShardId]
+	if !ok {
+		return nil, fmt.Errorf("batch has no blocks of shard %d", v.config.BridgeStateKeeperShardId)
+	}
+	bridgeState, err := v.bridgeStateGetter.GetBridgeState(ctx, bridgeBlock.Hash, bridgeBlock.MainShardHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bridge state: %w", err)
+	}
+
+	proposalData := types.NewProposalData(
+		batch.Id,
+		commitment.DataProofs,
+		oldStateRoot,
+		batch.LatestMainBlock().Hash,
+		time.Time{},
+	)
+
+	return types.NewUpdateStateData(
+		proposalData,
+		types.PlaceholderValidityProof,
+		bridgeState.L2toL1Root,
+		bridgeState.L1MessageHash,
+		bridgeState.DepositNonce,
+	), nil
+}
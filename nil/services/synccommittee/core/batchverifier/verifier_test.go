@@ -0,0 +1,235 @@
+package batchverifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	coreTypes "github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
+	v1 "github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode/v1"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/bridgecontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type batchSourceStub map[types.BatchId]*public.BatchViewDetailed
+
+func (s batchSourceStub) GetBatchView(_ context.Context, batchId types.BatchId) (*public.BatchViewDetailed, error) {
+	return s[batchId], nil
+}
+
+type blockFetcherStub map[common.Hash]*types.Block
+
+func (s blockFetcherStub) FetchSegments(
+	_ context.Context,
+	hashes map[coreTypes.ShardId][]common.Hash,
+) (types.ChainSegments, error) {
+	blocks := make(map[coreTypes.ShardId][]*types.Block)
+	for shardId, shardHashes := range hashes {
+		for _, hash := range shardHashes {
+			block, ok := s[hash]
+			if !ok {
+				return nil, types.ErrBlockNotFound
+			}
+			blocks[shardId] = append(blocks[shardId], block)
+		}
+	}
+	return types.NewChainSegments(blocks)
+}
+
+type blobStoreStub map[types.BatchId]*ethtypes.BlobTxSidecar
+
+func (s blobStoreStub) Get(_ context.Context, batchId types.BatchId) (*ethtypes.BlobTxSidecar, error) {
+	return s[batchId], nil
+}
+
+type bridgeStateGetterStub struct{}
+
+func (bridgeStateGetterStub) GetBridgeState(
+	context.Context, common.Hash, common.Hash,
+) (*bridgecontract.BridgeState, error) {
+	return &bridgecontract.BridgeStateEmpty, nil
+}
+
+type VerifierTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	logger   logging.Logger
+	preparer interface {
+		PrepareBatchCommitment(batch *types.BlockBatch) (*batches.Commitment, error)
+	}
+
+	batchSource  batchSourceStub
+	fetcher      blockFetcherStub
+	blobStore    blobStoreStub
+	stateUpdater *rollupcontract.WrapperMock
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VerifierTestSuite))
+}
+
+func (s *VerifierTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.logger = logging.NewLogger("batch_verifier_test")
+	s.preparer = batches.NewCommitPreparer(
+		v1.NewEncoder(s.logger), blob.NewBuilder(), batches.DefaultCommitConfig(), s.logger,
+	)
+}
+
+func (s *VerifierTestSuite) SetupTest() {
+	s.batchSource = make(batchSourceStub)
+	s.fetcher = make(blockFetcherStub)
+	s.blobStore = make(blobStoreStub)
+	s.stateUpdater = &rollupcontract.WrapperMock{}
+}
+
+func (s *VerifierTestSuite) newVerifier(withBlobs, withL1 bool) *Verifier {
+	var (
+		blobStore    BlobStore
+		stateUpdater StateUpdater
+	)
+	if withBlobs {
+		blobStore = s.blobStore
+	}
+	if withL1 {
+		stateUpdater = s.stateUpdater
+	}
+	return NewVerifier(
+		NewDefaultConfig(), s.batchSource, s.fetcher, blobStore, stateUpdater, bridgeStateGetterStub{}, s.logger,
+	)
+}
+
+// storeBatch puts the sealed batch to all the stubs, as the sync committee does after a commit
+func (s *VerifierTestSuite) storeBatch() (*types.BlockBatch, *batches.Commitment) {
+	s.T().Helper()
+
+	batch := testaide.NewBlockBatch(testaide.ShardsCount)
+	commitment, err := s.preparer.PrepareBatchCommitment(batch)
+	s.Require().NoError(err)
+	batch, err = batch.Seal(commitment.DataProofs, testaide.Now)
+	s.Require().NoError(err)
+
+	for block := range batch.BlocksIter() {
+		s.fetcher[block.Hash] = block
+	}
+	s.batchSource[batch.Id] = public.NewBatchViewDetailed(batch)
+	s.blobStore[batch.Id] = commitment.Sidecar
+	return batch, commitment
+}
+
+func (s *VerifierTestSuite) requireStatus(report *Report, name string, status CheckStatus) {
+	s.T().Helper()
+
+	check, ok := report.Get(name)
+	s.Require().True(ok, "check %q is missing", name)
+	s.Require().Equal(status, check.Status, "check %q: %s", name, check.Details)
+}
+
+func (s *VerifierTestSuite) Test_Batch_Not_Found() {
+	_, err := s.newVerifier(true, true).Verify(s.ctx, types.NewBatchId())
+	s.Require().ErrorContains(err, "not found")
+}
+
+func (s *VerifierTestSuite) Test_All_Checks_Passed() {
+	batch, _ := s.storeBatch()
+
+	oldRoot := testaide.RandomHash()
+	s.stateUpdater.GetLatestFinalizedStateRootFunc = func(context.Context) (common.Hash, error) {
+		return oldRoot, nil
+	}
+	var simulated *types.UpdateStateData
+	s.stateUpdater.SimulateUpdateStateFunc = func(_ context.Context, data *types.UpdateStateData) error {
+		simulated = data
+		return nil
+	}
+
+	report, err := s.newVerifier(true, true).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.Require().False(report.HasFailures(), "%+v", report.Checks)
+	for _, name := range []string{
+		CheckBlocks, CheckCommitment, CheckDataProofs, CheckStoredBlobs, CheckStoredBatch, CheckUpdateStateCall,
+	} {
+		s.requireStatus(report, name, CheckPassed)
+	}
+
+	s.Require().NotNil(simulated)
+	s.Equal(batch.Id, simulated.BatchId)
+	s.Equal(oldRoot, simulated.OldProvedStateRoot)
+	s.Equal(batch.LatestMainBlock().Hash, simulated.NewProvedStateRoot)
+	s.Equal(batch.DataProofs, simulated.DataProofs)
+}
+
+func (s *VerifierTestSuite) Test_Optional_Checks_Skipped() {
+	batch, _ := s.storeBatch()
+
+	report, err := s.newVerifier(false, false).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.Require().False(report.HasFailures())
+	s.requireStatus(report, CheckStoredBlobs, CheckSkipped)
+	s.requireStatus(report, CheckStoredBatch, CheckSkipped)
+	s.requireStatus(report, CheckUpdateStateCall, CheckSkipped)
+	s.Empty(s.stateUpdater.SimulateUpdateStateCalls())
+}
+
+func (s *VerifierTestSuite) Test_Block_Missing_On_L2() {
+	batch, _ := s.storeBatch()
+	delete(s.fetcher, batch.LatestMainBlock().Hash)
+
+	report, err := s.newVerifier(true, true).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.requireStatus(report, CheckBlocks, CheckFailed)
+	s.requireStatus(report, CheckCommitment, CheckSkipped)
+	s.requireStatus(report, CheckUpdateStateCall, CheckSkipped)
+}
+
+func (s *VerifierTestSuite) Test_Stored_Data_Proofs_Mismatch() {
+	batch, _ := s.storeBatch()
+	s.batchSource[batch.Id].DataProofs = testaide.NewDataProofs()
+
+	report, err := s.newVerifier(false, false).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.requireStatus(report, CheckDataProofs, CheckFailed)
+}
+
+func (s *VerifierTestSuite) Test_Stored_Blobs_Mismatch() {
+	batch, _ := s.storeBatch()
+
+	// blobs of another batch are stored under the id of the verified one
+	other := testaide.NewBlockBatch(testaide.ShardsCount)
+	otherCommitment, err := s.preparer.PrepareBatchCommitment(other)
+	s.Require().NoError(err)
+	s.blobStore[batch.Id] = otherCommitment.Sidecar
+
+	report, err := s.newVerifier(true, false).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.requireStatus(report, CheckStoredBlobs, CheckFailed)
+	s.requireStatus(report, CheckStoredBatch, CheckFailed)
+}
+
+func (s *VerifierTestSuite) Test_UpdateState_Rejected() {
+	batch, _ := s.storeBatch()
+
+	s.stateUpdater.GetLatestFinalizedStateRootFunc = func(context.Context) (common.Hash, error) {
+		return testaide.RandomHash(), nil
+	}
+	s.stateUpdater.SimulateUpdateStateFunc = func(context.Context, *types.UpdateStateData) error {
+		return rollupcontract.ErrBatchNotCommitted
+	}
+
+	report, err := s.newVerifier(false, true).Verify(s.ctx, batch.Id)
+	s.Require().NoError(err)
+	s.requireStatus(report, CheckUpdateStateCall, CheckFailed)
+
+	check, _ := report.Get(CheckUpdateStateCall)
+	s.Contains(check.Details, rollupcontract.ErrBatchNotCommitted.Error())
+}
@@ -48,12 +48,14 @@ func (s *BlockTasksIntegrationTestSuite) SetupSuite() {
 	logger := logging.NewLogger("block_tasks_test_suite")
 
 	s.clock = testaide.NewTestClock()
-	s.taskStorage = storage.NewTaskStorage(s.db, s.clock, metricsHandler, logger)
+	s.taskStorage, err = storage.NewTaskStorage(s.ctx, s.db, s.clock, metricsHandler, logger)
+	s.Require().NoError(err)
 	s.blockStorage = storage.NewBlockStorage(s.db, storage.DefaultBlockStorageConfig(), s.clock, metricsHandler, logger)
 
 	s.requestHandler = scheduler.New(
 		s.taskStorage,
 		newTaskStateChangeHandler(s.blockStorage, &StateResetLauncherMock{}, logger),
+		scheduler.NewExecutorRegistry(s.clock),
 		metricsHandler,
 		logger,
 	)
@@ -86,13 +88,13 @@ func (s *BlockTasksIntegrationTestSuite) Test_Provide_Tasks_And_Handle_Success_R
 	executorId := types.NewRandomExecutorId()
 
 	// requesting batch proof task for execution
-	taskToExecute, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId))
+	taskToExecute, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId, nil))
 	s.Require().NoError(err)
 	s.Require().NotNil(taskToExecute)
 	s.Require().Equal(types.ProofBatch, taskToExecute.TaskType)
 
 	// no new tasks available yet
-	nonAvailableTask, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId))
+	nonAvailableTask, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId, nil))
 	s.Require().NoError(err)
 	s.Require().Nil(nonAvailableTask)
 
@@ -126,7 +128,7 @@ func (s *BlockTasksIntegrationTestSuite) Test_Provide_Tasks_And_Handle_Failure_R
 	executorId := types.NewRandomExecutorId()
 
 	// requesting batch proof task
-	taskToExecute, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId))
+	taskToExecute, err := s.requestHandler.GetTask(s.ctx, api.NewTaskRequest(executorId, nil))
 	s.Require().NoError(err)
 	s.Require().NotNil(taskToExecute)
 	s.Require().Equal(types.ProofBatch, taskToExecute.TaskType)
@@ -2,6 +2,7 @@ package core
 
 import (
 	"github.com/NilFoundation/nil/nil/internal/telemetry"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/feeupdater"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/fetching"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
@@ -18,6 +19,7 @@ type Config struct {
 	AggregatorConfig          fetching.AggregatorConfig        `yaml:",inline"`
 	ProposerParams            ProposerConfig                   `yaml:",inline"`
 	ContractWrapperConfig     rollupcontract.WrapperConfig     `yaml:",inline"`
+	DataAvailability          da.Config                        `yaml:",inline"`
 	L1FeeUpdateConfig         feeupdater.Config                `yaml:",inline"`
 	L1FeeUpdateContractConfig feeupdater.ContractWrapperConfig `yaml:",inline"`
 	L2BridgeMessengerAddress  string                           `yaml:"l2BridgeMessengerAddress"`
@@ -31,6 +33,7 @@ func NewDefaultConfig() *Config {
 		AggregatorConfig:      fetching.NewDefaultAggregatorConfig(),
 		ProposerParams:        NewDefaultProposerConfig(),
 		ContractWrapperConfig: rollupcontract.NewDefaultWrapperConfig(),
+		DataAvailability:      da.NewDefaultConfig(),
 		L1FeeUpdateConfig:     feeupdater.DefaultConfig(),
 		Telemetry: &telemetry.Config{
 			ServiceName: "sync_committee",
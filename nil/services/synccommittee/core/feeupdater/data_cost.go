@@ -0,0 +1,71 @@
+package feeupdater
+
+import (
+	"sync"
+
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
+	scTypes "github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+type dataUsage struct {
+	batchCount int
+	blobCount  uint64
+	gasUsed    types.Gas
+}
+
+// costPerGas evaluates how much L1 blob space consumed by the batches costs per unit of L2 gas,
+// gasUsed is expected to be non-zero
+func (u dataUsage) costPerGas(blobBaseFee types.Value) types.Value {
+	totalCost := blobBaseFee.Mul64(u.blobCount * params.BlobTxBlobGasPerBlob)
+	return totalCost.Div64(u.gasUsed.Uint64())
+}
+
+// DataCostTracker keeps blob usage of the most recently committed batches.
+// It is fed by the committer and read by the Updater on each fee recalculation.
+type DataCostTracker struct {
+	mu         sync.Mutex
+	windowSize int
+	window     []dataUsage
+}
+
+var _ batches.CommitObserver = (*DataCostTracker)(nil)
+
+func NewDataCostTracker(windowSize int) *DataCostTracker {
+	return &DataCostTracker{
+		windowSize: max(windowSize, 1),
+	}
+}
+
+func (t *DataCostTracker) OnBatchCommitted(batch *scTypes.BlockBatch, commitment *batches.Commitment) {
+	usage := dataUsage{
+		batchCount: 1,
+		blobCount:  uint64(len(commitment.DataProofs)),
+	}
+	for block := range batch.BlocksIter() {
+		usage.gasUsed = usage.gasUsed.Add(block.GasUsed)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.window = append(t.window, usage)
+	if len(t.window) > t.windowSize {
+		t.window = t.window[len(t.window)-t.windowSize:]
+	}
+}
+
+// usage returns the blob usage summed over the current window
+func (t *DataCostTracker) usage() dataUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var total dataUsage
+	for _, usage := range t.window {
+		total.batchCount += usage.batchCount
+		total.blobCount += usage.blobCount
+		total.gasUsed = total.gasUsed.Add(usage.gasUsed)
+	}
+	return total
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -11,6 +12,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/fetching"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/metrics"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/srv"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
 	"github.com/jonboulle/clockwork"
 )
 
@@ -32,6 +34,15 @@ type Config struct {
 
 	// Minimum expected value of fee per gas collected from shards
 	MinFeePerGas uint64 `yaml:"minFeePerGas"`
+
+	// Number of the latest committed batches which blob usage is taken into account by the data cost estimation
+	DataCostWindowSize int `yaml:"dataCostWindowSize"`
+
+	// Weight (in percent) of the latest data cost sample in its exponential moving average
+	DataCostSmoothingPercent uint `yaml:"dataCostSmoothingPercent"`
+
+	// Max change (in percent) of the smoothed data cost per recalculation, 0 disables the limit
+	MaxDataCostChangePercent uint `yaml:"maxDataCostChangePercent"`
 }
 
 func DefaultConfig() Config {
@@ -42,6 +53,9 @@ func DefaultConfig() Config {
 		MarkupPercent:               25,
 		MaxPriorityFeePerGasFixed:   1_000_000,  // 0.001 gwei transformed into wei
 		MinFeePerGas:                20_000_000, // 0.02 gwei transformed into wei
+		DataCostWindowSize:          20,
+		DataCostSmoothingPercent:    30,
+		MaxDataCostChangePercent:    25,
 	}
 }
 
@@ -58,20 +72,29 @@ type Updater struct {
 	logger          logging.Logger
 	clock           clockwork.Clock
 	contractBinding NilGasPriceOracleContract
+	dataCostTracker *DataCostTracker
 	metrics         *metrics.FeeUpdaterMetrics
 
 	state struct {
 		feeParams            *feeParams
 		lastUpdatedTimestamp time.Time
+		dataCostPerGas       *types.Value
 	}
+
+	// components of the latest evaluated fee params, read concurrently by the debug API
+	componentsMu sync.Mutex
+	components   *public.FeeComponents
 }
 
+var _ public.FeeDebugApi = (*Updater)(nil)
+
 func NewUpdater(
 	config Config,
 	blockFetcher *fetching.Fetcher,
 	logger logging.Logger,
 	clock clockwork.Clock,
 	contractBinding NilGasPriceOracleContract,
+	dataCostTracker *DataCostTracker,
 	metrics *metrics.FeeUpdaterMetrics,
 ) *Updater {
 	u := &Updater{
@@ -80,6 +103,7 @@ func NewUpdater(
 		logger:          logger,
 		clock:           clock,
 		contractBinding: contractBinding,
+		dataCostTracker: dataCostTracker,
 		metrics:         metrics,
 	}
 
@@ -124,18 +148,31 @@ func (u *Updater) recalcBaseFee(ctx context.Context) error {
 		Uint256("maxCurrentBaseFee", maxBaseFee.String()).
 		Msg("fetched new value of max base fee")
 
-	maxBaseFee = maxBaseFee.Mul64(uint64(100 + u.config.MarkupPercent)).Div64(100)
+	executionFee := maxBaseFee.Mul64(uint64(100 + u.config.MarkupPercent)).Div64(100)
 
-	if maxBaseFee.Int().CmpUint64(u.config.MinFeePerGas) < 0 {
-		return fmt.Errorf("too small maxFeePerGas value evaluated: %s", maxBaseFee)
+	if executionFee.Int().CmpUint64(u.config.MinFeePerGas) < 0 {
+		return fmt.Errorf("too small maxFeePerGas value evaluated: %s", executionFee)
 	}
 
+	components, err := u.evalDataCost(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate L1 data cost: %w", err)
+	}
+	components.ExecutionFeePerGas = executionFee
+	components.MaxFeePerGas = executionFee.Add(components.DataCostPerGas)
+	components.MaxPriorityFeePerGas = types.NewValueFromUint64(uint64(u.config.MaxPriorityFeePerGasFixed))
+	components.EvaluatedAt = u.clock.Now()
+	u.setFeeComponents(components)
+	u.metrics.RecordFeeComponents(ctx, components)
+
 	update := feeParams{
-		maxFeePerGas:         maxBaseFee.ToBig(),
+		maxFeePerGas:         components.MaxFeePerGas.ToBig(),
 		maxPriorityFeePerGas: big.NewInt(u.config.MaxPriorityFeePerGasFixed),
 	}
 
 	u.logger.Info().
+		Stringer("executionFeePerGas", components.ExecutionFeePerGas).
+		Stringer("dataCostPerGas", components.DataCostPerGas).
 		Stringer("maxFeePerGas", update.maxFeePerGas).
 		Stringer("maxPriorityFeePerGas", update.maxPriorityFeePerGas).
 		Msg("evaluated new fee params")
@@ -143,6 +180,89 @@ func (u *Updater) recalcBaseFee(ctx context.Context) error {
 	return u.applyUpdate(ctx, update)
 }
 
+// evalDataCost estimates the L1 data availability cost per unit of L2 gas
+// from the blob usage of recently committed batches and the current L1 blob base fee.
+func (u *Updater) evalDataCost(ctx context.Context) (*public.FeeComponents, error) {
+	components := &public.FeeComponents{
+		BlobBaseFee:       types.NewZeroValue(),
+		RawDataCostPerGas: types.NewZeroValue(),
+		DataCostPerGas:    types.NewZeroValue(),
+	}
+
+	usage := u.dataCostTracker.usage()
+	components.WindowBatchCount = usage.batchCount
+	components.WindowBlobCount = usage.blobCount
+	components.WindowGasUsed = usage.gasUsed
+
+	if usage.gasUsed == 0 {
+		// there is nothing to spread the data cost over, keep the previous estimation (if any)
+		if u.state.dataCostPerGas != nil {
+			components.DataCostPerGas = *u.state.dataCostPerGas
+		}
+		return components, nil
+	}
+
+	l1BlockInfo, err := u.blockFetcher.GetLatestL1BlockInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blobBaseFee := l1BlockInfo.BlobBaseFee
+	components.BlobBaseFee = types.Value{Uint256: &blobBaseFee}
+
+	components.RawDataCostPerGas = usage.costPerGas(components.BlobBaseFee)
+	components.DataCostPerGas = u.smoothDataCost(components.RawDataCostPerGas)
+	u.state.dataCostPerGas = &components.DataCostPerGas
+
+	u.logger.Debug().
+		Int("batchCount", usage.batchCount).
+		Uint64("blobCount", usage.blobCount).
+		Stringer("gasUsed", usage.gasUsed).
+		Stringer("blobBaseFee", components.BlobBaseFee).
+		Stringer("rawDataCostPerGas", components.RawDataCostPerGas).
+		Stringer("dataCostPerGas", components.DataCostPerGas).
+		Msg("evaluated L1 data cost")
+
+	return components, nil
+}
+
+// smoothDataCost applies exponential moving average to the data cost samples
+// and limits the change of the result relative to its previous value
+func (u *Updater) smoothDataCost(sample types.Value) types.Value {
+	prev := u.state.dataCostPerGas
+	if prev == nil || prev.IsZero() {
+		return sample
+	}
+
+	weight := uint64(min(u.config.DataCostSmoothingPercent, 100))
+	smoothed := sample.Mul64(weight).Add(prev.Mul64(100 - weight)).Div64(100)
+
+	if u.config.MaxDataCostChangePercent == 0 {
+		return smoothed
+	}
+
+	maxChange := prev.Mul64(uint64(u.config.MaxDataCostChangePercent)).Div64(100)
+	if upper := prev.Add(maxChange); smoothed.Cmp(upper) > 0 {
+		return upper
+	}
+	if lower, overflow := prev.SubOverflow(maxChange); !overflow && smoothed.Cmp(lower) < 0 {
+		return lower
+	}
+	return smoothed
+}
+
+func (u *Updater) setFeeComponents(components *public.FeeComponents) {
+	u.componentsMu.Lock()
+	defer u.componentsMu.Unlock()
+	u.components = components
+}
+
+// GetFeeComponents implements public.FeeDebugApi
+func (u *Updater) GetFeeComponents(_ context.Context) (*public.FeeComponents, error) {
+	u.componentsMu.Lock()
+	defer u.componentsMu.Unlock()
+	return u.components, nil
+}
+
 func (u *Updater) applyUpdate(ctx context.Context, update feeParams) error {
 	var updateL1 bool
 	for _, condition := range u.getUpdateConditions() {
@@ -9,10 +9,14 @@ import (
 	"github.com/NilFoundation/nil/nil/client"
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/config"
 	"github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/fetching"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/metrics"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
+	scTypes "github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,9 +24,10 @@ import (
 type UpdaterTestSuite struct {
 	suite.Suite
 
-	updater *Updater
-	config  Config
-	metrics *metrics.FeeUpdaterMetrics
+	updater         *Updater
+	config          Config
+	metrics         *metrics.FeeUpdaterMetrics
+	dataCostTracker *DataCostTracker
 
 	rpcClientMock  client.ClientMock
 	l1ContractMock *NilGasPriceOracleContractMock
@@ -56,6 +61,7 @@ func (s *UpdaterTestSuite) SetupTest() {
 	fetcher := fetching.NewFetcher(&s.rpcClientMock, logger)
 
 	s.config = DefaultConfig()
+	s.dataCostTracker = NewDataCostTracker(s.config.DataCostWindowSize)
 
 	s.updater = NewUpdater(
 		s.config,
@@ -63,10 +69,12 @@ func (s *UpdaterTestSuite) SetupTest() {
 		logger,
 		s.clock,
 		s.l1ContractMock,
+		s.dataCostTracker,
 		s.metrics,
 	)
 
 	s.ctx, s.cancel = context.WithCancel(s.T().Context())
+	s.updaterStopped = nil
 }
 
 func (s *UpdaterTestSuite) runUpdater() {
@@ -85,7 +93,9 @@ func (s *UpdaterTestSuite) runUpdater() {
 
 func (s *UpdaterTestSuite) TearDownTest() {
 	s.cancel()
-	<-s.updaterStopped
+	if s.updaterStopped != nil {
+		<-s.updaterStopped
+	}
 }
 
 func (s *UpdaterTestSuite) expectedMaxFeePerGas(maxValue uint64) uint64 {
@@ -263,3 +273,130 @@ func (s *UpdaterTestSuite) TestNoRedundantUpdates() {
 
 	<-updated // update only once after significant change was reached
 }
+
+func (s *UpdaterTestSuite) configureBlobBaseFee(blobBaseFee uint64) {
+	s.rpcClientMock.GetDebugBlockFunc = func(
+		_ context.Context,
+		shardId types.ShardId,
+		blockId any,
+		fullTx bool,
+	) (*jsonrpc.DebugRPCBlock, error) {
+		s.Equal(types.MainShardId, shardId)
+		s.Equal("latest", blockId)
+		s.True(fullTx)
+
+		return &jsonrpc.DebugRPCBlock{
+			Config: &jsonrpc.ChainConfig{
+				L1BlockInfo: &config.ParamL1BlockInfo{
+					BlobBaseFee: *types.NewUint256(blobBaseFee),
+				},
+			},
+		}, nil
+	}
+}
+
+func (s *UpdaterTestSuite) commitBatch(blobCount int, gasUsed types.Gas) {
+	batch := testaide.NewBlockBatch(1)
+	blocksCount := types.Gas(batch.Blocks.BlocksCount())
+	for block := range batch.BlocksIter() {
+		block.GasUsed = gasUsed / blocksCount
+	}
+	commitment := batches.NewCommitment(nil, make(scTypes.DataProofs, blobCount))
+	s.dataCostTracker.OnBatchCommitted(batch, commitment)
+}
+
+func (s *UpdaterTestSuite) TestDataCostIsAdded() {
+	s.configureFeeData(200_000_000)
+	s.configureBlobBaseFee(1_000)
+
+	// 2 blobs * 131072 blob gas * 1000 wei spread over 2_621_440 gas units
+	s.commitBatch(2, 2_621_440)
+
+	var captured feeParams
+	s.l1ContractMock.SetOracleFeeFunc = func(_ context.Context, params feeParams) error {
+		captured = params
+		return nil
+	}
+
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+
+	executionFee := s.expectedMaxFeePerGas(200_000_000)
+	s.EqualValues(executionFee+100, captured.maxFeePerGas.Uint64())
+
+	components, err := s.updater.GetFeeComponents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(components)
+	s.EqualValues(executionFee, components.ExecutionFeePerGas.Uint64())
+	s.EqualValues(1_000, components.BlobBaseFee.Uint64())
+	s.EqualValues(100, components.RawDataCostPerGas.Uint64())
+	s.EqualValues(100, components.DataCostPerGas.Uint64())
+	s.EqualValues(1, components.WindowBatchCount)
+	s.EqualValues(2, components.WindowBlobCount)
+	s.EqualValues(2_621_440, components.WindowGasUsed)
+}
+
+func (s *UpdaterTestSuite) TestNoDataCostWithoutCommittedBatches() {
+	s.configureFeeData(200_000_000)
+	s.rpcClientMock.GetDebugBlockFunc = func(
+		context.Context, types.ShardId, any, bool,
+	) (*jsonrpc.DebugRPCBlock, error) {
+		s.Fail("L1 block info is not expected to be requested")
+		return nil, nil
+	}
+	s.l1ContractMock.SetOracleFeeFunc = func(context.Context, feeParams) error {
+		return nil
+	}
+
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+
+	components, err := s.updater.GetFeeComponents(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(components)
+	s.True(components.DataCostPerGas.IsZero())
+	s.EqualValues(s.expectedMaxFeePerGas(200_000_000), components.MaxFeePerGas.Uint64())
+}
+
+func (s *UpdaterTestSuite) TestDataCostSmoothing() {
+	s.configureFeeData(200_000_000)
+	s.l1ContractMock.SetOracleFeeFunc = func(context.Context, feeParams) error {
+		return nil
+	}
+
+	dataCost := func() uint64 {
+		components, err := s.updater.GetFeeComponents(s.ctx)
+		s.Require().NoError(err)
+		return components.DataCostPerGas.Uint64()
+	}
+
+	// raw data cost is 1000 per gas
+	s.commitBatch(1, 131_072)
+	s.configureBlobBaseFee(1_000)
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+	s.EqualValues(1_000, dataCost(), "first sample is taken as is")
+
+	// raw data cost grows to 1200: 30% weight gives 1060, within the 25% limit
+	s.configureBlobBaseFee(1_200)
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+	s.EqualValues(1_060, dataCost())
+
+	// raw data cost spikes to 10000: the smoothed value is capped at +25%
+	s.configureBlobBaseFee(10_000)
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+	s.EqualValues(1_325, dataCost())
+
+	// raw data cost drops to 0: the smoothed value is capped at -25%
+	s.configureBlobBaseFee(0)
+	s.Require().NoError(s.updater.recalcBaseFee(s.ctx))
+	s.EqualValues(994, dataCost())
+}
+
+func (s *UpdaterTestSuite) TestDataCostWindow() {
+	tracker := NewDataCostTracker(2)
+	tracker.OnBatchCommitted(testaide.NewBlockBatch(1), batches.NewCommitment(nil, make(scTypes.DataProofs, 1)))
+	tracker.OnBatchCommitted(testaide.NewBlockBatch(1), batches.NewCommitment(nil, make(scTypes.DataProofs, 2)))
+	tracker.OnBatchCommitted(testaide.NewBlockBatch(1), batches.NewCommitment(nil, make(scTypes.DataProofs, 4)))
+
+	usage := tracker.usage()
+	s.Equal(2, usage.batchCount)
+	s.EqualValues(6, usage.blobCount, "only the latest batches are expected to be kept")
+}
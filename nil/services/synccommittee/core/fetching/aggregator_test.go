@@ -10,6 +10,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/db"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/constraints"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/reset"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/rollupcontract"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/syncer"
@@ -53,7 +54,8 @@ func (s *AggregatorTestSuite) SetupSuite() {
 	s.Require().NoError(err)
 	clock := clockwork.NewRealClock()
 	s.blockStorage = s.newTestBlockStorage(storage.DefaultBlockStorageConfig())
-	s.taskStorage = storage.NewTaskStorage(s.db, clock, s.metrics, logger)
+	s.taskStorage, err = storage.NewTaskStorage(s.ctx, s.db, clock, s.metrics, logger)
+	s.Require().NoError(err)
 	s.rpcClientMock = &client.ClientMock{}
 
 	batchConstraints := constraints.NewBatchConstraints(time.Minute, 100)
@@ -72,11 +74,11 @@ func (s *AggregatorTestSuite) newTestAggregator(
 	contractWrapperConfig := rollupcontract.WrapperConfig{
 		DisableL1: true,
 	}
-	contractWrapper, err := rollupcontract.NewWrapper(s.ctx, contractWrapperConfig, logger)
+	contractWrapper, err := rollupcontract.NewWrapper(s.ctx, contractWrapperConfig, nil, clock, logger)
 	s.Require().NoError(err)
 
 	committer := batches.NewCommitter(
-		contractWrapper, clock, batches.DefaultCommitConfig(), s.metrics, logger,
+		da.NewBlobBackend(contractWrapper), clock, batches.DefaultCommitConfig(), s.metrics, logger,
 	)
 
 	fetcher := NewFetcher(s.rpcClientMock, logger)
@@ -298,7 +300,7 @@ func (s *AggregatorTestSuite) Test_Latest_Fetched_Does_Not_Exist_On_Chain() {
 // requireNoNewTasks asserts that there are no new tasks available for execution
 func (s *AggregatorTestSuite) requireNoNewTasks() {
 	s.T().Helper()
-	task, err := s.taskStorage.RequestTaskToExecute(s.ctx, scTypes.NewRandomExecutorId())
+	task, err := s.taskStorage.RequestTaskToExecute(s.ctx, scTypes.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().Nil(task, "expected no new tasks available for execution, but got one")
 }
@@ -323,7 +325,7 @@ func (s *AggregatorTestSuite) requireBatchHandled(batch *scTypes.BlockBatch) {
 	}
 
 	// one ProofBatch task created
-	taskToExecute, err := s.taskStorage.RequestTaskToExecute(s.ctx, scTypes.NewRandomExecutorId())
+	taskToExecute, err := s.taskStorage.RequestTaskToExecute(s.ctx, scTypes.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(taskToExecute)
 	s.Require().Equal(scTypes.ProofBatch, taskToExecute.TaskType)
@@ -2,12 +2,14 @@ package fetching
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"slices"
 
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/config"
 	coreTypes "github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/NilFoundation/nil/nil/services/rpc/jsonrpc"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
@@ -25,6 +27,9 @@ type RpcClient interface {
 		batchSize int,
 	) ([]*jsonrpc.RPCBlock, error)
 	GetShardIdList(ctx context.Context) ([]coreTypes.ShardId, error)
+	GetDebugBlock(
+		ctx context.Context, shardId coreTypes.ShardId, blockId any, fullTx bool,
+	) (*jsonrpc.DebugRPCBlock, error)
 }
 
 type Fetcher struct {
@@ -88,6 +93,22 @@ func (f *Fetcher) GetLatestBlock(
 	return f.getBlockByStrId(ctx, shardId, "latest")
 }
 
+// GetLatestL1BlockInfo returns the L1 block info recorded in the config of the latest main shard block
+func (f *Fetcher) GetLatestL1BlockInfo(ctx context.Context) (*config.ParamL1BlockInfo, error) {
+	// chain config is returned only along with the full block data
+	block, err := f.rpcClient.GetDebugBlock(ctx, coreTypes.MainShardId, "latest", true)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching latest main shard block: %w", err)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("%w: latest main shard block not found", types.ErrBlockNotFound)
+	}
+	if block.Config == nil || block.Config.L1BlockInfo == nil {
+		return nil, errors.New("L1 block info is missing in the latest main shard block config")
+	}
+	return block.Config.L1BlockInfo, nil
+}
+
 func (f *Fetcher) getBlockByStrId(
 	ctx context.Context,
 	shardId coreTypes.ShardId,
@@ -170,3 +191,30 @@ func (f *Fetcher) GetShardIdList(ctx context.Context) ([]coreTypes.ShardId, erro
 	slices.Sort(shardIds)
 	return shardIds, nil
 }
+
+// FetchSegments fetches blocks with the given hashes and assembles them into chain segments.
+// All the blocks are expected to exist on L2.
+func (f *Fetcher) FetchSegments(
+	ctx context.Context,
+	hashes map[coreTypes.ShardId][]common.Hash,
+) (types.ChainSegments, error) {
+	blocks := make(map[coreTypes.ShardId][]*types.Block, len(hashes))
+	for shardId, shardHashes := range hashes {
+		shardBlocks := make([]*types.Block, 0, len(shardHashes))
+		for _, hash := range shardHashes {
+			block, err := f.TryGetBlockByHash(ctx, shardId, hash)
+			if err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, fmt.Errorf(
+					"%w: block not found in chain, shardId=%d, hash=%s", types.ErrBlockNotFound, shardId, hash,
+				)
+			}
+			shardBlocks = append(shardBlocks, block)
+		}
+		blocks[shardId] = shardBlocks
+	}
+
+	return types.NewChainSegments(blocks)
+}
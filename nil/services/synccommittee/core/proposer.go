@@ -140,7 +140,7 @@ func (p *proposer) updateState(
 
 	updateStateData := scTypes.NewUpdateStateData(
 		proposalData,
-		[]byte{0x0A, 0x0B, 0x0C}, // TODO place valid proof
+		scTypes.PlaceholderValidityProof,
 		bridgeData.L2toL1Root,
 		bridgeData.L1MessageHash,
 		bridgeData.DepositNonce,
@@ -76,7 +76,11 @@ func (s *ProposerTestSuite) SetupSuite() {
 		SuggestGasPriceFunc: func(ctx context.Context) (*big.Int, error) { return big.NewInt(123), nil },
 		HeaderByNumberFunc: func(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
 			excessBlobGas := uint64(123)
-			return &ethtypes.Header{BaseFee: big.NewInt(123), ExcessBlobGas: &excessBlobGas}, nil
+			return &ethtypes.Header{
+				Number:        big.NewInt(100),
+				BaseFee:       big.NewInt(123),
+				ExcessBlobGas: &excessBlobGas,
+			}, nil
 		},
 		PendingCodeAtFunc: func(ctx context.Context, account ethcommon.Address) ([]byte, error) {
 			return []byte{123}, nil
@@ -88,7 +92,7 @@ func (s *ProposerTestSuite) SetupSuite() {
 			return []byte{123}, nil
 		},
 		TransactionReceiptFunc: func(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
-			return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil
+			return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
 		},
 		FilterLogsFunc: func(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
 			return []ethtypes.Log{{
@@ -108,7 +112,7 @@ func (s *ProposerTestSuite) SetupSuite() {
 		},
 	}
 	contractWrapper, err := rollupcontract.NewWrapperWithEthClient(
-		s.ctx, rollupcontract.NewDefaultWrapperConfig(), s.ethClient, logger,
+		s.ctx, rollupcontract.NewDefaultWrapperConfig(), s.ethClient, nil, clockwork.NewRealClock(), logger,
 	)
 	s.Require().NoError(err)
 
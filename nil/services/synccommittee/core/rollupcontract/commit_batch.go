@@ -9,6 +9,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/rollup"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethparams "github.com/ethereum/go-ethereum/params"
@@ -17,58 +18,134 @@ import (
 
 // CommitBatch creates blob transaction for `CommitBatch` contract method and sends it on chain.
 // If such `batchIndex` is already submitted, returns `nil, ErrBatchAlreadyCommitted`.
+// The transaction is handed over to the txSubmitter, which re-prices it until it is included;
+// if the previous call has left a transaction for the same batch in flight, it is resumed.
 func (r *wrapperImpl) CommitBatch(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error {
-	batchIdStr := batchId.String()
-	// go-ethereum states not all RPC nodes support EVM errors parsing
-	// explicitly check possible error in advance
-	isCommited, err := r.rollupContract.IsBatchCommitted(r.getEthCallOpts(ctx), batchIdStr)
+	return r.submitCommitBatch(ctx, batchId, func(ctx context.Context) (*ethtypes.Transaction, error) {
+		return r.buildCommitBatchTx(ctx, sidecar, batchId.String())
+	})
+}
+
+// CommitBatchWithVersionedHashes commits the batch whose data is published off-chain (in the local DA store),
+// the versioned hashes of the blob commitments are passed explicitly.
+// The rollup contract has to be in the external DA mode. Behaves the same way as CommitBatch otherwise.
+func (r *wrapperImpl) CommitBatchWithVersionedHashes(
+	ctx context.Context,
+	batchId types.BatchId,
+	versionedHashes []ethcommon.Hash,
+) error {
+	hashes, err := toContractHashes(versionedHashes)
 	if err != nil {
 		return err
 	}
-	if isCommited {
-		return ErrBatchAlreadyCommitted
-	}
 
-	blobTx, err := r.createBlobTx(ctx, sidecar, r.senderAddress, batchIdStr)
+	return r.submitCommitBatch(ctx, batchId, func(ctx context.Context) (*ethtypes.Transaction, error) {
+		return r.buildContractTx(ctx, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			return r.rollupContract.CommitBatchWithVersionedHashes(opts, batchId.String(), hashes)
+		})
+	})
+}
+
+// CommitBatchWithCalldata commits the batch whose data is posted by PostBatchData in partCount parts,
+// the contract binds the batch to the hashes of the posted parts.
+// The rollup contract has to be in the calldata DA mode. Behaves the same way as CommitBatch otherwise.
+func (r *wrapperImpl) CommitBatchWithCalldata(
+	ctx context.Context,
+	batchId types.BatchId,
+	versionedHashes []ethcommon.Hash,
+	partCount int,
+) error {
+	hashes, err := toContractHashes(versionedHashes)
 	if err != nil {
 		return err
 	}
+	if partCount <= 0 {
+		return errors.New("can't commit batch without data parts")
+	}
 
-	signedTx, err := r.signTx(blobTx)
-	if err != nil {
-		return err
+	return r.submitCommitBatch(ctx, batchId, func(ctx context.Context) (*ethtypes.Transaction, error) {
+		return r.buildContractTx(ctx, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			count := big.NewInt(int64(partCount))
+			return r.rollupContract.CommitBatchWithCalldata(opts, batchId.String(), hashes, count)
+		})
+	})
+}
+
+func toContractHashes(versionedHashes []ethcommon.Hash) ([][32]byte, error) {
+	if len(versionedHashes) == 0 {
+		return nil, errors.New("can't commit batch without versioned hashes")
 	}
 
-	err = r.simulateTx(ctx, signedTx, nil)
-	if err != nil {
-		return r.errorByName(fmt.Errorf("pre-submition simulation: %w", err))
+	hashes := make([][32]byte, len(versionedHashes))
+	for i, hash := range versionedHashes {
+		hashes[i] = hash
 	}
+	return hashes, nil
+}
 
-	err = r.ethClient.SendTransaction(ctx, signedTx)
-	if err != nil {
-		return fmt.Errorf("SendTransaction: %w", err)
+// buildContractTx builds a signed transaction calling the rollup contract method, it is not sent.
+func (r *wrapperImpl) buildContractTx(
+	ctx context.Context,
+	call func(opts *bind.TransactOpts) (*ethtypes.Transaction, error),
+) (*ethtypes.Transaction, error) {
+	var tx *ethtypes.Transaction
+	if err := r.buildTxWithCtx(ctx, func(opts *bind.TransactOpts) error {
+		var err error
+		tx, err = call(opts)
+		return err
+	}); err != nil {
+		return nil, err
 	}
+	return tx, nil
+}
 
-	r.logger.Info().
-		Hex("txHash", signedTx.Hash().Bytes()).
-		Int("gasLimit", int(signedTx.Gas())).
-		Int("blobGasLimit", int(signedTx.BlobGas())).
-		Int("cost", int(signedTx.Cost().Uint64())).
-		Any("blobHashes", signedTx.BlobHashes()).
-		Int("blobCount", len(sidecar.Blobs)).
-		Msg("commit transaction sent")
+func (r *wrapperImpl) submitCommitBatch(
+	ctx context.Context,
+	batchId types.BatchId,
+	build func(ctx context.Context) (*ethtypes.Transaction, error),
+) error {
+	batchIdStr := batchId.String()
+	key := "commitBatch:" + batchIdStr
+
+	isBatchCommitted := func(ctx context.Context, _ uint64) (bool, error) {
+		return r.rollupContract.IsBatchCommitted(r.getEthCallOpts(ctx), batchIdStr)
+	}
 
-	receipt, err := r.waitForReceipt(ctx, signedTx.Hash())
+	if !r.submitter.IsInFlight(key) {
+		// go-ethereum states not all RPC nodes support EVM errors parsing
+		// explicitly check possible error in advance
+		isCommited, err := r.rollupContract.IsBatchCommitted(r.getEthCallOpts(ctx), batchIdStr)
+		if err != nil {
+			return err
+		}
+		if isCommited {
+			return ErrBatchAlreadyCommitted
+		}
+	}
+
+	receipt, err := r.submitter.Submit(ctx, &submission{
+		key:    key,
+		build:  build,
+		isDone: isBatchCommitted,
+	})
 	if err != nil {
 		return err
 	}
+	if receipt == nil {
+		r.logger.Info().Str("batchId", batchIdStr).Msg("batch is found to be committed while waiting for receipt")
+		return nil
+	}
 
 	r.logReceiptDetails(receipt)
 	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
 		// Re-simulate the transaction on top of the block it originally failed in.
 		// Note: The execution order of transactions in the block is not preserved during simulation,
 		// so results may differ — but we attempt to identify the cause of failure anyway.
-		err = r.simulateTx(ctx, signedTx, receipt.BlockNumber)
+		tx, _, err := r.ethClient.TransactionByHash(ctx, receipt.TxHash)
+		if err != nil {
+			return fmt.Errorf("CommitBatch tx failed, can't fetch it to identify the reason: %w", err)
+		}
+		err = r.simulateTx(ctx, tx, receipt.BlockNumber)
 		if err != nil {
 			return r.errorByName(fmt.Errorf("post-submition simulation: %w", err))
 		}
@@ -78,6 +155,29 @@ func (r *wrapperImpl) CommitBatch(ctx context.Context, batchId types.BatchId, si
 	return nil
 }
 
+func (r *wrapperImpl) buildCommitBatchTx(
+	ctx context.Context,
+	sidecar *ethtypes.BlobTxSidecar,
+	batchIdStr string,
+) (*ethtypes.Transaction, error) {
+	blobTx, err := r.createBlobTx(ctx, sidecar, r.senderAddress, batchIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := r.signTx(blobTx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.simulateTx(ctx, signedTx, nil)
+	if err != nil {
+		return nil, r.errorByName(fmt.Errorf("pre-submition simulation: %w", err))
+	}
+
+	return signedTx, nil
+}
+
 func (r *wrapperImpl) VerifyDataProofs(ctx context.Context, commitment *batches.Commitment) error {
 	blobHashes := commitment.Sidecar.BlobHashes()
 
@@ -107,19 +207,36 @@ func (r *wrapperImpl) computeTxParams(ctx context.Context, from ethcommon.Addres
 	if blobCount == 0 {
 		return nil, errors.New("can't create blob tx params for 0 blobs")
 	}
+
+	params, head, err := r.computeDynamicFeeTxParams(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	params.BlobFeeCap = rollup.CalcBlobFee(*head.ExcessBlobGas)
+	params.Gas = ethparams.BlobTxBlobGasPerBlob * uint64(blobCount)
+	return params, nil
+}
+
+// computeDynamicFeeTxParams fetches the nonce and computes the fee caps, leaving the gas limit unset.
+// The header used to compute the fees is returned as well.
+func (r *wrapperImpl) computeDynamicFeeTxParams(
+	ctx context.Context,
+	from ethcommon.Address,
+) (*txParams, *ethtypes.Header, error) {
 	nonce, err := r.ethClient.PendingNonceAt(ctx, from)
 	if err != nil {
-		return nil, fmt.Errorf("getting nonce: %w", err)
+		return nil, nil, fmt.Errorf("getting nonce: %w", err)
 	}
 
 	gasTipCap, err := r.ethClient.SuggestGasTipCap(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("suggesting gas tip cap: %w", err)
+		return nil, nil, fmt.Errorf("suggesting gas tip cap: %w", err)
 	}
 
 	head, err := r.ethClient.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("getting header: %w", err)
+		return nil, nil, fmt.Errorf("getting header: %w", err)
 	}
 
 	const baseFeeWiggleMultiplier = 2
@@ -129,19 +246,14 @@ func (r *wrapperImpl) computeTxParams(ctx context.Context, from ethcommon.Addres
 	)
 
 	if gasFeeCap.Cmp(gasTipCap) < 0 {
-		return nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", gasFeeCap, gasTipCap)
+		return nil, nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", gasFeeCap, gasTipCap)
 	}
 
-	blobFee := rollup.CalcBlobFee(*head.ExcessBlobGas)
-	gas := ethparams.BlobTxBlobGasPerBlob * uint64(blobCount)
-
 	return &txParams{
-		Nonce:      nonce,
-		GasTipCap:  gasTipCap,
-		GasFeeCap:  gasFeeCap,
-		BlobFeeCap: blobFee,
-		Gas:        gas,
-	}, nil
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	}, head, nil
 }
 
 // createBlobTx creates a new blob transaction using the computed blob data and transaction parameters
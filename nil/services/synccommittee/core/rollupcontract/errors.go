@@ -24,4 +24,7 @@ var (
 	ErrInvalidPublicInputForProof                = errors.New("invalid public input for proof")
 	ErrCallPointEvaluationPrecompileFailed       = errors.New("call point evaluation precompile failed")
 	ErrUnexpectedPointEvaluationPrecompileOutput = errors.New("unexpected point evaluation precompile output")
+	ErrUnexpectedDataAvailabilityMode            = errors.New("unexpected data availability mode")
+	ErrEmptyBatchData                            = errors.New("empty batch data")
+	ErrBatchDataPartNotPosted                    = errors.New("batch data part is not posted")
 )
@@ -0,0 +1,74 @@
+package rollupcontract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PostBatchData sends a part of the batch data to the `postBatchData` contract method,
+// which stores its hash to bind the batch to the data when it is committed by CommitBatchWithCalldata.
+// It is used on L1 chains without blob support, the rollup contract has to be in the calldata DA mode.
+// Parts of the same batch are distinguished by `part` so that each of them could be resumed independently.
+func (r *wrapperImpl) PostBatchData(ctx context.Context, batchId types.BatchId, part int, data []byte) error {
+	if len(data) == 0 {
+		return errors.New("can't post empty batch data")
+	}
+
+	batchIdStr := batchId.String()
+	key := fmt.Sprintf("postBatchData:%s:%d", batchIdStr, part)
+	dataHash := crypto.Keccak256Hash(data)
+
+	isPosted := func(ctx context.Context, _ uint64) (bool, error) {
+		partIndex := big.NewInt(int64(part))
+		postedHash, err := r.rollupContract.BatchDataPartHashes(r.getEthCallOpts(ctx), batchIdStr, partIndex)
+		if err != nil {
+			return false, err
+		}
+		return postedHash == dataHash, nil
+	}
+
+	if !r.submitter.IsInFlight(key) {
+		// the part could have been posted before the restart
+		posted, err := isPosted(ctx, 0)
+		if err != nil {
+			return err
+		}
+		if posted {
+			return nil
+		}
+	}
+
+	receipt, err := r.submitter.Submit(ctx, &submission{
+		key: key,
+		build: func(ctx context.Context) (*ethtypes.Transaction, error) {
+			return r.buildContractTx(ctx, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+				return r.rollupContract.PostBatchData(opts, batchIdStr, big.NewInt(int64(part)), data)
+			})
+		},
+		isDone: isPosted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to post batch data, batchId=%s, part=%d: %w", batchId, part, err)
+	}
+	if receipt == nil {
+		r.logger.Info().
+			Stringer(logging.FieldBatchId, batchId).
+			Int("part", part).
+			Msg("batch data is found to be posted while waiting for receipt")
+		return nil
+	}
+
+	r.logReceiptDetails(receipt)
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("batch data tx failed, batchId=%s, part=%d", batchId, part)
+	}
+	return nil
+}
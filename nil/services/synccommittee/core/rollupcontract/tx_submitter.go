@@ -0,0 +1,668 @@
+package rollupcontract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/rollup"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/l1client"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/jonboulle/clockwork"
+)
+
+var (
+	ErrSubmissionTimeout = errors.New("transaction was not confirmed within the submission timeout")
+	ErrNonceInUse        = errors.New("nonce is already used by another in-flight transaction")
+)
+
+type TxSubmitterConfig struct {
+	// PollInterval is the delay between checks of the in-flight transaction receipt
+	PollInterval time.Duration `yaml:"l1PollInterval,omitempty"`
+
+	// ResubmitInterval is the time after which a not yet included transaction is re-priced and replaced
+	ResubmitInterval time.Duration `yaml:"l1ResubmitInterval,omitempty"`
+
+	// SubmissionTimeout limits a single Submit call, the transaction stays tracked and is resumed by the next call
+	SubmissionTimeout time.Duration `yaml:"l1SubmissionTimeout,omitempty"`
+
+	// FeeBumpPercent is the minimal fee increase of a replacement transaction
+	FeeBumpPercent uint64 `yaml:"l1FeeBumpPercent,omitempty"`
+
+	// BlobFeeBumpPercent is the minimal fee increase of a replacement blob transaction,
+	// L1 nodes require all the fee caps of such transactions to be doubled
+	BlobFeeBumpPercent uint64 `yaml:"l1BlobFeeBumpPercent,omitempty"`
+
+	// MaxGasFeeCapGwei and MaxBlobFeeCapGwei limit the fees of replacement transactions, zero means no limit
+	MaxGasFeeCapGwei  uint64 `yaml:"l1MaxGasFeeCapGwei,omitempty"`
+	MaxBlobFeeCapGwei uint64 `yaml:"l1MaxBlobFeeCapGwei,omitempty"`
+
+	// ConfirmationDepth is the number of blocks on top of the inclusion block required to consider it final
+	ConfirmationDepth uint64 `yaml:"l1ConfirmationDepth,omitempty"`
+}
+
+func DefaultTxSubmitterConfig() TxSubmitterConfig {
+	return TxSubmitterConfig{
+		PollInterval:       2 * time.Second,
+		ResubmitInterval:   time.Minute,
+		SubmissionTimeout:  10 * time.Minute,
+		FeeBumpPercent:     10,
+		BlobFeeBumpPercent: 100,
+		ConfirmationDepth:  3,
+	}
+}
+
+// submission describes a transaction handed over to the txSubmitter.
+type submission struct {
+	// key identifies the operation, e.g. commit of a specific batch
+	key string
+
+	// build creates and signs a new transaction with a fresh nonce and fees
+	build func(ctx context.Context) (*ethtypes.Transaction, error)
+
+	// isDone checks the L1 state to find out whether the operation is already applied,
+	// e.g. by another version of the transaction with the given nonce or by the previous run of the service
+	isDone func(ctx context.Context, nonce uint64) (bool, error)
+}
+
+// InFlightTxStorage persists the state of the transactions tracked by the txSubmitter,
+// so that they are resumed instead of being sent again after the service restart.
+type InFlightTxStorage interface {
+	GetInFlightTxs(ctx context.Context) (map[uint64][]byte, error)
+	PutInFlightTx(ctx context.Context, nonce uint64, state []byte) error
+	DeleteInFlightTx(ctx context.Context, nonce uint64) error
+}
+
+// inFlightTx tracks all the versions of a transaction sent with the same nonce.
+// Its fields are guarded by txSubmitter.mutex, since the same entry can be resumed by concurrent Submit calls.
+type inFlightTx struct {
+	key   string
+	nonce uint64
+
+	// sent holds every version of the transaction accepted by the L1 node, the last one is the most expensive
+	sent []*ethtypes.Transaction
+
+	// lastAttempt is the last built version, it is ahead of sent if the node rejected it as underpriced
+	lastAttempt *ethtypes.Transaction
+	lastSentAt  time.Time
+
+	// included is set once a receipt of any version is found, it is used to detect L1 reorgs
+	included bool
+}
+
+func (t *inFlightTx) latest() *ethtypes.Transaction {
+	return t.sent[len(t.sent)-1]
+}
+
+// inFlightTxState is the persisted form of inFlightTx, transactions are kept in their binary encoding
+// along with the blob sidecars, so that they can be re-broadcast and re-priced after restart.
+type inFlightTxState struct {
+	Key         string          `json:"key"`
+	Sent        []hexutil.Bytes `json:"sent"`
+	LastAttempt hexutil.Bytes   `json:"lastAttempt"`
+	LastSentAt  time.Time       `json:"lastSentAt"`
+	Included    bool            `json:"included"`
+}
+
+func (t *inFlightTx) marshal() ([]byte, error) {
+	state := inFlightTxState{
+		Key:        t.key,
+		Sent:       make([]hexutil.Bytes, 0, len(t.sent)),
+		LastSentAt: t.lastSentAt,
+		Included:   t.included,
+	}
+	for _, tx := range t.sent {
+		encoded, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		state.Sent = append(state.Sent, encoded)
+	}
+	lastAttempt, err := t.lastAttempt.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	state.LastAttempt = lastAttempt
+	return json.Marshal(state)
+}
+
+func unmarshalInFlightTx(nonce uint64, data []byte) (*inFlightTx, error) {
+	var state inFlightTxState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if len(state.Sent) == 0 {
+		return nil, errors.New("no sent transactions")
+	}
+
+	decode := func(encoded []byte) (*ethtypes.Transaction, error) {
+		tx := new(ethtypes.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return nil, err
+		}
+		if tx.Nonce() != nonce {
+			return nil, fmt.Errorf("transaction nonce %d doesn't match the key %d", tx.Nonce(), nonce)
+		}
+		return tx, nil
+	}
+
+	tracked := &inFlightTx{
+		key:        state.Key,
+		nonce:      nonce,
+		lastSentAt: state.LastSentAt,
+		included:   state.Included,
+	}
+	for _, encoded := range state.Sent {
+		tx, err := decode(encoded)
+		if err != nil {
+			return nil, err
+		}
+		tracked.sent = append(tracked.sent, tx)
+	}
+	lastAttempt, err := decode(state.LastAttempt)
+	if err != nil {
+		return nil, err
+	}
+	tracked.lastAttempt = lastAttempt
+	return tracked, nil
+}
+
+// txSubmitter sends transactions to L1 and keeps them alive until they are included and confirmed.
+// Transactions which are not included within ResubmitInterval are replaced by re-priced versions
+// with the same nonce, transactions dropped by L1 reorgs are re-broadcast.
+type txSubmitter struct {
+	ethClient l1client.EthClient
+	signTx    func(*ethtypes.Transaction) (*ethtypes.Transaction, error)
+	config    TxSubmitterConfig
+	storage   InFlightTxStorage
+	clock     clockwork.Clock
+	logger    logging.Logger
+
+	// sendMutex serializes building and sending of new transactions, so that they don't get the same nonce
+	sendMutex sync.Mutex
+
+	// mutex guards inFlight and the fields of its entries
+	mutex    sync.Mutex
+	inFlight map[uint64]*inFlightTx
+}
+
+// newTxSubmitter creates a txSubmitter resuming the transactions persisted in the storage.
+// If the storage is nil, in-flight transactions are kept in memory only.
+func newTxSubmitter(
+	ctx context.Context,
+	ethClient l1client.EthClient,
+	signTx func(*ethtypes.Transaction) (*ethtypes.Transaction, error),
+	config TxSubmitterConfig,
+	storage InFlightTxStorage,
+	clock clockwork.Clock,
+	logger logging.Logger,
+) (*txSubmitter, error) {
+	s := &txSubmitter{
+		ethClient: ethClient,
+		signTx:    signTx,
+		config:    config,
+		storage:   storage,
+		clock:     clock,
+		logger:    logger,
+		inFlight:  make(map[uint64]*inFlightTx),
+	}
+
+	if storage == nil {
+		return s, nil
+	}
+
+	states, err := storage.GetInFlightTxs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-flight transactions: %w", err)
+	}
+	for nonce, state := range states {
+		tracked, err := unmarshalInFlightTx(nonce, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode in-flight transaction with nonce %d: %w", nonce, err)
+		}
+		s.inFlight[nonce] = tracked
+		s.logger.Info().
+			Str("key", tracked.key).
+			Uint64("nonce", nonce).
+			Msg("loaded in-flight transaction")
+	}
+	return s, nil
+}
+
+// IsInFlight checks whether a transaction of the operation with the given key is sent and not yet confirmed.
+func (s *txSubmitter) IsInFlight(key string) bool {
+	return s.findByKey(key) != nil
+}
+
+// Submit sends the transaction of the submission and waits until it is included and confirmed.
+// If a transaction with the same key is already in flight, it is resumed instead of sending a new one.
+// Returns nil receipt if the operation was found to be applied without any of the tracked transactions.
+func (s *txSubmitter) Submit(ctx context.Context, sub *submission) (*ethtypes.Receipt, error) {
+	tracked := s.findByKey(sub.key)
+	if tracked == nil {
+		var err error
+		if tracked, err = s.sendNew(ctx, sub); err != nil {
+			return nil, err
+		}
+	} else {
+		s.logger.Info().
+			Str("key", sub.key).
+			Uint64("nonce", tracked.nonce).
+			Msg("resuming in-flight transaction")
+	}
+
+	return s.waitForConfirmation(ctx, tracked, sub)
+}
+
+func (s *txSubmitter) sendNew(ctx context.Context, sub *submission) (*inFlightTx, error) {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	tx, err := sub.build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &inFlightTx{
+		key:         sub.key,
+		nonce:       tx.Nonce(),
+		sent:        []*ethtypes.Transaction{tx},
+		lastAttempt: tx,
+		lastSentAt:  s.clock.Now(),
+	}
+
+	// The transaction is persisted before sending, so that it is not sent again with another nonce after restart
+	if err := s.track(ctx, tracked); err != nil {
+		return nil, err
+	}
+
+	if err := s.ethClient.SendTransaction(ctx, tx); err != nil {
+		if untrackErr := s.untrack(ctx, tracked); untrackErr != nil {
+			s.logger.Error().Err(untrackErr).Str("key", sub.key).Msg("failed to untrack rejected transaction")
+		}
+		return nil, fmt.Errorf("SendTransaction: %w", err)
+	}
+	s.logSent(sub.key, tx, "transaction sent")
+
+	return tracked, nil
+}
+
+func (s *txSubmitter) waitForConfirmation(
+	ctx context.Context,
+	tracked *inFlightTx,
+	sub *submission,
+) (*ethtypes.Receipt, error) {
+	ticker := s.clock.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+	deadline := s.clock.Now().Add(s.config.SubmissionTimeout)
+
+	for {
+		receipt, err := s.findReceipt(ctx, tracked)
+		if err != nil {
+			return nil, err
+		}
+
+		if receipt != nil {
+			if err := s.update(ctx, tracked, func() { tracked.included = true }); err != nil {
+				return nil, err
+			}
+			confirmed, err := s.isConfirmed(ctx, receipt)
+			if err != nil {
+				return nil, err
+			}
+			if confirmed {
+				return receipt, s.untrack(ctx, tracked)
+			}
+		} else {
+			done, err := s.keepAlive(ctx, tracked, sub)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return nil, s.untrack(ctx, tracked)
+			}
+		}
+
+		if s.clock.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: key=%s, nonce=%d", ErrSubmissionTimeout, tracked.key, tracked.nonce)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// keepAlive handles the transaction without a receipt: the one dropped by a reorg is re-broadcast,
+// the one waiting for inclusion for too long is replaced.
+// Returns true if the operation was found to be already applied.
+func (s *txSubmitter) keepAlive(ctx context.Context, tracked *inFlightTx, sub *submission) (bool, error) {
+	s.mutex.Lock()
+	reorged := tracked.included
+	latest := tracked.latest()
+	sinceLastSent := s.clock.Since(tracked.lastSentAt)
+	s.mutex.Unlock()
+
+	if !reorged && sinceLastSent < s.config.ResubmitInterval {
+		return false, nil
+	}
+
+	done, err := sub.isDone(ctx, tracked.nonce)
+	if err != nil || done {
+		return done, err
+	}
+
+	if reorged {
+		s.logger.Warn().
+			Str("key", tracked.key).
+			Uint64("nonce", tracked.nonce).
+			Msg("transaction receipt is gone after L1 reorg, re-broadcasting it")
+
+		if err := s.ethClient.SendTransaction(ctx, latest); err != nil && !isKnownTxError(err) {
+			return false, fmt.Errorf("failed to re-broadcast transaction: %w", err)
+		}
+		return false, s.update(ctx, tracked, func() {
+			tracked.included = false
+			tracked.lastSentAt = s.clock.Now()
+		})
+	}
+
+	return false, s.replace(ctx, tracked)
+}
+
+func (s *txSubmitter) replace(ctx context.Context, tracked *inFlightTx) error {
+	s.mutex.Lock()
+	lastAttempt := tracked.lastAttempt
+	s.mutex.Unlock()
+
+	replacement, err := s.reprice(ctx, lastAttempt)
+	if err != nil {
+		// Keep waiting for the current version, fees might decrease later
+		s.logger.Warn().Err(err).Str("key", tracked.key).Msg("failed to re-price transaction")
+		return s.update(ctx, tracked, func() { tracked.lastSentAt = s.clock.Now() })
+	}
+
+	err = s.ethClient.SendTransaction(ctx, replacement)
+	switch {
+	case err == nil:
+		s.logSent(tracked.key, replacement, "replacement transaction sent")
+		return s.update(ctx, tracked, func() {
+			tracked.sent = append(tracked.sent, replacement)
+			tracked.lastAttempt = replacement
+			tracked.lastSentAt = s.clock.Now()
+		})
+
+	case isUnderpricedError(err):
+		// Next replacement is bumped on top of the rejected one
+		s.logger.Warn().Err(err).Str("key", tracked.key).Msg("replacement transaction is underpriced")
+		return s.update(ctx, tracked, func() {
+			tracked.lastAttempt = replacement
+			tracked.lastSentAt = s.clock.Now()
+		})
+
+	case isKnownTxError(err):
+		// One of the versions has already been included, its receipt is found by the next poll
+		return s.update(ctx, tracked, func() {
+			tracked.lastAttempt = replacement
+			tracked.lastSentAt = s.clock.Now()
+		})
+
+	default:
+		return fmt.Errorf("failed to send replacement transaction: %w", err)
+	}
+}
+
+// reprice creates a copy of the transaction with fees bumped at least by the configured percentage
+// and not lower than the current network fees.
+func (s *txSubmitter) reprice(ctx context.Context, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	tipCap, err := s.ethClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("suggesting gas tip cap: %w", err)
+	}
+
+	head, err := s.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	bumpPercent := s.config.FeeBumpPercent
+	if tx.Type() == ethtypes.BlobTxType {
+		bumpPercent = s.config.BlobFeeBumpPercent
+	}
+
+	newTipCap := maxBig(bumpFee(tx.GasTipCap(), bumpPercent), tipCap)
+	newFeeCap := maxBig(
+		bumpFee(tx.GasFeeCap(), bumpPercent),
+		new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2))),
+	)
+	if err := checkFeeLimit("gas fee cap", newFeeCap, s.config.MaxGasFeeCapGwei); err != nil {
+		return nil, err
+	}
+
+	var replacement *ethtypes.Transaction
+	switch tx.Type() {
+	case ethtypes.BlobTxType:
+		newBlobFeeCap := bumpFee(tx.BlobGasFeeCap(), bumpPercent)
+		if head.ExcessBlobGas != nil {
+			newBlobFeeCap = maxBig(newBlobFeeCap, rollup.CalcBlobFee(*head.ExcessBlobGas))
+		}
+		if err := checkFeeLimit("blob fee cap", newBlobFeeCap, s.config.MaxBlobFeeCapGwei); err != nil {
+			return nil, err
+		}
+
+		replacement = ethtypes.NewTx(&ethtypes.BlobTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(newTipCap),
+			GasFeeCap:  uint256.MustFromBig(newFeeCap),
+			Gas:        tx.Gas(),
+			To:         *tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			BlobFeeCap: uint256.MustFromBig(newBlobFeeCap),
+			BlobHashes: tx.BlobHashes(),
+			Sidecar:    tx.BlobTxSidecar(),
+		})
+
+	case ethtypes.DynamicFeeTxType:
+		replacement = ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  newTipCap,
+			GasFeeCap:  newFeeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+
+	default:
+		return nil, fmt.Errorf("re-pricing of transactions of type %d is not supported", tx.Type())
+	}
+
+	return s.signTx(replacement)
+}
+
+// findReceipt looks for a receipt of any of the sent transaction versions, starting from the latest one.
+func (s *txSubmitter) findReceipt(ctx context.Context, tracked *inFlightTx) (*ethtypes.Receipt, error) {
+	s.mutex.Lock()
+	sent := tracked.sent
+	s.mutex.Unlock()
+
+	for i := len(sent) - 1; i >= 0; i-- {
+		receipt, err := s.ethClient.TransactionReceipt(ctx, sent[i].Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting transaction receipt: %w", err)
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *txSubmitter) isConfirmed(ctx context.Context, receipt *ethtypes.Receipt) (bool, error) {
+	if s.config.ConfirmationDepth == 0 {
+		return true, nil
+	}
+
+	head, err := s.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("getting header: %w", err)
+	}
+
+	confirmedAt := new(big.Int).Add(receipt.BlockNumber, new(big.Int).SetUint64(s.config.ConfirmationDepth))
+	return head.Number.Cmp(confirmedAt) >= 0, nil
+}
+
+func (s *txSubmitter) findByKey(key string) *inFlightTx {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, tracked := range s.inFlight {
+		if tracked.key == key {
+			return tracked
+		}
+	}
+	return nil
+}
+
+// track registers the new transaction, unless its nonce is already used by another tracked one.
+func (s *txSubmitter) track(ctx context.Context, tracked *inFlightTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if other, ok := s.inFlight[tracked.nonce]; ok {
+		return fmt.Errorf("%w: nonce=%d, key=%s", ErrNonceInUse, tracked.nonce, other.key)
+	}
+	if err := s.persistLocked(ctx, tracked); err != nil {
+		return err
+	}
+	s.inFlight[tracked.nonce] = tracked
+	return nil
+}
+
+// update applies the change to the tracked transaction and persists it.
+func (s *txSubmitter) update(ctx context.Context, tracked *inFlightTx, change func()) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	change()
+	return s.persistLocked(ctx, tracked)
+}
+
+func (s *txSubmitter) persistLocked(ctx context.Context, tracked *inFlightTx) error {
+	if s.storage == nil {
+		return nil
+	}
+	state, err := tracked.marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode in-flight transaction with nonce %d: %w", tracked.nonce, err)
+	}
+	if err := s.storage.PutInFlightTx(ctx, tracked.nonce, state); err != nil {
+		return fmt.Errorf("failed to persist in-flight transaction with nonce %d: %w", tracked.nonce, err)
+	}
+	return nil
+}
+
+func (s *txSubmitter) untrack(ctx context.Context, tracked *inFlightTx) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.inFlight, tracked.nonce)
+	if s.storage == nil {
+		return nil
+	}
+	if err := s.storage.DeleteInFlightTx(ctx, tracked.nonce); err != nil {
+		return fmt.Errorf("failed to delete in-flight transaction with nonce %d: %w", tracked.nonce, err)
+	}
+	return nil
+}
+
+func (s *txSubmitter) logSent(key string, tx *ethtypes.Transaction, msg string) {
+	s.logger.Info().
+		Str("key", key).
+		Hex("txHash", tx.Hash().Bytes()).
+		Uint64("nonce", tx.Nonce()).
+		Uint64("gasLimit", tx.Gas()).
+		Stringer("gasTipCap", tx.GasTipCap()).
+		Stringer("gasFeeCap", tx.GasFeeCap()).
+		Stringer("blobFeeCap", tx.BlobGasFeeCap()).
+		Int("blobCount", len(tx.BlobHashes())).
+		Msg(msg)
+}
+
+// isUnderpricedError checks whether the L1 node rejected the replacement because of insufficient fee bump.
+func isUnderpricedError(err error) bool {
+	return isTxPoolError(err, txpool.ErrReplaceUnderpriced, txpool.ErrUnderpriced)
+}
+
+// isKnownTxError checks whether the transaction is already in the pool or its nonce has already been used.
+func isKnownTxError(err error) bool {
+	return isTxPoolError(err, txpool.ErrAlreadyKnown, core.ErrNonceTooLow)
+}
+
+// isTxPoolError checks whether the error is one of the go-ethereum txpool errors.
+// Error types are lost on the RPC boundary and only the message of the wrapped txpool error is kept,
+// so the RPC errors are matched by the message of the target.
+func isTxPoolError(err error, targets ...error) bool {
+	var rpcErr rpc.Error
+	isRpcErr := errors.As(err, &rpcErr)
+
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+		if isRpcErr && strings.HasPrefix(rpcErr.Error(), target.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Div(bumped, big.NewInt(100))
+	// Integer division could make the bump of a tiny fee zero
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+func checkFeeLimit(name string, fee *big.Int, limitGwei uint64) error {
+	if limitGwei == 0 {
+		return nil
+	}
+	limit := new(big.Int).Mul(new(big.Int).SetUint64(limitGwei), big.NewInt(1_000_000_000))
+	if fee.Cmp(limit) > 0 {
+		return fmt.Errorf("%s %s exceeds the limit of %d gwei", name, fee, limitGwei)
+	}
+	return nil
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
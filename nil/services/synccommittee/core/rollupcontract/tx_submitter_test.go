@@ -0,0 +1,384 @@
+package rollupcontract
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/l1client"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/storage"
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/suite"
+)
+
+type TxSubmitterTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	signer ethtypes.Signer
+
+	mutex    sync.Mutex
+	sent     []*ethtypes.Transaction
+	receipts map[ethcommon.Hash]*ethtypes.Receipt
+	sendErrs []error
+	head     uint64
+
+	ethClient *l1client.EthClientMock
+	database  db.DB
+	txStorage *storage.L1TxStorage
+	submitter *txSubmitter
+}
+
+func TestTxSubmitterSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TxSubmitterTestSuite))
+}
+
+func (s *TxSubmitterTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.signer = ethtypes.NewCancunSigner(big.NewInt(1))
+}
+
+func (s *TxSubmitterTestSuite) SetupTest() {
+	s.sent = nil
+	s.receipts = make(map[ethcommon.Hash]*ethtypes.Receipt)
+	s.sendErrs = nil
+	s.head = 100
+
+	s.ethClient = &l1client.EthClientMock{
+		SuggestGasTipCapFunc: func(ctx context.Context) (*big.Int, error) { return big.NewInt(100), nil },
+		HeaderByNumberFunc: func(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			excessBlobGas := uint64(0)
+			return &ethtypes.Header{
+				Number:        new(big.Int).SetUint64(s.head),
+				BaseFee:       big.NewInt(100),
+				ExcessBlobGas: &excessBlobGas,
+			}, nil
+		},
+		SendTransactionFunc: func(ctx context.Context, tx *ethtypes.Transaction) error {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if len(s.sendErrs) > 0 {
+				err := s.sendErrs[0]
+				s.sendErrs = s.sendErrs[1:]
+				if err != nil {
+					return err
+				}
+			}
+			s.sent = append(s.sent, tx)
+			return nil
+		},
+		TransactionReceiptFunc: func(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if receipt, ok := s.receipts[txHash]; ok {
+				return receipt, nil
+			}
+			return nil, ethereum.NotFound
+		},
+	}
+
+	var err error
+	s.database, err = db.NewBadgerDbInMemory()
+	s.Require().NoError(err)
+	s.txStorage = storage.NewL1TxStorage(s.database, logging.NewLogger("tx_submitter_test"))
+	s.submitter = s.newSubmitter()
+}
+
+func (s *TxSubmitterTestSuite) TearDownTest() {
+	s.database.Close()
+}
+
+func (s *TxSubmitterTestSuite) newSubmitter() *txSubmitter {
+	submitter, err := newTxSubmitter(
+		s.ctx,
+		s.ethClient,
+		s.signTx,
+		s.testConfig(),
+		s.txStorage,
+		clockwork.NewRealClock(),
+		logging.NewLogger("tx_submitter_test"),
+	)
+	s.Require().NoError(err)
+	return submitter
+}
+
+// rpcError mimics an error returned by the L1 node, which keeps only the message of the original error.
+type rpcError struct {
+	message string
+}
+
+func (e rpcError) Error() string  { return e.message }
+func (e rpcError) ErrorCode() int { return -32000 }
+
+func (s *TxSubmitterTestSuite) testConfig() TxSubmitterConfig {
+	config := DefaultTxSubmitterConfig()
+	config.PollInterval = time.Millisecond
+	config.ResubmitInterval = 10 * time.Millisecond
+	config.SubmissionTimeout = 5 * time.Second
+	config.ConfirmationDepth = 0
+	return config
+}
+
+func (s *TxSubmitterTestSuite) signTx(tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	key, err := crypto.HexToECDSA(DefaultPrivateKey)
+	s.Require().NoError(err)
+	return ethtypes.SignTx(tx, s.signer, key)
+}
+
+func (s *TxSubmitterTestSuite) newBlobTx() *ethtypes.Transaction {
+	tx, err := s.signTx(ethtypes.NewTx(&ethtypes.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      7,
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(300),
+		Gas:        21000,
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(10),
+		BlobHashes: []ethcommon.Hash{{0x01}},
+	}))
+	s.Require().NoError(err)
+	return tx
+}
+
+func (s *TxSubmitterTestSuite) newSubmission(isDone func() bool) (*submission, *int) {
+	buildCount := 0
+	return &submission{
+		key: "test",
+		build: func(context.Context) (*ethtypes.Transaction, error) {
+			buildCount++
+			return s.newBlobTx(), nil
+		},
+		isDone: func(context.Context, uint64) (bool, error) {
+			return isDone(), nil
+		},
+	}, &buildCount
+}
+
+// includeOnSend makes the node include the n-th sent transaction.
+func (s *TxSubmitterTestSuite) includeOnSend(n int) {
+	sendFunc := s.ethClient.SendTransactionFunc
+	s.ethClient.SendTransactionFunc = func(ctx context.Context, tx *ethtypes.Transaction) error {
+		if err := sendFunc(ctx, tx); err != nil {
+			return err
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if len(s.sent) == n {
+			s.receipts[tx.Hash()] = &ethtypes.Receipt{
+				Status:      ethtypes.ReceiptStatusSuccessful,
+				TxHash:      tx.Hash(),
+				BlockNumber: new(big.Int).SetUint64(s.head),
+			}
+		}
+		return nil
+	}
+}
+
+func (s *TxSubmitterTestSuite) Test_Replaces_Stuck_Blob_Tx() {
+	s.includeOnSend(2)
+	sub, buildCount := s.newSubmission(func() bool { return false })
+
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().NotNil(receipt)
+	s.Require().Equal(1, *buildCount)
+
+	s.Require().Len(s.sent, 2)
+	original, replacement := s.sent[0], s.sent[1]
+	s.Require().Equal(original.Nonce(), replacement.Nonce())
+	s.Require().Equal(replacement.Hash(), receipt.TxHash)
+
+	// Blob transaction replacements require all the fee caps to be doubled
+	s.Require().Equal(big.NewInt(200), replacement.GasTipCap())
+	s.Require().Equal(big.NewInt(600), replacement.GasFeeCap())
+	s.Require().Equal(big.NewInt(20), replacement.BlobGasFeeCap())
+	s.Require().False(s.submitter.IsInFlight(sub.key))
+}
+
+func (s *TxSubmitterTestSuite) Test_Bumps_Underpriced_Replacement() {
+	s.sendErrs = []error{nil, txpool.ErrReplaceUnderpriced}
+	s.includeOnSend(2)
+	sub, _ := s.newSubmission(func() bool { return false })
+
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().NotNil(receipt)
+
+	// The rejected version is not tracked, but the next one is bumped on top of it
+	s.Require().Len(s.sent, 2)
+	s.Require().Equal(big.NewInt(400), s.sent[1].GasTipCap())
+	s.Require().Equal(big.NewInt(40), s.sent[1].BlobGasFeeCap())
+}
+
+func (s *TxSubmitterTestSuite) Test_Bumps_Replacement_Rejected_By_Node() {
+	s.sendErrs = []error{nil, rpcError{message: txpool.ErrReplaceUnderpriced.Error()}}
+	s.includeOnSend(2)
+	sub, _ := s.newSubmission(func() bool { return false })
+
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().NotNil(receipt)
+	s.Require().Len(s.sent, 2)
+	s.Require().Equal(big.NewInt(400), s.sent[1].GasTipCap())
+}
+
+func (s *TxSubmitterTestSuite) Test_Fails_On_Unknown_Send_Error() {
+	s.sendErrs = []error{nil, rpcError{message: "insufficient funds for gas * price + value"}}
+	sub, _ := s.newSubmission(func() bool { return false })
+
+	_, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().ErrorContains(err, "failed to send replacement transaction")
+	s.Require().True(s.submitter.IsInFlight(sub.key))
+}
+
+func (s *TxSubmitterTestSuite) Test_Stops_When_Applied_By_Other_Tx() {
+	sub, _ := s.newSubmission(func() bool { return true })
+
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().Nil(receipt)
+	s.Require().Len(s.sent, 1)
+	s.Require().False(s.submitter.IsInFlight(sub.key))
+}
+
+func (s *TxSubmitterTestSuite) Test_Rebroadcasts_Tx_Dropped_By_Reorg() {
+	config := s.testConfig()
+	config.ConfirmationDepth = 2
+	config.ResubmitInterval = time.Hour
+	s.submitter.config = config
+	s.includeOnSend(1)
+
+	// Drop the receipt of the included transaction before it gets enough confirmations
+	receiptFunc := s.ethClient.TransactionReceiptFunc
+	receiptCalls := 0
+	s.ethClient.TransactionReceiptFunc = func(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
+		receiptCalls++
+		s.mutex.Lock()
+		switch receiptCalls {
+		case 2:
+			delete(s.receipts, txHash)
+		case 3:
+			s.receipts[txHash] = &ethtypes.Receipt{
+				Status:      ethtypes.ReceiptStatusSuccessful,
+				TxHash:      txHash,
+				BlockNumber: new(big.Int).SetUint64(s.head),
+			}
+			s.head += config.ConfirmationDepth
+		}
+		s.mutex.Unlock()
+		return receiptFunc(ctx, txHash)
+	}
+
+	sub, _ := s.newSubmission(func() bool { return false })
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().NotNil(receipt)
+
+	s.Require().Len(s.sent, 2)
+	s.Require().Equal(s.sent[0].Hash(), s.sent[1].Hash())
+}
+
+func (s *TxSubmitterTestSuite) Test_Resumes_In_Flight_Tx_After_Timeout() {
+	config := s.testConfig()
+	config.SubmissionTimeout = 20 * time.Millisecond
+	config.ResubmitInterval = time.Hour
+	s.submitter.config = config
+	sub, buildCount := s.newSubmission(func() bool { return false })
+
+	_, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().ErrorIs(err, ErrSubmissionTimeout)
+	s.Require().True(s.submitter.IsInFlight(sub.key))
+
+	s.mutex.Lock()
+	s.receipts[s.sent[0].Hash()] = &ethtypes.Receipt{
+		Status:      ethtypes.ReceiptStatusSuccessful,
+		TxHash:      s.sent[0].Hash(),
+		BlockNumber: new(big.Int).SetUint64(s.head),
+	}
+	s.mutex.Unlock()
+
+	receipt, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().Equal(s.sent[0].Hash(), receipt.TxHash)
+	s.Require().Equal(1, *buildCount)
+	s.Require().Len(s.sent, 1)
+}
+
+func (s *TxSubmitterTestSuite) Test_Resumes_Persisted_Tx_After_Restart() {
+	s.submitter.config.SubmissionTimeout = 0
+	s.submitter.config.ResubmitInterval = time.Hour
+	sub, buildCount := s.newSubmission(func() bool { return false })
+
+	_, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().ErrorIs(err, ErrSubmissionTimeout)
+
+	restarted := s.newSubmitter()
+	s.Require().True(restarted.IsInFlight(sub.key))
+
+	s.mutex.Lock()
+	s.receipts[s.sent[0].Hash()] = &ethtypes.Receipt{
+		Status:      ethtypes.ReceiptStatusSuccessful,
+		TxHash:      s.sent[0].Hash(),
+		BlockNumber: new(big.Int).SetUint64(s.head),
+	}
+	s.mutex.Unlock()
+
+	receipt, err := restarted.Submit(s.ctx, sub)
+	s.Require().NoError(err)
+	s.Require().Equal(s.sent[0].Hash(), receipt.TxHash)
+	s.Require().Equal(1, *buildCount)
+	s.Require().Len(s.sent, 1)
+
+	states, err := s.txStorage.GetInFlightTxs(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(states)
+}
+
+func (s *TxSubmitterTestSuite) Test_Resumes_Tx_Concurrently() {
+	s.submitter.config.SubmissionTimeout = 0
+	s.submitter.config.ResubmitInterval = time.Millisecond
+	sub, _ := s.newSubmission(func() bool { return false })
+
+	_, err := s.submitter.Submit(s.ctx, sub)
+	s.Require().ErrorIs(err, ErrSubmissionTimeout)
+	s.includeOnSend(3)
+	s.submitter.config.SubmissionTimeout = 5 * time.Second
+
+	var waitGroup sync.WaitGroup
+	for range 2 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			_, err := s.submitter.Submit(s.ctx, sub)
+			s.NoError(err)
+		}()
+	}
+	waitGroup.Wait()
+
+	s.Require().False(s.submitter.IsInFlight(sub.key))
+}
+
+func (s *TxSubmitterTestSuite) Test_Rejects_Nonce_Used_By_Other_Key() {
+	s.submitter.config.SubmissionTimeout = 0
+	first, _ := s.newSubmission(func() bool { return false })
+	_, err := s.submitter.Submit(s.ctx, first)
+	s.Require().ErrorIs(err, ErrSubmissionTimeout)
+
+	second, _ := s.newSubmission(func() bool { return false })
+	second.key = "other"
+	_, err = s.submitter.Submit(s.ctx, second)
+	s.Require().ErrorIs(err, ErrNonceInUse)
+}
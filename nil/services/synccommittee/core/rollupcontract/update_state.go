@@ -8,6 +8,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -20,7 +21,56 @@ func (r *wrapperImpl) UpdateState(ctx context.Context, data *types.UpdateStateDa
 	}
 
 	batchIdStr := data.BatchId.String()
-	batchState, err := r.getBatchState(ctx, batchIdStr)
+	key := "updateState:" + batchIdStr
+	if !r.submitter.IsInFlight(key) {
+		if err := r.checkUpdateStatePreconditions(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	receipt, err := r.submitter.Submit(ctx, &submission{
+		key: key,
+		build: func(ctx context.Context) (*ethtypes.Transaction, error) {
+			return r.buildUpdateStateTx(ctx, data)
+		},
+		isDone: func(ctx context.Context, _ uint64) (bool, error) {
+			batchState, err := r.getBatchState(ctx, batchIdStr)
+			if err != nil {
+				return false, err
+			}
+			return batchState.IsFinalized, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("UpdateState submission failed: %w", err)
+	}
+	if receipt == nil {
+		r.logger.Info().Str("batchId", batchIdStr).Msg("batch is found to be finalized while waiting for receipt")
+		return nil
+	}
+
+	r.logReceiptDetails(receipt)
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		// Re-simulate the transaction on top of the block it originally failed in.
+		// Note: The execution order of transactions in the block is not preserved during simulation,
+		// so results may differ — but we attempt to identify the cause of failure anyway.
+		tx, _, err := r.ethClient.TransactionByHash(ctx, receipt.TxHash)
+		if err != nil {
+			return fmt.Errorf("UpdateState tx failed, can't fetch it to identify the reason: %w", err)
+		}
+		err = r.simulateTx(ctx, tx, receipt.BlockNumber)
+		if err != nil {
+			return r.errorByName(fmt.Errorf("post-submition simulation: %w", err))
+		}
+		return errors.New("UpdateState tx failed, can't identify the reason")
+	}
+
+	return nil
+}
+
+// checkUpdateStatePreconditions validates the contract state before the first UpdateState transaction is sent.
+func (r *wrapperImpl) checkUpdateStatePreconditions(ctx context.Context, data *types.UpdateStateData) error {
+	batchState, err := r.getBatchState(ctx, data.BatchId.String())
 	if err != nil {
 		return err
 	}
@@ -43,23 +93,21 @@ func (r *wrapperImpl) UpdateState(ctx context.Context, data *types.UpdateStateDa
 		return fmt.Errorf("%w: latestFinalizedRoot=%s batchOldStateRoot=%s, batchId=%s",
 			ErrOldStateRootMismatch, latestFinalizedStateRoot, data.OldProvedStateRoot, data.BatchId)
 	}
+	return nil
+}
 
-	publicDataInputs := INilRollupPublicDataInfo{
-		L2Tol1Root:    data.L2Tol1Root,
-		L1MessageHash: data.L1MessageHash,
-		DepositNonce:  data.DepositNonce,
-	}
-
-	dataProofBytes := make([][]byte, 0, len(data.DataProofs))
-	for _, proof := range data.DataProofs {
-		dataProofBytes = append(dataProofBytes, proof[:])
-	}
+func (r *wrapperImpl) buildUpdateStateTx(
+	ctx context.Context,
+	data *types.UpdateStateData,
+) (*ethtypes.Transaction, error) {
+	batchIdStr := data.BatchId.String()
+	publicDataInputs, dataProofBytes := updateStateCallArgs(data)
 
 	// The transaction will be simulated (via eth_estimateGas) before submission,
 	// but there is still a chance it may fail on-chain if the state changes
 	// between simulation and actual inclusion in a block.
 	var tx *ethtypes.Transaction
-	if err := r.transactWithCtx(ctx, func(opts *bind.TransactOpts) error {
+	if err := r.buildTxWithCtx(ctx, func(opts *bind.TransactOpts) error {
 		var err error
 		tx, err = r.rollupContract.UpdateState(
 			opts,
@@ -72,32 +120,62 @@ func (r *wrapperImpl) UpdateState(ctx context.Context, data *types.UpdateStateDa
 		)
 		return err
 	}); err != nil {
-		return fmt.Errorf("simulation transaction creation failed: %w", err)
+		return nil, fmt.Errorf("simulation transaction creation failed: %w", err)
 	}
 
-	r.logger.Info().
-		Hex("txHash", tx.Hash().Bytes()).
-		Int("gasLimit", int(tx.Gas())).
-		Int("cost", int(tx.Cost().Uint64())).
-		Msg("UpdateState transaction sent")
+	return tx, nil
+}
+
+// SimulateUpdateState performs the same checks as UpdateState and executes the call via `eth_call`
+// on top of the latest L1 block, no transaction is sent.
+func (r *wrapperImpl) SimulateUpdateState(ctx context.Context, data *types.UpdateStateData) error {
+	if err := r.validateUpdateStateData(data); err != nil {
+		return fmt.Errorf("invalid update state data: %w", err)
+	}
+
+	if err := r.checkUpdateStatePreconditions(ctx, data); err != nil {
+		return err
+	}
 
-	receipt, err := r.waitForReceipt(ctx, tx.Hash())
+	publicDataInputs, dataProofBytes := updateStateCallArgs(data)
+	calldata, err := r.abi.Pack(
+		"updateState",
+		data.BatchId.String(),
+		data.OldProvedStateRoot,
+		data.NewProvedStateRoot,
+		dataProofBytes,
+		[]byte(data.ValidityProof),
+		publicDataInputs,
+	)
 	if err != nil {
-		return fmt.Errorf("error during waiting for receipt: %w", err)
+		return fmt.Errorf("failed to pack updateState call: %w", err)
 	}
-	r.logReceiptDetails(receipt)
-	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
-		// Re-simulate the transaction on top of the block it originally failed in.
-		// Note: The execution order of transactions in the block is not preserved during simulation,
-		// so results may differ — but we attempt to identify the cause of failure anyway.
-		err = r.simulateTx(ctx, tx, receipt.BlockNumber)
-		if err != nil {
-			return r.errorByName(fmt.Errorf("post-submition simulation: %w", err))
-		}
-		return errors.New("UpdateState tx failed, can't identify the reason")
+
+	args := map[string]any{
+		"from": r.senderAddress,
+		"to":   r.contractAddress,
+		"data": hexutil.Bytes(calldata),
 	}
 
-	return err
+	var result any
+	if err := r.ethClient.RawCall(ctx, &result, "eth_call", args, "latest"); err != nil {
+		return r.errorByName(r.decodeContractError(err))
+	}
+	return nil
+}
+
+func updateStateCallArgs(data *types.UpdateStateData) (INilRollupPublicDataInfo, [][]byte) {
+	publicDataInputs := INilRollupPublicDataInfo{
+		L2Tol1Root:    data.L2Tol1Root,
+		L1MessageHash: data.L1MessageHash,
+		DepositNonce:  data.DepositNonce,
+	}
+
+	dataProofBytes := make([][]byte, 0, len(data.DataProofs))
+	for _, proof := range data.DataProofs {
+		dataProofBytes = append(dataProofBytes, proof[:])
+	}
+	return publicDataInputs, dataProofBytes
 }
 
 func (*wrapperImpl) validateUpdateStateData(data *types.UpdateStateData) error {
@@ -22,6 +22,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jonboulle/clockwork"
 )
 
 type Wrapper interface {
@@ -29,12 +30,22 @@ type Wrapper interface {
 
 	UpdateState(ctx context.Context, data *types.UpdateStateData) error
 
+	SimulateUpdateState(ctx context.Context, data *types.UpdateStateData) error
+
 	GetLatestFinalizedStateRoot(ctx context.Context) (common.Hash, error)
 
 	VerifyDataProofs(ctx context.Context, commitment *batches.Commitment) error
 
 	CommitBatch(ctx context.Context, batchId types.BatchId, sidecar *ethtypes.BlobTxSidecar) error
 
+	CommitBatchWithVersionedHashes(ctx context.Context, batchId types.BatchId, versionedHashes []ethcommon.Hash) error
+
+	CommitBatchWithCalldata(
+		ctx context.Context, batchId types.BatchId, versionedHashes []ethcommon.Hash, partCount int,
+	) error
+
+	PostBatchData(ctx context.Context, batchId types.BatchId, part int, data []byte) error
+
 	RollbackState(ctx context.Context, targetRoot common.Hash) error
 }
 
@@ -51,6 +62,8 @@ type WrapperConfig struct {
 	DisableL1          bool          `yaml:"disableL1,omitempty"`
 	PrivateKeyHex      string        `yaml:"l1PrivateKey,omitempty"`
 	ContractAddressHex string        `yaml:"l1ContractAddress,omitempty"`
+
+	TxSubmitter TxSubmitterConfig `yaml:",inline"`
 }
 
 func NewWrapperConfig(
@@ -66,6 +79,7 @@ func NewWrapperConfig(
 		ContractAddressHex: contractAddressHex,
 		RequestsTimeout:    requestsTimeout,
 		DisableL1:          disableL1,
+		TxSubmitter:        DefaultTxSubmitterConfig(),
 	}
 }
 
@@ -87,6 +101,7 @@ type wrapperImpl struct {
 	chainID         *big.Int
 	ethClient       l1client.EthClient
 	abi             *abi.ABI
+	submitter       *txSubmitter
 	logger          logging.Logger
 }
 
@@ -95,9 +110,12 @@ var _ Wrapper = (*wrapperImpl)(nil)
 // NewWrapper initializes a Wrapper for interacting with an Ethereum contract.
 // It converts contract and private key hex strings to Ethereum formats, sets up the contract instance,
 // and fetches the Ethereum client's chain ID.
+// In-flight L1 transactions are persisted in txStorage, nil storage keeps them in memory only.
 func NewWrapper(
 	ctx context.Context,
 	cfg WrapperConfig,
+	txStorage InFlightTxStorage,
+	clock clockwork.Clock,
 	logger logging.Logger,
 ) (Wrapper, error) {
 	var ethClient l1client.EthClient
@@ -112,13 +130,15 @@ func NewWrapper(
 		return nil, fmt.Errorf("error initializing eth client: %w", err)
 	}
 
-	return NewWrapperWithEthClient(ctx, cfg, ethClient, logger)
+	return NewWrapperWithEthClient(ctx, cfg, ethClient, txStorage, clock, logger)
 }
 
 func NewWrapperWithEthClient(
 	ctx context.Context,
 	cfg WrapperConfig,
 	ethClient l1client.EthClient,
+	txStorage InFlightTxStorage,
+	clock clockwork.Clock,
 	logger logging.Logger,
 ) (Wrapper, error) {
 	contactAddress := ethcommon.HexToAddress(cfg.ContractAddressHex)
@@ -148,7 +168,7 @@ func NewWrapperWithEthClient(
 	}
 	senderAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 
-	return &wrapperImpl{
+	wrapper := &wrapperImpl{
 		rollupContract:  rollupContract,
 		contractAddress: contactAddress,
 		senderAddress:   senderAddress,
@@ -157,7 +177,12 @@ func NewWrapperWithEthClient(
 		ethClient:       ethClient,
 		abi:             abiDefinition,
 		logger:          logger,
-	}, nil
+	}
+	wrapper.submitter, err = newTxSubmitter(ctx, ethClient, wrapper.signTx, cfg.TxSubmitter, txStorage, clock, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing tx submitter: %w", err)
+	}
+	return wrapper, nil
 }
 
 func (r *wrapperImpl) SetGenesisStateRoot(ctx context.Context, genesisStateRoot common.Hash) error {
@@ -242,7 +267,21 @@ func (r *wrapperImpl) transactWithCtx(ctx context.Context, transactFunc contract
 	if err != nil {
 		return err
 	}
+	return r.transact(transactOpts, transactFunc)
+}
+
+// buildTxWithCtx works like transactWithCtx, but the signed transaction is not sent,
+// so that its submission could be handled by the txSubmitter.
+func (r *wrapperImpl) buildTxWithCtx(ctx context.Context, transactFunc contractTransactFunc) error {
+	transactOpts, err := r.getEthTransactOpts(ctx)
+	if err != nil {
+		return err
+	}
+	transactOpts.NoSend = true
+	return r.transact(transactOpts, transactFunc)
+}
 
+func (r *wrapperImpl) transact(transactOpts *bind.TransactOpts, transactFunc contractTransactFunc) error {
 	// Any execution-level error (e.g., reverts, failed require statements) would have been triggered
 	// during eth_estimateGas, which is implicitly called before sending the transaction.
 	// Such errors can be parsed similarly to those from eth_call, and in those cases,
@@ -396,6 +435,9 @@ var contractErrorMap = map[string]error{
 	"ErrorCallPointEvaluationPrecompileFailed":       ErrCallPointEvaluationPrecompileFailed,
 	"ErrorUnexpectedPointEvaluationPrecompileOutput": ErrUnexpectedPointEvaluationPrecompileOutput,
 	"ErrorInvalidVersionedHash":                      ErrInvalidVersionedHash,
+	"ErrorUnexpectedDataAvailabilityMode":            ErrUnexpectedDataAvailabilityMode,
+	"ErrorEmptyBatchData":                            ErrEmptyBatchData,
+	"ErrorBatchDataPartNotPosted":                    ErrBatchDataPartNotPosted,
 }
 
 // errorByName looks for specific error type by its name, returns it if found, otherwise, returns
@@ -487,6 +529,20 @@ func (w *noopWrapper) UpdateState(_ context.Context, data *types.UpdateStateData
 	return nil
 }
 
+func (w *noopWrapper) SimulateUpdateState(_ context.Context, data *types.UpdateStateData) error {
+	w.logger.Debug().Msg("SimulateUpdateState noop wrapper method called")
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if w.stateRoot != data.OldProvedStateRoot {
+		return fmt.Errorf(
+			"%w, currentState=%s, received=%s", ErrOldStateRootMismatch, w.stateRoot, data.OldProvedStateRoot,
+		)
+	}
+	return nil
+}
+
 func (w *noopWrapper) GetLatestFinalizedStateRoot(context.Context) (common.Hash, error) {
 	w.logger.Debug().Msg("FinalizedStateRoot noop wrapper method called")
 
@@ -506,6 +562,21 @@ func (w *noopWrapper) CommitBatch(context.Context, types.BatchId, *ethtypes.Blob
 	return nil
 }
 
+func (w *noopWrapper) CommitBatchWithVersionedHashes(context.Context, types.BatchId, []ethcommon.Hash) error {
+	w.logger.Debug().Msg("CommitBatchWithVersionedHashes noop wrapper method called")
+	return nil
+}
+
+func (w *noopWrapper) CommitBatchWithCalldata(context.Context, types.BatchId, []ethcommon.Hash, int) error {
+	w.logger.Debug().Msg("CommitBatchWithCalldata noop wrapper method called")
+	return nil
+}
+
+func (w *noopWrapper) PostBatchData(context.Context, types.BatchId, int, []byte) error {
+	w.logger.Debug().Msg("PostBatchData noop wrapper method called")
+	return nil
+}
+
 func (w *noopWrapper) RollbackState(context.Context, common.Hash) error {
 	w.logger.Debug().Msg("RollbackState noop wrapper method called")
 	return nil
@@ -9,6 +9,7 @@ import (
 	"github.com/NilFoundation/nil/nil/common/logging"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/blob"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
 	v1 "github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/encode/v1"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/l1client"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
@@ -16,8 +17,10 @@ import (
 	"github.com/ethereum/go-ethereum"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/holiman/uint256"
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -53,7 +56,11 @@ func (s *WrapperTestSuite) SetupSuite() {
 		SuggestGasPriceFunc: func(ctx context.Context) (*big.Int, error) { return big.NewInt(123), nil },
 		HeaderByNumberFunc: func(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
 			excessBlobGas := uint64(123)
-			return &ethtypes.Header{BaseFee: big.NewInt(123), ExcessBlobGas: &excessBlobGas}, nil
+			return &ethtypes.Header{
+				Number:        big.NewInt(100),
+				BaseFee:       big.NewInt(123),
+				ExcessBlobGas: &excessBlobGas,
+			}, nil
 		},
 		PendingCodeAtFunc: func(ctx context.Context, account ethcommon.Address) ([]byte, error) {
 			return []byte{123}, nil
@@ -65,7 +72,7 @@ func (s *WrapperTestSuite) SetupSuite() {
 			return []byte{123}, nil
 		},
 		TransactionReceiptFunc: func(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
-			return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil
+			return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
 		},
 		FilterLogsFunc: func(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
 			return []ethtypes.Log{{
@@ -107,7 +114,7 @@ func (s *WrapperTestSuite) SetupSuite() {
 		},
 	}
 
-	wrapper, err := NewWrapperWithEthClient(s.ctx, s.config, s.ethClient, s.logger)
+	wrapper, err := NewWrapperWithEthClient(s.ctx, s.config, s.ethClient, nil, clockwork.NewRealClock(), s.logger)
 	s.Require().NoError(err)
 	s.wrapper = wrapper
 }
@@ -270,6 +277,125 @@ func (s *WrapperTestSuite) TestCommitBatch_AlreadyCommitted() {
 	s.Require().Empty(s.ethClient.SendTransactionCalls())
 }
 
+// Test PostBatchData - calldata is sent to the rollup contract, which stores its hash
+func (s *WrapperTestSuite) TestPostBatchData() {
+	batchId := types.NewBatchId()
+	data := []byte{1, 2, 3}
+
+	s.callContractMock.AddExpectedCall("batchDataPartHashes", ethcommon.Hash{})
+
+	err := s.wrapper.PostBatchData(s.ctx, batchId, 1, data)
+	s.Require().NoError(err)
+	s.Require().NoError(s.callContractMock.EverythingCalled())
+
+	sendCalls := s.ethClient.SendTransactionCalls()
+	s.Require().Len(sendCalls, 1)
+	tx := sendCalls[0].Tx
+	s.Require().Equal(ethcommon.HexToAddress(s.config.ContractAddressHex), *tx.To())
+
+	abi, err := RollupcontractMetaData.GetAbi()
+	s.Require().NoError(err)
+	method, err := abi.MethodById(tx.Data())
+	s.Require().NoError(err)
+	s.Require().Equal("postBatchData", method.Name)
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	s.Require().NoError(err)
+	s.Require().Equal([]any{batchId.String(), big.NewInt(1), data}, args)
+}
+
+// Test PostBatchData - the part already posted with the same data is not sent again
+func (s *WrapperTestSuite) TestPostBatchData_AlreadyPosted() {
+	data := []byte{1, 2, 3}
+
+	s.callContractMock.AddExpectedCall("batchDataPartHashes", crypto.Keccak256Hash(data))
+
+	err := s.wrapper.PostBatchData(s.ctx, types.NewBatchId(), 0, data)
+	s.Require().NoError(err)
+	s.Require().NoError(s.callContractMock.EverythingCalled())
+	s.Require().Empty(s.ethClient.SendTransactionCalls())
+}
+
+// Test Publish + UpdateState - batches published off the blob space are committed with versioned hashes,
+// so that UpdateState finds them committed
+func (s *WrapperTestSuite) TestPublishOffBlobs_Then_UpdateState() {
+	testCases := []struct {
+		name         string
+		newBackend   func() batches.DataAvailabilityBackend
+		commitMethod string
+		partCount    int
+	}{
+		{
+			"Calldata",
+			func() batches.DataAvailabilityBackend {
+				return da.NewCalldataBackend(s.wrapper, da.DefaultCalldataChunkSize, s.logger)
+			},
+			"commitBatchWithCalldata",
+			1,
+		},
+		{
+			"Local",
+			func() batches.DataAvailabilityBackend {
+				return da.NewLocalBackend(da.NewFsStore(s.T().TempDir()), s.wrapper, s.logger)
+			},
+			"commitBatchWithVersionedHashes",
+			0,
+		},
+	}
+
+	commitment := s.getSampleCommitment()
+	abi, err := RollupcontractMetaData.GetAbi()
+	s.Require().NoError(err)
+
+	for _, testCase := range testCases {
+		s.Run(testCase.name, func() {
+			s.ethClient.ResetCalls()
+			s.callContractMock.Reset()
+			batchId := types.NewBatchId()
+
+			for range testCase.partCount {
+				s.callContractMock.AddExpectedCall("batchDataPartHashes", ethcommon.Hash{})
+			}
+			s.callContractMock.AddExpectedCall("isBatchCommitted", false)
+			s.Require().NoError(testCase.newBackend().Publish(s.ctx, batchId, commitment))
+			s.Require().NoError(s.callContractMock.EverythingCalled())
+
+			sendCalls := s.ethClient.SendTransactionCalls()
+			s.Require().NotEmpty(sendCalls)
+			commitTx := sendCalls[len(sendCalls)-1].Tx
+			s.Require().Equal(ethcommon.HexToAddress(s.config.ContractAddressHex), *commitTx.To())
+
+			method, err := abi.MethodById(commitTx.Data())
+			s.Require().NoError(err)
+			s.Require().Equal(testCase.commitMethod, method.Name)
+			args, err := method.Inputs.Unpack(commitTx.Data()[4:])
+			s.Require().NoError(err)
+			s.Require().Equal(batchId.String(), args[0])
+			expectedHashes := make([][32]byte, 0, len(commitment.Sidecar.Blobs))
+			for _, hash := range commitment.Sidecar.BlobHashes() {
+				expectedHashes = append(expectedHashes, hash)
+			}
+			s.Require().Equal(expectedHashes, args[1])
+			if testCase.partCount != 0 {
+				s.Require().Len(sendCalls, testCase.partCount+1)
+				s.Require().Equal(big.NewInt(int64(testCase.partCount)), args[2])
+			}
+
+			updateStateData := testaide.NewUpdateStateData()
+			updateStateData.BatchId = batchId
+			updateStateData.DataProofs = commitment.DataProofs
+
+			s.callContractMock.AddExpectedCall("isBatchFinalized", false)
+			s.callContractMock.AddExpectedCall("isBatchCommitted", true)
+			s.callContractMock.AddExpectedCall("getLastFinalizedBatchIndex", types.NewBatchId().String())
+			s.callContractMock.AddExpectedCall("finalizedStateRoots", updateStateData.OldProvedStateRoot)
+
+			s.Require().NoError(s.wrapper.UpdateState(s.ctx, updateStateData))
+			s.Require().NoError(s.callContractMock.EverythingCalled())
+			s.Require().Len(s.ethClient.SendTransactionCalls(), len(sendCalls)+1)
+		})
+	}
+}
+
 // Test verifyDataProofs method
 func (s *WrapperTestSuite) TestVerifyDataProofs() {
 	commitment := s.getSampleCommitment()
@@ -10,6 +10,7 @@ import (
 	"github.com/NilFoundation/nil/nil/internal/types"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/constraints"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/core/batches/da"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/bridgecontract"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/feeupdater"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/core/fetching"
@@ -55,11 +56,16 @@ func New(ctx context.Context, cfg *Config, database db.DB) (*SyncCommittee, erro
 	blockStorage := storage.NewBlockStorage(
 		database, storage.DefaultBlockStorageConfig(), clock, metricsHandler, logger,
 	)
-	taskStorage := storage.NewTaskStorage(database, clock, metricsHandler, logger)
+	taskStorage, err := storage.NewTaskStorage(ctx, database, clock, metricsHandler, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing task storage: %w", err)
+	}
 
 	rollupContractWrapper, err := rollupcontract.NewWrapper(
 		ctx,
 		cfg.ContractWrapperConfig,
+		storage.NewL1TxStorage(database, logger),
+		clock,
 		logger,
 	)
 	if err != nil {
@@ -82,8 +88,15 @@ func New(ctx context.Context, cfg *Config, database db.DB) (*SyncCommittee, erro
 		logger,
 	)
 
+	daBackend, err := da.NewBackend(cfg.DataAvailability, rollupContractWrapper, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing DA backend: %w", err)
+	}
+
+	dataCostTracker := feeupdater.NewDataCostTracker(cfg.L1FeeUpdateConfig.DataCostWindowSize)
+
 	committer := batches.NewCommitter(
-		rollupContractWrapper, clock, batches.DefaultCommitConfig(), metricsHandler, logger,
+		daBackend, clock, batches.DefaultCommitConfig(), metricsHandler, logger, dataCostTracker,
 	)
 
 	agg := fetching.NewAggregator(
@@ -123,23 +136,17 @@ func New(ctx context.Context, cfg *Config, database db.DB) (*SyncCommittee, erro
 	resetLauncher.AddPausableComponent(agg)
 	resetLauncher.AddPausableComponent(proposer)
 
+	executorRegistry := scheduler.NewExecutorRegistry(clock)
 	taskScheduler := scheduler.New(
 		taskStorage,
 		newTaskStateChangeHandler(blockStorage, resetLauncher, logger),
+		executorRegistry,
 		metricsHandler,
 		logger,
 	)
 
 	blockDebugger := debug.NewBlockDebugger(rollupContractWrapper, blockStorage)
 
-	rpcServer := rpc.NewServerWithTasks(
-		rpc.NewServerConfig(cfg.OwnRpcEndpoint),
-		logger,
-		taskScheduler,
-		debug.NewTaskDebugger(taskStorage, logger),
-		rpc.DebugBlocksServerHandler(blockDebugger),
-	)
-
 	feeUpdaterMetrics, err := metrics.NewFeeUpdaterMetrics()
 	if err != nil {
 		return nil, err
@@ -165,14 +172,28 @@ func New(ctx context.Context, cfg *Config, database db.DB) (*SyncCommittee, erro
 		logger,
 		clock,
 		feeUpdaterContract,
+		dataCostTracker,
 		feeUpdaterMetrics,
 	)
 
-	syncCommittee.Service = srv.NewServiceWithHeartbeat(
-		metricsHandler,
+	rpcServer := rpc.NewServerWithTasks(
+		rpc.NewServerConfig(cfg.OwnRpcEndpoint),
 		logger,
-		syncRunner, proposer, agg, lagTracker, taskScheduler, feeUpdater, rpcServer,
+		taskScheduler,
+		debug.NewTaskDebugger(taskStorage, executorRegistry, taskScheduler, logger),
+		rpc.DebugBlocksServerHandler(blockDebugger),
+		rpc.DebugFeesServerHandler(feeUpdater),
 	)
 
+	workers := []srv.Worker{syncRunner, proposer, agg, lagTracker, taskScheduler, feeUpdater, rpcServer}
+	if cfg.DataAvailability.ServerListenAddr != "" {
+		daStore := da.NewFsStore(cfg.DataAvailability.LocalDir)
+		workers = append(workers, da.NewServer(
+			cfg.DataAvailability.ServerListenAddr, cfg.DataAvailability.ServerToken, daStore, logger,
+		))
+	}
+
+	syncCommittee.Service = srv.NewServiceWithHeartbeat(metricsHandler, logger, workers...)
+
 	return syncCommittee, nil
 }
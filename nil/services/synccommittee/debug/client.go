@@ -13,3 +13,7 @@ func NewTasksClient(endpoint string, logger logging.Logger) public.TaskDebugApi
 func NewBlocksClient(endpoint string, logger logging.Logger) public.BlockDebugApi {
 	return rpc.NewBlockDebugRpcClient(endpoint, logger)
 }
+
+func NewFeesClient(endpoint string, logger logging.Logger) public.FeeDebugApi {
+	return rpc.NewFeeDebugRpcClient(endpoint, logger)
+}
@@ -15,10 +15,16 @@ const (
 
 type TaskRequest struct {
 	ExecutorId types.TaskExecutorId `json:"executorId"`
+
+	// Capabilities are used to pick a task the executor is able to handle, nil value means no restrictions
+	Capabilities *types.ExecutorCapabilities `json:"capabilities,omitempty"`
 }
 
-func NewTaskRequest(executorId types.TaskExecutorId) *TaskRequest {
-	return &TaskRequest{ExecutorId: executorId}
+func NewTaskRequest(executorId types.TaskExecutorId, capabilities *types.ExecutorCapabilities) *TaskRequest {
+	return &TaskRequest{
+		ExecutorId:   executorId,
+		Capabilities: capabilities,
+	}
 }
 
 type TaskCheckRequest struct {
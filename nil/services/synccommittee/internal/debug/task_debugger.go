@@ -1,8 +1,11 @@
 package debug
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/NilFoundation/nil/nil/common/heap"
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -20,15 +23,33 @@ type DebuggerStorage interface {
 	GetTaskTreeView(ctx context.Context, taskId types.TaskId) (*public.TaskTreeView, error)
 }
 
+type ExecutorSource interface {
+	GetActiveExecutors() []*public.ExecutorView
+}
+
+type DeadLetterResolver interface {
+	RequeueTask(ctx context.Context, taskId types.TaskId) error
+	AbandonTask(ctx context.Context, taskId types.TaskId) error
+}
+
 type taskDebugger struct {
-	storage DebuggerStorage
-	logger  logging.Logger
+	storage   DebuggerStorage
+	executors ExecutorSource
+	resolver  DeadLetterResolver
+	logger    logging.Logger
 }
 
-func NewTaskDebugger(storage DebuggerStorage, logger logging.Logger) public.TaskDebugApi {
+func NewTaskDebugger(
+	storage DebuggerStorage,
+	executors ExecutorSource,
+	resolver DeadLetterResolver,
+	logger logging.Logger,
+) public.TaskDebugApi {
 	return &taskDebugger{
-		storage: storage,
-		logger:  logger,
+		storage:   storage,
+		executors: executors,
+		resolver:  resolver,
+		logger:    logger,
 	}
 }
 
@@ -119,3 +140,74 @@ func (d *taskDebugger) getComparator(request *public.TaskDebugRequest) (func(i,
 func (d *taskDebugger) GetTaskTree(ctx context.Context, taskId types.TaskId) (*public.TaskTreeView, error) {
 	return d.storage.GetTaskTreeView(ctx, taskId)
 }
+
+func (d *taskDebugger) RequeueDeadLetterTask(ctx context.Context, taskId types.TaskId) error {
+	if err := d.resolver.RequeueTask(ctx, taskId); err != nil {
+		d.logger.Error().Err(err).Stringer(logging.FieldTaskId, taskId).Msg("failed to requeue dead-lettered task")
+		return err
+	}
+	return nil
+}
+
+func (d *taskDebugger) AbandonDeadLetterTask(ctx context.Context, taskId types.TaskId) error {
+	if err := d.resolver.AbandonTask(ctx, taskId); err != nil {
+		d.logger.Error().Err(err).Stringer(logging.FieldTaskId, taskId).Msg("failed to abandon dead-lettered task")
+		return err
+	}
+	return nil
+}
+
+func (d *taskDebugger) GetExecutorClasses(ctx context.Context) ([]*public.ExecutorClassView, error) {
+	executors := d.executors.GetActiveExecutors()
+
+	classes := make(map[types.ResourceClass]*public.ExecutorClassView)
+	getClass := func(class types.ResourceClass) *public.ExecutorClassView {
+		view, ok := classes[class]
+		if !ok {
+			view = &public.ExecutorClassView{ResourceClass: class}
+			classes[class] = view
+		}
+		return view
+	}
+
+	for _, executor := range executors {
+		view := getClass(executor.ResourceClass())
+		view.Executors++
+		if executor.Idle {
+			view.IdleExecutors++
+		}
+	}
+
+	pendingTasks := &taskViewCollector{}
+	isPending := func(task *public.TaskView) bool { return task.Status == types.WaitingForExecutor }
+	if err := d.storage.GetTaskViews(ctx, pendingTasks, isPending); err != nil {
+		d.logger.Error().Err(err).Msg("failed to get pending tasks from the storage (GetTaskViews)")
+		return nil, err
+	}
+
+	for _, task := range pendingTasks.tasks {
+		view := getClass(task.Type.RequiredResourceClass())
+		view.PendingTasks++
+
+		served := slices.ContainsFunc(executors, func(executor *public.ExecutorView) bool {
+			return executor.Capabilities.CanExecute(task.Type)
+		})
+		if !served {
+			view.UnservedTasks++
+		}
+	}
+
+	result := slices.Collect(maps.Values(classes))
+	slices.SortFunc(result, func(i, j *public.ExecutorClassView) int {
+		return cmp.Compare(i.ResourceClass, j.ResourceClass)
+	})
+	return result, nil
+}
+
+type taskViewCollector struct {
+	tasks []*public.TaskView
+}
+
+func (c *taskViewCollector) Add(task *public.TaskView) {
+	c.tasks = append(c.tasks, task)
+}
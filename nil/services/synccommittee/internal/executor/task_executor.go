@@ -18,6 +18,9 @@ const (
 
 type Config struct {
 	TaskPollingInterval time.Duration
+
+	// Capabilities are sent with every task request, so that the scheduler assigns only suitable tasks
+	Capabilities *types.ExecutorCapabilities
 }
 
 func DefaultConfig() *Config {
@@ -75,7 +78,7 @@ func (p *taskExecutor) runIteration(ctx context.Context) error {
 		return err
 	}
 
-	taskRequest := api.NewTaskRequest(*executorId)
+	taskRequest := api.NewTaskRequest(*executorId, p.config.Capabilities)
 	task, err := p.requestHandler.GetTask(ctx, taskRequest)
 	if err != nil {
 		return err
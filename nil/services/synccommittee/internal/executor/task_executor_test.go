@@ -32,6 +32,11 @@ func (s *TestSuite) SetupTest() {
 
 	config := Config{
 		TaskPollingInterval: 10 * time.Millisecond,
+		Capabilities: &types.ExecutorCapabilities{
+			TaskTypes:     []types.TaskType{types.PartialProve, types.AggregatedFRI},
+			ResourceClass: types.ResourceClassLarge,
+			Concurrency:   2,
+		},
 	}
 	logger := logging.NewLogger("task-executor-test")
 	metricsHandler, err := metrics.NewSyncCommitteeMetrics()
@@ -76,7 +81,7 @@ func (s *TestSuite) Test_TaskExecutor_Executes_Tasks() {
 	executorId, err := s.idSource.GetCurrentId(s.context)
 	s.Require().NoError(err)
 
-	expectedTaskRequest := api.NewTaskRequest(*executorId)
+	expectedTaskRequest := api.NewTaskRequest(*executorId, s.taskExecutor.config.Capabilities)
 	const tasksThreshold = 5
 
 	s.Require().Eventually(
@@ -90,7 +95,7 @@ func (s *TestSuite) Test_TaskExecutor_Executes_Tasks() {
 
 	for _, call := range s.requestHandler.GetTaskCalls() {
 		s.Require().Equal(expectedTaskRequest, call.Request,
-			"Task executor should have passed its id to the target handler")
+			"Task executor should have passed its id and capabilities to the target handler")
 	}
 
 	s.Require().Eventually(
@@ -5,12 +5,20 @@ import (
 	"fmt"
 
 	"github.com/NilFoundation/nil/nil/internal/telemetry"
+	"github.com/NilFoundation/nil/nil/internal/telemetry/telattr"
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
 
+const attrFeeComponent = "fee.component"
+
 type FeeUpdaterMetrics struct {
 	basicMetricsHandler
-	l1Updates telemetry.Counter
+	l1Updates       telemetry.Counter
+	feeComponents   telemetry.Gauge
+	dataWindowBlobs telemetry.Gauge
 }
 
 func NewFeeUpdaterMetrics() (*FeeUpdaterMetrics, error) {
@@ -33,9 +41,34 @@ func (m *FeeUpdaterMetrics) init(attributes metric.MeasurementOption, meter tele
 		return err
 	}
 
+	if m.feeComponents, err = meter.Int64Gauge(namespace + "fee_components_per_gas"); err != nil {
+		return err
+	}
+
+	if m.dataWindowBlobs, err = meter.Int64Gauge(namespace + "fee_data_window_blobs"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (m *FeeUpdaterMetrics) RegisterL1Update(ctx context.Context) {
 	m.l1Updates.Add(ctx, 1)
 }
+
+func (m *FeeUpdaterMetrics) RecordFeeComponents(ctx context.Context, components *public.FeeComponents) {
+	values := map[string]types.Value{
+		"execution_fee":      components.ExecutionFeePerGas,
+		"blob_base_fee":      components.BlobBaseFee,
+		"raw_data_cost":      components.RawDataCostPerGas,
+		"smoothed_data_cost": components.DataCostPerGas,
+		"max_fee":            components.MaxFeePerGas,
+		"max_priority_fee":   components.MaxPriorityFeePerGas,
+	}
+	for name, value := range values {
+		attr := telattr.With(attribute.String(attrFeeComponent, name))
+		m.feeComponents.Record(ctx, int64(value.Uint64()), m.attributes, attr)
+	}
+
+	m.dataWindowBlobs.Record(ctx, int64(components.WindowBlobCount), m.attributes)
+}
@@ -26,6 +26,7 @@ type taskStorageMetricsHandler struct {
 	activeTasksByType     telemetry.ObservableUpDownCounter
 	activeTasksByExecutor telemetry.ObservableUpDownCounter
 	pendingTasksByType    telemetry.ObservableUpDownCounter
+	deadLetterTasksByType telemetry.ObservableUpDownCounter
 
 	totalTasksCreated     telemetry.Counter
 	totalTasksSucceeded   telemetry.Counter
@@ -55,6 +56,11 @@ func (h *taskStorageMetricsHandler) init(attributes metric.MeasurementOption, me
 		return err
 	}
 
+	h.deadLetterTasksByType, err = meter.Int64ObservableUpDownCounter(tasksNamespace + "current_dead_letter_by_type")
+	if err != nil {
+		return err
+	}
+
 	if err := h.registerStatsCallback(meter); err != nil {
 		return err
 	}
@@ -101,6 +107,7 @@ func (h *taskStorageMetricsHandler) registerStatsCallback(meter telemetry.Meter)
 				)
 				observer.ObserveInt64(h.activeTasksByType, int64(entry.ActiveCount), h.attributes, attr)
 				observer.ObserveInt64(h.pendingTasksByType, int64(entry.PendingCount), h.attributes, attr)
+				observer.ObserveInt64(h.deadLetterTasksByType, int64(entry.DeadLetterCount), h.attributes, attr)
 			}
 
 			for executor, count := range stats.CountPerExecutor {
@@ -112,7 +119,7 @@ func (h *taskStorageMetricsHandler) registerStatsCallback(meter telemetry.Meter)
 
 			return nil
 		},
-		h.activeTasksByType, h.activeTasksByExecutor, h.pendingTasksByType,
+		h.activeTasksByType, h.activeTasksByExecutor, h.pendingTasksByType, h.deadLetterTasksByType,
 	)
 	return err
 }
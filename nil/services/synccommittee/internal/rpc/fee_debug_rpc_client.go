@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/NilFoundation/nil/nil/client"
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+)
+
+type feeDebugRpcClient struct {
+	client client.RawClient
+}
+
+func NewFeeDebugRpcClient(apiEndpoint string, logger logging.Logger) public.FeeDebugApi {
+	return &feeDebugRpcClient{
+		client: NewRetryClient(apiEndpoint, logger),
+	}
+}
+
+func (c feeDebugRpcClient) GetFeeComponents(ctx context.Context) (*public.FeeComponents, error) {
+	return doRPCCall[*public.FeeComponents](
+		ctx,
+		c.client,
+		public.DebugGetFeeComponents,
+	)
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"github.com/stretchr/testify/suite"
+)
+
+type feeDebugApiStub struct {
+	mu         sync.Mutex
+	components *public.FeeComponents
+}
+
+func (s *feeDebugApiStub) GetFeeComponents(context.Context) (*public.FeeComponents, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.components, nil
+}
+
+type FeeDebugRpcTestSuite struct {
+	ServerTestSuite
+	service   *feeDebugApiStub
+	rpcClient public.FeeDebugApi
+}
+
+func TestFeeDebugRpcTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(FeeDebugRpcTestSuite))
+}
+
+func (s *FeeDebugRpcTestSuite) SetupSuite() {
+	s.ServerTestSuite.SetupSuite()
+
+	s.service = &feeDebugApiStub{}
+	s.RunRpcServer(DebugFeesServerHandler(s.service))
+
+	s.rpcClient = NewFeeDebugRpcClient(s.serverEndpoint, s.logger)
+}
+
+func (s *FeeDebugRpcTestSuite) Test_GetFeeComponents_NotEvaluated() {
+	s.service.mu.Lock()
+	s.service.components = nil
+	s.service.mu.Unlock()
+
+	components, err := s.rpcClient.GetFeeComponents(s.context)
+	s.Require().NoError(err)
+	s.Nil(components)
+}
+
+func (s *FeeDebugRpcTestSuite) Test_GetFeeComponents() {
+	expected := &public.FeeComponents{
+		ExecutionFeePerGas:   types.NewValueFromUint64(250_000_000),
+		BlobBaseFee:          types.NewValueFromUint64(1_000),
+		WindowBatchCount:     3,
+		WindowBlobCount:      5,
+		WindowGasUsed:        1_000_000,
+		RawDataCostPerGas:    types.NewValueFromUint64(655),
+		DataCostPerGas:       types.NewValueFromUint64(600),
+		MaxFeePerGas:         types.NewValueFromUint64(250_000_600),
+		MaxPriorityFeePerGas: types.NewValueFromUint64(1_000_000),
+		EvaluatedAt:          testaide.Now,
+	}
+	s.service.mu.Lock()
+	s.service.components = expected
+	s.service.mu.Unlock()
+
+	components, err := s.rpcClient.GetFeeComponents(s.context)
+	s.Require().NoError(err)
+	s.Require().NotNil(components)
+	s.Equal(expected.MaxFeePerGas, components.MaxFeePerGas)
+	s.Equal(expected.DataCostPerGas, components.DataCostPerGas)
+	s.Equal(expected.WindowGasUsed, components.WindowGasUsed)
+	s.True(expected.EvaluatedAt.Equal(components.EvaluatedAt))
+}
@@ -18,6 +18,10 @@ func DebugBlocksServerHandler(service public.BlockDebugApi) Handler {
 	return NewHandler(public.DebugBlocksNamespace, service)
 }
 
+func DebugFeesServerHandler(service public.FeeDebugApi) Handler {
+	return NewHandler(public.DebugFeesNamespace, service)
+}
+
 func NewServerWithTasks(
 	config ServerConfig,
 	logger logging.Logger,
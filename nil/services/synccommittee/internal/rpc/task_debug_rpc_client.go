@@ -39,3 +39,31 @@ func (c *taskDebugRpcClient) GetTaskTree(ctx context.Context, taskId types.TaskI
 		taskId,
 	)
 }
+
+func (c *taskDebugRpcClient) RequeueDeadLetterTask(ctx context.Context, taskId types.TaskId) error {
+	_, err := doRPCCall2[types.TaskId, any](
+		ctx,
+		c.client,
+		public.DebugRequeueDeadLetterTask,
+		taskId,
+	)
+	return err
+}
+
+func (c *taskDebugRpcClient) AbandonDeadLetterTask(ctx context.Context, taskId types.TaskId) error {
+	_, err := doRPCCall2[types.TaskId, any](
+		ctx,
+		c.client,
+		public.DebugAbandonDeadLetterTask,
+		taskId,
+	)
+	return err
+}
+
+func (c *taskDebugRpcClient) GetExecutorClasses(ctx context.Context) ([]*public.ExecutorClassView, error) {
+	return doRPCCall[[]*public.ExecutorClassView](
+		ctx,
+		c.client,
+		public.DebugGetExecutorClasses,
+	)
+}
@@ -1,12 +1,15 @@
 package rpc
 
 import (
+	"context"
 	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/api"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/debug"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/scheduler"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/storage"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/testaide"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
@@ -17,6 +20,7 @@ import (
 type TaskDebugRpcTestSuite struct {
 	ServerTestSuite
 	storage   *storage.TaskStorage
+	executors *scheduler.ExecutorRegistry
 	rpcClient public.TaskDebugApi
 }
 
@@ -50,8 +54,15 @@ func newTaskEntries(now time.Time) []*types.TaskEntry {
 func (s *TaskDebugRpcTestSuite) SetupSuite() {
 	s.ServerTestSuite.SetupSuite()
 
-	s.storage = storage.NewTaskStorage(s.database, s.clock, s.metricsHandler, s.logger)
-	taskDebugger := debug.NewTaskDebugger(s.storage, s.logger)
+	var err error
+	s.storage, err = storage.NewTaskStorage(s.context, s.database, s.clock, s.metricsHandler, s.logger)
+	s.Require().NoError(err)
+	s.executors = scheduler.NewExecutorRegistry(s.clock)
+	stateHandler := &api.TaskStateChangeHandlerMock{
+		OnTaskTerminatedFunc: func(context.Context, *types.Task, *types.TaskResult) error { return nil },
+	}
+	taskScheduler := scheduler.New(s.storage, stateHandler, s.executors, s.metricsHandler, s.logger)
+	taskDebugger := debug.NewTaskDebugger(s.storage, s.executors, taskScheduler, s.logger)
 
 	handler := DebugTasksServerHandler(taskDebugger)
 	s.RunRpcServer(handler)
@@ -300,12 +311,109 @@ func (s *TaskDebugRpcTestSuite) Test_Get_Task_Tree_With_Terminated_Dependencies(
 	s.requireHasTerminatedLeafDependency(taskATree, executor, taskC, false)
 }
 
+func (s *TaskDebugRpcTestSuite) Test_Get_Executor_Classes() {
+	now := s.clock.Now()
+
+	err := s.storage.AddTaskEntries(s.context,
+		testaide.NewTaskEntryOfType(types.AggregatedChallenge, now, types.WaitingForExecutor, types.UnknownExecutorId),
+		testaide.NewTaskEntryOfType(types.PartialProve, now, types.WaitingForExecutor, types.UnknownExecutorId),
+		testaide.NewTaskEntryOfType(types.MergeProof, now, types.WaitingForExecutor, types.UnknownExecutorId),
+		testaide.NewTaskEntryOfType(types.MergeProof, now, types.WaitingForInput, types.UnknownExecutorId),
+	)
+	s.Require().NoError(err)
+
+	smallExecutor := api.NewTaskRequest(types.NewRandomExecutorId(), &types.ExecutorCapabilities{
+		ResourceClass: types.ResourceClassSmall,
+	})
+	s.executors.OnTaskRequested(smallExecutor, false)
+
+	mediumExecutor := api.NewTaskRequest(types.NewRandomExecutorId(), &types.ExecutorCapabilities{
+		TaskTypes:     []types.TaskType{types.PartialProve},
+		ResourceClass: types.ResourceClassMedium,
+	})
+	s.executors.OnTaskRequested(mediumExecutor, true)
+
+	classes, err := s.rpcClient.GetExecutorClasses(s.context)
+	s.Require().NoError(err)
+
+	expected := []*public.ExecutorClassView{
+		{ResourceClass: types.ResourceClassSmall, Executors: 1, IdleExecutors: 1, PendingTasks: 1},
+		{ResourceClass: types.ResourceClassMedium, Executors: 1, PendingTasks: 1},
+		{ResourceClass: types.ResourceClassLarge, PendingTasks: 1, UnservedTasks: 1},
+	}
+	s.Require().Equal(expected, classes)
+	s.Require().True(classes[2].IsStarving())
+
+	// Executors which haven't requested tasks for a long time are not taken into account
+	s.clock.Advance(time.Hour)
+	classes, err = s.rpcClient.GetExecutorClasses(s.context)
+	s.Require().NoError(err)
+	for _, class := range classes {
+		s.Require().Zero(class.Executors)
+		s.Require().True(class.IsStarving())
+	}
+}
+
+func (s *TaskDebugRpcTestSuite) Test_Resolve_Dead_Letter_Tasks() {
+	now := s.clock.Now()
+
+	parent := testaide.NewTaskEntry(now, types.WaitingForInput, types.UnknownExecutorId)
+	toRequeue := newDeadLetterTaskEntry(now)
+	parent.AddDependency(toRequeue)
+	toAbandon := newDeadLetterTaskEntry(now)
+	parent.AddDependency(toAbandon)
+
+	err := s.storage.AddTaskEntries(s.context, parent, toRequeue, toAbandon)
+	s.Require().NoError(err)
+
+	// Dead-lettered tasks are shown in the tree along with their last error
+	tree, err := s.rpcClient.GetTaskTree(s.context, parent.Task.Id)
+	s.Require().NoError(err)
+	s.Require().Len(tree.Dependencies, 2)
+	for _, dependency := range tree.Dependencies {
+		s.Require().True(dependency.IsDeadLettered())
+		s.Require().Equal(toRequeue.LastError.ErrText, dependency.ResultErrorText)
+	}
+
+	err = s.rpcClient.RequeueDeadLetterTask(s.context, toRequeue.Task.Id)
+	s.Require().NoError(err)
+
+	requeued, err := s.storage.TryGetTaskEntry(s.context, toRequeue.Task.Id)
+	s.Require().NoError(err)
+	s.Require().Equal(types.WaitingForExecutor, requeued.Status)
+	s.Require().Zero(requeued.RetryCount)
+
+	// Only dead-lettered tasks can be resolved
+	err = s.rpcClient.AbandonDeadLetterTask(s.context, toRequeue.Task.Id)
+	s.Require().ErrorContains(err, types.ErrTaskInvalidStatus.Error())
+
+	err = s.rpcClient.AbandonDeadLetterTask(s.context, toAbandon.Task.Id)
+	s.Require().NoError(err)
+
+	tree, err = s.rpcClient.GetTaskTree(s.context, parent.Task.Id)
+	s.Require().NoError(err)
+	abandoned := tree.Dependencies[toAbandon.Task.Id]
+	s.Require().NotNil(abandoned)
+	s.Require().True(abandoned.IsFailed())
+	s.Require().Contains(abandoned.ResultErrorText, toAbandon.LastError.ErrText)
+
+	err = s.rpcClient.RequeueDeadLetterTask(s.context, types.NewTaskId())
+	s.Require().ErrorContains(err, types.ErrTaskNotFound.Error())
+}
+
+func newDeadLetterTaskEntry(now time.Time) *types.TaskEntry {
+	entry := testaide.NewTaskEntry(now, types.DeadLetter, types.UnknownExecutorId)
+	entry.RetryCount = 5
+	entry.LastError = types.NewTaskExecError(types.TaskErrRpc, "RPC method failed")
+	return entry
+}
+
 func (s *TaskDebugRpcTestSuite) requestAndSendResult(
 	expected *types.Task, executor types.TaskExecutorId, completeSuccessfully bool,
 ) {
 	s.T().Helper()
 
-	taskToExec, err := s.storage.RequestTaskToExecute(s.context, executor)
+	taskToExec, err := s.storage.RequestTaskToExecute(s.context, executor, nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(taskToExec)
 	s.Require().Equal(expected, taskToExec)
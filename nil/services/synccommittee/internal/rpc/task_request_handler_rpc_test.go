@@ -21,9 +21,13 @@ type TaskRequestHandlerSuite struct {
 func (s *TaskRequestHandlerSuite) SetupSuite() {
 	s.ServerTestSuite.SetupSuite()
 
-	s.storage = storage.NewTaskStorage(s.database, s.clock, s.metricsHandler, s.logger)
+	var err error
+	s.storage, err = storage.NewTaskStorage(s.context, s.database, s.clock, s.metricsHandler, s.logger)
+	s.Require().NoError(err)
 	noopStateHandler := &api.TaskStateChangeHandlerMock{}
-	taskScheduler := scheduler.New(s.storage, noopStateHandler, s.metricsHandler, s.logger)
+	taskScheduler := scheduler.New(
+		s.storage, noopStateHandler, scheduler.NewExecutorRegistry(s.clock), s.metricsHandler, s.logger,
+	)
 
 	handler := TaskRequestServerHandler(taskScheduler)
 	s.RunRpcServer(handler)
@@ -46,7 +50,7 @@ func (s *TaskRequestHandlerSuite) Test_GetTask_Without_Deps() {
 	s.Require().NoError(err)
 
 	// Make the request
-	request := api.NewTaskRequest(executor)
+	request := api.NewTaskRequest(executor, nil)
 	receivedTask, err := s.rpcClient.GetTask(s.context, request)
 	s.Require().NoError(err)
 
@@ -83,7 +87,7 @@ func (s *TaskRequestHandlerSuite) Test_GetTask_With_Deps() {
 	s.Require().NoError(err)
 
 	// Make the request
-	request := api.NewTaskRequest(executor)
+	request := api.NewTaskRequest(executor, nil)
 	receivedTask, err := s.rpcClient.GetTask(s.context, request)
 	s.Require().NoError(err)
 
@@ -95,7 +99,7 @@ func (s *TaskRequestHandlerSuite) Test_GetTask_With_Deps() {
 func (s *TaskRequestHandlerSuite) Test_GetTask_Returns_Nil_When_No_Tasks_Available() {
 	executor := types.NewRandomExecutorId()
 
-	request := api.NewTaskRequest(executor)
+	request := api.NewTaskRequest(executor, nil)
 
 	receivedTask, err := s.rpcClient.GetTask(s.context, request)
 	s.Require().NoError(err)
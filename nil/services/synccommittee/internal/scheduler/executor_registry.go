@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/api"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/public"
+	"github.com/jonboulle/clockwork"
+)
+
+// executorActivityTimeout defines how long an executor is considered active after its last task request.
+const executorActivityTimeout = 5 * time.Minute
+
+// ExecutorRegistry keeps track of the executors requesting tasks from the scheduler.
+type ExecutorRegistry struct {
+	mutex     sync.Mutex
+	executors map[types.TaskExecutorId]*public.ExecutorView
+	clock     clockwork.Clock
+}
+
+func NewExecutorRegistry(clock clockwork.Clock) *ExecutorRegistry {
+	return &ExecutorRegistry{
+		executors: make(map[types.TaskExecutorId]*public.ExecutorView),
+		clock:     clock,
+	}
+}
+
+// OnTaskRequested records the request of the executor and whether any task was assigned to it.
+func (r *ExecutorRegistry) OnTaskRequested(request *api.TaskRequest, taskAssigned bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.executors[request.ExecutorId] = &public.ExecutorView{
+		Id:           request.ExecutorId,
+		Capabilities: request.Capabilities,
+		LastSeen:     r.clock.Now(),
+		Idle:         !taskAssigned,
+	}
+}
+
+// GetActiveExecutors returns executors which have requested tasks within executorActivityTimeout.
+func (r *ExecutorRegistry) GetActiveExecutors() []*public.ExecutorView {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	activeSince := r.clock.Now().Add(-executorActivityTimeout)
+
+	executors := make([]*public.ExecutorView, 0, len(r.executors))
+	for id, executor := range r.executors {
+		if executor.LastSeen.Before(activeSince) {
+			delete(r.executors, id)
+			continue
+		}
+		view := *executor
+		executors = append(executors, &view)
+	}
+	return executors
+}
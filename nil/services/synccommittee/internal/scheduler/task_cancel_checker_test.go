@@ -47,7 +47,8 @@ func (s *TaskCancelCheckerSuite) SetupSuite() {
 
 	clock := testaide.NewTestClock()
 
-	s.taskStorage = storage.NewTaskStorage(database, clock, metricsHandler, logger)
+	s.taskStorage, err = storage.NewTaskStorage(s.ctx, database, clock, metricsHandler, logger)
+	s.Require().NoError(err)
 }
 
 func (s *TaskCancelCheckerSuite) TearDownSuite() {
@@ -31,22 +31,30 @@ func DefaultConfig() Config {
 type Storage interface {
 	TryGetTaskEntry(ctx context.Context, id types.TaskId) (*types.TaskEntry, error)
 
-	RequestTaskToExecute(ctx context.Context, executor types.TaskExecutorId) (*types.Task, error)
+	RequestTaskToExecute(
+		ctx context.Context,
+		executor types.TaskExecutorId,
+		capabilities *types.ExecutorCapabilities,
+	) (*types.Task, error)
 
 	ProcessTaskResult(ctx context.Context, res *types.TaskResult) error
 
 	RescheduleHangingTasks(ctx context.Context, taskExecutionTimeout time.Duration) error
+
+	RequeueDeadLetteredTask(ctx context.Context, taskId types.TaskId) error
 }
 
 func New(
 	storage Storage,
 	stateHandler api.TaskStateChangeHandler,
+	executors *ExecutorRegistry,
 	metrics srv.WorkerMetrics,
 	logger logging.Logger,
 ) *taskScheduler {
 	scheduler := &taskScheduler{
 		storage:      storage,
 		stateHandler: stateHandler,
+		executors:    executors,
 		config:       DefaultConfig(),
 	}
 
@@ -60,6 +68,7 @@ type taskScheduler struct {
 
 	storage      Storage
 	stateHandler api.TaskStateChangeHandler
+	executors    *ExecutorRegistry
 	config       Config
 }
 
@@ -75,7 +84,7 @@ func (s *taskScheduler) runIteration(ctx context.Context) error {
 func (s *taskScheduler) GetTask(ctx context.Context, request *api.TaskRequest) (*types.Task, error) {
 	s.Logger.Debug().Stringer(logging.FieldTaskExecutorId, request.ExecutorId).Msg("Received new task request")
 
-	task, err := s.storage.RequestTaskToExecute(ctx, request.ExecutorId)
+	task, err := s.storage.RequestTaskToExecute(ctx, request.ExecutorId, request.Capabilities)
 	switch {
 	case errors.Is(err, context.Canceled):
 		return nil, err
@@ -87,6 +96,8 @@ func (s *taskScheduler) GetTask(ctx context.Context, request *api.TaskRequest) (
 		return nil, err
 	}
 
+	s.executors.OnTaskRequested(request, task != nil)
+
 	if task != nil {
 		log.NewTaskEvent(s.Logger, zerolog.DebugLevel, task).
 			Stringer(logging.FieldTaskExecutorId, request.ExecutorId).
@@ -155,6 +166,29 @@ func (s *taskScheduler) SetTaskResult(ctx context.Context, result *types.TaskRes
 	return nil
 }
 
+// RequeueTask makes a dead-lettered task available for execution again.
+func (s *taskScheduler) RequeueTask(ctx context.Context, taskId types.TaskId) error {
+	return s.storage.RequeueDeadLetteredTask(ctx, taskId)
+}
+
+// AbandonTask fails a dead-lettered task.
+// The failure is handled the same way as a non-retryable error reported by an executor.
+func (s *taskScheduler) AbandonTask(ctx context.Context, taskId types.TaskId) error {
+	entry, err := s.storage.TryGetTaskEntry(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("%w: taskId=%s", types.ErrTaskNotFound, taskId)
+	}
+	if entry.Status != types.DeadLetter {
+		return fmt.Errorf("%w: taskId=%s, status=%s", types.ErrTaskInvalidStatus, taskId, entry.Status)
+	}
+
+	s.Logger.Info().Stringer(logging.FieldTaskId, taskId).Msg("Abandoning dead-lettered task")
+	return s.SetTaskResult(ctx, types.NewAbandonTaskResult(entry))
+}
+
 func (s *taskScheduler) onTaskResultError(cause error, result *types.TaskResult) error {
 	log.NewTaskResultEvent(s.Logger, zerolog.ErrorLevel, result).Err(cause).Msg("Failed to process task result")
 	return fmt.Errorf("%w: %w", ErrFailedToProcessTaskResult, cause)
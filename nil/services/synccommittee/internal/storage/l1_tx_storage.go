@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common/logging"
+	"github.com/NilFoundation/nil/nil/internal/db"
+)
+
+const (
+	// l1InFlightTxsTable stores L1 transactions which are sent and not yet confirmed.
+	// Key: nonce (8 bytes, big endian), Value: transaction state encoded by the submitter.
+	l1InFlightTxsTable db.TableName = "l1_in_flight_txs"
+)
+
+// L1TxStorage keeps the state of the in-flight L1 transactions,
+// so that they are resumed instead of being sent again after the service restart.
+type L1TxStorage struct {
+	commonStorage
+}
+
+func NewL1TxStorage(
+	database db.DB,
+	logger logging.Logger,
+) *L1TxStorage {
+	return &L1TxStorage{
+		commonStorage: makeCommonStorage(database, logger),
+	}
+}
+
+// GetInFlightTxs returns the states of all the stored transactions by their nonces.
+func (s *L1TxStorage) GetInFlightTxs(ctx context.Context) (map[uint64][]byte, error) {
+	tx, err := s.database.CreateRoTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	txIter, err := tx.Range(l1InFlightTxsTable, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer txIter.Close()
+
+	states := make(map[uint64][]byte)
+	for txIter.HasNext() {
+		key, val, err := txIter.Next()
+		if err != nil {
+			return nil, err
+		}
+		if len(key) != 8 {
+			return nil, fmt.Errorf("%w: invalid in-flight transaction key %x", ErrSerializationFailed, key)
+		}
+		states[binary.BigEndian.Uint64(key)] = val
+	}
+	return states, nil
+}
+
+// PutInFlightTx saves the state of the transaction with the given nonce, replacing the previous one.
+func (s *L1TxStorage) PutInFlightTx(ctx context.Context, nonce uint64, state []byte) error {
+	return s.retryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := tx.Put(l1InFlightTxsTable, makeL1TxKey(nonce), state); err != nil {
+			return fmt.Errorf("failed to put in-flight transaction with nonce %d: %w", nonce, err)
+		}
+		return s.commit(tx)
+	})
+}
+
+// DeleteInFlightTx removes the state of the transaction with the given nonce.
+func (s *L1TxStorage) DeleteInFlightTx(ctx context.Context, nonce uint64) error {
+	return s.retryRunner.Do(ctx, func(ctx context.Context) error {
+		tx, err := s.database.CreateRwTx(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := tx.Delete(l1InFlightTxsTable, makeL1TxKey(nonce)); err != nil {
+			return fmt.Errorf("failed to delete in-flight transaction with nonce %d: %w", nonce, err)
+		}
+		return s.commit(tx)
+	})
+}
+
+func makeL1TxKey(nonce uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, nonce)
+}
@@ -29,6 +29,10 @@ const (
 	// rescheduledTasksPerTxLimit defines the maximum number of tasks that can be rescheduled
 	// in a single transaction of TaskStorage.RescheduleHangingTasks.
 	rescheduledTasksPerTxLimit = 100
+
+	// releasedRetriesPerTxLimit defines the maximum number of tasks waiting for retry that can be made available
+	// in a single transaction of TaskStorage.RequestTaskToExecute.
+	releasedRetriesPerTxLimit = 100
 )
 
 type TaskStorageMetrics interface {
@@ -42,28 +46,39 @@ type TaskStorageMetrics interface {
 // TaskStorage defines a type for managing tasks and their lifecycle operations.
 type TaskStorage struct {
 	commonStorage
-	clock   clockwork.Clock
-	metrics TaskStorageMetrics
+	clock         clockwork.Clock
+	metrics       TaskStorageMetrics
+	retryPolicies types.RetryPolicies
 }
 
+// NewTaskStorage creates a task storage on top of the given database
+// and migrates the data stored by the previous versions of the service.
 func NewTaskStorage(
+	ctx context.Context,
 	db db.DB,
 	clock clockwork.Clock,
 	metrics TaskStorageMetrics,
 	logger logging.Logger,
-) *TaskStorage {
+) (*TaskStorage, error) {
 	taskStorage := &TaskStorage{
 		commonStorage: makeCommonStorage(
 			db,
 			logger,
-			common.DoNotRetryIf(types.ErrTaskWrongExecutor, types.ErrTaskInvalidStatus, ErrTaskAlreadyExists),
+			common.DoNotRetryIf(
+				types.ErrTaskWrongExecutor, types.ErrTaskInvalidStatus, types.ErrTaskNotFound, ErrTaskAlreadyExists,
+			),
 		),
-		clock:   clock,
-		metrics: metrics,
+		clock:         clock,
+		metrics:       metrics,
+		retryPolicies: types.DefaultRetryPolicies(),
+	}
+
+	if err := taskStorage.migrate(ctx); err != nil {
+		return nil, fmt.Errorf("failed to migrate task storage: %w", err)
 	}
 
 	metrics.SetStatsProvider(taskStorage)
-	return taskStorage
+	return taskStorage, nil
 }
 
 func (st *TaskStorage) GetTaskStats(ctx context.Context) (*types.TaskStats, error) {
@@ -125,6 +140,8 @@ func (st *TaskStorage) putTaskEntry(tx db.RwTx, entry *types.TaskEntry, isFailed
 	tableName := taskEntriesTable
 	if isFailedTask {
 		tableName = failedTaskEntriesTable
+	} else if err := st.putIndexes(tx, entry); err != nil {
+		return err
 	}
 	if err := tx.Put(tableName, key, inputBuffer.Bytes()); err != nil {
 		return fmt.Errorf("failed to put task with id %s: %w", entry.Task.Id, err)
@@ -282,33 +299,41 @@ func (st *TaskStorage) GetTaskTreeView(ctx context.Context, rootTaskId types.Tas
 	return getTaskTreeRec(rootTaskId, 0)
 }
 
-// Helper to find available task with higher priority
-func (st *TaskStorage) findTopPriorityTask(tx db.RoTx) (*types.TaskEntry, error) {
-	var topPriorityTask *types.TaskEntry
-
-	for entry, err := range st.getStoredTasksSeq(tx) {
+// Helper to find available task with higher priority which can be handled by the executor
+func (st *TaskStorage) findTopPriorityTask(
+	tx db.RoTx,
+	capabilities *types.ExecutorCapabilities,
+) (*types.TaskEntry, error) {
+	// Waiting tasks are indexed in the order of their priority, the first suitable one is the top priority task
+	var topPriorityKey *taskIndexKey
+	for indexKey, err := range st.getIndexedTasksSeq(tx, types.WaitingForExecutor) {
 		if err != nil {
 			return nil, err
 		}
-
-		if entry.Status != types.WaitingForExecutor {
-			continue
-		}
-
-		if entry.HasHigherPriorityThan(topPriorityTask) {
-			topPriorityTask = entry
+		if capabilities.CanExecute(indexKey.taskType) {
+			topPriorityKey = indexKey
+			break
 		}
 	}
 
-	return topPriorityTask, nil
+	if topPriorityKey == nil {
+		return nil, nil
+	}
+	return st.extractIndexedTaskEntry(tx, topPriorityKey)
 }
 
-// RequestTaskToExecute Find task with no dependencies and higher priority and assign it to the executor
-func (st *TaskStorage) RequestTaskToExecute(ctx context.Context, executor types.TaskExecutorId) (*types.Task, error) {
+// RequestTaskToExecute Find task with no dependencies and higher priority and assign it to the executor.
+// Only tasks matching the executor capabilities are considered, nil capabilities mean no restrictions.
+// If the executor already has the maximum number of running tasks, method returns nil.
+func (st *TaskStorage) RequestTaskToExecute(
+	ctx context.Context,
+	executor types.TaskExecutorId,
+	capabilities *types.ExecutorCapabilities,
+) (*types.Task, error) {
 	var taskEntry *types.TaskEntry
 	err := st.retryRunner.Do(ctx, func(ctx context.Context) error {
 		var err error
-		taskEntry, err = st.requestTaskToExecuteImpl(ctx, executor)
+		taskEntry, err = st.requestTaskToExecuteImpl(ctx, executor, capabilities)
 		return err
 	})
 	if err != nil {
@@ -325,6 +350,7 @@ func (st *TaskStorage) RequestTaskToExecute(ctx context.Context, executor types.
 func (st *TaskStorage) requestTaskToExecuteImpl(
 	ctx context.Context,
 	executor types.TaskExecutorId,
+	capabilities *types.ExecutorCapabilities,
 ) (*types.TaskEntry, error) {
 	tx, err := st.database.CreateRwTx(ctx)
 	if err != nil {
@@ -332,16 +358,34 @@ func (st *TaskStorage) requestTaskToExecuteImpl(
 	}
 	defer tx.Rollback()
 
-	taskEntry, err := st.findTopPriorityTask(tx)
+	if capabilities != nil && capabilities.Concurrency > 0 {
+		assigned, err := st.countExecutorTasks(tx, executor)
+		if err != nil {
+			return nil, err
+		}
+		if !capabilities.HasFreeSlot(assigned) {
+			return nil, nil
+		}
+	}
+
+	currentTime := st.clock.Now()
+	released, err := st.releaseDueRetriesTx(tx, currentTime)
+	if err != nil {
+		return nil, err
+	}
+
+	taskEntry, err := st.findTopPriorityTask(tx, capabilities)
 	if err != nil {
 		return nil, err
 	}
 	if taskEntry == nil {
-		// No task available
+		// No task available, but released ones still have to be saved
+		if released > 0 {
+			return nil, st.commit(tx)
+		}
 		return nil, nil
 	}
 
-	currentTime := st.clock.Now()
 	if err := taskEntry.Start(executor, currentTime); err != nil {
 		return nil, fmt.Errorf("failed to start task: %w", err)
 	}
@@ -354,6 +398,37 @@ func (st *TaskStorage) requestTaskToExecuteImpl(
 	return taskEntry, nil
 }
 
+// Helper to make tasks waiting for retry available for execution once their backoff delay has passed
+func (st *TaskStorage) releaseDueRetriesTx(tx db.RwTx, currentTime time.Time) (int, error) {
+	// Tasks waiting for retry are indexed by their retry time, so only the due ones are visited
+	var due []*types.TaskEntry
+	for indexKey, err := range st.getIndexedTasksSeq(tx, types.WaitingForRetry) {
+		if err != nil {
+			return 0, err
+		}
+		if len(due) == releasedRetriesPerTxLimit || indexKey.orderTime.After(currentTime) {
+			break
+		}
+
+		entry, err := st.extractIndexedTaskEntry(tx, indexKey)
+		if err != nil {
+			return 0, err
+		}
+		due = append(due, entry)
+	}
+
+	for _, entry := range due {
+		if err := entry.ReleaseRetry(); err != nil {
+			return 0, err
+		}
+		if err := st.putTaskEntry(tx, entry, false); err != nil {
+			return 0, fmt.Errorf("failed to put released task: %w", err)
+		}
+	}
+
+	return len(due), nil
+}
+
 // ProcessTaskResult checks task result and updates dependencies in case of success
 func (st *TaskStorage) ProcessTaskResult(ctx context.Context, res *types.TaskResult) error {
 	return st.retryRunner.Do(ctx, func(ctx context.Context) error {
@@ -385,7 +460,8 @@ func (st *TaskStorage) processTaskResultImpl(ctx context.Context, res *types.Tas
 	}
 
 	if res.HasRetryableError() {
-		if err := st.rescheduleTaskTx(tx, entry, res.Error); err != nil {
+		retried, err := st.rescheduleTaskTx(tx, entry, res.Error)
+		if err != nil {
 			return err
 		}
 
@@ -393,7 +469,9 @@ func (st *TaskStorage) processTaskResultImpl(ctx context.Context, res *types.Tas
 			return err
 		}
 
-		st.metrics.RecordTaskRescheduled(ctx, entry.Task.TaskType, res.Sender)
+		if retried {
+			st.metrics.RecordTaskRescheduled(ctx, entry.Task.TaskType, res.Sender)
+		}
 		return nil
 	}
 
@@ -420,7 +498,7 @@ func (st *TaskStorage) terminateTaskTx(tx db.RwTx, entry *types.TaskEntry, res *
 		Msgf("Task execution is completed with status %s, removing it from the storage", res.StatusStr())
 
 	// We don't keep finished tasks in DB
-	if err := tx.Delete(taskEntriesTable, res.TaskId.Bytes()); err != nil {
+	if err := st.deleteTaskEntry(tx, res.TaskId); err != nil {
 		return err
 	}
 
@@ -496,28 +574,34 @@ func (st *TaskStorage) rescheduleHangingTasksImpl(
 
 	currentTime := st.clock.Now()
 
-loop:
-	for entry, err := range st.getStoredTasksSeq(tx) {
-		switch {
-		case err != nil:
-			return nil, err
-
-		case len(rescheduled) == rescheduledTasksPerTxLimit:
-			break loop
+	// Running tasks are indexed by their start time, so only the hanging ones are visited
+	startedBefore := currentTime.Add(-taskExecutionTimeout)
 
-		case entry.Status != types.Running:
-			continue
+	var hanging []*types.TaskEntry
+	for indexKey, err := range st.getIndexedTasksSeq(tx, types.Running) {
+		if err != nil {
+			return nil, err
+		}
+		if len(hanging) == rescheduledTasksPerTxLimit || !indexKey.orderTime.Before(startedBefore) {
+			break
+		}
 
-		case *entry.ExecutionTime(currentTime) <= taskExecutionTimeout:
-			continue
+		entry, err := st.extractIndexedTaskEntry(tx, indexKey)
+		if err != nil {
+			return nil, err
+		}
+		hanging = append(hanging, entry)
+	}
 
-		default:
-			previousExecutor := entry.Owner
-			timeoutErr := types.NewTaskErrTimeout(*entry.ExecutionTime(currentTime), taskExecutionTimeout)
-			if err := st.rescheduleTaskTx(tx, entry, timeoutErr); err != nil {
-				return nil, err
-			}
+	for _, entry := range hanging {
+		previousExecutor := entry.Owner
+		timeoutErr := types.NewTaskErrTimeout(*entry.ExecutionTime(currentTime), taskExecutionTimeout)
+		retried, err := st.rescheduleTaskTx(tx, entry, timeoutErr)
+		if err != nil {
+			return nil, err
+		}
 
+		if retried {
 			rescheduled = append(rescheduled, rescheduledTask{entry.Task.TaskType, previousExecutor})
 		}
 	}
@@ -529,26 +613,84 @@ loop:
 	return rescheduled, nil
 }
 
+// rescheduleTaskTx schedules the next execution attempt of the failed task according to its retry policy.
+// If the retry budget is exhausted, the task is moved to the dead-letter state and false is returned.
 func (st *TaskStorage) rescheduleTaskTx(
 	tx db.RwTx,
 	entry *types.TaskEntry,
 	cause *types.TaskExecError,
-) error {
+) (bool, error) {
+	policy := st.retryPolicies.For(entry.Task.TaskType, cause.ErrType)
+
+	if policy.IsExhausted(entry.RetryCount) {
+		log.NewTaskEvent(st.logger, zerolog.ErrorLevel, &entry.Task).
+			Err(cause).
+			Stringer(logging.FieldTaskExecutorId, entry.Owner).
+			Int("retryCount", entry.RetryCount).
+			Msg("Task execution error, retry budget is exhausted, moving task to the dead-letter state")
+
+		if err := entry.MoveToDeadLetter(cause); err != nil {
+			return false, fmt.Errorf("failed to move task to the dead-letter state: %w", err)
+		}
+		if err := st.putTaskEntry(tx, entry, false); err != nil {
+			return false, fmt.Errorf("failed to put dead-lettered task: %w", err)
+		}
+		return false, nil
+	}
+
+	backoff := policy.Backoff(entry.RetryCount)
+
 	log.NewTaskEvent(st.logger, zerolog.WarnLevel, &entry.Task).
 		Err(cause).
 		Stringer(logging.FieldTaskExecutorId, entry.Owner).
 		Int("retryCount", entry.RetryCount).
+		Dur("backoff", backoff).
 		Msg("Task execution error, rescheduling")
 
-	if err := entry.ResetRunning(); err != nil {
-		return fmt.Errorf("failed to reset task: %w", err)
+	if err := entry.ScheduleRetry(cause, backoff, st.clock.Now()); err != nil {
+		return false, fmt.Errorf("failed to reset task: %w", err)
 	}
 
 	if err := st.putTaskEntry(tx, entry, false); err != nil {
-		return fmt.Errorf("failed to put rescheduled task: %w", err)
+		return false, fmt.Errorf("failed to put rescheduled task: %w", err)
 	}
 
-	return nil
+	return true, nil
+}
+
+// RequeueDeadLetteredTask makes a dead-lettered task available for execution again with the full retry budget.
+func (st *TaskStorage) RequeueDeadLetteredTask(ctx context.Context, taskId types.TaskId) error {
+	return st.retryRunner.Do(ctx, func(ctx context.Context) error {
+		return st.requeueDeadLetteredTaskImpl(ctx, taskId)
+	})
+}
+
+func (st *TaskStorage) requeueDeadLetteredTaskImpl(ctx context.Context, taskId types.TaskId) error {
+	tx, err := st.database.CreateRwTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	entry, err := st.extractTaskEntry(tx, taskId)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return fmt.Errorf("%w: taskId=%s", types.ErrTaskNotFound, taskId)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := entry.Requeue(); err != nil {
+		return err
+	}
+
+	log.NewTaskEvent(st.logger, zerolog.InfoLevel, &entry.Task).Msg("Dead-lettered task is re-queued")
+
+	if err := st.putTaskEntry(tx, entry, false); err != nil {
+		return fmt.Errorf("failed to put re-queued task: %w", err)
+	}
+
+	return st.commit(tx)
 }
 
 func (st *TaskStorage) CancelTasksByParentId(
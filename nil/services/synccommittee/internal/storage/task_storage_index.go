@@ -0,0 +1,316 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/internal/db"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+)
+
+// taskStatusIndexTable BadgerDB table, keeps a key for each entry of taskEntriesTable.
+// Key layout: status (1 byte) | order time (12 bytes) | task type (1 byte) | task id.
+// Order time is the start time for running tasks, the retry time for tasks waiting for retry
+// and the creation time for others, so within a status keys are sorted the same way
+// as TaskEntry.HasHigherPriorityThan orders tasks, running tasks are sorted by the time they were started
+// and tasks waiting for retry by the time they become available.
+const taskStatusIndexTable db.TableName = "task_status_index"
+
+const (
+	statusIndexTimeOffset = 1
+	statusIndexTypeOffset = statusIndexTimeOffset + 12
+	statusIndexIdOffset   = statusIndexTypeOffset + 1
+)
+
+type taskIndexKey struct {
+	status    types.TaskStatus
+	orderTime time.Time
+	taskType  types.TaskType
+	taskId    []byte
+}
+
+func (*TaskStorage) makeStatusIndexKey(entry *types.TaskEntry) []byte {
+	orderTime := entry.Created
+	switch {
+	case entry.Status == types.Running && entry.Started != nil:
+		orderTime = *entry.Started
+	case entry.Status == types.WaitingForRetry && entry.RetryAfter != nil:
+		orderTime = *entry.RetryAfter
+	}
+
+	id := entry.Task.Id.Bytes()
+	key := make([]byte, statusIndexIdOffset+len(id))
+	key[0] = byte(entry.Status)
+	putIndexTime(key[statusIndexTimeOffset:], orderTime)
+	key[statusIndexTypeOffset] = byte(entry.Task.TaskType)
+	copy(key[statusIndexIdOffset:], id)
+	return key
+}
+
+func parseStatusIndexKey(key []byte) (*taskIndexKey, error) {
+	if len(key) <= statusIndexIdOffset {
+		return nil, fmt.Errorf("%w: invalid task index key %x", ErrSerializationFailed, key)
+	}
+	return &taskIndexKey{
+		status:    types.TaskStatus(key[0]),
+		orderTime: getIndexTime(key[statusIndexTimeOffset:]),
+		taskType:  types.TaskType(key[statusIndexTypeOffset]),
+		taskId:    key[statusIndexIdOffset:],
+	}, nil
+}
+
+// putIndexTime writes seconds with the flipped sign bit followed by nanoseconds,
+// so that the byte order of the encoded values matches the order of the timestamps.
+// Unlike UnixNano, it works for any time including the zero one.
+func putIndexTime(dst []byte, t time.Time) {
+	binary.BigEndian.PutUint64(dst, uint64(t.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(dst[8:], uint32(t.Nanosecond()))
+}
+
+func getIndexTime(src []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint64(src) ^ (1 << 63))
+	return time.Unix(seconds, int64(binary.BigEndian.Uint32(src[8:])))
+}
+
+// taskExecutorIndexTable BadgerDB table, keeps a key for each running task of taskEntriesTable,
+// so that the tasks assigned to an executor can be counted without visiting the tasks of other executors.
+// Key layout: executor id (4 bytes, big endian) | task id.
+const taskExecutorIndexTable db.TableName = "task_executor_index"
+
+func makeExecutorIndexPrefix(executor types.TaskExecutorId) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(executor))
+}
+
+// makeExecutorIndexKey returns nil for the entries which are not running.
+func (*TaskStorage) makeExecutorIndexKey(entry *types.TaskEntry) []byte {
+	if entry.Status != types.Running {
+		return nil
+	}
+	return append(makeExecutorIndexPrefix(entry.Owner), entry.Task.Id.Bytes()...)
+}
+
+// putIndexes replaces the index keys of the previously stored version of the entry (if any) with the actual ones.
+func (st *TaskStorage) putIndexes(tx db.RwTx, entry *types.TaskEntry) error {
+	if err := st.deleteIndexes(tx, entry.Task.Id); err != nil {
+		return err
+	}
+	return st.putIndexKeys(tx, entry)
+}
+
+func (st *TaskStorage) putIndexKeys(tx db.RwTx, entry *types.TaskEntry) error {
+	if err := tx.Put(taskStatusIndexTable, st.makeStatusIndexKey(entry), nil); err != nil {
+		return fmt.Errorf("failed to put index of task with id %s: %w", entry.Task.Id, err)
+	}
+	if key := st.makeExecutorIndexKey(entry); key != nil {
+		if err := tx.Put(taskExecutorIndexTable, key, nil); err != nil {
+			return fmt.Errorf("failed to put executor index of task with id %s: %w", entry.Task.Id, err)
+		}
+	}
+	return nil
+}
+
+func (st *TaskStorage) deleteIndexes(tx db.RwTx, id types.TaskId) error {
+	stored, err := st.extractTaskEntry(tx, id)
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Delete(taskStatusIndexTable, st.makeStatusIndexKey(stored)); err != nil {
+		return fmt.Errorf("failed to delete index of task with id %s: %w", id, err)
+	}
+	if key := st.makeExecutorIndexKey(stored); key != nil {
+		if err := tx.Delete(taskExecutorIndexTable, key); err != nil {
+			return fmt.Errorf("failed to delete executor index of task with id %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// deleteTaskEntry removes the entry from taskEntriesTable along with its index keys.
+func (st *TaskStorage) deleteTaskEntry(tx db.RwTx, id types.TaskId) error {
+	if err := st.deleteIndexes(tx, id); err != nil {
+		return err
+	}
+	return tx.Delete(taskEntriesTable, id.Bytes())
+}
+
+// getIndexedTasksSeq iterates over the index keys of the tasks with the given status in the index order.
+func (*TaskStorage) getIndexedTasksSeq(tx db.RoTx, status types.TaskStatus) iter.Seq2[*taskIndexKey, error] {
+	return func(yield func(*taskIndexKey, error) bool) {
+		txIter, err := tx.Range(taskStatusIndexTable, []byte{byte(status)}, nil)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer txIter.Close()
+
+		for txIter.HasNext() {
+			key, _, err := txIter.Next()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			indexKey, err := parseStatusIndexKey(key)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if indexKey.status != status {
+				return
+			}
+			if !yield(indexKey, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (st *TaskStorage) extractIndexedTaskEntry(tx db.RoTx, indexKey *taskIndexKey) (*types.TaskEntry, error) {
+	var id types.TaskId
+	if err := id.UnmarshalText(indexKey.taskId); err != nil {
+		return nil, fmt.Errorf("%w: invalid task id in index: %w", ErrSerializationFailed, err)
+	}
+	entry, err := st.extractTaskEntry(tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexed task with id %s: %w", id, err)
+	}
+	return entry, nil
+}
+
+// countExecutorTasks counts running tasks assigned to the executor, only the keys of its own tasks are visited.
+func (*TaskStorage) countExecutorTasks(tx db.RoTx, executor types.TaskExecutorId) (uint32, error) {
+	prefix := makeExecutorIndexPrefix(executor)
+	txIter, err := tx.Range(taskExecutorIndexTable, prefix, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer txIter.Close()
+
+	var count uint32
+	for txIter.HasNext() {
+		key, _, err := txIter.Next()
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+const (
+	// taskStorageVersionTable BadgerDB table, keeps the version of the layout of TaskStorage tables.
+	// Key: taskStorageVersionKey, Value: version (4 bytes, big endian).
+	taskStorageVersionTable db.TableName = "task_storage_version"
+
+	// taskStorageVersion is the current layout version, it must be incremented
+	// each time data written by the previous versions needs to be migrated in TaskStorage.migrate.
+	// Version 1: taskStatusIndexTable is introduced.
+	// Version 2: taskExecutorIndexTable is introduced.
+	taskStorageVersion uint32 = 2
+)
+
+var taskStorageVersionKey = []byte(taskStorageVersionTable)
+
+// migrate brings the data written by the previous versions of the service to the actual layout.
+func (st *TaskStorage) migrate(ctx context.Context) error {
+	return st.retryRunner.Do(ctx, func(ctx context.Context) error {
+		return st.migrateImpl(ctx)
+	})
+}
+
+func (st *TaskStorage) migrateImpl(ctx context.Context) error {
+	tx, err := st.database.CreateRwTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	version, err := st.getStorageVersion(tx)
+	if err != nil {
+		return err
+	}
+	if version >= taskStorageVersion {
+		return nil
+	}
+
+	st.logger.Info().
+		Uint32("fromVersion", version).
+		Uint32("toVersion", taskStorageVersion).
+		Msg("migrating task storage")
+
+	if err := st.rebuildIndexes(tx); err != nil {
+		return err
+	}
+
+	versionBytes := binary.BigEndian.AppendUint32(nil, taskStorageVersion)
+	if err := tx.Put(taskStorageVersionTable, taskStorageVersionKey, versionBytes); err != nil {
+		return fmt.Errorf("failed to put task storage version: %w", err)
+	}
+	return st.commit(tx)
+}
+
+func (*TaskStorage) getStorageVersion(tx db.RoTx) (uint32, error) {
+	value, err := tx.Get(taskStorageVersionTable, taskStorageVersionKey)
+	switch {
+	case errors.Is(err, db.ErrKeyNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("failed to get task storage version: %w", err)
+	case len(value) != 4:
+		return 0, fmt.Errorf("%w: invalid task storage version %x", ErrSerializationFailed, value)
+	}
+	return binary.BigEndian.Uint32(value), nil
+}
+
+// rebuildIndexes drops all keys of the index tables and puts the keys for each entry of taskEntriesTable.
+func (st *TaskStorage) rebuildIndexes(tx db.RwTx) error {
+	for _, table := range []db.TableName{taskStatusIndexTable, taskExecutorIndexTable} {
+		staleKeys, err := collectTableKeys(tx, table)
+		if err != nil {
+			return err
+		}
+		for _, key := range staleKeys {
+			if err := tx.Delete(table, key); err != nil {
+				return fmt.Errorf("failed to delete key %x of %s: %w", key, table, err)
+			}
+		}
+	}
+
+	for entry, err := range st.getStoredTasksSeq(tx) {
+		if err != nil {
+			return err
+		}
+		if err := st.putIndexKeys(tx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectTableKeys(tx db.RoTx, table db.TableName) ([][]byte, error) {
+	txIter, err := tx.Range(table, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer txIter.Close()
+
+	var keys [][]byte
+	for txIter.HasNext() {
+		key, _, err := txIter.Next()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, bytes.Clone(key))
+	}
+	return keys, nil
+}
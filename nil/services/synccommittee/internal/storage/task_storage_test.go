@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -23,7 +24,7 @@ const (
 type TaskStorageSuite struct {
 	suite.Suite
 	database db.DB
-	clock    clockwork.Clock
+	clock    *clockwork.FakeClock
 	ts       *TaskStorage
 	ctx      context.Context
 }
@@ -45,13 +46,15 @@ func (s *TaskStorageSuite) SetupSuite() {
 	s.Require().NoError(err)
 
 	s.clock = testaide.NewTestClock()
-	s.ts = NewTaskStorage(database, s.clock, metricsHandler, logger)
 	s.ctx = context.Background()
+	s.ts, err = NewTaskStorage(s.ctx, database, s.clock, metricsHandler, logger)
+	s.Require().NoError(err)
 }
 
 func (s *TaskStorageSuite) TearDownTest() {
 	err := s.database.DropAll()
 	s.Require().NoError(err, "failed to clear database in TearDownTest")
+	testaide.ResetTestClock(s.clock)
 }
 
 func (s *TaskStorageSuite) Test_Request_And_Process_Result() {
@@ -73,7 +76,7 @@ func (s *TaskStorageSuite) Test_Request_And_Process_Result() {
 	s.Require().NoError(err)
 
 	// No available tasks for executor at this point
-	task, err := s.ts.RequestTaskToExecute(s.ctx, 88)
+	task, err := s.ts.RequestTaskToExecute(s.ctx, 88, nil)
 	s.Require().NoError(err)
 	s.Require().Nil(task)
 
@@ -84,7 +87,7 @@ func (s *TaskStorageSuite) Test_Request_And_Process_Result() {
 			dependency1.Task.Id, dependency1.Owner, types.TaskOutputArtifacts{}, types.TaskResultData{}),
 	)
 	s.Require().NoError(err)
-	task, err = s.ts.RequestTaskToExecute(s.ctx, 88)
+	task, err = s.ts.RequestTaskToExecute(s.ctx, 88, nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(task)
 	s.Equal(task.Id, lowerPriorityEntry.Task.Id)
@@ -97,12 +100,62 @@ func (s *TaskStorageSuite) Test_Request_And_Process_Result() {
 	)
 	s.Require().NoError(err)
 
-	task, err = s.ts.RequestTaskToExecute(s.ctx, 88)
+	task, err = s.ts.RequestTaskToExecute(s.ctx, 88, nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(task)
 	s.Equal(task.Id, higherPriorityEntry.Task.Id)
 }
 
+func (s *TaskStorageSuite) Test_Status_Index_Rebuilt_On_Open() {
+	now := s.clock.Now()
+
+	readyEntry := testaide.NewTaskEntry(now, types.WaitingForExecutor, types.UnknownExecutorId)
+	runningEntry := testaide.NewTaskEntry(now, types.Running, types.NewRandomExecutorId())
+	err := s.ts.AddTaskEntries(s.ctx, readyEntry, runningEntry)
+	s.Require().NoError(err)
+
+	// Simulate a database written before the index was introduced:
+	// index keys are missing for stored tasks and may remain for deleted ones
+	tx, err := s.database.CreateRwTx(s.ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	indexKeys, err := collectTableKeys(tx, taskStatusIndexTable)
+	s.Require().NoError(err)
+	s.Require().Len(indexKeys, 2)
+	for _, key := range indexKeys {
+		s.Require().NoError(tx.Delete(taskStatusIndexTable, key))
+	}
+	deletedEntry := testaide.NewTaskEntry(now.Add(-time.Minute), types.WaitingForExecutor, types.UnknownExecutorId)
+	s.Require().NoError(tx.Put(taskStatusIndexTable, s.ts.makeStatusIndexKey(deletedEntry), nil))
+	executorKeys, err := collectTableKeys(tx, taskExecutorIndexTable)
+	s.Require().NoError(err)
+	s.Require().Len(executorKeys, 1)
+	s.Require().NoError(tx.Delete(taskExecutorIndexTable, executorKeys[0]))
+	s.Require().NoError(tx.Delete(taskStorageVersionTable, taskStorageVersionKey))
+	s.Require().NoError(tx.Commit())
+
+	metricsHandler, err := metrics.NewSyncCommitteeMetrics()
+	s.Require().NoError(err)
+	reopened, err := NewTaskStorage(s.ctx, s.database, s.clock, metricsHandler, logging.Nop())
+	s.Require().NoError(err)
+
+	roTx, err := s.database.CreateRoTx(s.ctx)
+	s.Require().NoError(err)
+	defer roTx.Rollback()
+	rebuiltKeys, err := collectTableKeys(roTx, taskStatusIndexTable)
+	s.Require().NoError(err)
+	s.Require().ElementsMatch(indexKeys, rebuiltKeys)
+	assigned, err := reopened.countExecutorTasks(roTx, runningEntry.Owner)
+	s.Require().NoError(err)
+	s.Require().EqualValues(1, assigned)
+
+	task, err := reopened.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+	s.Require().Equal(readyEntry.Task.Id, task.Id)
+}
+
 func (s *TaskStorageSuite) Test_ProcessTaskResult_Retryable_Error() {
 	now := s.clock.Now()
 	executorId := types.NewRandomExecutorId()
@@ -146,12 +199,104 @@ func (s *TaskStorageSuite) Test_ProcessTaskResult_Retryable_Error() {
 	s.Require().Equal(sndChildTask, sndChildFromStorage)
 }
 
+func (s *TaskStorageSuite) Test_ProcessTaskResult_Retry_Backoff() {
+	executorId := types.NewRandomExecutorId()
+	entry := testaide.NewTaskEntry(s.clock.Now(), types.WaitingForExecutor, types.UnknownExecutorId)
+	err := s.ts.AddTaskEntries(s.ctx, entry)
+	s.Require().NoError(err)
+
+	policy := s.ts.retryPolicies.For(entry.Task.TaskType, types.TaskErrRpc)
+
+	for retryCount := range policy.MaxRetries {
+		task, err := s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
+		s.Require().NoError(err)
+		s.Require().NotNil(task, "retryCount=%d", retryCount)
+
+		err = s.ts.ProcessTaskResult(s.ctx, testaide.NewRetryableErrorTaskResult(task.Id, executorId))
+		s.Require().NoError(err)
+
+		backoff := policy.Backoff(retryCount)
+		if backoff == 0 {
+			continue
+		}
+
+		fromStorage, err := s.ts.TryGetTaskEntry(s.ctx, entry.Task.Id)
+		s.Require().NoError(err)
+		s.Require().Equal(types.WaitingForRetry, fromStorage.Status)
+		s.Require().Equal(retryCount+1, fromStorage.RetryCount)
+
+		// Task is not available until the backoff delay has passed
+		s.clock.Advance(backoff - time.Second)
+		task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
+		s.Require().NoError(err)
+		s.Require().Nil(task)
+
+		s.clock.Advance(time.Second)
+	}
+
+	task, err := s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+
+	// Retry budget is exhausted, the task is moved to the dead-letter state
+	err = s.ts.ProcessTaskResult(s.ctx, testaide.NewRetryableErrorTaskResult(task.Id, executorId))
+	s.Require().NoError(err)
+
+	deadLettered, err := s.ts.TryGetTaskEntry(s.ctx, entry.Task.Id)
+	s.Require().NoError(err)
+	s.Require().Equal(types.DeadLetter, deadLettered.Status)
+	s.Require().Equal(types.UnknownExecutorId, deadLettered.Owner)
+	s.Require().NotNil(deadLettered.LastError)
+
+	s.clock.Advance(time.Hour)
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+
+	stats, err := s.ts.GetTaskStats(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(1), stats.CountPerType[entry.Task.TaskType].DeadLetterCount)
+
+	// Re-queued task is available again with the full retry budget
+	err = s.ts.RequeueDeadLetteredTask(s.ctx, entry.Task.Id)
+	s.Require().NoError(err)
+
+	err = s.ts.RequeueDeadLetteredTask(s.ctx, entry.Task.Id)
+	s.Require().ErrorIs(err, types.ErrTaskInvalidStatus)
+
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+
+	requeued, err := s.ts.TryGetTaskEntry(s.ctx, entry.Task.Id)
+	s.Require().NoError(err)
+	s.Require().Zero(requeued.RetryCount)
+}
+
+func (s *TaskStorageSuite) Test_TaskRescheduling_Exhausted_Retries() {
+	now := s.clock.Now()
+	executionTimeout := time.Minute
+
+	hangingEntry := testaide.NewTaskEntry(now.Add(-executionTimeout*2), types.Running, types.NewRandomExecutorId())
+	hangingEntry.RetryCount = s.ts.retryPolicies.For(hangingEntry.Task.TaskType, types.TaskErrTimeout).MaxRetries
+	err := s.ts.AddTaskEntries(s.ctx, hangingEntry)
+	s.Require().NoError(err)
+
+	err = s.ts.RescheduleHangingTasks(s.ctx, executionTimeout)
+	s.Require().NoError(err)
+
+	fromStorage, err := s.ts.TryGetTaskEntry(s.ctx, hangingEntry.Task.Id)
+	s.Require().NoError(err)
+	s.Require().Equal(types.DeadLetter, fromStorage.Status)
+	s.Require().Equal(types.TaskErrTimeout, fromStorage.LastError.ErrType)
+}
+
 func (s *TaskStorageSuite) Test_TaskRescheduling_NoEntries() {
 	executionTimeout := time.Minute
 	err := s.ts.RescheduleHangingTasks(s.ctx, executionTimeout)
 	s.Require().NoError(err)
 
-	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().Nil(taskToExecute)
 }
@@ -173,7 +318,7 @@ func (s *TaskStorageSuite) Test_TaskRescheduling_NoActiveTasks() {
 
 	// All existing tasks are still available for execution
 	for range entries {
-		taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+		taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 		s.Require().NoError(err)
 		s.Require().NotNil(taskToExecute)
 	}
@@ -192,7 +337,7 @@ func (s *TaskStorageSuite) Test_TaskRescheduling_SingleActiveTask() {
 	s.Require().NoError(err)
 
 	// Active task wasn't rescheduled
-	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().Nil(taskToExecute)
 }
@@ -215,17 +360,143 @@ func (s *TaskStorageSuite) Test_TaskRescheduling_MultipleTasks() {
 	s.Require().NoError(err)
 
 	// Outdated task was rescheduled and became available for execution
-	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+	taskToExecute, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().NotNil(taskToExecute)
 	s.Require().Equal(outdatedEntry.Task, *taskToExecute)
 
 	// Active and failed tasks weren't rescheduled
-	taskToExecute, err = s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+	taskToExecute, err = s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().Nil(taskToExecute)
 }
 
+func (s *TaskStorageSuite) Test_RequestTaskToExecute_PriorityOrder() {
+	now := s.clock.Now()
+
+	oldest := newWaitingTaskEntry(types.PartialProve, now.Add(-time.Hour))
+	sameTimeLowerType := newWaitingTaskEntry(types.PartialProve, now)
+	sameTimeHigherType := newWaitingTaskEntry(types.AggregatedChallenge, now)
+	newest := newWaitingTaskEntry(types.PartialProve, now.Add(time.Hour))
+	waitingForInput := testaide.NewTaskEntry(now.Add(-2*time.Hour), types.WaitingForInput, types.UnknownExecutorId)
+
+	err := s.ts.AddTaskEntries(s.ctx, newest, sameTimeHigherType, waitingForInput, oldest, sameTimeLowerType)
+	s.Require().NoError(err)
+
+	for _, expected := range []*types.TaskEntry{oldest, sameTimeLowerType, sameTimeHigherType, newest} {
+		task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
+		s.Require().NoError(err)
+		s.Require().NotNil(task)
+		s.Require().Equal(expected.Task.Id, task.Id)
+	}
+
+	task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+}
+
+func (s *TaskStorageSuite) Test_RequestTaskToExecute_Capabilities() {
+	now := s.clock.Now()
+
+	heavyTask := newWaitingTaskEntry(types.MergeProof, now.Add(-time.Hour))
+	partialProve := newWaitingTaskEntry(types.PartialProve, now)
+	err := s.ts.AddTaskEntries(s.ctx, heavyTask, partialProve)
+	s.Require().NoError(err)
+
+	// Small executor is not able to handle any of the tasks
+	smallExecutor := &types.ExecutorCapabilities{ResourceClass: types.ResourceClassSmall}
+	task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), smallExecutor)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+
+	// Medium executor skips the top priority task which requires a large machine
+	mediumExecutor := &types.ExecutorCapabilities{ResourceClass: types.ResourceClassMedium}
+	task, err = s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), mediumExecutor)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+	s.Require().Equal(partialProve.Task.Id, task.Id)
+
+	// Large executor which doesn't support the task type doesn't receive it
+	largeExecutor := &types.ExecutorCapabilities{
+		TaskTypes:     []types.TaskType{types.AggregatedFRI},
+		ResourceClass: types.ResourceClassLarge,
+	}
+	task, err = s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), largeExecutor)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+
+	largeExecutor.TaskTypes = append(largeExecutor.TaskTypes, types.MergeProof)
+	task, err = s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), largeExecutor)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+	s.Require().Equal(heavyTask.Task.Id, task.Id)
+}
+
+func (s *TaskStorageSuite) Test_RequestTaskToExecute_Concurrency() {
+	now := s.clock.Now()
+
+	executorId := types.NewRandomExecutorId()
+	runningEntry := testaide.NewTaskEntry(now, types.Running, executorId)
+	err := s.ts.AddTaskEntries(s.ctx,
+		runningEntry,
+		testaide.NewTaskEntry(now, types.WaitingForExecutor, types.UnknownExecutorId),
+		testaide.NewTaskEntry(now, types.WaitingForExecutor, types.UnknownExecutorId),
+	)
+	s.Require().NoError(err)
+
+	capabilities := &types.ExecutorCapabilities{Concurrency: 2}
+
+	task, err := s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+
+	// Executor already has the maximum number of running tasks
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+
+	err = s.ts.ProcessTaskResult(s.ctx, testaide.NewSuccessTaskResult(runningEntry.Task.Id, executorId))
+	s.Require().NoError(err)
+
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+}
+
+func (s *TaskStorageSuite) Test_RequestTaskToExecute_Concurrency_Per_Executor() {
+	now := s.clock.Now()
+
+	executorId := types.NewRandomExecutorId()
+	otherExecutorId := types.NewRandomExecutorId()
+	err := s.ts.AddTaskEntries(s.ctx,
+		testaide.NewTaskEntry(now, types.Running, otherExecutorId),
+		testaide.NewTaskEntry(now, types.Running, otherExecutorId),
+		testaide.NewTaskEntry(now, types.WaitingForExecutor, types.UnknownExecutorId),
+		testaide.NewTaskEntry(now, types.WaitingForExecutor, types.UnknownExecutorId),
+	)
+	s.Require().NoError(err)
+
+	capabilities := &types.ExecutorCapabilities{Concurrency: 1}
+
+	// Tasks of other executors are not counted
+	task, err := s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().Nil(task)
+
+	// Rescheduled task no longer occupies the slot of the executor
+	s.clock.Advance(time.Hour)
+	err = s.ts.RescheduleHangingTasks(s.ctx, time.Minute)
+	s.Require().NoError(err)
+
+	task, err = s.ts.RequestTaskToExecute(s.ctx, executorId, capabilities)
+	s.Require().NoError(err)
+	s.Require().NotNil(task)
+}
+
 func (s *TaskStorageSuite) Test_AddSingleTaskEntry_Concurrently() {
 	now := s.clock.Now()
 
@@ -271,18 +542,22 @@ func (s *TaskStorageSuite) Test_AddTaskEntries_Concurrently() {
 	s.requireExactTasksCount(degreeOfParallelism * tasksPerWorker)
 }
 
+func newWaitingTaskEntry(taskType types.TaskType, modifiedAt time.Time) *types.TaskEntry {
+	return testaide.NewTaskEntryOfType(taskType, modifiedAt, types.WaitingForExecutor, types.UnknownExecutorId)
+}
+
 func (s *TaskStorageSuite) requireExactTasksCount(tasksCount int) {
 	s.T().Helper()
 
 	// All added tasks became available
 	for range tasksCount {
-		task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+		task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 		s.Require().NoError(err)
 		s.Require().NotNil(task)
 	}
 
 	// There no more tasks left
-	task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+	task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 	s.Require().NoError(err)
 	s.Require().Nil(task)
 }
@@ -302,7 +577,7 @@ func (s *TaskStorageSuite) Test_RequestTaskToExecute_Concurrently() {
 	for range degreeOfParallelism {
 		go func() {
 			defer waitGroup.Done()
-			task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId())
+			task, err := s.ts.RequestTaskToExecute(s.ctx, types.NewRandomExecutorId(), nil)
 			s.NoError(err)
 
 			if task != nil {
@@ -342,7 +617,7 @@ func (s *TaskStorageSuite) Test_ProcessTaskResult_Concurrently() {
 	waitGroup.Wait()
 
 	// Task was successfully completed and was removed from the storage
-	task, err := s.ts.RequestTaskToExecute(s.ctx, executorId)
+	task, err := s.ts.RequestTaskToExecute(s.ctx, executorId, nil)
 	s.Require().NoError(err)
 	s.Require().Nil(task)
 }
@@ -479,7 +754,7 @@ func (s *TaskStorageSuite) Test_GetStats() {
 	s.Empty(stats.CountPerExecutor)
 
 	executor := types.NewRandomExecutorId()
-	_, err = s.ts.RequestTaskToExecute(s.ctx, executor)
+	_, err = s.ts.RequestTaskToExecute(s.ctx, executor, nil)
 	s.Require().NoError(err)
 
 	stats, err = s.ts.GetTaskStats(s.ctx)
@@ -533,3 +808,99 @@ func (s *TaskStorageSuite) Test_TaskCancelation() {
 	s.Require().Equal(taskEntry.Task, failedEntry.Task)
 	s.Require().Equal(types.Failed, failedEntry.Status)
 }
+
+// tasksCountsForBenchmark defines the storage sizes used to show
+// that the cost of the queue operations doesn't grow with the number of stored tasks.
+var tasksCountsForBenchmark = []int{100, 1_000, 10_000}
+
+func newTaskStorageForBenchmark(b *testing.B, clock clockwork.Clock) *TaskStorage {
+	b.Helper()
+
+	database, err := db.NewBadgerDbInMemory()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(database.Close)
+
+	metricsHandler, err := metrics.NewSyncCommitteeMetrics()
+	if err != nil {
+		b.Fatal(err)
+	}
+	ts, err := NewTaskStorage(b.Context(), database, clock, metricsHandler, logging.Nop())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return ts
+}
+
+func addTasksForBenchmark(b *testing.B, ts *TaskStorage, count int, newEntry func(i int) *types.TaskEntry) {
+	b.Helper()
+
+	const batchSize = 500
+	for start := 0; start < count; start += batchSize {
+		batch := make([]*types.TaskEntry, 0, batchSize)
+		for i := start; i < min(start+batchSize, count); i++ {
+			batch = append(batch, newEntry(i))
+		}
+		if err := ts.AddTaskEntries(b.Context(), batch...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkTaskStorage_RequestTaskToExecute measures a dequeue of the top priority task.
+// The task is rescheduled right after, so the number of waiting tasks stays the same.
+func BenchmarkTaskStorage_RequestTaskToExecute(b *testing.B) {
+	for _, count := range tasksCountsForBenchmark {
+		b.Run(fmt.Sprintf("tasks=%d", count), func(b *testing.B) {
+			clock := testaide.NewTestClock()
+			ts := newTaskStorageForBenchmark(b, clock)
+			now := clock.Now()
+
+			addTasksForBenchmark(b, ts, count, func(i int) *types.TaskEntry {
+				return newWaitingTaskEntry(types.PartialProve, now.Add(time.Duration(i)*time.Second))
+			})
+
+			executorId := types.NewRandomExecutorId()
+			retryableErr := types.NewTaskExecError(types.TaskErrRpc, "benchmark")
+
+			b.ResetTimer()
+			for range b.N {
+				task, err := ts.RequestTaskToExecute(b.Context(), executorId, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if task == nil {
+					b.Fatal("no task to execute")
+				}
+
+				res := types.NewFailureProverTaskResult(task.Id, executorId, retryableErr)
+				if err := ts.ProcessTaskResult(b.Context(), res); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkTaskStorage_RescheduleHangingTasks measures hang detection when none of the running tasks hang.
+func BenchmarkTaskStorage_RescheduleHangingTasks(b *testing.B) {
+	for _, count := range tasksCountsForBenchmark {
+		b.Run(fmt.Sprintf("tasks=%d", count), func(b *testing.B) {
+			clock := testaide.NewTestClock()
+			ts := newTaskStorageForBenchmark(b, clock)
+			now := clock.Now()
+
+			addTasksForBenchmark(b, ts, count, func(int) *types.TaskEntry {
+				return testaide.NewTaskEntry(now, types.Running, types.NewRandomExecutorId())
+			})
+
+			b.ResetTimer()
+			for range b.N {
+				if err := ts.RescheduleHangingTasks(b.Context(), time.Hour); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
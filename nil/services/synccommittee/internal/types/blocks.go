@@ -316,6 +316,9 @@ func NewTransaction(transaction *jsonrpc.RPCInTransaction) PrunedTransaction {
 
 type ValidityProof []byte
 
+// PlaceholderValidityProof is submitted to L1 until the actual batch proof is wired into the proposer
+var PlaceholderValidityProof = ValidityProof{0x0A, 0x0B, 0x0C} // TODO place valid proof
+
 type ProposalData struct {
 	BatchId             BatchId
 	DataProofs          DataProofs
@@ -40,3 +40,21 @@ func copyToDataProof(proof *DataProof, data []byte, offset int) {
 }
 
 type DataProofs []DataProof
+
+// Unpack splits the data proof into the values passed to the point evaluation precompile.
+func (p DataProof) Unpack() (
+	evalPoint kzg4844.Point,
+	evalClaim kzg4844.Claim,
+	blobCommitment kzg4844.Commitment,
+	validityProof kzg4844.Proof,
+) {
+	const claimOffset = kzgPointSize
+	const commitmentOffset = claimOffset + kzgClaimSize
+	const proofOffset = commitmentOffset + kzgCommitmentSize
+
+	copy(evalPoint[:], p[:claimOffset])
+	copy(evalClaim[:], p[claimOffset:commitmentOffset])
+	copy(blobCommitment[:], p[commitmentOffset:proofOffset])
+	copy(validityProof[:], p[proofOffset:])
+	return evalPoint, evalClaim, blobCommitment, validityProof
+}
@@ -14,6 +14,7 @@ var (
 )
 
 var (
+	ErrTaskNotFound      = errors.New("task with the specified id is not found")
 	ErrTaskInvalidStatus = errors.New("task has invalid status")
 	ErrTaskWrongExecutor = errors.New("task belongs to another executor")
 )
@@ -62,6 +63,9 @@ const (
 
 	// TaskErrUnknown indicates an unspecified task error.
 	TaskErrUnknown
+
+	// TaskErrRetriesExhausted indicates that a dead-lettered task was abandoned after exhausting its retry budget.
+	TaskErrRetriesExhausted
 )
 
 var RetryableErrors = map[TaskErrType]bool{
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ResourceClass describes the hardware resources available to a task executor.
+// Classes are ordered, an executor of a higher class can handle tasks that require a lower one.
+type ResourceClass uint8
+
+const (
+	ResourceClassNone ResourceClass = iota
+	ResourceClassSmall
+	ResourceClassMedium
+	ResourceClassLarge
+)
+
+var ResourceClasses = map[string]ResourceClass{
+	"Small":  ResourceClassSmall,
+	"Medium": ResourceClassMedium,
+	"Large":  ResourceClassLarge,
+}
+
+func (c *ResourceClass) Set(str string) error {
+	if v, ok := ResourceClasses[str]; ok {
+		*c = v
+		return nil
+	}
+	return fmt.Errorf("unknown resource class: %s", str)
+}
+
+func (*ResourceClass) Type() string {
+	return "ResourceClass"
+}
+
+func (*ResourceClass) PossibleValues() []string {
+	return slices.Collect(maps.Keys(ResourceClasses))
+}
+
+// taskResourceClasses defines the minimal resource class required by task types.
+// Aggregation tasks load the results of all the partial proofs into memory, so they need large machines.
+var taskResourceClasses = map[TaskType]ResourceClass{
+	ProofBatch:           ResourceClassSmall,
+	PartialProve:         ResourceClassMedium,
+	AggregatedChallenge:  ResourceClassSmall,
+	CombinedQ:            ResourceClassMedium,
+	AggregatedFRI:        ResourceClassLarge,
+	FRIConsistencyChecks: ResourceClassMedium,
+	MergeProof:           ResourceClassLarge,
+}
+
+// RequiredResourceClass returns the minimal resource class of an executor capable of handling the task type.
+func (t TaskType) RequiredResourceClass() ResourceClass {
+	if class, ok := taskResourceClasses[t]; ok {
+		return class
+	}
+	return ResourceClassSmall
+}
+
+// ExecutorCapabilities are advertised by a task executor with every task request.
+type ExecutorCapabilities struct {
+	// TaskTypes is the set of supported task types, empty value means that all types are supported
+	TaskTypes []TaskType `json:"taskTypes,omitempty"`
+
+	// ResourceClass of the executor, ResourceClassNone means that the executor doesn't restrict tasks by resources
+	ResourceClass ResourceClass `json:"resourceClass,omitempty"`
+
+	// Concurrency is the maximum number of tasks assigned to the executor at once, zero means no limit
+	Concurrency uint32 `json:"concurrency,omitempty"`
+}
+
+// CanExecute checks whether the executor supports the task type and has enough resources to handle it.
+// Nil capabilities are treated as unrestricted to keep compatibility with executors which don't advertise them.
+func (c *ExecutorCapabilities) CanExecute(taskType TaskType) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.TaskTypes) > 0 && !slices.Contains(c.TaskTypes, taskType) {
+		return false
+	}
+	return c.ResourceClass == ResourceClassNone || c.ResourceClass >= taskType.RequiredResourceClass()
+}
+
+// HasFreeSlot checks whether the executor can accept one more task given the number of tasks it already has.
+func (c *ExecutorCapabilities) HasFreeSlot(assignedTasks uint32) bool {
+	return c == nil || c.Concurrency == 0 || assignedTasks < c.Concurrency
+}
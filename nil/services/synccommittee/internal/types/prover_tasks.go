@@ -173,6 +173,12 @@ type TaskEntry struct {
 
 	// RetryCount specifies the number of times the task execution has been retried
 	RetryCount int
+
+	// RetryAfter: time after which the task waiting for retry becomes available for execution
+	RetryAfter *time.Time
+
+	// LastError: error of the last failed execution attempt
+	LastError *TaskExecError
 }
 
 // AddDependency adds a dependency to the current task entry and updates the dependents and pending dependencies.
@@ -261,6 +267,58 @@ func (t *TaskEntry) ResetRunning() error {
 	return nil
 }
 
+// ScheduleRetry resets a running task which has failed with the given error.
+// If backoff is positive, the task gets WaitingForRetry status and stays unavailable until the delay has passed,
+// otherwise it becomes available for execution immediately.
+func (t *TaskEntry) ScheduleRetry(cause *TaskExecError, backoff time.Duration, currentTime time.Time) error {
+	if err := t.ResetRunning(); err != nil {
+		return err
+	}
+
+	t.LastError = cause
+	if backoff > 0 {
+		retryAfter := currentTime.Add(backoff)
+		t.Status = WaitingForRetry
+		t.RetryAfter = &retryAfter
+	}
+	return nil
+}
+
+// ReleaseRetry changes the status of a task from WaitingForRetry to WaitingForExecutor.
+func (t *TaskEntry) ReleaseRetry() error {
+	if t.Status != WaitingForRetry {
+		return errTaskInvalidStatus(t, "ReleaseRetry")
+	}
+
+	t.Status = WaitingForExecutor
+	t.RetryAfter = nil
+	return nil
+}
+
+// MoveToDeadLetter parks a running task which has exhausted its retry budget until it is resolved by an operator.
+func (t *TaskEntry) MoveToDeadLetter(cause *TaskExecError) error {
+	if t.Status != Running {
+		return errTaskInvalidStatus(t, "MoveToDeadLetter")
+	}
+
+	t.Started = nil
+	t.Status = DeadLetter
+	t.Owner = UnknownExecutorId
+	t.LastError = cause
+	return nil
+}
+
+// Requeue makes a dead-lettered task available for execution again with the full retry budget.
+func (t *TaskEntry) Requeue() error {
+	if t.Status != DeadLetter {
+		return errTaskInvalidStatus(t, "Requeue")
+	}
+
+	t.Status = WaitingForExecutor
+	t.RetryCount = 0
+	return nil
+}
+
 func errTaskInvalidStatus(task *TaskEntry, methodName string) error {
 	return fmt.Errorf("%w: id=%s, status=%s, operation=%s", ErrTaskInvalidStatus, task.Task.Id, task.Status, methodName)
 }
@@ -0,0 +1,83 @@
+package types
+
+import "time"
+
+// RetryPolicy defines the retry budget of a task and the delay before each of its retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after which the task is moved to the dead-letter state
+	MaxRetries int
+
+	// InitialBackoff is the delay before the second retry, the first one is performed immediately.
+	// Each subsequent retry doubles the delay until MaxBackoff is reached.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// IsExhausted checks whether a task which has already been retried retryCount times can't be retried anymore.
+func (p RetryPolicy) IsExhausted(retryCount int) bool {
+	return retryCount >= p.MaxRetries
+}
+
+// Backoff returns the delay before the next retry of a task which has already been retried retryCount times.
+func (p RetryPolicy) Backoff(retryCount int) time.Duration {
+	if retryCount <= 0 || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	backoff := p.InitialBackoff
+	for range retryCount - 1 {
+		if backoff >= p.MaxBackoff/2 {
+			return p.MaxBackoff
+		}
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
+
+// RetryPolicies holds retry policies per task type and per error type.
+type RetryPolicies struct {
+	Default     RetryPolicy
+	PerTaskType map[TaskType]RetryPolicy
+	PerErrType  map[TaskErrType]RetryPolicy
+}
+
+func DefaultRetryPolicies() RetryPolicies {
+	return RetryPolicies{
+		Default: RetryPolicy{MaxRetries: 5, InitialBackoff: 10 * time.Second, MaxBackoff: 10 * time.Minute},
+		PerTaskType: map[TaskType]RetryPolicy{
+			// Whole batch has to be proved from scratch if the task is abandoned
+			ProofBatch: {MaxRetries: 10, InitialBackoff: 30 * time.Second, MaxBackoff: 30 * time.Minute},
+		},
+		PerErrType: map[TaskErrType]RetryPolicy{
+			// Network failures are usually transient and worth retrying as long as the task type allows
+			TaskErrRpc:        {MaxRetries: 10, InitialBackoff: 5 * time.Second, MaxBackoff: 5 * time.Minute},
+			TaskErrIO:         {MaxRetries: 10, InitialBackoff: 5 * time.Second, MaxBackoff: 5 * time.Minute},
+			TaskErrTerminated: {MaxRetries: 10, InitialBackoff: 5 * time.Second, MaxBackoff: 5 * time.Minute},
+
+			// Repeating failures of these types are likely to be deterministic
+			TaskErrTimeout:     {MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: 10 * time.Minute},
+			TaskErrOutOfMemory: {MaxRetries: 2, InitialBackoff: time.Minute, MaxBackoff: 10 * time.Minute},
+		},
+	}
+}
+
+// For returns the policy applied to a task of the given type which failed with the given error.
+// The policy of the task type (or the default one) is combined with the policy of the error type
+// so that the stricter budget and the longer delays are applied.
+func (p *RetryPolicies) For(taskType TaskType, errType TaskErrType) RetryPolicy {
+	policy, ok := p.PerTaskType[taskType]
+	if !ok {
+		policy = p.Default
+	}
+
+	errPolicy, ok := p.PerErrType[errType]
+	if !ok {
+		return policy
+	}
+
+	return RetryPolicy{
+		MaxRetries:     min(policy.MaxRetries, errPolicy.MaxRetries),
+		InitialBackoff: max(policy.InitialBackoff, errPolicy.InitialBackoff),
+		MaxBackoff:     max(policy.MaxBackoff, errPolicy.MaxBackoff),
+	}
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RetryPolicyTestSuite))
+}
+
+func (s *RetryPolicyTestSuite) Test_Backoff() {
+	policy := RetryPolicy{MaxRetries: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for retryCount, backoff := range expected {
+		s.Require().Equal(backoff, policy.Backoff(retryCount), "retryCount=%d", retryCount)
+	}
+
+	s.Require().Equal(5*time.Second, policy.Backoff(1000))
+}
+
+func (s *RetryPolicyTestSuite) Test_IsExhausted() {
+	policy := RetryPolicy{MaxRetries: 2}
+
+	s.Require().False(policy.IsExhausted(0))
+	s.Require().False(policy.IsExhausted(1))
+	s.Require().True(policy.IsExhausted(2))
+}
+
+func (s *RetryPolicyTestSuite) Test_For_Combines_Policies() {
+	policies := RetryPolicies{
+		Default: RetryPolicy{MaxRetries: 5, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+		PerTaskType: map[TaskType]RetryPolicy{
+			MergeProof: {MaxRetries: 10, InitialBackoff: time.Second, MaxBackoff: time.Hour},
+		},
+		PerErrType: map[TaskErrType]RetryPolicy{
+			TaskErrOutOfMemory: {MaxRetries: 2, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+		},
+	}
+
+	s.Require().Equal(policies.Default, policies.For(PartialProve, TaskErrRpc))
+	s.Require().Equal(policies.PerTaskType[MergeProof], policies.For(MergeProof, TaskErrRpc))
+
+	s.Require().Equal(
+		RetryPolicy{MaxRetries: 2, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+		policies.For(PartialProve, TaskErrOutOfMemory),
+	)
+	s.Require().Equal(
+		RetryPolicy{MaxRetries: 2, InitialBackoff: time.Minute, MaxBackoff: time.Hour},
+		policies.For(MergeProof, TaskErrOutOfMemory),
+	)
+}
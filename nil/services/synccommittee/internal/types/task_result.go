@@ -71,6 +71,13 @@ func (r *TaskResult) ValidateForTask(entry *TaskEntry) error {
 	if r.Error != nil && r.Error.ErrType == TaskErrCancelled {
 		return nil
 	}
+	// Dead-lettered task has no owner, it is abandoned by an operator
+	if r.Error != nil && r.Error.ErrType == TaskErrRetriesExhausted {
+		if entry.Status != DeadLetter {
+			return errTaskInvalidStatus(entry, "Validate")
+		}
+		return nil
+	}
 	// Otherwise, it should only be terminated by the current owner
 	if r.Sender == UnknownExecutorId || r.Sender != entry.Owner {
 		return fmt.Errorf(
@@ -153,6 +160,20 @@ func NewCancelTaskResult(
 	}
 }
 
+// NewAbandonTaskResult creates a failure result for a dead-lettered task which won't be retried anymore.
+func NewAbandonTaskResult(entry *TaskEntry) *TaskResult {
+	errText := "task is abandoned after exhausting its retry budget"
+	if entry.LastError != nil {
+		errText = fmt.Sprintf("%s, last error: %s", errText, entry.LastError)
+	}
+
+	return &TaskResult{
+		TaskId: entry.Task.Id,
+		Sender: UnknownExecutorId,
+		Error:  NewTaskExecError(TaskErrRetriesExhausted, errText),
+	}
+}
+
 // TaskResultDetails represents the result of a task, extending TaskResult with additional task-specific metadata.
 type TaskResultDetails struct {
 	TaskResult
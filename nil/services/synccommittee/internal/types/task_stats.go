@@ -6,8 +6,9 @@ import (
 )
 
 type TaskStatNumbers struct {
-	ActiveCount  uint32
-	PendingCount uint32
+	ActiveCount     uint32
+	PendingCount    uint32
+	DeadLetterCount uint32
 }
 
 type TaskStats struct {
@@ -30,10 +31,14 @@ func (s *TaskStats) Add(entry *TaskEntry) {
 		s.CountPerType[entry.Task.TaskType] = statNumbersByType
 		s.CountPerExecutor[entry.Owner]++
 
-	case WaitingForExecutor, WaitingForInput:
+	case WaitingForExecutor, WaitingForInput, WaitingForRetry:
 		statNumbersByType.PendingCount++
 		s.CountPerType[entry.Task.TaskType] = statNumbersByType
 
+	case DeadLetter:
+		statNumbersByType.DeadLetterCount++
+		s.CountPerType[entry.Task.TaskType] = statNumbersByType
+
 	case Failed, Completed:
 		return
 
@@ -15,6 +15,12 @@ const (
 	Running
 	Failed
 	Completed
+
+	// WaitingForRetry is a status of a failed task which will become available for execution after the backoff delay
+	WaitingForRetry
+
+	// DeadLetter is a status of a task which has exhausted its retry budget and has to be resolved by an operator
+	DeadLetter
 )
 
 var TaskStatuses = map[string]TaskStatus{
@@ -22,6 +28,8 @@ var TaskStatuses = map[string]TaskStatus{
 	"WaitingForExecutor": WaitingForExecutor,
 	"Running":            Running,
 	"Failed":             Failed,
+	"WaitingForRetry":    WaitingForRetry,
+	"DeadLetter":         DeadLetter,
 }
 
 func (t *TaskStatus) Set(str string) error {
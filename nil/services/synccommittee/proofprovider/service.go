@@ -14,6 +14,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/scheduler"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/srv"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/storage"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
 	"github.com/jonboulle/clockwork"
 )
 
@@ -59,14 +60,23 @@ func New(config *Config, database db.DB) (*ProofProvider, error) {
 	taskResultStorage := storage.NewTaskResultStorage(database, logger)
 	taskResultSender := scheduler.NewTaskResultSender(taskRpcClient, taskResultStorage, metricsHandler, logger)
 
-	taskStorage := storage.NewTaskStorage(database, clock, metricsHandler, logger)
+	taskStorage, err := storage.NewTaskStorage(context.Background(), database, clock, metricsHandler, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing task storage: %w", err)
+	}
 
 	executorIdSource := executor.NewPersistentIdSource(
 		storage.NewExecutorIdStorage(database, logger),
 	)
 
+	executorConfig := executor.DefaultConfig()
+	// Proof provider only splits batch proofs into subtasks for provers
+	executorConfig.Capabilities = &types.ExecutorCapabilities{
+		TaskTypes: []types.TaskType{types.ProofBatch},
+	}
+
 	taskExecutor, err := executor.New(
-		executor.DefaultConfig(),
+		executorConfig,
 		taskRpcClient,
 		newTaskHandler(taskStorage, taskResultStorage, config.SkipRate, config.MaxConcurrentBatches, clock, logger),
 		executorIdSource,
@@ -77,9 +87,11 @@ func New(config *Config, database db.DB) (*ProofProvider, error) {
 		return nil, err
 	}
 
+	executorRegistry := scheduler.NewExecutorRegistry(clock)
 	taskScheduler := scheduler.New(
 		taskStorage,
 		newTaskStateChangeHandler(taskResultStorage, executorIdSource, logger),
+		executorRegistry,
 		metricsHandler,
 		logger,
 	)
@@ -88,7 +100,7 @@ func New(config *Config, database db.DB) (*ProofProvider, error) {
 		rpc.NewServerConfig(config.OwnRpcEndpoint),
 		logger,
 		taskScheduler,
-		debug.NewTaskDebugger(taskStorage, logger),
+		debug.NewTaskDebugger(taskStorage, executorRegistry, taskScheduler, logger),
 	)
 
 	taskCancelChecker := scheduler.NewTaskCancelChecker(
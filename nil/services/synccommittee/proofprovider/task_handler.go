@@ -51,9 +51,10 @@ func (h *taskHandler) IsReadyToHandle(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	// Number of currently handled batches is equal to the amount of merge proof tasks in the storage
+	// Number of currently handled batches is equal to the amount of merge proof tasks in the storage,
+	// dead-lettered ones are included as they are kept until resolved by an operator
 	mergeProofStats := stats.CountPerType[types.MergeProof]
-	batchesBeingHandled := mergeProofStats.ActiveCount + mergeProofStats.PendingCount
+	batchesBeingHandled := mergeProofStats.ActiveCount + mergeProofStats.PendingCount + mergeProofStats.DeadLetterCount
 	return batchesBeingHandled < h.maxConcurrentBatches, nil
 }
 
@@ -3,6 +3,7 @@ package prover
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/NilFoundation/nil/nil/client"
 	"github.com/NilFoundation/nil/nil/common/logging"
@@ -14,6 +15,7 @@ import (
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/scheduler"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/srv"
 	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/storage"
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
 	"github.com/jonboulle/clockwork"
 )
 
@@ -21,6 +23,47 @@ type Config struct {
 	ProofProviderRpcEndpoint string            `yaml:"proofProviderEndpoint,omitempty"`
 	NilRpcEndpoint           string            `yaml:"nilEndpoint,omitempty"`
 	Telemetry                *telemetry.Config `yaml:",inline"`
+
+	// Capabilities advertised to the proof provider.
+	// Empty TaskTypes means all the types supported by the prover.
+	TaskTypes     []string `yaml:"taskTypes,omitempty"`
+	ResourceClass string   `yaml:"resourceClass,omitempty"`
+	Concurrency   uint32   `yaml:"concurrency,omitempty"`
+}
+
+func (c *Config) executorCapabilities() (*types.ExecutorCapabilities, error) {
+	capabilities := &types.ExecutorCapabilities{
+		Concurrency: c.Concurrency,
+	}
+
+	if c.ResourceClass != "" {
+		if err := capabilities.ResourceClass.Set(c.ResourceClass); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, typeName := range c.TaskTypes {
+		var taskType types.TaskType
+		if err := taskType.Set(typeName); err != nil {
+			return nil, err
+		}
+		if taskType == types.ProofBatch {
+			return nil, fmt.Errorf("task type %s is not supported by prover", taskType)
+		}
+		capabilities.TaskTypes = append(capabilities.TaskTypes, taskType)
+	}
+
+	if len(capabilities.TaskTypes) == 0 {
+		// ProofBatch tasks are handled by the proof provider
+		for _, taskType := range types.TaskTypes {
+			if taskType != types.ProofBatch {
+				capabilities.TaskTypes = append(capabilities.TaskTypes, taskType)
+			}
+		}
+		slices.Sort(capabilities.TaskTypes)
+	}
+
+	return capabilities, nil
 }
 
 func NewDefaultConfig() *Config {
@@ -49,6 +92,12 @@ func New(config Config, database db.DB) (*Prover, error) {
 		return nil, fmt.Errorf("error initializing metrics: %w", err)
 	}
 
+	executorConfig := executor.DefaultConfig()
+	executorConfig.Capabilities, err = config.executorCapabilities()
+	if err != nil {
+		return nil, fmt.Errorf("invalid executor capabilities: %w", err)
+	}
+
 	taskRpcClient := rpc.NewTaskRequestRpcClient(config.ProofProviderRpcEndpoint, logger)
 	taskResultStorage := storage.NewTaskResultStorage(database, logger)
 	taskResultSender := scheduler.NewTaskResultSender(taskRpcClient, taskResultStorage, metricsHandler, logger)
@@ -61,7 +110,7 @@ func New(config Config, database db.DB) (*Prover, error) {
 	)
 
 	taskExecutor, err := executor.New(
-		executor.DefaultConfig(),
+		executorConfig,
 		taskRpcClient,
 		handler,
 		executor.NewInMemoryIdSource(),
@@ -66,7 +66,8 @@ func NewBatchViewCompact(
 
 type BatchViewDetailed struct {
 	batchViewCommon
-	Blocks ChainSegmentsView `json:"blocks"`
+	Blocks     ChainSegmentsView `json:"blocks"`
+	DataProofs types.DataProofs  `json:"dataProofs,omitempty"`
 }
 
 func NewBatchViewDetailed(batch *types.BlockBatch) *BatchViewDetailed {
@@ -87,6 +88,7 @@ func NewBatchViewDetailed(batch *types.BlockBatch) *BatchViewDetailed {
 			batch.CreatedAt,
 			batch.UpdatedAt,
 		),
-		Blocks: blocks,
+		Blocks:     blocks,
+		DataProofs: batch.DataProofs,
 	}
 }
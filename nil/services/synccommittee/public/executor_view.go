@@ -0,0 +1,48 @@
+package public
+
+import (
+	"time"
+
+	"github.com/NilFoundation/nil/nil/services/synccommittee/internal/types"
+)
+
+type (
+	ResourceClass        = types.ResourceClass
+	ExecutorCapabilities = types.ExecutorCapabilities
+)
+
+// ExecutorView describes a task executor which has recently requested tasks.
+type ExecutorView struct {
+	Id           TaskExecutorId        `json:"id"`
+	Capabilities *ExecutorCapabilities `json:"capabilities,omitempty"`
+	LastSeen     time.Time             `json:"lastSeen"`
+
+	// Idle is set if no task was available for the executor on its last request
+	Idle bool `json:"idle"`
+}
+
+func (e *ExecutorView) ResourceClass() ResourceClass {
+	if e.Capabilities == nil {
+		return types.ResourceClassNone
+	}
+	return e.Capabilities.ResourceClass
+}
+
+// ExecutorClassView aggregates executors and pending tasks by resource class.
+type ExecutorClassView struct {
+	ResourceClass ResourceClass `json:"resourceClass"`
+
+	Executors     uint32 `json:"executors"`
+	IdleExecutors uint32 `json:"idleExecutors"`
+
+	// PendingTasks is the number of tasks waiting for an executor which require the resource class
+	PendingTasks uint32 `json:"pendingTasks"`
+
+	// UnservedTasks is the number of pending tasks that none of the active executors is able to handle
+	UnservedTasks uint32 `json:"unservedTasks"`
+}
+
+// IsStarving returns true if there are tasks of the class which can't be handled by the active executors.
+func (v *ExecutorClassView) IsStarving() bool {
+	return v.UnservedTasks > 0
+}
@@ -0,0 +1,44 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	"github.com/NilFoundation/nil/nil/internal/types"
+)
+
+const (
+	DebugFeesNamespace    = "DebugFees"
+	DebugGetFeeComponents = DebugFeesNamespace + "_getFeeComponents"
+)
+
+// FeeComponents describes how the most recent fee params for the L1 gas price oracle were evaluated.
+type FeeComponents struct {
+	// Max base fee across L2 shards with the configured markup applied
+	ExecutionFeePerGas types.Value `json:"executionFeePerGas"`
+
+	// L1 blob base fee taken from the latest main shard block
+	BlobBaseFee types.Value `json:"blobBaseFee"`
+
+	// Blobs and L2 gas of the recently committed batches the data cost is estimated from
+	WindowBatchCount int       `json:"windowBatchCount"`
+	WindowBlobCount  uint64    `json:"windowBlobCount"`
+	WindowGasUsed    types.Gas `json:"windowGasUsed"`
+
+	// L1 data cost per L2 gas unit evaluated from the current window and blob base fee
+	RawDataCostPerGas types.Value `json:"rawDataCostPerGas"`
+
+	// Smoothed and capped data cost which is actually added to the execution fee
+	DataCostPerGas types.Value `json:"dataCostPerGas"`
+
+	MaxFeePerGas         types.Value `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas types.Value `json:"maxPriorityFeePerGas"`
+
+	EvaluatedAt time.Time `json:"evaluatedAt"`
+}
+
+type FeeDebugApi interface {
+	// GetFeeComponents retrieves the components of the most recently evaluated fee params.
+	// Nil is returned if fee params were not evaluated yet.
+	GetFeeComponents(ctx context.Context) (*FeeComponents, error)
+}
@@ -13,6 +13,11 @@ const (
 	DebugTasksNamespace = "DebugTasks"
 	DebugGetTasks       = DebugTasksNamespace + "_getTasks"
 	DebugGetTaskTree    = DebugTasksNamespace + "_getTaskTree"
+
+	DebugGetExecutorClasses = DebugTasksNamespace + "_getExecutorClasses"
+
+	DebugRequeueDeadLetterTask = DebugTasksNamespace + "_requeueDeadLetterTask"
+	DebugAbandonDeadLetterTask = DebugTasksNamespace + "_abandonDeadLetterTask"
 )
 
 type TaskDebugOrder int8
@@ -108,11 +113,20 @@ func NewTaskDebugRequest(
 	}
 }
 
-// TaskDebugApi provides methods to retrieve debug information on tasks.
+// TaskDebugApi provides methods to retrieve debug information on tasks and to resolve dead-lettered ones.
 type TaskDebugApi interface {
 	// GetTasks retrieves a list of tasks based on the specified TaskDebugRequest criteria.
 	GetTasks(ctx context.Context, request *TaskDebugRequest) ([]*TaskView, error)
 
 	// GetTaskTree retrieves the task tree structure for a specific task identified by taskId
 	GetTaskTree(ctx context.Context, taskId TaskId) (*TaskTreeView, error)
+
+	// GetExecutorClasses retrieves the number of active executors and pending tasks per resource class
+	GetExecutorClasses(ctx context.Context) ([]*ExecutorClassView, error)
+
+	// RequeueDeadLetterTask makes a dead-lettered task available for execution again with the full retry budget
+	RequeueDeadLetterTask(ctx context.Context, taskId TaskId) error
+
+	// AbandonDeadLetterTask fails a dead-lettered task, the failure is propagated to the dependent tasks
+	AbandonDeadLetterTask(ctx context.Context, taskId TaskId) error
 }
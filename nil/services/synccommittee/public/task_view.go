@@ -29,12 +29,17 @@ type TaskViewCommon struct {
 	ExecutionTime *time.Duration `json:"executionTime,omitempty"`
 	Owner         TaskExecutorId `json:"owner"`
 	Status        TaskStatus     `json:"status"`
+	RetryCount    int            `json:"retryCount,omitempty"`
 }
 
 func (t *TaskViewCommon) IsFailed() bool {
 	return t.Status == types.Failed
 }
 
+func (t *TaskViewCommon) IsDeadLettered() bool {
+	return t.Status == types.DeadLetter
+}
+
 func makeTaskViewCommon(taskEntry *types.TaskEntry, currentTime time.Time) TaskViewCommon {
 	return TaskViewCommon{
 		Id:          taskEntry.Task.Id,
@@ -44,6 +49,7 @@ func makeTaskViewCommon(taskEntry *types.TaskEntry, currentTime time.Time) TaskV
 		ExecutionTime: taskEntry.ExecutionTime(currentTime),
 		Owner:         taskEntry.Owner,
 		Status:        taskEntry.Status,
+		RetryCount:    taskEntry.RetryCount,
 	}
 }
 
@@ -84,9 +90,16 @@ type TaskTreeView struct {
 }
 
 func NewTaskTreeFromEntry(taskEntry *types.TaskEntry, currentTime time.Time) *TaskTreeView {
+	// Error of the last attempt is shown for tasks waiting for retry and dead-lettered ones
+	var errorText string
+	if taskEntry.LastError != nil {
+		errorText = taskEntry.LastError.ErrText
+	}
+
 	return &TaskTreeView{
-		TaskViewCommon: makeTaskViewCommon(taskEntry, currentTime),
-		Dependencies:   emptyDependencies(),
+		TaskViewCommon:  makeTaskViewCommon(taskEntry, currentTime),
+		ResultErrorText: errorText,
+		Dependencies:    emptyDependencies(),
 	}
 }
 
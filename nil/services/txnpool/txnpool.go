@@ -193,6 +193,19 @@ func (p *TxnPool) validateTxn(txn *metaTxn) (DiscardReason, bool) {
 		return InvalidChainId, false
 	}
 
+	if txn.IsSetCode() || len(txn.AuthorizationList) > 0 {
+		if err := txn.VerifyFlags(); err != nil {
+			return InvalidAuthorization, false
+		}
+		if err := txn.AuthorizationList.Verify(p.cfg.ShardId); err != nil {
+			p.logger.Debug().
+				Err(err).
+				Stringer(logging.FieldTransactionHash, txn.Hash()).
+				Msg("Invalid authorization list.")
+			return InvalidAuthorization, false
+		}
+	}
+
 	if seqno, ok := p.seqnoMap[txn.To]; ok && seqno > txn.Seqno {
 		p.logger.Debug().
 			Stringer(logging.FieldTransactionHash, txn.Hash()).
@@ -389,6 +389,41 @@ func (s *SuiteTxnPool) TestNetwork() {
 	}, 20*time.Second, 200*time.Millisecond)
 }
 
+func (s *SuiteTxnPool) TestSetCode() {
+	// Main shard accounts cannot delegate, so use a pool of another shard.
+	var err error
+	s.pool, err = New(s.ctx, NewConfig(1), nil)
+	s.Require().NoError(err)
+
+	address := types.ShardAndHexToAddress(1, "11")
+	newSetCodeTransaction := func(seqno types.Seqno, authorizations ...types.Authorization) *types.Transaction {
+		txn := newTransaction(address, seqno, 123)
+		txn.Flags = types.TransactionFlagsFromKind(false, types.SetCodeTransactionKind)
+		txn.AuthorizationList = authorizations
+		return txn
+	}
+	auth := types.Authorization{
+		Address:   address,
+		Delegate:  types.ShardAndHexToAddress(1, "22"),
+		Signature: []byte{1},
+	}
+
+	s.addTransactionsSuccessfully(newSetCodeTransaction(0, auth))
+
+	// Empty authorization list
+	s.addTransactionWithDiscardReason(newSetCodeTransaction(1), InvalidAuthorization)
+
+	// Delegate in another shard
+	otherShardAuth := auth
+	otherShardAuth.Delegate = types.ShardAndHexToAddress(2, "22")
+	s.addTransactionWithDiscardReason(newSetCodeTransaction(1, otherShardAuth), InvalidAuthorization)
+
+	// Authorization list in a regular transaction
+	txn := newTransaction(address, 1, 123)
+	txn.AuthorizationList = types.AuthorizationList{auth}
+	s.addTransactionWithDiscardReason(txn, InvalidAuthorization)
+}
+
 func (s *SuiteTxnPool) TestUnverifiedDuplicates() {
 	txn1 := newTransaction(defaultAddress, 0, 123)
 	txn2 := newTransaction(defaultAddress, 1, 123)
@@ -42,6 +42,8 @@ const (
 	Unverified DiscardReason = 22
 	// Transaction max fee is too small
 	TooSmallMaxFee DiscardReason = 23
+	// Authorization list of the transaction is malformed
+	InvalidAuthorization DiscardReason = 24
 )
 
 func (r DiscardReason) String() string {
@@ -72,6 +74,8 @@ func (r DiscardReason) String() string {
 		return "verification failed"
 	case TooSmallMaxFee:
 		return "max fee too small"
+	case InvalidAuthorization:
+		return "invalid authorization"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}
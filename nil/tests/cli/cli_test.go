@@ -275,7 +275,11 @@ func (s *SuiteCliService) TestToken() {
 	value := types.NewValueFromUint64(12345)
 
 	// create token
-	_, err := s.cli.TokenCreate(smartAccount, value, "token1")
+	_, err := s.cli.TokenCreate(smartAccount, value, cliservice.TokenMetadata{
+		Name:     "token1",
+		Symbol:   "TK1",
+		Decimals: 6,
+	})
 	s.Require().NoError(err)
 	tok, err := s.cli.GetTokens(smartAccount)
 	s.Require().NoError(err)
@@ -286,6 +290,19 @@ func (s *SuiteCliService) TestToken() {
 	s.Require().True(ok)
 	s.Require().Equal(value, val)
 
+	info, err := s.cli.GetTokenInfo(tokenId)
+	s.Require().NoError(err)
+	s.Equal("token1", info.Name)
+	s.Equal("TK1", info.Symbol)
+	s.Equal(uint8(6), info.Decimals)
+	s.Equal(value, info.TotalSupply)
+	s.Equal(smartAccount, info.Minter)
+	s.Equal("TK1", s.cli.GetTokenSymbol(tokenId))
+
+	holders, err := s.cli.GetTokenHolders(tokenId, 0)
+	s.Require().NoError(err)
+	s.Require().Equal([]types.TokenHolder{{Address: smartAccount, Balance: value}}, holders)
+
 	// mint
 	_, err = s.cli.ChangeTokenAmount(smartAccount, value, true)
 	s.Require().NoError(err)
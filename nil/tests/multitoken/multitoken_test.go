@@ -26,6 +26,7 @@ type SuiteMultiTokenRpc struct {
 	smartAccountAddress3 types.Address
 	testAddress1_0       types.Address
 	testAddress1_1       types.Address
+	testAddress2_0       types.Address
 	testAddressNoAccess  types.Address
 	abiTest              *abi.ABI
 	abiSmartAccount      *abi.ABI
@@ -45,6 +46,9 @@ func (s *SuiteMultiTokenRpc) SetupSuite() {
 	s.testAddress1_1, err = contracts.CalculateAddress(contracts.NameTokensTest, 1, []byte{2})
 	s.Require().NoError(err)
 
+	s.testAddress2_0, err = contracts.CalculateAddress(contracts.NameTokensTest, 2, []byte{1})
+	s.Require().NoError(err)
+
 	s.testAddressNoAccess, err = contracts.CalculateAddress(contracts.NameTokensTestNoExternalAccess, 1, nil)
 	s.Require().NoError(err)
 
@@ -93,6 +97,12 @@ func (s *SuiteMultiTokenRpc) SetupTest() {
 				Address:  s.testAddress1_1,
 				Value:    smartAccountValue,
 			},
+			{
+				Name:     "TokensTest2_0",
+				Contract: contracts.NameTokensTest,
+				Address:  s.testAddress2_0,
+				Value:    smartAccountValue,
+			},
 			{
 				Name:     "TokensTestNoAccess",
 				Contract: contracts.NameTokensTestNoExternalAccess,
@@ -664,6 +674,121 @@ func (s *SuiteMultiTokenRpc) TestIncomingBalance() {
 	checkBalance(big.NewInt(20_000), big.NewInt(20_100), receipt.OutReceipts[0])
 }
 
+func (s *SuiteMultiTokenRpc) TestAllowance() {
+	token := CreateTokenId(&s.testAddress1_0)
+	s.createTokenForTestContract(token, types.NewValueFromUint64(1_000_000), "allowance")
+
+	checkAllowance := func(spender types.Address, expected int64) {
+		s.T().Helper()
+		data := s.AbiPack(s.abiTest, "checkTokenAllowance",
+			s.testAddress1_0, spender, *token.id, big.NewInt(expected))
+		s.CallGetter(s.testAddress1_1, data, "latest", nil)
+	}
+
+	// Owner approves the spender
+	data := s.AbiPack(s.abiTest, "approveToken", s.testAddress1_1, *token.id, big.NewInt(100))
+	receipt := s.SendExternalTransactionNoCheck(data, s.testAddress1_0)
+	s.Require().True(receipt.AllSuccess())
+	s.Require().Len(receipt.Logs, 1)
+	s.Equal(vm.ManageAllowanceAddress, receipt.Logs[0].Address)
+
+	abiPrecompile, err := contracts.GetAbi(contracts.NamePrecompile)
+	s.Require().NoError(err)
+	s.Equal(abiPrecompile.Events["TokenApproval"].ID.Bytes(), receipt.Logs[0].Topics[0].Bytes())
+
+	checkAllowance(s.testAddress1_1, 100)
+
+	// Spender pulls tokens within the same shard
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress1_1, big.NewInt(60))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress1_1)
+	s.Require().True(receipt.AllSuccess())
+	s.Require().Len(receipt.Logs, 1)
+	s.Equal(vm.ManageAllowanceAddress, receipt.Logs[0].Address)
+	s.Equal(abiPrecompile.Events["TokenTransfer"].ID.Bytes(), receipt.Logs[0].Topics[0].Bytes())
+
+	s.Equal(types.NewValueFromUint64(999_940), s.getTokenBalance(&s.testAddress1_0, token))
+	s.Equal(types.NewValueFromUint64(60), s.getTokenBalance(&s.testAddress1_1, token))
+	checkAllowance(s.testAddress1_1, 40)
+
+	// Transfer above the allowance fails and changes nothing
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress1_1, big.NewInt(41))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress1_1)
+	s.Require().False(receipt.Success)
+	checkAllowance(s.testAddress1_1, 40)
+
+	// Spender pulls tokens to another shard
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.smartAccountAddress1, big.NewInt(30))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress1_1)
+	s.Require().True(receipt.AllSuccess())
+	s.Require().Len(receipt.OutReceipts, 1)
+
+	s.Equal(types.NewValueFromUint64(999_910), s.getTokenBalance(&s.testAddress1_0, token))
+	s.Equal(types.NewValueFromUint64(60), s.getTokenBalance(&s.testAddress1_1, token))
+	s.Equal(types.NewValueFromUint64(30), s.getTokenBalance(&s.smartAccountAddress1, token))
+	checkAllowance(s.testAddress1_1, 10)
+
+	// Spender from another shard pulls tokens asynchronously
+	s.Require().NotEqual(s.testAddress1_0.ShardId(), s.testAddress2_0.ShardId())
+	data = s.AbiPack(s.abiTest, "approveToken", s.testAddress2_0, *token.id, big.NewInt(50))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress1_0)
+	s.Require().True(receipt.AllSuccess())
+
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress1_1, big.NewInt(20))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress2_0)
+	s.Require().True(receipt.AllSuccess())
+	s.Require().Len(receipt.OutReceipts, 1)
+	s.Require().Len(receipt.OutReceipts[0].Logs, 1)
+	s.Equal(vm.ManageAllowanceAddress, receipt.OutReceipts[0].Logs[0].Address)
+
+	s.Equal(types.NewValueFromUint64(999_890), s.getTokenBalance(&s.testAddress1_0, token))
+	s.Equal(types.NewValueFromUint64(80), s.getTokenBalance(&s.testAddress1_1, token))
+	checkAllowance(s.testAddress2_0, 30)
+
+	// Pull above the allowance fails in the owner shard and changes nothing
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress1_1, big.NewInt(31))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress2_0)
+	s.Require().True(receipt.Success)
+	s.Require().Len(receipt.OutReceipts, 1)
+	s.Require().False(receipt.OutReceipts[0].Success)
+	s.Equal(types.ErrorInsufficientAllowance.String(), receipt.OutReceipts[0].Status)
+
+	s.Equal(types.NewValueFromUint64(999_890), s.getTokenBalance(&s.testAddress1_0, token))
+	checkAllowance(s.testAddress2_0, 30)
+
+	// Spender pulls tokens to itself, the allowance is reserved until the delivery
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress2_0, big.NewInt(10))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress2_0)
+	s.Require().True(receipt.AllSuccess())
+
+	s.Equal(types.NewValueFromUint64(999_880), s.getTokenBalance(&s.testAddress1_0, token))
+	s.Equal(types.NewValueFromUint64(10), s.getTokenBalance(&s.testAddress2_0, token))
+	checkAllowance(s.testAddress2_0, 20)
+
+	// A bounced delivery returns the tokens to the owner and releases the reservation
+	data = s.AbiPack(s.abiTest, "setRejectTokens", true)
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress2_0)
+	s.Require().True(receipt.AllSuccess())
+
+	data = s.AbiPack(s.abiTest, "testTransferTokenFrom",
+		*token.id, s.testAddress1_0, s.testAddress2_0, big.NewInt(15))
+	receipt = s.SendExternalTransactionNoCheck(data, s.testAddress2_0)
+	s.Require().True(receipt.Success)
+	s.Require().False(receipt.AllSuccess())
+
+	s.Equal(types.NewValueFromUint64(999_880), s.getTokenBalance(&s.testAddress1_0, token))
+	s.Equal(types.NewValueFromUint64(10), s.getTokenBalance(&s.testAddress2_0, token))
+	tokens, err := s.Client.GetTokens(s.Context, vm.TokenAllowanceEscrowAddress(1), "latest")
+	s.Require().NoError(err)
+	s.Empty(tokens)
+	checkAllowance(s.testAddress2_0, 20)
+}
+
 // NameTokensTestNoExternalAccess contract has no external access to token
 func (s *SuiteMultiTokenRpc) TestNoExternalAccess() {
 	abiTest, err := contracts.GetAbi(contracts.NameTokensTestNoExternalAccess)
@@ -53,8 +53,14 @@ contract NilRollup is OwnableUpgradeable, PausableUpgradeable, NilAccessControlU
 
   IL1BridgeMessenger public l1BridgeMessenger;
 
+  /// @dev The data availability mode of the batches committed from now on, set by the owner.
+  DataAvailabilityMode public dataAvailabilityMode;
+
+  /// @dev mapping of batchIndex to the hashes of the batch data parts posted in the calldata mode
+  mapping(string => mapping(uint256 => bytes32)) public batchDataPartHashes;
+
   /// @dev The storage slots for future usage.
-  uint256[50] private __gap;
+  uint256[49] private __gap;
 
   /*//////////////////////////////////////////////////////////////////////////
                                     CONSTRUCTOR
@@ -173,7 +179,9 @@ contract NilRollup is OwnableUpgradeable, PausableUpgradeable, NilAccessControlU
       dataProofs: new bytes[](0),
       validityProof: "",
       publicDataInfo: publicDataInfo,
-      blobCount: 0
+      blobCount: 0,
+      dataAvailabilityMode: DataAvailabilityMode.Blob,
+      dataHash: bytes32(0)
     });
 
     // Initialize the stateRootIndex mapping for the _genesisStateRoot to GENESIS_BATCH_INDEX
@@ -257,41 +265,107 @@ contract NilRollup is OwnableUpgradeable, PausableUpgradeable, NilAccessControlU
 
   /// @inheritdoc INilRollup
   function commitBatch(string memory batchIndex, uint256 blobCount) external override whenNotPaused onlyProposer {
-    // check if the batch is not committed and finalized yet
+    // get the versionedHashes using the opcode blobhash for each blob index
+    bytes32[] memory versionedHashes = new bytes32[](blobCount);
+    for (uint256 i = 0; i < blobCount; ++i) {
+      versionedHashes[i] = getBlobHash(i);
+    }
 
-    if (bytes(batchIndex).length == 0) {
-      revert ErrorInvalidBatchIndex();
+    _commitBatch(batchIndex, versionedHashes, DataAvailabilityMode.Blob);
+  }
+
+  /// @inheritdoc INilRollup
+  function commitBatchWithVersionedHashes(
+    string memory batchIndex,
+    bytes32[] calldata versionedHashes
+  ) external override whenNotPaused onlyProposer {
+    _commitBatch(batchIndex, versionedHashes, DataAvailabilityMode.External);
+  }
+
+  /// @inheritdoc INilRollup
+  function postBatchData(
+    string memory batchIndex,
+    uint256 partIndex,
+    bytes calldata data
+  ) external override whenNotPaused onlyProposer {
+    checkDataAvailabilityMode(DataAvailabilityMode.Calldata);
+    checkBatchNotCommitted(batchIndex);
+
+    if (data.length == 0) {
+      revert ErrorEmptyBatchData(batchIndex, partIndex);
     }
 
-    if (batchInfoRecords[batchIndex].isFinalized) {
-      revert ErrorBatchAlreadyFinalized(batchIndex);
+    bytes32 dataHash = keccak256(data);
+    batchDataPartHashes[batchIndex][partIndex] = dataHash;
+
+    emit BatchDataPosted(batchIndex, partIndex, dataHash);
+  }
+
+  /// @inheritdoc INilRollup
+  function commitBatchWithCalldata(
+    string memory batchIndex,
+    bytes32[] calldata versionedHashes,
+    uint256 partCount
+  ) external override whenNotPaused onlyProposer {
+    _commitBatch(batchIndex, versionedHashes, DataAvailabilityMode.Calldata);
+
+    if (partCount == 0) {
+      revert ErrorBatchDataPartNotPosted(batchIndex, 0);
     }
 
-    if (batchInfoRecords[batchIndex].isCommitted) {
-      revert ErrorBatchAlreadyCommitted(batchIndex);
+    // bind the batch to the data posted on-chain
+    bytes32[] memory partHashes = new bytes32[](partCount);
+    for (uint256 i = 0; i < partCount; ++i) {
+      partHashes[i] = batchDataPartHashes[batchIndex][i];
+      if (partHashes[i] == bytes32(0)) {
+        revert ErrorBatchDataPartNotPosted(batchIndex, i);
+      }
     }
+    batchInfoRecords[batchIndex].dataHash = keccak256(abi.encodePacked(partHashes));
+  }
 
-    // get the versionedHashes using the opcode blobhash for each blob index
-    bytes32[] memory versionedHashes = new bytes32[](blobCount);
-    for (uint256 i = 0; i < blobCount; ++i) {
-      bytes32 versionedHash = getBlobHash(i);
+  function _commitBatch(string memory batchIndex, bytes32[] memory versionedHashes, DataAvailabilityMode mode) private {
+    checkDataAvailabilityMode(mode);
+    checkBatchNotCommitted(batchIndex);
 
-      if (versionedHash == bytes32(0)) {
+    for (uint256 i = 0; i < versionedHashes.length; ++i) {
+      if (versionedHashes[i] == bytes32(0)) {
         revert ErrorInvalidVersionedHash(batchIndex, i);
       }
-
-      versionedHashes[i] = versionedHash;
     }
 
     // mark the batch as committed
     batchInfoRecords[batchIndex].isCommitted = true;
     batchInfoRecords[batchIndex].versionedHashes = versionedHashes;
-    batchInfoRecords[batchIndex].blobCount = blobCount;
+    batchInfoRecords[batchIndex].blobCount = versionedHashes.length;
+    batchInfoRecords[batchIndex].dataAvailabilityMode = mode;
 
     // emit an event for the committed batch
     emit BatchCommitted(batchIndex);
   }
 
+  function checkDataAvailabilityMode(DataAvailabilityMode mode) private view {
+    if (dataAvailabilityMode != mode) {
+      revert ErrorUnexpectedDataAvailabilityMode(mode, dataAvailabilityMode);
+    }
+  }
+
+  function checkBatchNotCommitted(string memory batchIndex) private view {
+    // check if the batch is not committed and finalized yet
+
+    if (bytes(batchIndex).length == 0) {
+      revert ErrorInvalidBatchIndex();
+    }
+
+    if (batchInfoRecords[batchIndex].isFinalized) {
+      revert ErrorBatchAlreadyFinalized(batchIndex);
+    }
+
+    if (batchInfoRecords[batchIndex].isCommitted) {
+      revert ErrorBatchAlreadyCommitted(batchIndex);
+    }
+  }
+
   function getBlobHash(uint256 index) public view virtual returns (bytes32) {
     bytes32 versionedHash;
     assembly {
@@ -369,12 +443,18 @@ contract NilRollup is OwnableUpgradeable, PausableUpgradeable, NilAccessControlU
 
     validatePublicDataInput(publicDataInfo);
 
+    // the batches committed in the calldata mode are bound to their data by its hash,
+    // there are no blobs to evaluate the points of, so the point evaluation precompile is not called
+    bool isCalldataBatch = batchInfoRecords[batchIndex].dataAvailabilityMode == DataAvailabilityMode.Calldata;
+
     for (uint256 i = 0; i < blobVersionedHashes.length; i++) {
       if (dataProofs[i].length == 0) {
         revert ErrorInvalidDataProofItem(i);
       }
 
-      verifyDataProof(blobVersionedHashes[i], dataProofs[i]);
+      if (!isCalldataBatch) {
+        verifyDataProof(blobVersionedHashes[i], dataProofs[i]);
+      }
     }
 
     // generate publicInput for validityProof Verification
@@ -495,6 +575,13 @@ contract NilRollup is OwnableUpgradeable, PausableUpgradeable, NilAccessControlU
     }
   }
 
+  /// @inheritdoc INilRollup
+  function setDataAvailabilityMode(DataAvailabilityMode mode) external override onlyOwner {
+    dataAvailabilityMode = mode;
+
+    emit DataAvailabilityModeSet(mode);
+  }
+
   /// @inheritdoc INilRollup
   function transferOwnershipRole(address newOwner) external override onlyOwner {
     _revokeRole(NilConstants.OWNER_ROLE, owner());
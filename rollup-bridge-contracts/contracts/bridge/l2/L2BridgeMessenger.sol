@@ -230,36 +230,22 @@ contract L2BridgeMessenger is
     bytes memory message,
     uint256 messageExpiryTime
   ) external override onlyRelayer whenNotPaused {
-    if (messageType != NilConstants.MessageType.DEPOSIT_ERC20 && messageType != NilConstants.MessageType.DEPOSIT_ETH) {
-      revert ErrorInvalidMessageType();
-    }
-
-    bytes32 messageHash = computeDepositMessageHash(messageType, messageSender, messageTarget, messageNonce, message);
-
-    if (relayedMessageHashStore.contains(messageHash)) {
-      revert ErrorDuplicateMessageRelayed(messageHash);
-    }
-
-    relayedMessageHashStore.add(messageHash);
-
-    if (l1MessageHash == bytes32(0)) {
-      l1MessageHash = messageHash;
-    } else {
-      l1MessageHash = keccak256(abi.encode(messageHash, l1MessageHash));
-    }
-
-    if (messageExpiryTime < block.timestamp + messageExpiryDelta) {
-      INilMessageTree(nilMessageTree).appendMessage(messageHash);
-      emit MessageExecutionFailed(messageHash);
-    } else {
-      bool isExecutionSuccessful = _executeMessage(messageTarget, message);
+    _relayMessage(messageSender, messageTarget, messageType, messageNonce, message, messageExpiryTime, false);
+  }
 
-      if (!isExecutionSuccessful) {
-        INilMessageTree(nilMessageTree).appendMessage(messageHash);
-        emit MessageExecutionFailed(messageHash);
-      } else {
-        emit MessageExecutionSuccessful(messageHash);
-      }
+  /// @inheritdoc IRelayMessage
+  function relayMessages(RelayMessageParams[] calldata messages) external override onlyRelayer whenNotPaused {
+    for (uint256 i = 0; i < messages.length; i++) {
+      RelayMessageParams calldata params = messages[i];
+      _relayMessage(
+        params.messageSender,
+        params.messageTarget,
+        params.messageType,
+        params.messageNonce,
+        params.message,
+        params.messageExpiryTime,
+        true
+      );
     }
   }
 
@@ -298,6 +284,51 @@ contract L2BridgeMessenger is
     return isSuccessful;
   }
 
+  function _relayMessage(
+    address messageSender,
+    address messageTarget,
+    NilConstants.MessageType messageType,
+    uint256 messageNonce,
+    bytes memory message,
+    uint256 messageExpiryTime,
+    bool skipRelayed
+  ) internal {
+    if (messageType != NilConstants.MessageType.DEPOSIT_ERC20 && messageType != NilConstants.MessageType.DEPOSIT_ETH) {
+      revert ErrorInvalidMessageType();
+    }
+
+    bytes32 messageHash = computeDepositMessageHash(messageType, messageSender, messageTarget, messageNonce, message);
+
+    if (relayedMessageHashStore.contains(messageHash)) {
+      if (skipRelayed) {
+        return;
+      }
+      revert ErrorDuplicateMessageRelayed(messageHash);
+    }
+
+    relayedMessageHashStore.add(messageHash);
+
+    if (l1MessageHash == bytes32(0)) {
+      l1MessageHash = messageHash;
+    } else {
+      l1MessageHash = keccak256(abi.encode(messageHash, l1MessageHash));
+    }
+
+    if (messageExpiryTime < block.timestamp + messageExpiryDelta) {
+      INilMessageTree(nilMessageTree).appendMessage(messageHash);
+      emit MessageExecutionFailed(messageHash);
+    } else {
+      bool isExecutionSuccessful = _executeMessage(messageTarget, message);
+
+      if (!isExecutionSuccessful) {
+        INilMessageTree(nilMessageTree).appendMessage(messageHash);
+        emit MessageExecutionFailed(messageHash);
+      } else {
+        emit MessageExecutionSuccessful(messageHash);
+      }
+    }
+  }
+
   /*//////////////////////////////////////////////////////////////////////////
                              INTERNAL FUNCTIONS
     //////////////////////////////////////////////////////////////////////////*/
@@ -6,6 +6,16 @@ import { NilConstants } from "../../../common/libraries/NilConstants.sol";
 /// @title IRelayMessage
 /// @notice Interface for the L2BridgeMessenger contract which also used by relayer.
 interface IRelayMessage {
+  /// @notice Parameters of a single message relayed as a part of the batch
+  struct RelayMessageParams {
+    address messageSender;
+    address messageTarget;
+    NilConstants.MessageType messageType;
+    uint256 messageNonce;
+    bytes message;
+    uint256 messageExpiryTime;
+  }
+
   /*//////////////////////////////////////////////////////////////////////////
                          PUBLIC MUTATION FUNCTIONS
     //////////////////////////////////////////////////////////////////////////*/
@@ -25,4 +35,10 @@ interface IRelayMessage {
     bytes calldata message,
     uint256 messageExpiryTime
   ) external;
+
+  /// @notice receive a batch of relayedMessages originated from L1BridgeMessenger via Relayer in a single transaction
+  /// @dev messages which are already relayed are skipped instead of reverting the whole batch,
+  /// so the relayer is able to resubmit the batch after partial failure
+  /// @param messages The list of messages in the order they were sent on L1.
+  function relayMessages(RelayMessageParams[] calldata messages) external;
 }
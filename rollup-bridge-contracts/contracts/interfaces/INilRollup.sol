@@ -106,6 +106,15 @@ interface INilRollup is INilAccessControlUpgradeable {
   /// @dev State root being used for state reset is invalid.
   error ErrorInvalidResetStateRoot();
 
+  /// @dev Batch is committed in a way not allowed by the data availability mode set by the owner.
+  error ErrorUnexpectedDataAvailabilityMode(DataAvailabilityMode expectedMode, DataAvailabilityMode currentMode);
+
+  /// @dev Batch data part posted in the calldata mode is empty.
+  error ErrorEmptyBatchData(string batchIndex, uint256 partIndex);
+
+  /// @dev Batch data part was not posted before committing the batch in the calldata mode.
+  error ErrorBatchDataPartNotPosted(string batchIndex, uint256 partIndex);
+
   /// @dev State root being used for state reset was not found in state roots storage.
   error ErrorResetStateRootNotFound();
 
@@ -127,10 +136,30 @@ interface INilRollup is INilAccessControlUpgradeable {
   /// @param stateRoot The state root to which the system was reset.
   event StateReset(bytes32 stateRoot);
 
+  /// @notice Emitted when the owner sets the data availability mode.
+  /// @param mode The data availability mode of the batches committed from now on.
+  event DataAvailabilityModeSet(DataAvailabilityMode mode);
+
+  /// @notice Emitted when a part of the batch data is posted in the calldata mode.
+  /// @param batchIndex The index of the batch.
+  /// @param partIndex The index of the part.
+  /// @param dataHash The keccak256 hash of the part.
+  event BatchDataPosted(string indexed batchIndex, uint256 partIndex, bytes32 dataHash);
+
   /*//////////////////////////////////////////////////////////////////////////
                                           STRUCTS
         //////////////////////////////////////////////////////////////////////////*/
 
+  /// @notice Defines where the data of the committed batches is published.
+  enum DataAvailabilityMode {
+    /// @notice The data is sent as EIP-4844 blobs with the `commitBatch` transaction.
+    Blob,
+    /// @notice The data is posted as calldata via `postBatchData`, for L1 chains without blob support.
+    Calldata,
+    /// @notice The data is published off-chain, e.g. to a DA layer, only the versioned hashes are committed.
+    External
+  }
+
   struct PublicDataInfo {
     /// @notice The Merkle root representing the rootHash of the
     /// merkle tree which has messageHash values of failed
@@ -163,6 +192,10 @@ interface INilRollup is INilAccessControlUpgradeable {
     PublicDataInfo publicDataInfo;
     /// @notice The number of blobs in the batch
     uint256 blobCount;
+    /// @notice The data availability mode the batch is committed in
+    DataAvailabilityMode dataAvailabilityMode;
+    /// @notice The hash of the batch data posted in the calldata mode
+    bytes32 dataHash;
   }
 
   /*//////////////////////////////////////////////////////////////////////////
@@ -213,6 +246,14 @@ interface INilRollup is INilAccessControlUpgradeable {
   /// @return The state root that immediately precedes the given stateRoot in the history.
   function previousStateRoot(bytes32 stateRoot) external view returns (bytes32);
 
+  /// @return The data availability mode of the batches committed from now on.
+  function dataAvailabilityMode() external view returns (DataAvailabilityMode);
+
+  /// @return The hash of the batch data part posted in the calldata mode, zero if the part is not posted.
+  /// @param batchIndex The index of the batch.
+  /// @param partIndex The index of the part.
+  function batchDataPartHashes(string memory batchIndex, uint256 partIndex) external view returns (bytes32);
+
   /// @dev function to check dataProof
   /// @param blobVersionedHash The blob versioned hash to check.
   /// @param dataProof The dataProof used to verify the blob versioned hash.
@@ -234,11 +275,49 @@ interface INilRollup is INilAccessControlUpgradeable {
   /**
    * @notice Commits a new batch with the specified number of blobs.
    * @dev This function allows an account with the COMMITTER_ROLE to commit a new batch.
+   *      Allowed only in the `Blob` data availability mode.
    * @param batchIndex The index of the batch.
    * @param blobCount The number of blobs in the batch.
    */
   function commitBatch(string memory batchIndex, uint256 blobCount) external;
 
+  /**
+   * @notice Commits a new batch whose data is published off-chain, e.g. to a DA layer.
+   * @dev This function allows an account with the PROPOSER_ROLE to commit a new batch without blobs.
+   *      Allowed only in the `External` data availability mode, where the owner trusts the proposer
+   *      to publish the data. The versioned hashes are derived from the KZG commitments of the batch data,
+   *      so the data proofs of the batch are verified by `updateState` the same way as for blob batches.
+   * @param batchIndex The index of the batch.
+   * @param versionedHashes The versioned hashes of the KZG commitments of the batch data.
+   */
+  function commitBatchWithVersionedHashes(string memory batchIndex, bytes32[] calldata versionedHashes) external;
+
+  /**
+   * @notice Posts a part of the batch data as calldata.
+   * @dev This function allows an account with the PROPOSER_ROLE to post the data of a batch before committing it.
+   *      Allowed only in the `Calldata` data availability mode. The hash of the part is stored,
+   *      posting the same part again overrides it until the batch is committed.
+   * @param batchIndex The index of the batch.
+   * @param partIndex The index of the part.
+   * @param data The part of the batch data.
+   */
+  function postBatchData(string memory batchIndex, uint256 partIndex, bytes calldata data) external;
+
+  /**
+   * @notice Commits a new batch whose data is posted as calldata via `postBatchData`.
+   * @dev This function allows an account with the PROPOSER_ROLE to commit a new batch on L1 chains without
+   *      blob support. Allowed only in the `Calldata` data availability mode. The batch is bound to the hash
+   *      of its posted parts, data proofs are not checked by `updateState` as there are no blobs to evaluate.
+   * @param batchIndex The index of the batch.
+   * @param versionedHashes The versioned hashes of the KZG commitments of the batch data.
+   * @param partCount The number of the posted parts of the batch data.
+   */
+  function commitBatchWithCalldata(
+    string memory batchIndex,
+    bytes32[] calldata versionedHashes,
+    uint256 partCount
+  ) external;
+
   /**
    * @notice Sets the initial state root.
    * @dev Allows an account with the PROPOSER_ROLE to set the initial state root,
@@ -286,6 +365,14 @@ interface INilRollup is INilAccessControlUpgradeable {
    */
   function setPause(bool _status) external;
 
+  /**
+   * @notice Sets the data availability mode of the batches committed from now on.
+   * @dev This function allows the owner to switch the way the batch data is published.
+   *      Already committed batches keep the mode they are committed in.
+   * @param mode The data availability mode.
+   */
+  function setDataAvailabilityMode(DataAvailabilityMode mode) external;
+
   /**
    * @notice transfers ownership to the newOwner.
    * @dev This function revokes the `OWNER_ROLE` from the current owner, calls `acceptOwnership` using
@@ -171,6 +171,194 @@ contract NilRollupTest is BaseTest {
     updateStateWithTestData(_proposer, batchData);
   }
 
+  /**
+   * @notice Tests the `commitBatchWithVersionedHashes` function to ensure a batch committed without blobs
+   * can be finalized by `updateState`.
+   *
+   * @dev This test follows these steps:
+   * 1. Sets the external data availability mode by the owner.
+   * 2. Commits the batch with the versioned hashes of the test data, no blob hashes are set in the mock.
+   * 3. Asserts that the batch is committed with the given versioned hashes.
+   * 4. Calls the `updateStateWithTestData` function to update the state with the committed batch data.
+   */
+  function test_commitBatchWithVersionedHashes_and_UpdateState() external {
+    BatchData memory batchData = generateBatchData();
+    BatchDataItem memory batchDataItem = batchData.batches[0];
+
+    setDataAvailabilityMode(INilRollup.DataAvailabilityMode.External);
+
+    vm.startPrank(_proposer);
+    vm.expectEmit(false, false, false, true);
+    emit BatchCommitted(batchDataItem.batchId);
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, batchDataItem.versionedHashes);
+    vm.stopPrank();
+
+    assertTrue(rollup.isBatchCommitted(batchDataItem.batchId));
+    assertFalse(rollup.isBatchFinalized(batchDataItem.batchId));
+    bytes32[] memory committedHashes = rollup.getBlobVersionedHashes(batchDataItem.batchId);
+    assertEq(committedHashes.length, batchDataItem.versionedHashes.length);
+    for (uint256 i = 0; i < committedHashes.length; i++) {
+      assertEq(committedHashes[i], batchDataItem.versionedHashes[i]);
+    }
+
+    updateStateWithTestData(_proposer, batchData);
+    assertTrue(rollup.isBatchFinalized(batchDataItem.batchId));
+  }
+
+  /**
+   * @notice Tests the `commitBatchWithVersionedHashes` function to ensure it rejects invalid commits.
+   *
+   * @dev This test follows these steps:
+   * 1. Attempts to commit the batch in the default blob mode, expecting a revert.
+   * 2. Attempts to commit the batch by a non-proposer, expecting a revert.
+   * 3. Attempts to commit the batch with an empty versioned hash, expecting a revert.
+   * 4. Commits the batch and attempts to commit it again, expecting a revert due to the duplicate commit.
+   */
+  function test_commitBatchWithVersionedHashes_toRevert() external {
+    BatchData memory batchData = generateBatchData();
+    BatchDataItem memory batchDataItem = batchData.batches[0];
+
+    vm.prank(_proposer);
+    vm.expectRevert(
+      abi.encodeWithSelector(
+        INilRollup.ErrorUnexpectedDataAvailabilityMode.selector,
+        INilRollup.DataAvailabilityMode.External,
+        INilRollup.DataAvailabilityMode.Blob
+      )
+    );
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, batchDataItem.versionedHashes);
+
+    setDataAvailabilityMode(INilRollup.DataAvailabilityMode.External);
+
+    vm.expectRevert(NilAccessControlUpgradeable.ErrorCallerIsNotProposer.selector);
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, batchDataItem.versionedHashes);
+
+    vm.startPrank(_proposer);
+
+    bytes32[] memory invalidHashes = new bytes32[](2);
+    invalidHashes[0] = batchDataItem.versionedHashes[0];
+    vm.expectRevert(
+      abi.encodeWithSelector(INilRollup.ErrorInvalidVersionedHash.selector, batchDataItem.batchId, 1)
+    );
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, invalidHashes);
+
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, batchDataItem.versionedHashes);
+    vm.expectRevert(
+      abi.encodeWithSelector(INilRollup.ErrorBatchAlreadyCommitted.selector, batchDataItem.batchId)
+    );
+    rollup.commitBatchWithVersionedHashes(batchDataItem.batchId, batchDataItem.versionedHashes);
+
+    vm.stopPrank();
+  }
+
+  /**
+   * @notice Tests the calldata data availability mode to ensure a batch can be committed and finalized
+   * on a chain without blob support.
+   *
+   * @dev This test follows these steps:
+   * 1. Sets the calldata data availability mode by the owner.
+   * 2. Posts the batch data in two parts and commits the batch, no blob hashes are set in the mock.
+   * 3. Asserts that the batch is bound to the hashes of the posted parts.
+   * 4. Updates the state with data proofs that don't pass the point evaluation,
+   *    expecting the point evaluation precompile not to be called.
+   */
+  function test_commitBatchWithCalldata_and_UpdateState_withoutBlobs() external {
+    BatchData memory batchData = generateBatchData();
+    BatchDataItem memory batchDataItem = batchData.batches[0];
+
+    setDataAvailabilityMode(INilRollup.DataAvailabilityMode.Calldata);
+
+    vm.startPrank(_proposer);
+    rollup.postBatchData(batchDataItem.batchId, 0, PLACEHOLDER1);
+    rollup.postBatchData(batchDataItem.batchId, 1, PLACEHOLDER2);
+
+    vm.expectEmit(false, false, false, true);
+    emit BatchCommitted(batchDataItem.batchId);
+    rollup.commitBatchWithCalldata(batchDataItem.batchId, batchDataItem.versionedHashes, 2);
+    vm.stopPrank();
+
+    assertTrue(rollup.isBatchCommitted(batchDataItem.batchId));
+    assertEq(rollup.batchDataPartHashes(batchDataItem.batchId, 0), keccak256(PLACEHOLDER1));
+    assertEq(rollup.batchDataPartHashes(batchDataItem.batchId, 1), keccak256(PLACEHOLDER2));
+
+    // the posted parts can't be replaced after the batch is committed
+    vm.prank(_proposer);
+    vm.expectRevert(abi.encodeWithSelector(INilRollup.ErrorBatchAlreadyCommitted.selector, batchDataItem.batchId));
+    rollup.postBatchData(batchDataItem.batchId, 0, PLACEHOLDER2);
+
+    for (uint256 i = 0; i < batchDataItem.dataProofs.length; i++) {
+      batchDataItem.dataProofs[i] = PLACEHOLDER1;
+    }
+
+    vm.expectCall(rollup.POINT_EVALUATION_PRECOMPILE_ADDR(), bytes(""), 0);
+    updateStateWithTestData(_proposer, batchData);
+    assertTrue(rollup.isBatchFinalized(batchDataItem.batchId));
+  }
+
+  /**
+   * @notice Tests the calldata data availability mode to ensure it rejects invalid commits.
+   *
+   * @dev This test follows these steps:
+   * 1. Attempts to post the batch data in the default blob mode, expecting a revert.
+   * 2. Sets the calldata data availability mode by the owner, a non-owner attempt is expected to revert.
+   * 3. Attempts to post an empty part and to commit the batch with a missing part, expecting reverts.
+   * 4. Attempts to commit the batch with blobs, expecting a revert.
+   */
+  function test_commitBatchWithCalldata_toRevert() external {
+    BatchData memory batchData = generateBatchData();
+    BatchDataItem memory batchDataItem = batchData.batches[0];
+
+    vm.prank(_proposer);
+    vm.expectRevert(
+      abi.encodeWithSelector(
+        INilRollup.ErrorUnexpectedDataAvailabilityMode.selector,
+        INilRollup.DataAvailabilityMode.Calldata,
+        INilRollup.DataAvailabilityMode.Blob
+      )
+    );
+    rollup.postBatchData(batchDataItem.batchId, 0, PLACEHOLDER1);
+
+    vm.prank(_proposer);
+    vm.expectRevert();
+    rollup.setDataAvailabilityMode(INilRollup.DataAvailabilityMode.Calldata);
+
+    setDataAvailabilityMode(INilRollup.DataAvailabilityMode.Calldata);
+
+    vm.startPrank(_proposer);
+
+    vm.expectRevert(abi.encodeWithSelector(INilRollup.ErrorEmptyBatchData.selector, batchDataItem.batchId, 0));
+    rollup.postBatchData(batchDataItem.batchId, 0, "");
+
+    rollup.postBatchData(batchDataItem.batchId, 0, PLACEHOLDER1);
+    vm.expectRevert(
+      abi.encodeWithSelector(INilRollup.ErrorBatchDataPartNotPosted.selector, batchDataItem.batchId, 1)
+    );
+    rollup.commitBatchWithCalldata(batchDataItem.batchId, batchDataItem.versionedHashes, 2);
+
+    vm.expectRevert(
+      abi.encodeWithSelector(
+        INilRollup.ErrorUnexpectedDataAvailabilityMode.selector,
+        INilRollup.DataAvailabilityMode.Blob,
+        INilRollup.DataAvailabilityMode.Calldata
+      )
+    );
+    rollup.commitBatch(batchDataItem.batchId, batchDataItem.blobCount);
+
+    vm.stopPrank();
+  }
+
+  /**
+   * @notice Sets the data availability mode of the rollup by the owner.
+   * @param mode The data availability mode.
+   */
+  function setDataAvailabilityMode(INilRollup.DataAvailabilityMode mode) internal {
+    vm.prank(_owner);
+    vm.expectEmit(false, false, false, true);
+    emit INilRollup.DataAvailabilityModeSet(mode);
+    rollup.setDataAvailabilityMode(mode);
+    assertEq(uint256(rollup.dataAvailabilityMode()), uint256(mode));
+  }
+
   /**
    * @notice Tests the `commitBatch` function to ensure it reverts when called by a non-proposer.
    *
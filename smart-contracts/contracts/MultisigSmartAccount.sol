@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: GPL-3.0
+
+pragma solidity ^0.8.9;
+
+import "./NilTokenBase.sol";
+
+/**
+ * @title MultisigSmartAccount
+ * @dev Smart Account controlled by M-of-N owners. An external transaction is accepted
+ * if its auth data carries signatures of at least `threshold` distinct owners.
+ * The signatures are 65-byte secp256k1 signatures (r, s, v) concatenated in the ascending
+ * order of the signer addresses, so duplicates are rejected in a single pass.
+ * The number of owners is limited to keep the verification gas bounded.
+ */
+contract MultisigSmartAccount is NilTokenBase {
+    uint256 public constant MAX_OWNERS = 10;
+    uint256 constant SIGNATURE_LENGTH = 65;
+
+    // Owners sorted in the ascending order
+    address[] owners;
+    uint256 public threshold;
+
+    event OwnersChanged(address[] owners, uint256 threshold);
+
+    /**
+     * @dev Fallback function to receive Ether.
+     */
+    receive() external payable {}
+
+    /**
+     * @dev Function to handle bounce transactions.
+     * @param err The error transaction.
+     */
+    function bounce(string calldata err) external payable {}
+
+    /**
+     * @dev Constructor to initialize the smart account with the owners.
+     * @param _owners Addresses of the owners sorted in the ascending order.
+     * @param _threshold The number of owner signatures required to accept a transaction.
+     */
+    constructor(address[] memory _owners, uint256 _threshold) payable {
+        setOwners(_owners, _threshold);
+    }
+
+    /**
+     * @dev Returns the owners of the smart account.
+     * @return Addresses of the owners sorted in the ascending order.
+     */
+    function getOwners() external view returns (address[] memory) {
+        return owners;
+    }
+
+    /**
+     * @dev Replaces the owners and the threshold. It has to be approved by the current owners.
+     * @param _owners Addresses of the new owners sorted in the ascending order.
+     * @param _threshold The new number of required signatures.
+     */
+    function changeOwners(
+        address[] memory _owners,
+        uint256 _threshold
+    ) public onlyExternal {
+        setOwners(_owners, _threshold);
+    }
+
+    function setOwners(address[] memory _owners, uint256 _threshold) internal {
+        require(_owners.length > 0, "no owners");
+        require(_owners.length <= MAX_OWNERS, "too many owners");
+        require(
+            _threshold > 0 && _threshold <= _owners.length,
+            "invalid threshold"
+        );
+        address last = address(0);
+        for (uint256 i = 0; i < _owners.length; i++) {
+            require(_owners[i] > last, "owners must be sorted and unique");
+            last = _owners[i];
+        }
+        owners = _owners;
+        threshold = _threshold;
+        emit OwnersChanged(_owners, _threshold);
+    }
+
+    /**
+     * @dev Deploys a contract asynchronously.
+     * @param shardId The shard ID where to deploy contract.
+     * @param value The value to send.
+     * @param code The init code to be deployed. Constructor arguments must be appended to it.
+     * @param salt Salt for the contract address creation.
+     */
+    function asyncDeploy(
+        uint shardId,
+        uint value,
+        bytes calldata code,
+        uint salt
+    ) public onlyExternal {
+        Nil.asyncDeploy(shardId, address(this), value, code, salt);
+    }
+
+    /**
+     * @dev Makes an asynchronous call.
+     * @param dst The destination address.
+     * @param refundTo The address where to send refund transaction.
+     * @param bounceTo The address where to send bounce transaction.
+     * @param tokens Multi-tokens to send.
+     * @param value The value to send.
+     * @param callData The call data of the called method.
+     */
+    function asyncCall(
+        address dst,
+        address refundTo,
+        address bounceTo,
+        Nil.Token[] memory tokens,
+        uint value,
+        bytes calldata callData
+    ) public onlyExternal {
+        Nil.asyncCallWithTokens(
+            dst,
+            refundTo,
+            bounceTo,
+            0,
+            Nil.FORWARD_REMAINING,
+            value,
+            tokens,
+            callData
+        );
+    }
+
+    /**
+     * @dev Makes a synchronous call, which is just a regular EVM call, without using transactions.
+     * @param dst The destination address.
+     * @param feeCredit The amount of tokens available to pay all fees during transaction processing.
+     * @param value The value to send.
+     * @param call_data The call data of the called method.
+     */
+    function syncCall(
+        address dst,
+        uint feeCredit,
+        uint value,
+        bytes memory call_data
+    ) public onlyExternal {
+        (bool success, ) = dst.call{value: value, gas: feeCredit}(call_data);
+        require(success, "Call failed");
+    }
+
+    /**
+     * @dev Verifies an external transaction.
+     * @param hash The hash of the data.
+     * @param signatures Owner signatures ordered by the signer address.
+     * @return True if enough distinct owners signed the hash, false otherwise.
+     */
+    function verifyExternal(
+        uint256 hash,
+        bytes calldata signatures
+    ) external view returns (bool) {
+        if (signatures.length % SIGNATURE_LENGTH != 0) {
+            return false;
+        }
+        uint256 count = signatures.length / SIGNATURE_LENGTH;
+        uint256 ownersCount = owners.length;
+        if (count < threshold || count > ownersCount) {
+            return false;
+        }
+
+        address last = address(0);
+        uint256 ownerIndex = 0;
+        for (uint256 i = 0; i < count; i++) {
+            address signer = recoverSigner(
+                bytes32(hash),
+                signatures[i * SIGNATURE_LENGTH:(i + 1) * SIGNATURE_LENGTH]
+            );
+            if (signer <= last) {
+                return false;
+            }
+            // Both lists are sorted, so the owners are scanned once for all the signatures
+            while (ownerIndex < ownersCount && owners[ownerIndex] < signer) {
+                ownerIndex++;
+            }
+            if (ownerIndex == ownersCount || owners[ownerIndex] != signer) {
+                return false;
+            }
+            last = signer;
+        }
+        return true;
+    }
+
+    function recoverSigner(
+        bytes32 hash,
+        bytes calldata signature
+    ) internal pure returns (address) {
+        bytes32 r = bytes32(signature[0:32]);
+        bytes32 s = bytes32(signature[32:64]);
+        uint8 v = uint8(signature[64]);
+        if (v < 27) {
+            v += 27;
+        }
+        return ecrecover(hash, v, r, s);
+    }
+}
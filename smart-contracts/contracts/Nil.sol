@@ -21,10 +21,14 @@ library Nil {
     address private constant SEND_TOKEN_SYNC = address(0xd2);
     address private constant GET_TRANSACTION_TOKENS = address(0xd3);
     address private constant GET_GAS_PRICE = address(0xd4);
+    address private constant MANAGE_TOKEN_ALLOWANCE = address(0xd5);
+    address private constant GET_TOKEN_ALLOWANCE = address(0xd6);
     address private constant CONFIG_PARAM = address(0xd7);
+    address private constant SCHEDULE_CALL = address(0xd8);
     address public constant IS_RESPONSE_TRANSACTION = address(0xd9);
     address public constant LOG = address(0xda);
     address public constant GOVERNANCE = address(0xdb);
+    address private constant RANDOMNESS = address(0xdc);
 
     // The following constants specify from where and how the gas should be taken during async call.
     // Forwarding values are calculated in the following order: FORWARD_VALUE, FORWARD_PERCENTAGE, FORWARD_REMAINING.
@@ -235,6 +239,91 @@ library Nil {
         return __Precompile__(GET_TOKEN_BALANCE).precompileGetTokenBalance(id, addr);
     }
 
+    /**
+     * @dev Allows `spender` to transfer up to `amount` of the token from the current contract.
+     * The previous allowance is overwritten. The maximum uint256 value means an unlimited allowance.
+     * @param spender Address of the spender. It may be in any shard.
+     * @param id TokenId of the token.
+     * @param amount Maximum amount the spender is allowed to transfer.
+     */
+    function approveToken(address spender, TokenId id, uint256 amount) internal {
+        bool success = __Precompile__(MANAGE_TOKEN_ALLOWANCE).precompileApproveToken(spender, id, amount);
+        require(success, "Token approve failed");
+    }
+
+    /**
+     * @dev Returns the amount of the token that `spender` is allowed to transfer from `owner`.
+     * @param owner Address of the token owner. It should be in the same shard as the current contract.
+     * @param spender Address of the spender.
+     * @param id TokenId of the token.
+     * @return Remaining allowance.
+     */
+    function tokenAllowance(address owner, address spender, TokenId id) internal view returns(uint256) {
+        return __Precompile__(GET_TOKEN_ALLOWANCE).precompileGetTokenAllowance(owner, spender, id);
+    }
+
+    /**
+     * @dev Transfers `amount` of the token from `from` to `to` using the allowance given to the current contract.
+     * If `from` is in another shard, the call is sent there asynchronously and the transfer is done by it:
+     * this function succeeds right away, and a failed pull (e.g., insufficient allowance) changes nothing.
+     * If `to` is in another shard than `from`, the allowance is reserved and the tokens are delivered by an async
+     * transaction. If the delivery bounces, the tokens are returned to `from` and the reservation to the allowance.
+     * The async transactions are paid from the remaining fee credit of the inbound transaction.
+     * @param id TokenId of the token.
+     * @param from Address of the token owner.
+     * @param to Address of the recipient.
+     * @param amount Amount of the token to transfer.
+     */
+    function transferTokenFrom(TokenId id, address from, address to, uint256 amount) internal {
+        bool success = __Precompile__(MANAGE_TOKEN_ALLOWANCE).precompileTransferTokenFrom(id, from, to, amount);
+        require(success, "Token transferFrom failed");
+    }
+
+    /**
+     * @dev Schedules a call of `dst` that the collator executes at block `startBlock`, and then every `interval`
+     * blocks until `count` runs are done. Fees for all the runs (`count * feeCredit`) are withdrawn from the current
+     * contract immediately, the unused part of every run is refunded to it. The scheduling itself is charged for
+     * every 32-byte word of `callData` and for every run.
+     * @param dst Destination address. It should be in the same shard as the current contract.
+     * @param startBlock Block of the first run. It should be in the future.
+     * @param interval Number of blocks between the runs. It can be zero only for a single run.
+     * @param count Number of the runs.
+     * @param feeCredit Fee credit of every run.
+     * @param callData Calldata of the call.
+     * @return Id of the schedule.
+     */
+    function scheduleCall(
+        address dst,
+        uint64 startBlock,
+        uint64 interval,
+        uint64 count,
+        uint feeCredit,
+        bytes memory callData
+    ) internal returns(uint64) {
+        return __Precompile__(SCHEDULE_CALL).precompileScheduleCall(dst, startBlock, interval, count, feeCredit, callData);
+    }
+
+    /**
+     * @dev Cancels the schedule created by the current contract and refunds the fees of the remaining runs.
+     * @param id Id of the schedule.
+     */
+    function cancelScheduledCall(uint64 id) internal {
+        bool success = __Precompile__(SCHEDULE_CALL).precompileCancelScheduledCall(id);
+        require(success, "Cancel scheduled call failed");
+    }
+
+    /**
+     * @dev Returns a random value derived from the block randomness, the current contract address and `seed`.
+     * The block randomness (also available as `block.prevrandao`) is the hash of the finalized main shard block
+     * referenced by the current block, so it is unknown until that block is committed. It is the same
+     * for all transactions of the block, thus the same seed gives the same value within the block.
+     * @param seed Arbitrary value to get independent random values.
+     * @return Random value.
+     */
+    function randomness(uint256 seed) internal view returns(uint256) {
+        return __Precompile__(RANDOMNESS).precompileGetRandomness(seed);
+    }
+
     /**
      * @dev Returns tokens from the current transaction.
      * @return Array of tokens from the current transaction.
@@ -435,13 +524,23 @@ abstract contract NilBounceable is NilBase {
 
 // WARNING: User should never use this contract directly.
 contract __Precompile__ {
+    // Events emitted by the token allowance precompile from its own address.
+    event TokenApproval(address indexed owner, address indexed spender, TokenId indexed id, uint256 amount);
+    event TokenTransfer(address indexed from, address indexed to, TokenId indexed id, uint256 amount);
+
     // if mint flag is set to false, token will be burned instead
     function precompileManageToken(uint256 amount, bool mint) public returns(bool) {}
     function precompileGetTokenBalance(TokenId id, address addr) public view returns(uint256) {}
+    function precompileApproveToken(address spender, TokenId id, uint256 amount) public returns(bool) {}
+    function precompileTransferTokenFrom(TokenId id, address from, address to, uint256 amount) public returns(bool) {}
+    function precompileGetTokenAllowance(address owner, address spender, TokenId id) public view returns(uint256) {}
     function precompileAsyncCall(bool, uint8, address, address, address, uint, Nil.Token[] memory, bytes memory, uint256, uint) public payable returns(bool) {}
     function precompileSendTokens(address, Nil.Token[] memory) public returns(bool) {}
     function precompileGetTransactionTokens() public returns(Nil.Token[] memory) {}
     function precompileGetGasPrice(uint id) public returns(uint256) {}
+    function precompileGetRandomness(uint256 seed) public view returns(uint256) {}
+    function precompileScheduleCall(address dst, uint64 startBlock, uint64 interval, uint64 count, uint feeCredit, bytes memory callData) public returns(uint64) {}
+    function precompileCancelScheduledCall(uint64 id) public returns(bool) {}
     function precompileConfigParam(bool isSet, string calldata name, bytes calldata data) public returns(bytes memory) {}
     function precompileLog(string memory transaction, int[] memory data) public returns(bool) {}
     function precompileRollback(uint32, uint32, uint32, uint64 /*, uint32, uint32*/) public returns(bool) {}
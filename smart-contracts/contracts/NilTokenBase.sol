@@ -12,9 +12,31 @@ import "./Nil.sol";
  * internal methods.
  */
 abstract contract NilTokenBase is NilBase {
+    event TokenMetadataSet(TokenId indexed tokenId, string name, string symbol, uint8 decimals);
+
+    /**
+     * @dev Token metadata added after `tokenName`. It is kept in a namespaced storage slot,
+     * so the storage layout of the contracts derived from NilTokenBase is not shifted.
+     * @custom:storage-location erc7201:nil.storage.TokenMetadata
+     */
+    struct TokenMetadataStorage {
+        string symbol;
+        uint8 decimals;
+    }
+
+    // keccak256(abi.encode(uint256(keccak256("nil.storage.TokenMetadata")) - 1)) & ~bytes32(uint256(0xff))
+    bytes32 private constant TOKEN_METADATA_STORAGE_LOCATION =
+        0x8e8e0d7b10d8263cb9bc28cb8d5b2c378bca924690d48e3981660deef92fc400;
+
     uint totalSupply;
     string tokenName;
 
+    function _getTokenMetadataStorage() private pure returns (TokenMetadataStorage storage $) {
+        assembly {
+            $.slot := TOKEN_METADATA_STORAGE_LOCATION
+        }
+    }
+
     /**
      * @dev Returns the total supply of the token.
      * @return The total supply of the token.
@@ -55,6 +77,60 @@ abstract contract NilTokenBase is NilBase {
         tokenName = name;
     }
 
+    /**
+     * @dev Returns the symbol of the token.
+     * @return The symbol of the token.
+     */
+    function getTokenSymbol() public view returns(string memory) {
+        return _getTokenMetadataStorage().symbol;
+    }
+
+    /**
+     * @dev Returns the number of decimals used to display the token amounts.
+     * @return The number of decimals of the token.
+     */
+    function getTokenDecimals() public view returns(uint8) {
+        return _getTokenMetadataStorage().decimals;
+    }
+
+    /**
+     * @dev Returns the metadata of the token. It is the standard way for wallets and explorers to get information about
+     * the token, see `eth_getTokenInfo`.
+     * @return name The name of the token.
+     * @return symbol The symbol of the token.
+     * @return decimals The number of decimals of the token.
+     * @return supply The total supply of the token.
+     */
+    function getTokenMetadata() public view returns(string memory name, string memory symbol, uint8 decimals, uint supply) {
+        TokenMetadataStorage storage $ = _getTokenMetadataStorage();
+        return (tokenName, $.symbol, $.decimals, totalSupply);
+    }
+
+    /**
+     * @dev Sets the metadata of the token using external call.
+     * It is wrapper over `setTokenMetadataInternal` method to provide access to the owner of the account.
+     * @param name The name of the token.
+     * @param symbol The symbol of the token.
+     * @param decimals The number of decimals of the token.
+     */
+    function setTokenMetadata(string memory name, string memory symbol, uint8 decimals) onlyExternal virtual public {
+        setTokenMetadataInternal(name, symbol, decimals);
+    }
+
+    /**
+     * @dev Sets the metadata of the token and emits the `TokenMetadataSet` event.
+     * @param name The name of the token.
+     * @param symbol The symbol of the token.
+     * @param decimals The number of decimals of the token.
+     */
+    function setTokenMetadataInternal(string memory name, string memory symbol, uint8 decimals) internal {
+        TokenMetadataStorage storage $ = _getTokenMetadataStorage();
+        tokenName = name;
+        $.symbol = symbol;
+        $.decimals = decimals;
+        emit TokenMetadataSet(getTokenId(), name, symbol, decimals);
+    }
+
     /**
      * @dev Mints a specified amount of token using external call.
      * It is wrapper over `mintTokenInternal` method to provide access to the owner of the account.
@@ -82,6 +158,17 @@ abstract contract NilTokenBase is NilBase {
         sendTokenInternal(to, tokenId, amount);
     }
 
+    /**
+     * @dev Allows `spender` to transfer up to `amount` of arbitrary token from this account.
+     * It is wrapper over `Nil.approveToken` method to provide access to the owner of the account.
+     * @param spender The address allowed to transfer the token.
+     * @param tokenId ID of the token.
+     * @param amount The maximum amount the spender can transfer.
+     */
+    function approveToken(address spender, TokenId tokenId, uint256 amount) onlyExternal virtual public {
+        Nil.approveToken(spender, tokenId, amount);
+    }
+
     /**
      * @dev Mints a specified amount of token and increases the total supply.
      * All minting should be carried out using this method.